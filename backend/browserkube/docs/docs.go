@@ -144,37 +144,39 @@ const docTemplate = `{
                 }
             }
         },
-        "/results": {
-            "get": {
-                "description": "get results of sessions",
-                "produces": [
+        "/browsersets/{name}": {
+            "put": {
+                "description": "replace the spec of the BrowserSet of the caller's tenant",
+                "consumes": [
                     "application/json"
                 ],
                 "tags": [
                     "browsers"
                 ],
-                "summary": "results",
+                "summary": "updateBrowserSet",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "page size",
-                        "name": "pageSize",
+                        "description": "BrowserSet name",
+                        "name": "name",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "page token",
-                        "name": "pageToken",
-                        "in": "path",
-                        "required": true
+                        "description": "BrowserSet spec",
+                        "name": "spec",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.BrowserSetSpec"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "204": {
+                        "description": "No Content",
                         "schema": {
-                            "$ref": "#/definitions/github_com_browserkube_browserkube_pkg_util.Page-browserkube_internal_api_SessionResult"
+                            "type": "string"
                         }
                     },
                     "400": {
@@ -183,6 +185,18 @@ const docTemplate = `{
                             "type": "string"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -192,42 +206,24 @@ const docTemplate = `{
                 }
             }
         },
-        "/results/{sessionID}": {
+        "/extensions": {
             "get": {
-                "description": "get result of sessions by ID",
+                "description": "returns the latest versions of all uploaded extensions",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "browsers"
-                ],
-                "summary": "resultByID",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "sessionID",
-                        "name": "sessionID",
-                        "in": "path",
-                        "required": true
-                    }
+                    "extensions"
                 ],
+                "summary": "list extensions",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/browserkube_internal_api.SessionResult"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "type": "string"
-                        }
-                    },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "type": "string"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/browserkube_internal_extension.Extension"
+                            }
                         }
                     },
                     "500": {
@@ -239,26 +235,41 @@ const docTemplate = `{
                 }
             }
         },
-        "/sessions": {
+        "/extensions/{name}": {
             "get": {
-                "description": "get all sessions",
+                "description": "returns all versions of the extension, the latest first",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "browsers"
+                    "extensions"
+                ],
+                "summary": "list extension versions",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "extension name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "sessions",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/browserkube_internal_api.Session"
+                                "$ref": "#/definitions/browserkube_internal_extension.Extension"
                             }
                         }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -266,32 +277,42 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/sessions/{sessionID}": {
-            "delete": {
-                "description": "delete session result",
+            },
+            "post": {
+                "description": "uploads CRX or XPI artifact as a new version of the extension. CRX signatures are verified",
+                "consumes": [
+                    "application/octet-stream"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "browsers"
+                    "extensions"
                 ],
-                "summary": "deleteSessionResult",
+                "summary": "upload extension",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "sessionID",
-                        "name": "sessionID",
+                        "description": "extension name",
+                        "name": "name",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "CRX or XPI artifact",
+                        "name": "artifact",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/browserkube_internal_extension.Extension"
                         }
                     },
                     "400": {
@@ -300,6 +321,24 @@ const docTemplate = `{
                             "type": "string"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "413": {
+                        "description": "Request Entity Too Large",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -309,32 +348,29 @@ const docTemplate = `{
                 }
             }
         },
-        "/sessions/{sessionID}/commands": {
+        "/extensions/{name}/{version}": {
             "get": {
+                "description": "returns metadata of the extension version. \"latest\" returns the latest version",
+                "produces": [
+                    "application/json"
+                ],
                 "tags": [
-                    "browsers"
+                    "extensions"
                 ],
-                "summary": "get list commands",
+                "summary": "get extension version",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "sessionID",
-                        "name": "sessionID",
+                        "description": "extension name",
+                        "name": "name",
                         "in": "path",
                         "required": true
                     },
                     {
                         "type": "string",
-                        "description": "for the first request always indicate - first page",
-                        "name": "pageToken",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "integer",
-                        "description": "pageSize",
-                        "name": "pageSize",
-                        "in": "query",
+                        "description": "extension version",
+                        "name": "version",
+                        "in": "path",
                         "required": true
                     }
                 ],
@@ -342,13 +378,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/browserkube_internal_api.CommandLogResponse"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/browserkube_internal_extension.Extension"
                         }
                     },
                     "404": {
@@ -364,39 +394,38 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/sessions/{sessionID}/files/{filePath}": {
-            "get": {
+            },
+            "delete": {
+                "description": "deletes the extension version. Browsers requesting it fail to start",
                 "tags": [
-                    "browsers"
+                    "extensions"
                 ],
-                "summary": "get session file commands",
+                "summary": "delete extension version",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "sessionID",
-                        "name": "sessionID",
+                        "description": "extension name",
+                        "name": "name",
                         "in": "path",
                         "required": true
                     },
                     {
                         "type": "string",
-                        "description": "filePath",
-                        "name": "filePath",
+                        "description": "extension version",
+                        "name": "version",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "204": {
+                        "description": "No Content",
                         "schema": {
                             "type": "string"
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "type": "string"
                         }
@@ -416,17 +445,28 @@ const docTemplate = `{
                 }
             }
         },
-        "/sessions/{sessionID}/screenshots": {
+        "/extensions/{name}/{version}/artifact": {
             "get": {
+                "description": "downloads CRX or XPI artifact of the extension version. \"latest\" returns the latest version.\nDigest, X-Extension-Id and X-Extension-Version headers describe the artifact",
+                "produces": [
+                    "application/octet-stream"
+                ],
                 "tags": [
-                    "browsers"
+                    "extensions"
                 ],
-                "summary": "get list screenshots",
+                "summary": "download extension artifact",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "sessionID",
-                        "name": "sessionID",
+                        "description": "extension name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "extension version",
+                        "name": "version",
                         "in": "path",
                         "required": true
                     }
@@ -435,16 +475,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "type": "string"
-                            }
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "type": "string"
+                            "type": "file"
                         }
                     },
                     "404": {
@@ -460,18 +491,50 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "post": {
-                "description": "create a screenshot",
+            }
+        },
+        "/me": {
+            "get": {
+                "description": "get the caller and the actions the caller's role allows",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "me",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/browserkube_internal_api.Me"
+                        }
+                    }
+                }
+            }
+        },
+        "/results": {
+            "get": {
+                "description": "get results of sessions",
+                "produces": [
+                    "application/json"
+                ],
                 "tags": [
                     "browsers"
                 ],
-                "summary": "createScreenshot",
+                "summary": "results",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "sessionID",
-                        "name": "sessionID",
+                        "description": "page size",
+                        "name": "pageSize",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "page token",
+                        "name": "pageToken",
                         "in": "path",
                         "required": true
                     }
@@ -480,7 +543,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/github_com_browserkube_browserkube_pkg_util.Page-browserkube_internal_api_SessionResult"
                         }
                     },
                     "400": {
@@ -489,12 +552,6 @@ const docTemplate = `{
                             "type": "string"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "type": "string"
-                        }
-                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -504,13 +561,16 @@ const docTemplate = `{
                 }
             }
         },
-        "/sessions/{sessionID}/screenshots/{screenshotID}": {
+        "/results/{sessionID}": {
             "get": {
-                "description": "get a screenshot",
+                "description": "get result of sessions by ID",
+                "produces": [
+                    "application/json"
+                ],
                 "tags": [
                     "browsers"
                 ],
-                "summary": "get screenshot by ID",
+                "summary": "resultByID",
                 "parameters": [
                     {
                         "type": "string",
@@ -518,20 +578,13 @@ const docTemplate = `{
                         "name": "sessionID",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "screenshotID",
-                        "name": "screenshotID",
-                        "in": "path",
-                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/browserkube_internal_api.SessionResult"
                         }
                     },
                     "400": {
@@ -555,34 +608,61 @@ const docTemplate = `{
                 }
             }
         },
-        "/snippet": {
+        "/sessions": {
             "get": {
-                "description": "get code snippet",
+                "description": "get all sessions",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "browsers"
                 ],
-                "summary": "getSnippet",
-                "parameters": [
-                    {
-                        "type": "boolean",
-                        "description": "manual session",
-                        "name": "manual",
-                        "in": "query"
-                    }
-                ],
+                "summary": "sessions",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/browserkube_internal_api.Browser"
+                                "$ref": "#/definitions/browserkube_internal_api.Session"
                             }
                         }
                     },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/sessions/{sessionID}": {
+            "delete": {
+                "description": "delete session result",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "browsers"
+                ],
+                "summary": "deleteSessionResult",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "sessionID",
+                        "name": "sessionID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
@@ -598,21 +678,102 @@ const docTemplate = `{
                 }
             }
         },
-        "/status": {
+        "/sessions/{sessionID}/commands": {
             "get": {
-                "description": "get status of sessions",
-                "produces": [
-                    "application/json"
+                "tags": [
+                    "browsers"
+                ],
+                "summary": "get list commands",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "sessionID",
+                        "name": "sessionID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "for the first request always indicate - first page",
+                        "name": "pageToken",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "pageSize",
+                        "name": "pageSize",
+                        "in": "query",
+                        "required": true
+                    }
                 ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/browserkube_internal_api.CommandLogResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/sessions/{sessionID}/files/{filePath}": {
+            "get": {
                 "tags": [
                     "browsers"
                 ],
-                "summary": "status",
+                "summary": "get session file commands",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "sessionID",
+                        "name": "sessionID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "filePath",
+                        "name": "filePath",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/browserkube_internal_api.Status"
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
                         }
                     },
                     "500": {
@@ -623,449 +784,1644 @@ const docTemplate = `{
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "browserkube_internal_api.Browser": {
+        },
+        "/sessions/{sessionID}/screenshots": {
+            "get": {
+                "tags": [
+                    "browsers"
+                ],
+                "summary": "get list screenshots",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "sessionID",
+                        "name": "sessionID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "create a screenshot",
+                "tags": [
+                    "browsers"
+                ],
+                "summary": "createScreenshot",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "sessionID",
+                        "name": "sessionID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/sessions/{sessionID}/screenshots/{screenshotID}": {
+            "get": {
+                "description": "get a screenshot",
+                "tags": [
+                    "browsers"
+                ],
+                "summary": "get screenshot by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "sessionID",
+                        "name": "sessionID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "screenshotID",
+                        "name": "screenshotID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/snippet": {
+            "get": {
+                "description": "get code snippet",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "browsers"
+                ],
+                "summary": "getSnippet",
+                "parameters": [
+                    {
+                        "type": "boolean",
+                        "description": "manual session",
+                        "name": "manual",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/browserkube_internal_api.Browser"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/status": {
+            "get": {
+                "description": "get status of sessions",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "browsers"
+                ],
+                "summary": "status",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/browserkube_internal_api.Status"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "browserkube_internal_api.Browser": {
+            "type": "object",
+            "properties": {
+                "browserSet": {
+                    "type": "string"
+                },
+                "default": {
+                    "type": "boolean"
+                },
+                "image": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "platformName": {
+                    "type": "string"
+                },
+                "resolutions": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "type": {
+                    "type": "string"
+                },
+                "version": {
+                    "type": "string"
+                }
+            }
+        },
+        "browserkube_internal_api.CommandLog": {
+            "type": "object",
+            "properties": {
+                "command": {
+                    "type": "string"
+                },
+                "commandId": {
+                    "type": "string"
+                },
+                "method": {
+                    "type": "string"
+                },
+                "request": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "response": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "sessionId": {
+                    "type": "string"
+                },
+                "statusCode": {
+                    "type": "integer"
+                },
+                "timestamp": {
+                    "type": "string"
+                }
+            }
+        },
+        "browserkube_internal_api.CommandLogResponse": {
+            "type": "object",
+            "properties": {
+                "commands": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/browserkube_internal_api.CommandLog"
+                    }
+                },
+                "newPageToken": {
+                    "type": "string"
+                }
+            }
+        },
+        "browserkube_internal_api.Me": {
+            "type": "object",
+            "properties": {
+                "groups": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "permissions": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/browserkube_internal_api.Permission"
+                    }
+                },
+                "role": {
+                    "$ref": "#/definitions/github_com_browserkube_browserkube_browserkube_internal_provision.Role"
+                },
+                "tenant": {
+                    "type": "string"
+                },
+                "user": {
+                    "type": "string"
+                }
+            }
+        },
+        "browserkube_internal_api.Permission": {
+            "type": "object",
+            "properties": {
+                "others": {
+                    "type": "boolean"
+                },
+                "own": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "browserkube_internal_api.Resolution": {
+            "type": "object",
+            "properties": {
+                "height": {
+                    "type": "integer"
+                },
+                "width": {
+                    "type": "integer"
+                }
+            }
+        },
+        "browserkube_internal_api.Session": {
+            "type": "object",
+            "properties": {
+                "browser": {
+                    "type": "string"
+                },
+                "browserVersion": {
+                    "type": "string"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "details": {
+                    "type": "object",
+                    "additionalProperties": true
+                },
+                "id": {
+                    "type": "string"
+                },
+                "image": {
+                    "type": "string"
+                },
+                "logsOn": {
+                    "type": "boolean"
+                },
+                "logsRefAddr": {
+                    "type": "string"
+                },
+                "manual": {
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "owner": {
+                    "description": "Owner is the user who created the session",
+                    "type": "string"
+                },
+                "platformName": {
+                    "type": "string"
+                },
+                "screenResolution": {
+                    "type": "string"
+                },
+                "state": {
+                    "type": "string"
+                },
+                "terminationReason": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                },
+                "videoCodec": {
+                    "type": "string"
+                },
+                "videoFps": {
+                    "type": "integer"
+                },
+                "videoName": {
+                    "type": "string"
+                },
+                "videoRecOn": {
+                    "type": "boolean"
+                },
+                "videoRefAddr": {
+                    "type": "string"
+                },
+                "videoSize": {
+                    "$ref": "#/definitions/browserkube_internal_api.Resolution"
+                },
+                "vncOn": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "browserkube_internal_api.SessionResult": {
+            "type": "object",
+            "properties": {
+                "browser": {
+                    "type": "string"
+                },
+                "browserVersion": {
+                    "type": "string"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "details": {
+                    "type": "object",
+                    "additionalProperties": true
+                },
+                "id": {
+                    "type": "string"
+                },
+                "image": {
+                    "type": "string"
+                },
+                "logsOn": {
+                    "type": "boolean"
+                },
+                "logsRefAddr": {
+                    "type": "string"
+                },
+                "manual": {
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "owner": {
+                    "description": "Owner is the user who created the session",
+                    "type": "string"
+                },
+                "platformName": {
+                    "type": "string"
+                },
+                "screenResolution": {
+                    "type": "string"
+                },
+                "state": {
+                    "type": "string"
+                },
+                "terminationReason": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                },
+                "videoCodec": {
+                    "type": "string"
+                },
+                "videoFps": {
+                    "type": "integer"
+                },
+                "videoName": {
+                    "type": "string"
+                },
+                "videoRecOn": {
+                    "type": "boolean"
+                },
+                "videoRefAddr": {
+                    "type": "string"
+                },
+                "videoSize": {
+                    "$ref": "#/definitions/browserkube_internal_api.Resolution"
+                },
+                "vncOn": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "browserkube_internal_api.Status": {
+            "type": "object",
+            "properties": {
+                "maxTimeout": {
+                    "$ref": "#/definitions/time.Duration"
+                },
+                "queue": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_browserkube_browserkube_browserkube_internal_provision.QueuedSession"
+                    }
+                },
+                "quotesLimit": {
+                    "type": "integer"
+                },
+                "stats": {
+                    "$ref": "#/definitions/browserkube_internal_api.StatusStats"
+                }
+            }
+        },
+        "browserkube_internal_api.StatusStats": {
+            "type": "object",
+            "properties": {
+                "all": {
+                    "type": "integer"
+                },
+                "connecting": {
+                    "type": "integer"
+                },
+                "queued": {
+                    "type": "integer"
+                },
+                "running": {
+                    "type": "integer"
+                }
+            }
+        },
+        "browserkube_internal_extension.Extension": {
+            "type": "object",
+            "properties": {
+                "browser": {
+                    "type": "string"
+                },
+                "extensionId": {
+                    "type": "string"
+                },
+                "format": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "sha256": {
+                    "type": "string"
+                },
+                "signed": {
+                    "description": "Signed is set if the artifact is signed. CRX signatures are verified on upload,\nXPI signatures are verified by Firefox on installation",
+                    "type": "boolean"
+                },
+                "size": {
+                    "type": "integer"
+                },
+                "uploadedAt": {
+                    "type": "string"
+                },
+                "uploadedBy": {
+                    "type": "string"
+                },
+                "version": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_browserkube_browserkube_browserkube_internal_provision.QueuedSession": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string"
+                },
+                "position": {
+                    "type": "integer"
+                },
+                "queuedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_browserkube_browserkube_browserkube_internal_provision.Role": {
+            "type": "string",
+            "enum": [
+                "",
+                "viewer",
+                "user",
+                "operator",
+                "admin",
+                "user"
+            ],
+            "x-enum-varnames": [
+                "RoleNone",
+                "RoleViewer",
+                "RoleUser",
+                "RoleOperator",
+                "RoleAdmin",
+                "DefaultRole"
+            ]
+        },
+        "github_com_browserkube_browserkube_pkg_util.Page-browserkube_internal_api_SessionResult": {
+            "type": "object",
+            "properties": {
+                "continueToken": {
+                    "type": "string"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/browserkube_internal_api.SessionResult"
+                    }
+                },
+                "remaining": {
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_browserkube_browserkube_pkg_wd_wdproto.CreateBrowserRequest": {
+            "type": "object",
+            "properties": {
+                "browserName": {
+                    "type": "string"
+                },
+                "browserVersion": {
+                    "type": "string"
+                },
+                "platformName": {
+                    "type": "string"
+                },
+                "recordVideo": {
+                    "type": "boolean"
+                },
+                "screenResolution": {
+                    "type": "string"
+                },
+                "sessionName": {
+                    "type": "string"
+                }
+            }
+        },
+        "k8s_io_apimachinery_pkg_apis_meta_v1.ConditionStatus": {
+            "type": "string",
+            "enum": [
+                "True",
+                "False",
+                "Unknown"
+            ],
+            "x-enum-varnames": [
+                "ConditionTrue",
+                "ConditionFalse",
+                "ConditionUnknown"
+            ]
+        },
+        "resource.Quantity": {
+            "type": "object",
+            "properties": {
+                "Format": {
+                    "type": "string",
+                    "enum": [
+                        "DecimalExponent",
+                        "BinarySI",
+                        "DecimalSI"
+                    ],
+                    "x-enum-comments": {
+                        "BinarySI": "e.g., 12Mi (12 * 2^20)",
+                        "DecimalExponent": "e.g., 12e6",
+                        "DecimalSI": "e.g., 12M  (12 * 10^6)"
+                    },
+                    "x-enum-varnames": [
+                        "DecimalExponent",
+                        "BinarySI",
+                        "DecimalSI"
+                    ]
+                }
+            }
+        },
+        "runtime.RawExtension": {
+            "type": "object"
+        },
+        "time.Duration": {
+            "type": "integer",
+            "enum": [
+                -9223372036854775808,
+                9223372036854775807,
+                1,
+                1000,
+                1000000,
+                1000000000,
+                60000000000,
+                3600000000000
+            ],
+            "x-enum-varnames": [
+                "minDuration",
+                "maxDuration",
+                "Nanosecond",
+                "Microsecond",
+                "Millisecond",
+                "Second",
+                "Minute",
+                "Hour"
+            ]
+        },
+        "v1.Affinity": {
+            "type": "object",
+            "properties": {
+                "nodeAffinity": {
+                    "description": "Describes node affinity scheduling rules for the pod.\n+optional",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/v1.NodeAffinity"
+                        }
+                    ]
+                },
+                "podAffinity": {
+                    "description": "Describes pod affinity scheduling rules (e.g. co-locate this pod in the same node, zone, etc. as some other pod(s)).\n+optional",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/v1.PodAffinity"
+                        }
+                    ]
+                },
+                "podAntiAffinity": {
+                    "description": "Describes pod anti-affinity scheduling rules (e.g. avoid putting this pod in the same node, zone, etc. as some other pod(s)).\n+optional",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/v1.PodAntiAffinity"
+                        }
+                    ]
+                }
+            }
+        },
+        "v1.Browser": {
+            "type": "object",
+            "properties": {
+                "apiVersion": {
+                    "description": "APIVersion defines the versioned schema of this representation of an object.\nServers should convert recognized schemas to the latest internal value, and\nmay reject unrecognized values.\nMore info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources\n+optional",
+                    "type": "string"
+                },
+                "kind": {
+                    "description": "Kind is a string value representing the REST resource this object represents.\nServers may infer this from the endpoint the client submits requests to.\nCannot be updated.\nIn CamelCase.\nMore info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds\n+optional",
+                    "type": "string"
+                },
+                "metadata": {
+                    "$ref": "#/definitions/v1.ObjectMeta"
+                },
+                "spec": {
+                    "$ref": "#/definitions/v1.BrowserSpec"
+                },
+                "status": {
+                    "$ref": "#/definitions/v1.BrowserStatus"
+                }
+            }
+        },
+        "v1.BrowserConfig": {
+            "type": "object",
+            "properties": {
+                "awsAccessKeyID": {
+                    "description": "+optional",
+                    "type": "string"
+                },
+                "awsSecretAccessKey": {
+                    "description": "+optional",
+                    "type": "string"
+                },
+                "caBundles": {
+                    "description": "CABundles of the browser version. Added to BrowserSet CA bundles\n+optional",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/v1.CABundle"
+                    }
+                },
+                "enableVideo": {
+                    "description": "+optional",
+                    "type": "boolean"
+                },
+                "idleTimeout": {
+                    "description": "IdleTimeout closes the session once no commands are received for this long. Overrides BrowserSet timeouts\n+optional",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/v1.Duration"
+                        }
+                    ]
+                },
+                "image": {
+                    "type": "string"
+                },
+                "imageProfile": {
+                    "description": "ImageProfile describes how to run the image. Built-in profile detected from the image registry is used\nfor the fields which are not set. Profile flavour is required for images with unknown registry\n+optional",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/v1.ImageProfile"
+                        }
+                    ]
+                },
+                "networkPolicy": {
+                    "description": "NetworkPolicy restricts network egress of the browser version. Overrides BrowserSet network policy\n+optional",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/v1.BrowserNetworkPolicy"
+                        }
+                    ]
+                },
+                "path": {
+                    "description": "+optional",
+                    "type": "string"
+                },
+                "podTemplateOverlay": {
+                    "description": "PodTemplateOverlay is a strategic merge patch of the Pod applied after the BrowserSet one\n+optional\n+kubebuilder:pruning:PreserveUnknownFields",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/runtime.RawExtension"
+                        }
+                    ]
+                },
+                "port": {
+                    "type": "string"
+                },
+                "provider": {
+                    "type": "string"
+                },
+                "proxy": {
+                    "description": "Proxy of the browser version. Overrides BrowserSet proxy\n+optional",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/v1.BrowserProxy"
+                        }
+                    ]
+                },
+                "recording": {
+                    "description": "Recording options of the browser version. Override BrowserSet recording options\n+optional",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/v1.BrowserRecording"
+                        }
+                    ]
+                },
+                "sessionDeleteTimeout": {
+                    "description": "SessionDeleteTimeout is the grace period of browser pod deletion. Overrides BrowserSet timeouts\n+optional",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/v1.Duration"
+                        }
+                    ]
+                },
+                "sessionTimeout": {
+                    "description": "SessionTimeout is the max session duration. Overrides BrowserSet timeouts\n+optional",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/v1.Duration"
+                        }
+                    ]
+                },
+                "spec": {
+                    "description": "+optional",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/v1.BrowserPodSpec"
+                        }
+                    ]
+                },
+                "startupTimeout": {
+                    "description": "StartupTimeout limits how long the browser may stay pending. Overrides BrowserSet timeouts\n+optional",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/v1.Duration"
+                        }
+                    ]
+                },
+                "timezone": {
+                    "description": "+optional",
+                    "type": "string"
+                }
+            }
+        },
+        "v1.BrowserExtension": {
             "type": "object",
             "properties": {
-                "image": {
+                "artifact": {
+                    "description": "Artifact is the name of the extension uploaded to browserkube. Artifacts are installed from browserkube\ninstead of the public stores. ExtensionID is verified against the artifact if set\n+optional",
                     "type": "string"
                 },
-                "name": {
+                "extensionId": {
                     "type": "string"
                 },
-                "platformName": {
+                "updateUrl": {
                     "type": "string"
                 },
-                "resolutions": {
+                "version": {
+                    "description": "Version of the artifact, the latest uploaded version is installed by default",
+                    "type": "string"
+                }
+            }
+        },
+        "v1.BrowserNetwork": {
+            "type": "object",
+            "properties": {
+                "allowedCIDRs": {
+                    "description": "AllowedCIDRs the browser may connect to. They must be within the ones allowed by the BrowserSet\n+optional",
                     "type": "array",
                     "items": {
                         "type": "string"
                     }
                 },
-                "type": {
-                    "type": "string"
-                },
-                "version": {
-                    "type": "string"
+                "allowedDomains": {
+                    "description": "AllowedDomains the browser may connect to. They must be allowed by the BrowserSet\n+optional",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 }
             }
         },
-        "browserkube_internal_api.CommandLog": {
+        "v1.BrowserNetworkPolicy": {
             "type": "object",
             "properties": {
-                "command": {
-                    "type": "string"
-                },
-                "commandId": {
-                    "type": "string"
+                "allowClusterInternal": {
+                    "description": "AllowClusterInternal allows private, link-local and shared address ranges\nwhich are denied by default to keep cluster-internal services unreachable\n+optional",
+                    "type": "boolean"
                 },
-                "method": {
-                    "type": "string"
+                "allowedCIDRs": {
+                    "description": "AllowedCIDRs browsers may connect to\n+optional",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 },
-                "request": {
+                "allowedDomains": {
+                    "description": "AllowedDomains browsers may connect to. Domains are resolved to addresses once the browser is created\n+optional",
                     "type": "array",
                     "items": {
-                        "type": "integer"
+                        "type": "string"
                     }
                 },
-                "response": {
+                "enabled": {
+                    "description": "Enabled creates NetworkPolicy for every browser of the set.\nPolicy is created for browsers requesting network restrictions regardless of it\n+optional",
+                    "type": "boolean"
+                }
+            }
+        },
+        "v1.BrowserPodSpec": {
+            "type": "object",
+            "properties": {
+                "activeDeadlineSeconds": {
+                    "type": "integer"
+                },
+                "affinity": {
+                    "$ref": "#/definitions/v1.Affinity"
+                },
+                "dnsConfig": {
+                    "$ref": "#/definitions/v1.PodDNSConfig"
+                },
+                "dnsPolicy": {
+                    "$ref": "#/definitions/v1.DNSPolicy"
+                },
+                "hostAliases": {
                     "type": "array",
                     "items": {
-                        "type": "integer"
+                        "$ref": "#/definitions/v1.HostAlias"
                     }
                 },
-                "sessionId": {
+                "nodeName": {
                     "type": "string"
                 },
-                "statusCode": {
+                "nodeSelector": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "priority": {
                     "type": "integer"
                 },
-                "timestamp": {
+                "priorityClassName": {
+                    "type": "string"
+                },
+                "resources": {
+                    "description": "+optional",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/v1.BrowserResources"
+                        }
+                    ]
+                },
+                "schedulerName": {
+                    "type": "string"
+                },
+                "serviceAccountName": {
                     "type": "string"
+                },
+                "terminationGracePeriodSeconds": {
+                    "type": "integer"
+                },
+                "tolerations": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/v1.Toleration"
+                    }
                 }
             }
         },
-        "browserkube_internal_api.CommandLogResponse": {
+        "v1.BrowserProxy": {
             "type": "object",
             "properties": {
-                "commands": {
+                "credentialsSecret": {
+                    "description": "CredentialsSecret is the name of the Secret in the browser namespace\nholding proxy credentials under ProxyUsernameKey and ProxyPasswordKey\n+optional",
+                    "type": "string"
+                },
+                "noProxy": {
+                    "description": "NoProxy are the hosts, domain suffixes (e.g. .corp) and CIDRs reached directly\n+optional",
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/browserkube_internal_api.CommandLog"
+                        "type": "string"
                     }
                 },
-                "newPageToken": {
+                "url": {
+                    "description": "URL of the proxy, e.g. http://proxy.corp:3128, https://proxy.corp:3129 or socks5://proxy.corp:1080.\nCredentials can't be part of the URL, use CredentialsSecret instead",
                     "type": "string"
                 }
             }
         },
-        "browserkube_internal_api.Resolution": {
+        "v1.BrowserRecording": {
             "type": "object",
             "properties": {
-                "height": {
+                "codec": {
+                    "description": "Codec is the video encoder, e.g. libx264 for mp4 or libvpx-vp9 for webm. Defaults to the first codec of the format\n+optional",
+                    "type": "string"
+                },
+                "crf": {
+                    "description": "CRF is the constant rate factor of the encoder, lower values mean better quality and bigger files\n+optional",
                     "type": "integer"
                 },
-                "width": {
+                "format": {
+                    "description": "Format is the container format of the video: mp4 or webm\n+optional",
+                    "type": "string"
+                },
+                "frameRate": {
+                    "description": "FrameRate of the video\n+optional",
                     "type": "integer"
+                },
+                "videoSize": {
+                    "description": "VideoSize is the size of the recorded area, e.g. 1280x720. Defaults to the screen resolution\n+optional",
+                    "type": "string"
                 }
             }
         },
-        "browserkube_internal_api.Session": {
+        "v1.BrowserResources": {
             "type": "object",
             "properties": {
                 "browser": {
-                    "type": "string"
+                    "description": "+optional",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/v1.ResourceRequirements"
+                        }
+                    ]
                 },
-                "browserVersion": {
-                    "type": "string"
+                "clipboard": {
+                    "description": "+optional",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/v1.ResourceRequirements"
+                        }
+                    ]
                 },
-                "createdAt": {
-                    "type": "string"
+                "recorder": {
+                    "description": "+optional",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/v1.ResourceRequirements"
+                        }
+                    ]
                 },
-                "details": {
-                    "type": "object",
-                    "additionalProperties": true
+                "shmSize": {
+                    "description": "ShmSize is the size limit of /dev/shm. Defaults to 1Gi\n+optional",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/resource.Quantity"
+                        }
+                    ]
                 },
-                "id": {
-                    "type": "string"
+                "sidecar": {
+                    "description": "+optional",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/v1.ResourceRequirements"
+                        }
+                    ]
                 },
-                "image": {
+                "vnc": {
+                    "description": "VNC resources are applied to both x-server and vnc-server containers\n+optional",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/v1.ResourceRequirements"
+                        }
+                    ]
+                }
+            }
+        },
+        "v1.BrowserSetSpec": {
+            "type": "object",
+            "properties": {
+                "caBundles": {
+                    "description": "CABundles are CA certificates all browsers of the set trust in addition to the image ones\n+optional",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/v1.CABundle"
+                    }
+                },
+                "defaultTimezone": {
+                    "description": "INSERT ADDITIONAL SPEC FIELDS - desired state of cluster\nImportant: Run \"make\" to regenerate code after modifying this file",
                     "type": "string"
                 },
-                "logsOn": {
+                "forceProxy": {
+                    "description": "ForceProxy sends traffic of all sessions of the set through the configured proxy.\nSessions can't request another proxy then\n+optional",
                     "type": "boolean"
                 },
-                "logsRefAddr": {
-                    "type": "string"
+                "maxTimeouts": {
+                    "description": "MaxTimeouts bound timeouts sessions may request. Sessions may only shorten the timeouts which have no bound\n+optional",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/v1.BrowserTimeouts"
+                        }
+                    ]
                 },
-                "manual": {
-                    "type": "boolean"
+                "networkPolicy": {
+                    "description": "NetworkPolicy restricts network egress of the browsers of the set\n+optional",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/v1.BrowserNetworkPolicy"
+                        }
+                    ]
                 },
-                "name": {
-                    "type": "string"
+                "playwright": {
+                    "description": "+optional",
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/v1.BrowsersConfig"
+                    }
                 },
-                "platformName": {
-                    "type": "string"
+                "podSpec": {
+                    "description": "+optional",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/v1.BrowserPodSpec"
+                        }
+                    ]
                 },
-                "screenResolution": {
+                "podTemplateOverlay": {
+                    "description": "PodTemplateOverlay is a strategic merge patch of the Pod applied to every browser pod of the set,\ne.g. to add env variables, volumes, init containers or annotations.\nContainers and ports managed by browserkube can't be removed\n+optional\n+kubebuilder:pruning:PreserveUnknownFields",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/runtime.RawExtension"
+                        }
+                    ]
+                },
+                "priority": {
+                    "description": "Priority of the set. Browsers defined by the sets with higher priority override the ones with lower\n+optional",
+                    "type": "integer"
+                },
+                "proxy": {
+                    "description": "Proxy is the upstream proxy of the sessions which don't request one\n+optional",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/v1.BrowserProxy"
+                        }
+                    ]
+                },
+                "recording": {
+                    "description": "Recording are the default video recording options for all browsers of the set\n+optional",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/v1.BrowserRecording"
+                        }
+                    ]
+                },
+                "sizeProfiles": {
+                    "description": "SizeProfiles are named resource presets sessions may pick with sizeProfile capability.\nProfile overrides resources configured for the browser version\n+optional",
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/v1.BrowserResources"
+                    }
+                },
+                "timeouts": {
+                    "description": "Timeouts are the defaults for all browsers of the set\n+optional",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/v1.BrowserTimeouts"
+                        }
+                    ]
+                },
+                "webdriver": {
+                    "description": "+optional",
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/v1.BrowsersConfig"
+                    }
+                }
+            }
+        },
+        "v1.BrowserSpec": {
+            "type": "object",
+            "properties": {
+                "browserName": {
                     "type": "string"
                 },
-                "state": {
+                "browserSet": {
+                    "description": "BrowserSet restricts configuration lookup to the BrowserSet with the given name\n+optional",
                     "type": "string"
                 },
-                "type": {
+                "browserSetSelector": {
+                    "description": "BrowserSetSelector restricts configuration lookup to the BrowserSets matching the label selector\n+optional",
                     "type": "string"
                 },
-                "videoCodec": {
+                "browserVersion": {
                     "type": "string"
                 },
-                "videoFps": {
-                    "type": "integer"
+                "caps": {
+                    "description": "+optional",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
                 },
-                "videoName": {
-                    "type": "string"
+                "enableVNC": {
+                    "description": "nolint: tagliatelle",
+                    "type": "boolean"
                 },
-                "videoRecOn": {
+                "enableVideo": {
+                    "description": "video recording options",
                     "type": "boolean"
                 },
-                "videoRefAddr": {
+                "extensions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/v1.BrowserExtension"
+                    }
+                },
+                "network": {
+                    "description": "Network narrows network egress allowed by the BrowserSet network policy\n+optional",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/v1.BrowserNetwork"
+                        }
+                    ]
+                },
+                "platformName": {
+                    "type": "string"
+                },
+                "proxy": {
+                    "description": "Proxy overrides the upstream proxy configured by the BrowserSet unless the set forces its proxy\n+optional",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/v1.BrowserProxy"
+                        }
+                    ]
+                },
+                "recording": {
+                    "description": "Recording overrides video recording options configured by the BrowserSet\n+optional",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/v1.BrowserRecording"
+                        }
+                    ]
+                },
+                "screenResolution": {
+                    "type": "string"
+                },
+                "sizeProfile": {
+                    "description": "SizeProfile selects one of BrowserSet's size profiles\n+optional",
                     "type": "string"
                 },
-                "videoSize": {
-                    "$ref": "#/definitions/browserkube_internal_api.Resolution"
+                "timeZone": {
+                    "type": "string"
                 },
-                "vncOn": {
-                    "type": "boolean"
+                "timeouts": {
+                    "description": "Timeouts requested by the session. They are bound by BrowserSet maxTimeouts\n+optional",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/v1.BrowserTimeouts"
+                        }
+                    ]
                 },
-                "vncPsw": {
+                "type": {
                     "type": "string"
                 }
             }
         },
-        "browserkube_internal_api.SessionResult": {
+        "v1.BrowserStatus": {
             "type": "object",
             "properties": {
-                "browser": {
+                "browserSet": {
+                    "description": "BrowserSet the browser configuration is taken from",
                     "type": "string"
                 },
-                "browserVersion": {
-                    "type": "string"
-                },
-                "createdAt": {
-                    "type": "string"
-                },
-                "details": {
-                    "type": "object",
-                    "additionalProperties": true
+                "conditions": {
+                    "description": "Conditions describe browser pod lifecycle in detail\n+optional\n+listType=map\n+listMapKey=type\n+patchStrategy=merge\n+patchMergeKey=type",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/v1.Condition"
+                    }
                 },
-                "id": {
+                "host": {
                     "type": "string"
                 },
                 "image": {
                     "type": "string"
                 },
-                "logsOn": {
-                    "type": "boolean"
-                },
-                "logsRefAddr": {
+                "message": {
                     "type": "string"
                 },
-                "manual": {
-                    "type": "boolean"
-                },
-                "name": {
+                "networkPolicy": {
+                    "description": "NetworkPolicy is the name of the NetworkPolicy restricting network egress of the browser\n+optional",
                     "type": "string"
                 },
-                "platformName": {
+                "phase": {
+                    "description": "INSERT ADDITIONAL STATUS FIELD - define observed state of cluster\nImportant: Run \"make\" to regenerate code after modifying this file",
                     "type": "string"
                 },
-                "screenResolution": {
+                "podName": {
                     "type": "string"
                 },
-                "state": {
-                    "type": "string"
+                "portConfig": {
+                    "$ref": "#/definitions/v1.PortConfig"
                 },
-                "type": {
-                    "type": "string"
+                "proxy": {
+                    "description": "Proxy effective for the browser\n+optional",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/v1.BrowserProxy"
+                        }
+                    ]
                 },
-                "videoCodec": {
+                "reason": {
                     "type": "string"
                 },
-                "videoFps": {
-                    "type": "integer"
+                "recording": {
+                    "description": "Recording options effective for the browser. Set only if video is recorded\n+optional",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/v1.BrowserRecording"
+                        }
+                    ]
                 },
-                "videoName": {
+                "seleniumURL": {
                     "type": "string"
                 },
-                "videoRecOn": {
-                    "type": "boolean"
+                "sessionDeadline": {
+                    "description": "SessionDeadline is the time the session is closed at regardless of its activity\n+optional",
+                    "type": "string"
                 },
-                "videoRefAddr": {
+                "startupDeadline": {
+                    "description": "StartupDeadline is the time the browser is deleted at unless it is running\n+optional",
                     "type": "string"
                 },
-                "videoSize": {
-                    "$ref": "#/definitions/browserkube_internal_api.Resolution"
+                "terminationReason": {
+                    "description": "TerminationReason tells why the session of the terminated browser has ended\n+optional",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/v1.TerminationReason"
+                        }
+                    ]
                 },
-                "vncOn": {
-                    "type": "boolean"
+                "timeouts": {
+                    "description": "Timeouts effective for the browser\n+optional",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/v1.BrowserTimeouts"
+                        }
+                    ]
                 },
-                "vncPsw": {
+                "vncSecret": {
+                    "description": "VNCSecret is the name of the Secret holding the VNC password under VNCSecretKey\n+optional",
                     "type": "string"
                 }
             }
         },
-        "browserkube_internal_api.Status": {
+        "v1.BrowserTimeouts": {
             "type": "object",
             "properties": {
-                "maxTimeout": {
-                    "$ref": "#/definitions/time.Duration"
+                "idle": {
+                    "description": "Idle closes the session once no commands are received for this long\n+optional",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/v1.Duration"
+                        }
+                    ]
                 },
-                "quotesLimit": {
-                    "type": "integer"
+                "session": {
+                    "description": "Session is the max session duration\n+optional",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/v1.Duration"
+                        }
+                    ]
                 },
-                "stats": {
-                    "$ref": "#/definitions/browserkube_internal_api.StatusStats"
+                "sessionDelete": {
+                    "description": "SessionDelete is the grace period of browser pod deletion\n+optional",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/v1.Duration"
+                        }
+                    ]
+                },
+                "startup": {
+                    "description": "Startup limits how long the browser may stay pending before it is deleted\n+optional",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/v1.Duration"
+                        }
+                    ]
                 }
             }
         },
-        "browserkube_internal_api.StatusStats": {
+        "v1.BrowsersConfig": {
             "type": "object",
             "properties": {
-                "all": {
-                    "type": "integer"
-                },
-                "connecting": {
-                    "type": "integer"
+                "defaultPath": {
+                    "description": "+optional",
+                    "type": "string"
                 },
-                "queued": {
-                    "type": "integer"
+                "defaultVersion": {
+                    "type": "string"
                 },
-                "running": {
-                    "type": "integer"
+                "versions": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/v1.BrowserConfig"
+                    }
                 }
             }
         },
-        "github_com_browserkube_browserkube_pkg_util.Page-browserkube_internal_api_SessionResult": {
+        "v1.CABundle": {
             "type": "object",
             "properties": {
-                "continueToken": {
+                "configMap": {
+                    "description": "ConfigMap holding the bundle\n+optional",
                     "type": "string"
                 },
-                "items": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/browserkube_internal_api.SessionResult"
-                    }
+                "key": {
+                    "description": "Key of the bundle, CABundleDefaultKey by default\n+optional",
+                    "type": "string"
                 },
-                "remaining": {
-                    "type": "integer"
+                "secret": {
+                    "description": "Secret holding the bundle\n+optional",
+                    "type": "string"
                 }
             }
         },
-        "github_com_browserkube_browserkube_pkg_wd_wdproto.CreateBrowserRequest": {
+        "v1.Condition": {
             "type": "object",
             "properties": {
-                "browserName": {
-                    "type": "string"
-                },
-                "browserVersion": {
+                "lastTransitionTime": {
+                    "description": "lastTransitionTime is the last time the condition transitioned from one status to another.\nThis should be when the underlying condition changed.  If that is not known, then using the time when the API field changed is acceptable.\n+required\n+kubebuilder:validation:Required\n+kubebuilder:validation:Type=string\n+kubebuilder:validation:Format=date-time",
                     "type": "string"
                 },
-                "platformName": {
+                "message": {
+                    "description": "message is a human readable message indicating details about the transition.\nThis may be an empty string.\n+required\n+kubebuilder:validation:Required\n+kubebuilder:validation:MaxLength=32768",
                     "type": "string"
                 },
-                "recordVideo": {
-                    "type": "boolean"
+                "observedGeneration": {
+                    "description": "observedGeneration represents the .metadata.generation that the condition was set based upon.\nFor instance, if .metadata.generation is currently 12, but the .status.conditions[x].observedGeneration is 9, the condition is out of date\nwith respect to the current state of the instance.\n+optional\n+kubebuilder:validation:Minimum=0",
+                    "type": "integer"
                 },
-                "screenResolution": {
+                "reason": {
+                    "description": "reason contains a programmatic identifier indicating the reason for the condition's last transition.\nProducers of specific condition types may define expected values and meanings for this field,\nand whether the values are considered a guaranteed API.\nThe value should be a CamelCase string.\nThis field may not be empty.\n+required\n+kubebuilder:validation:Required\n+kubebuilder:validation:MaxLength=1024\n+kubebuilder:validation:MinLength=1\n+kubebuilder:validation:Pattern=` + "`" + `^[A-Za-z]([A-Za-z0-9_,:]*[A-Za-z0-9_])?$` + "`" + `",
                     "type": "string"
                 },
-                "sessionName": {
+                "status": {
+                    "description": "status of the condition, one of True, False, Unknown.\n+required\n+kubebuilder:validation:Required\n+kubebuilder:validation:Enum=True;False;Unknown",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/k8s_io_apimachinery_pkg_apis_meta_v1.ConditionStatus"
+                        }
+                    ]
+                },
+                "type": {
+                    "description": "type of condition in CamelCase or in foo.example.com/CamelCase.\n---\nMany .condition.type values are consistent across resources like Available, but because arbitrary conditions can be\nuseful (see .node.status.conditions), the ability to deconflict is important.\nThe regex it matches is (dns1123SubdomainFmt/)?(qualifiedNameFmt)\n+required\n+kubebuilder:validation:Required\n+kubebuilder:validation:Pattern=` + "`" + `^([a-z0-9]([-a-z0-9]*[a-z0-9])?(\\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*/)?(([A-Za-z0-9][-A-Za-z0-9_.]*)?[A-Za-z0-9])$` + "`" + `\n+kubebuilder:validation:MaxLength=316",
                     "type": "string"
                 }
             }
         },
-        "time.Duration": {
-            "type": "integer",
+        "v1.DNSPolicy": {
+            "type": "string",
             "enum": [
-                -9223372036854775808,
-                9223372036854775807,
-                1,
-                1000,
-                1000000,
-                1000000000,
-                60000000000,
-                3600000000000
+                "ClusterFirstWithHostNet",
+                "ClusterFirst",
+                "Default",
+                "None"
             ],
             "x-enum-varnames": [
-                "minDuration",
-                "maxDuration",
-                "Nanosecond",
-                "Microsecond",
-                "Millisecond",
-                "Second",
-                "Minute",
-                "Hour"
+                "DNSClusterFirstWithHostNet",
+                "DNSClusterFirst",
+                "DNSDefault",
+                "DNSNone"
             ]
         },
-        "v1.Browser": {
+        "v1.Duration": {
             "type": "object",
             "properties": {
-                "apiVersion": {
-                    "description": "APIVersion defines the versioned schema of this representation of an object.\nServers should convert recognized schemas to the latest internal value, and\nmay reject unrecognized values.\nMore info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources\n+optional",
-                    "type": "string"
-                },
-                "kind": {
-                    "description": "Kind is a string value representing the REST resource this object represents.\nServers may infer this from the endpoint the client submits requests to.\nCannot be updated.\nIn CamelCase.\nMore info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds\n+optional",
-                    "type": "string"
-                },
-                "metadata": {
-                    "$ref": "#/definitions/v1.ObjectMeta"
-                },
-                "spec": {
-                    "$ref": "#/definitions/v1.BrowserSpec"
-                },
-                "status": {
-                    "$ref": "#/definitions/v1.BrowserStatus"
+                "time.Duration": {
+                    "type": "integer",
+                    "enum": [
+                        -9223372036854775808,
+                        9223372036854775807,
+                        1,
+                        1000,
+                        1000000,
+                        1000000000,
+                        60000000000,
+                        3600000000000
+                    ],
+                    "x-enum-varnames": [
+                        "minDuration",
+                        "maxDuration",
+                        "Nanosecond",
+                        "Microsecond",
+                        "Millisecond",
+                        "Second",
+                        "Minute",
+                        "Hour"
+                    ]
                 }
             }
         },
-        "v1.BrowserExtension": {
+        "v1.FieldsV1": {
+            "type": "object"
+        },
+        "v1.HostAlias": {
             "type": "object",
             "properties": {
-                "extensionId": {
-                    "type": "string"
-                },
-                "updateUrl": {
-                    "type": "string"
+                "hostnames": {
+                    "description": "Hostnames for the above IP address.",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 },
-                "version": {
+                "ip": {
+                    "description": "IP address of the host file entry.",
                     "type": "string"
                 }
             }
         },
-        "v1.BrowserSpec": {
+        "v1.ImageProfile": {
             "type": "object",
             "properties": {
-                "browserName": {
+                "displayNum": {
+                    "description": "DisplayNum is the X display number the browser is rendered to\n+optional",
                     "type": "string"
                 },
-                "browserVersion": {
-                    "type": "string"
-                },
-                "caps": {
-                    "description": "+optional",
-                    "type": "array",
-                    "items": {
-                        "type": "integer"
+                "extensionDirs": {
+                    "description": "ExtensionDirs are the directories browser extensions are installed to, by browser name\n+optional",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        }
                     }
                 },
-                "enableVNC": {
-                    "description": "nolint: tagliatelle",
-                    "type": "boolean"
-                },
-                "enableVideo": {
-                    "description": "video recording options",
-                    "type": "boolean"
-                },
-                "extensions": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/v1.BrowserExtension"
-                    }
+                "flavour": {
+                    "description": "Flavour selects the pod builder\n+kubebuilder:validation:Enum=selenium;selenoid;aerokube;microsoft\n+optional",
+                    "type": "string"
                 },
-                "platformName": {
+                "homeDir": {
+                    "description": "HomeDir of the browser user. Videos and downloads are stored there\n+optional",
                     "type": "string"
                 },
-                "screenResolution": {
+                "readinessPath": {
+                    "description": "ReadinessPath is the HTTP path of the browser readiness probe\n+optional",
                     "type": "string"
                 },
-                "timeZone": {
+                "serverCommand": {
+                    "description": "ServerCommand is the shell command starting Playwright server of microsoft images.\nBy default, the server of the image version is fetched by npx from the npm registry\n+optional",
                     "type": "string"
                 },
-                "type": {
+                "vncPort": {
+                    "description": "VNCPort the image serves VNC on\n+optional",
                     "type": "string"
                 }
             }
         },
-        "v1.BrowserStatus": {
+        "v1.LabelSelector": {
             "type": "object",
             "properties": {
-                "host": {
-                    "type": "string"
-                },
-                "image": {
-                    "type": "string"
-                },
-                "message": {
-                    "type": "string"
-                },
-                "phase": {
-                    "description": "INSERT ADDITIONAL STATUS FIELD - define observed state of cluster\nImportant: Run \"make\" to regenerate code after modifying this file",
-                    "type": "string"
-                },
-                "podName": {
-                    "type": "string"
-                },
-                "portConfig": {
-                    "$ref": "#/definitions/v1.PortConfig"
+                "matchExpressions": {
+                    "description": "matchExpressions is a list of label selector requirements. The requirements are ANDed.\n+optional",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/v1.LabelSelectorRequirement"
+                    }
                 },
-                "reason": {
+                "matchLabels": {
+                    "description": "matchLabels is a map of {key,value} pairs. A single {key,value} in the matchLabels\nmap is equivalent to an element of matchExpressions, whose key field is \"key\", the\noperator is \"In\", and the values array contains only \"value\". The requirements are ANDed.\n+optional",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "v1.LabelSelectorOperator": {
+            "type": "string",
+            "enum": [
+                "In",
+                "NotIn",
+                "Exists",
+                "DoesNotExist"
+            ],
+            "x-enum-varnames": [
+                "LabelSelectorOpIn",
+                "LabelSelectorOpNotIn",
+                "LabelSelectorOpExists",
+                "LabelSelectorOpDoesNotExist"
+            ]
+        },
+        "v1.LabelSelectorRequirement": {
+            "type": "object",
+            "properties": {
+                "key": {
+                    "description": "key is the label key that the selector applies to.",
                     "type": "string"
                 },
-                "seleniumURL": {
-                    "type": "string"
+                "operator": {
+                    "description": "operator represents a key's relationship to a set of values.\nValid operators are In, NotIn, Exists and DoesNotExist.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/v1.LabelSelectorOperator"
+                        }
+                    ]
                 },
-                "vncPass": {
-                    "type": "string"
+                "values": {
+                    "description": "values is an array of string values. If the operator is In or NotIn,\nthe values array must be non-empty. If the operator is Exists or DoesNotExist,\nthe values array must be empty. This array is replaced during a strategic\nmerge patch.\n+optional",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 }
             }
         },
-        "v1.FieldsV1": {
-            "type": "object"
-        },
         "v1.ManagedFieldsEntry": {
             "type": "object",
             "properties": {
@@ -1118,6 +2474,100 @@ const docTemplate = `{
                 "ManagedFieldsOperationUpdate"
             ]
         },
+        "v1.NodeAffinity": {
+            "type": "object",
+            "properties": {
+                "preferredDuringSchedulingIgnoredDuringExecution": {
+                    "description": "The scheduler will prefer to schedule pods to nodes that satisfy\nthe affinity expressions specified by this field, but it may choose\na node that violates one or more of the expressions. The node that is\nmost preferred is the one with the greatest sum of weights, i.e.\nfor each node that meets all of the scheduling requirements (resource\nrequest, requiredDuringScheduling affinity expressions, etc.),\ncompute a sum by iterating through the elements of this field and adding\n\"weight\" to the sum if the node matches the corresponding matchExpressions; the\nnode(s) with the highest sum are the most preferred.\n+optional",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/v1.PreferredSchedulingTerm"
+                    }
+                },
+                "requiredDuringSchedulingIgnoredDuringExecution": {
+                    "description": "If the affinity requirements specified by this field are not met at\nscheduling time, the pod will not be scheduled onto the node.\nIf the affinity requirements specified by this field cease to be met\nat some point during pod execution (e.g. due to an update), the system\nmay or may not try to eventually evict the pod from its node.\n+optional",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/v1.NodeSelector"
+                        }
+                    ]
+                }
+            }
+        },
+        "v1.NodeSelector": {
+            "type": "object",
+            "properties": {
+                "nodeSelectorTerms": {
+                    "description": "Required. A list of node selector terms. The terms are ORed.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/v1.NodeSelectorTerm"
+                    }
+                }
+            }
+        },
+        "v1.NodeSelectorOperator": {
+            "type": "string",
+            "enum": [
+                "In",
+                "NotIn",
+                "Exists",
+                "DoesNotExist",
+                "Gt",
+                "Lt"
+            ],
+            "x-enum-varnames": [
+                "NodeSelectorOpIn",
+                "NodeSelectorOpNotIn",
+                "NodeSelectorOpExists",
+                "NodeSelectorOpDoesNotExist",
+                "NodeSelectorOpGt",
+                "NodeSelectorOpLt"
+            ]
+        },
+        "v1.NodeSelectorRequirement": {
+            "type": "object",
+            "properties": {
+                "key": {
+                    "description": "The label key that the selector applies to.",
+                    "type": "string"
+                },
+                "operator": {
+                    "description": "Represents a key's relationship to a set of values.\nValid operators are In, NotIn, Exists, DoesNotExist. Gt, and Lt.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/v1.NodeSelectorOperator"
+                        }
+                    ]
+                },
+                "values": {
+                    "description": "An array of string values. If the operator is In or NotIn,\nthe values array must be non-empty. If the operator is Exists or DoesNotExist,\nthe values array must be empty. If the operator is Gt or Lt, the values\narray must have a single element, which will be interpreted as an integer.\nThis array is replaced during a strategic merge patch.\n+optional",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "v1.NodeSelectorTerm": {
+            "type": "object",
+            "properties": {
+                "matchExpressions": {
+                    "description": "A list of node selector requirements by node's labels.\n+optional",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/v1.NodeSelectorRequirement"
+                    }
+                },
+                "matchFields": {
+                    "description": "A list of node selector requirements by node's fields.\n+optional",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/v1.NodeSelectorRequirement"
+                    }
+                }
+            }
+        },
         "v1.ObjectMeta": {
             "type": "object",
             "properties": {
@@ -1227,6 +2677,129 @@ const docTemplate = `{
                 }
             }
         },
+        "v1.PodAffinity": {
+            "type": "object",
+            "properties": {
+                "preferredDuringSchedulingIgnoredDuringExecution": {
+                    "description": "The scheduler will prefer to schedule pods to nodes that satisfy\nthe affinity expressions specified by this field, but it may choose\na node that violates one or more of the expressions. The node that is\nmost preferred is the one with the greatest sum of weights, i.e.\nfor each node that meets all of the scheduling requirements (resource\nrequest, requiredDuringScheduling affinity expressions, etc.),\ncompute a sum by iterating through the elements of this field and adding\n\"weight\" to the sum if the node has pods which matches the corresponding podAffinityTerm; the\nnode(s) with the highest sum are the most preferred.\n+optional",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/v1.WeightedPodAffinityTerm"
+                    }
+                },
+                "requiredDuringSchedulingIgnoredDuringExecution": {
+                    "description": "If the affinity requirements specified by this field are not met at\nscheduling time, the pod will not be scheduled onto the node.\nIf the affinity requirements specified by this field cease to be met\nat some point during pod execution (e.g. due to a pod label update), the\nsystem may or may not try to eventually evict the pod from its node.\nWhen there are multiple elements, the lists of nodes corresponding to each\npodAffinityTerm are intersected, i.e. all terms must be satisfied.\n+optional",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/v1.PodAffinityTerm"
+                    }
+                }
+            }
+        },
+        "v1.PodAffinityTerm": {
+            "type": "object",
+            "properties": {
+                "labelSelector": {
+                    "description": "A label query over a set of resources, in this case pods.\nIf it's null, this PodAffinityTerm matches with no Pods.\n+optional",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/v1.LabelSelector"
+                        }
+                    ]
+                },
+                "matchLabelKeys": {
+                    "description": "MatchLabelKeys is a set of pod label keys to select which pods will\nbe taken into consideration. The keys are used to lookup values from the\nincoming pod labels, those key-value labels are merged with ` + "`" + `LabelSelector` + "`" + ` as ` + "`" + `key in (value)` + "`" + `\nto select the group of existing pods which pods will be taken into consideration\nfor the incoming pod's pod (anti) affinity. Keys that don't exist in the incoming\npod labels will be ignored. The default value is empty.\nThe same key is forbidden to exist in both MatchLabelKeys and LabelSelector.\nAlso, MatchLabelKeys cannot be set when LabelSelector isn't set.\nThis is an alpha field and requires enabling MatchLabelKeysInPodAffinity feature gate.\n+listType=atomic\n+optional",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "mismatchLabelKeys": {
+                    "description": "MismatchLabelKeys is a set of pod label keys to select which pods will\nbe taken into consideration. The keys are used to lookup values from the\nincoming pod labels, those key-value labels are merged with ` + "`" + `LabelSelector` + "`" + ` as ` + "`" + `key notin (value)` + "`" + `\nto select the group of existing pods which pods will be taken into consideration\nfor the incoming pod's pod (anti) affinity. Keys that don't exist in the incoming\npod labels will be ignored. The default value is empty.\nThe same key is forbidden to exist in both MismatchLabelKeys and LabelSelector.\nAlso, MismatchLabelKeys cannot be set when LabelSelector isn't set.\nThis is an alpha field and requires enabling MatchLabelKeysInPodAffinity feature gate.\n+listType=atomic\n+optional",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "namespaceSelector": {
+                    "description": "A label query over the set of namespaces that the term applies to.\nThe term is applied to the union of the namespaces selected by this field\nand the ones listed in the namespaces field.\nnull selector and null or empty namespaces list means \"this pod's namespace\".\nAn empty selector ({}) matches all namespaces.\n+optional",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/v1.LabelSelector"
+                        }
+                    ]
+                },
+                "namespaces": {
+                    "description": "namespaces specifies a static list of namespace names that the term applies to.\nThe term is applied to the union of the namespaces listed in this field\nand the ones selected by namespaceSelector.\nnull or empty namespaces list and null namespaceSelector means \"this pod's namespace\".\n+optional",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "topologyKey": {
+                    "description": "This pod should be co-located (affinity) or not co-located (anti-affinity) with the pods matching\nthe labelSelector in the specified namespaces, where co-located is defined as running on a node\nwhose value of the label with key topologyKey matches that of any node on which any of the\nselected pods is running.\nEmpty topologyKey is not allowed.",
+                    "type": "string"
+                }
+            }
+        },
+        "v1.PodAntiAffinity": {
+            "type": "object",
+            "properties": {
+                "preferredDuringSchedulingIgnoredDuringExecution": {
+                    "description": "The scheduler will prefer to schedule pods to nodes that satisfy\nthe anti-affinity expressions specified by this field, but it may choose\na node that violates one or more of the expressions. The node that is\nmost preferred is the one with the greatest sum of weights, i.e.\nfor each node that meets all of the scheduling requirements (resource\nrequest, requiredDuringScheduling anti-affinity expressions, etc.),\ncompute a sum by iterating through the elements of this field and adding\n\"weight\" to the sum if the node has pods which matches the corresponding podAffinityTerm; the\nnode(s) with the highest sum are the most preferred.\n+optional",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/v1.WeightedPodAffinityTerm"
+                    }
+                },
+                "requiredDuringSchedulingIgnoredDuringExecution": {
+                    "description": "If the anti-affinity requirements specified by this field are not met at\nscheduling time, the pod will not be scheduled onto the node.\nIf the anti-affinity requirements specified by this field cease to be met\nat some point during pod execution (e.g. due to a pod label update), the\nsystem may or may not try to eventually evict the pod from its node.\nWhen there are multiple elements, the lists of nodes corresponding to each\npodAffinityTerm are intersected, i.e. all terms must be satisfied.\n+optional",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/v1.PodAffinityTerm"
+                    }
+                }
+            }
+        },
+        "v1.PodDNSConfig": {
+            "type": "object",
+            "properties": {
+                "nameservers": {
+                    "description": "A list of DNS name server IP addresses.\nThis will be appended to the base nameservers generated from DNSPolicy.\nDuplicated nameservers will be removed.\n+optional",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "options": {
+                    "description": "A list of DNS resolver options.\nThis will be merged with the base options generated from DNSPolicy.\nDuplicated entries will be removed. Resolution options given in Options\nwill override those that appear in the base DNSPolicy.\n+optional",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/v1.PodDNSConfigOption"
+                    }
+                },
+                "searches": {
+                    "description": "A list of DNS search domains for host-name lookup.\nThis will be appended to the base search paths generated from DNSPolicy.\nDuplicated search paths will be removed.\n+optional",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "v1.PodDNSConfigOption": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "description": "Required.",
+                    "type": "string"
+                },
+                "value": {
+                    "description": "+optional",
+                    "type": "string"
+                }
+            }
+        },
         "v1.PortConfig": {
             "type": "object",
             "properties": {
@@ -1249,6 +2822,155 @@ const docTemplate = `{
                     "type": "string"
                 }
             }
+        },
+        "v1.PreferredSchedulingTerm": {
+            "type": "object",
+            "properties": {
+                "preference": {
+                    "description": "A node selector term, associated with the corresponding weight.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/v1.NodeSelectorTerm"
+                        }
+                    ]
+                },
+                "weight": {
+                    "description": "Weight associated with matching the corresponding nodeSelectorTerm, in the range 1-100.",
+                    "type": "integer"
+                }
+            }
+        },
+        "v1.ResourceClaim": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "description": "Name must match the name of one entry in pod.spec.resourceClaims of\nthe Pod where this field is used. It makes that resource available\ninside a container.",
+                    "type": "string"
+                }
+            }
+        },
+        "v1.ResourceList": {
+            "type": "object",
+            "additionalProperties": {
+                "$ref": "#/definitions/resource.Quantity"
+            }
+        },
+        "v1.ResourceRequirements": {
+            "type": "object",
+            "properties": {
+                "claims": {
+                    "description": "Claims lists the names of resources, defined in spec.resourceClaims,\nthat are used by this container.\n\nThis is an alpha field and requires enabling the\nDynamicResourceAllocation feature gate.\n\nThis field is immutable. It can only be set for containers.\n\n+listType=map\n+listMapKey=name\n+featureGate=DynamicResourceAllocation\n+optional",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/v1.ResourceClaim"
+                    }
+                },
+                "limits": {
+                    "description": "Limits describes the maximum amount of compute resources allowed.\nMore info: https://kubernetes.io/docs/concepts/configuration/manage-resources-containers/\n+optional",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/v1.ResourceList"
+                        }
+                    ]
+                },
+                "requests": {
+                    "description": "Requests describes the minimum amount of compute resources required.\nIf Requests is omitted for a container, it defaults to Limits if that is explicitly specified,\notherwise to an implementation-defined value. Requests cannot exceed Limits.\nMore info: https://kubernetes.io/docs/concepts/configuration/manage-resources-containers/\n+optional",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/v1.ResourceList"
+                        }
+                    ]
+                }
+            }
+        },
+        "v1.TaintEffect": {
+            "type": "string",
+            "enum": [
+                "NoSchedule",
+                "PreferNoSchedule",
+                "NoExecute"
+            ],
+            "x-enum-varnames": [
+                "TaintEffectNoSchedule",
+                "TaintEffectPreferNoSchedule",
+                "TaintEffectNoExecute"
+            ]
+        },
+        "v1.TerminationReason": {
+            "type": "string",
+            "enum": [
+                "IdleTimeout",
+                "SessionTimeout",
+                "ClientQuit",
+                "BrowserCrash"
+            ],
+            "x-enum-varnames": [
+                "TerminationReasonIdleTimeout",
+                "TerminationReasonSessionTimeout",
+                "TerminationReasonClientQuit",
+                "TerminationReasonBrowserCrash"
+            ]
+        },
+        "v1.Toleration": {
+            "type": "object",
+            "properties": {
+                "effect": {
+                    "description": "Effect indicates the taint effect to match. Empty means match all taint effects.\nWhen specified, allowed values are NoSchedule, PreferNoSchedule and NoExecute.\n+optional",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/v1.TaintEffect"
+                        }
+                    ]
+                },
+                "key": {
+                    "description": "Key is the taint key that the toleration applies to. Empty means match all taint keys.\nIf the key is empty, operator must be Exists; this combination means to match all values and all keys.\n+optional",
+                    "type": "string"
+                },
+                "operator": {
+                    "description": "Operator represents a key's relationship to the value.\nValid operators are Exists and Equal. Defaults to Equal.\nExists is equivalent to wildcard for value, so that a pod can\ntolerate all taints of a particular category.\n+optional",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/v1.TolerationOperator"
+                        }
+                    ]
+                },
+                "tolerationSeconds": {
+                    "description": "TolerationSeconds represents the period of time the toleration (which must be\nof effect NoExecute, otherwise this field is ignored) tolerates the taint. By default,\nit is not set, which means tolerate the taint forever (do not evict). Zero and\nnegative values will be treated as 0 (evict immediately) by the system.\n+optional",
+                    "type": "integer"
+                },
+                "value": {
+                    "description": "Value is the taint value the toleration matches to.\nIf the operator is Exists, the value should be empty, otherwise just a regular string.\n+optional",
+                    "type": "string"
+                }
+            }
+        },
+        "v1.TolerationOperator": {
+            "type": "string",
+            "enum": [
+                "Exists",
+                "Equal"
+            ],
+            "x-enum-varnames": [
+                "TolerationOpExists",
+                "TolerationOpEqual"
+            ]
+        },
+        "v1.WeightedPodAffinityTerm": {
+            "type": "object",
+            "properties": {
+                "podAffinityTerm": {
+                    "description": "Required. A pod affinity term, associated with the corresponding weight.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/v1.PodAffinityTerm"
+                        }
+                    ]
+                },
+                "weight": {
+                    "description": "weight associated with matching the corresponding podAffinityTerm,\nin the range 1-100.",
+                    "type": "integer"
+                }
+            }
         }
     }
 }`
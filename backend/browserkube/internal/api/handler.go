@@ -14,6 +14,7 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/go-chi/chi/v5"
@@ -24,17 +25,21 @@ import (
 	"go.uber.org/fx"
 	"go.uber.org/zap"
 	netwebsocket "golang.org/x/net/websocket"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/browserkube/browserkube/browserkube/internal/provision"
 	"github.com/browserkube/browserkube/browserkube/internal/snippet"
+	"github.com/browserkube/browserkube/browserkube/internal/sso"
 	browserkubev1 "github.com/browserkube/browserkube/operator/api/v1"
+	"github.com/browserkube/browserkube/operator/pkg/browserset"
 	browserkubehttp "github.com/browserkube/browserkube/pkg/http"
 	"github.com/browserkube/browserkube/pkg/opentelemetry"
 	"github.com/browserkube/browserkube/pkg/session"
 	"github.com/browserkube/browserkube/pkg/sessionresult"
 	browserkubeutil "github.com/browserkube/browserkube/pkg/util"
 	"github.com/browserkube/browserkube/pkg/util/broadcast"
+	"github.com/browserkube/browserkube/pkg/vnc"
 	"github.com/browserkube/browserkube/pkg/wd/wdproto"
 	"github.com/browserkube/browserkube/storage"
 )
@@ -42,6 +47,7 @@ import (
 const (
 	defaultBatchFrameDuration = 3 * time.Second
 	defaultPageSize           = 20
+	vncHandshakeTimeout       = 10 * time.Second
 	keySessionID              = "sessionID"
 	screenshotID              = "screenshotID"
 	CommandsPath              = "/commands"
@@ -60,39 +66,52 @@ func initRoutes(mux chi.Router, h *handler) {
 		} else {
 			r.Use(opentelemetry.NewMetricsMiddleware("api"))
 		}
+		r.Use(h.sso.Middleware)
+		r.Use(browserkubehttp.Authenticate(h.auth, false))
+		r.Use(provision.TenantMiddleware(h.envConfig))
+		r.Use(provision.RoleMiddleware(h.envConfig, h.roles))
+		r.Use(provision.Authorize(provision.ActionView, nil))
+		authorized := func(action provision.Action) func(http.Handler) http.Handler {
+			return provision.Authorize(action, h.requestedSession)
+		}
 
+		r.Get("/me", browserkubehttp.Handler(h.me))
 		r.Route("/sessions", func(r chi.Router) {
+			// artifacts are stored by session ID, they are served for sessions of the caller's tenant only
+			artifacts := r.With(authorized(provision.ActionView), h.knownSession)
 			r.Get("/", browserkubehttp.Handler(h.sessions))
-			r.Delete("/{sessionID}", browserkubehttp.Handler(h.deleteSessionResult))
+			r.With(authorized(provision.ActionDeleteResult)).Delete("/{sessionID}", browserkubehttp.Handler(h.deleteSessionResult))
 
-			r.Get("/{sessionID}/commands", browserkubehttp.Handler(h.getListCommands))
+			artifacts.Get("/{sessionID}/commands", browserkubehttp.Handler(h.getListCommands))
 
-			r.Post("/{sessionID}/screenshots", browserkubehttp.Handler(h.createScreenshot))
-			r.Get("/{sessionID}/screenshots/{screenshotID}", browserkubehttp.Handler(h.getScreenshotByID))
+			r.With(authorized(provision.ActionControl)).Post("/{sessionID}/screenshots", browserkubehttp.Handler(h.createScreenshot))
+			artifacts.Get("/{sessionID}/screenshots/{screenshotID}", browserkubehttp.Handler(h.getScreenshotByID))
 
-			r.Get("/{sessionID}/screenshots", browserkubehttp.Handler(h.getListScreenshots))
-			r.Get("/{sessionID}/files/*", browserkubehttp.Handler(h.getSessionFile))
+			artifacts.Get("/{sessionID}/screenshots", browserkubehttp.Handler(h.getListScreenshots))
+			artifacts.Get("/{sessionID}/files/*", browserkubehttp.Handler(h.getSessionFile))
 		})
 
 		r.Route("/results", func(r chi.Router) {
 			r.Get("/", browserkubehttp.Handler(h.results))
-			r.Get("/{sessionID}", browserkubehttp.Handler(h.resultByID))
+			r.With(authorized(provision.ActionView)).Get("/{sessionID}", browserkubehttp.Handler(h.resultByID))
 		})
 		r.Get("/status", browserkubehttp.Handler(h.status))
 		// Deprecated
 		r.Get("/browsers", browserkubehttp.Handler(h.browsers))
 		//-
+		r.With(provision.Authorize(provision.ActionEditBrowserSets, nil)).Put("/browsersets/{name}", browserkubehttp.Handler(h.updateBrowserSet))
 		r.HandleFunc("/events", h.events)
 
-		r.Handle("/logs/{sessionID}", h.logs())
-		r.Handle("/vnc/{sessionID}", h.vnc())
-		r.HandleFunc("/devtools/{sessionID}", h.reverseProxy(func(s *session.Session) string {
+		r.With(authorized(provision.ActionView)).Handle("/logs/{sessionID}", h.logs())
+		// VNC input is dropped unless the caller is allowed to control the session
+		r.With(authorized(provision.ActionView)).Handle("/vnc/{sessionID}", h.vnc())
+		r.With(authorized(provision.ActionControl)).HandleFunc("/devtools/{sessionID}", h.reverseProxy(func(s *session.Session) string {
 			return s.Browser.Status.PortConfig.DevTools
 		}))
-		r.HandleFunc("/download/{sessionID}", h.reverseProxy(func(s *session.Session) string {
+		r.With(authorized(provision.ActionView)).HandleFunc("/download/{sessionID}", h.reverseProxy(func(s *session.Session) string {
 			return s.Browser.Status.PortConfig.FileServer
 		}))
-		r.HandleFunc("/clipboard/{sessionID}", h.reverseProxy(func(s *session.Session) string {
+		r.With(authorized(provision.ActionControl)).HandleFunc("/clipboard/{sessionID}", h.reverseProxy(func(s *session.Session) string {
 			return s.Browser.Status.PortConfig.Clipboard
 		}))
 
@@ -104,6 +123,11 @@ type handler struct {
 	sessionRepo           session.Repository
 	sessionResultsRepo    sessionresult.Repository
 	provisioner           provision.Provisioner
+	queues                provision.TenantQueues
+	envConfig             *provision.Config
+	auth                  browserkubehttp.Authenticator
+	roles                 provision.RoleResolver
+	sso                   *sso.Sessions
 	upgrader              websocket.Upgrader
 	logger                *zap.SugaredLogger
 	startTime             time.Time
@@ -117,6 +141,11 @@ func newHandler(
 	sessionRepo session.Repository,
 	sessionResultsRepo sessionresult.Repository,
 	provisioner provision.Provisioner,
+	queues provision.TenantQueues,
+	envConfig *provision.Config,
+	auth browserkubehttp.Authenticator,
+	roles provision.RoleResolver,
+	sso *sso.Sessions,
 	sessionStorage storage.BlobSessionStorage,
 ) *handler {
 	provider, err := opentelemetry.InitProvider("api")
@@ -129,9 +158,15 @@ func newHandler(
 		sessionRepo:        sessionRepo,
 		sessionResultsRepo: sessionResultsRepo,
 		provisioner:        provisioner,
+		queues:             queues,
+		envConfig:          envConfig,
+		auth:               auth,
+		roles:              roles,
+		sso:                sso,
 		upgrader: websocket.Upgrader{
 			ReadBufferSize:  1024,
 			WriteBufferSize: 1024,
+			// origins of requests authenticated by the session cookie are checked by sso.Sessions
 			CheckOrigin: func(r *http.Request) bool {
 				return true
 			},
@@ -171,27 +206,49 @@ func (h *handler) events(w http.ResponseWriter, rq *http.Request) {
 		}
 	}
 
+	// events of the caller's tenant only
+	namespace := h.envConfig.Namespace(rq.Context())
 	go func() {
 		ctx, cancelFunc := context.WithCancel(context.Background())
 		defer cancelFunc()
 		defer browserkubeutil.CloseQuietly(ws)
 
+		// websocket connection doesn't support concurrent writes
+		var wsMu sync.Mutex
+		go func() {
+			// queue positions are changed without session events
+			for range h.queues.For(namespace).Watch(ctx) {
+				wsMu.Lock()
+				wErr := h.wsStatus(ws, namespace)
+				wsMu.Unlock()
+				if wErr != nil {
+					h.logger.Error(wErr)
+					cancelFunc()
+				}
+			}
+		}()
+
 		sessions := h.sessionRepo.Watch(ctx)
 		batcher := broadcast.NewBatcher[*session.Session](barchFrame, false)
 
 		bErr := batcher.Batch(ctx, sessions, func(batch []*session.Session) error {
+			wsMu.Lock()
+			defer wsMu.Unlock()
+
 			// Events
-			deduplicated := browserkubeutil.ReverseDeduplicateBY[*session.Session, string](batch, func(s *session.Session) string {
-				return s.ID
-			})
-			sess := browserkubeutil.Map[*session.Session, *Session](deduplicated, h.toSession)
-			wErr := ws.WriteJSON(NewWSMessage("session", sess))
-			if wErr != nil {
-				return errors.WithStack(wErr)
+			deduplicated := browserkubeutil.ReverseDeduplicateBY[*session.Session, string](inNamespace(batch, namespace),
+				func(s *session.Session) string {
+					return s.ID
+				})
+			if len(deduplicated) > 0 {
+				sess := browserkubeutil.Map[*session.Session, *Session](deduplicated, h.toSession)
+				if wErr := ws.WriteJSON(NewWSMessage("session", sess)); wErr != nil {
+					return errors.WithStack(wErr)
+				}
 			}
 
 			// Stats
-			return h.wsStatus(ws)
+			return h.wsStatus(ws, namespace)
 		})
 		if bErr != nil {
 			h.logger.Error(bErr)
@@ -208,11 +265,12 @@ func (h *handler) events(w http.ResponseWriter, rq *http.Request) {
 //	@Success		200	{object}	[]Session
 //	@Failure		500	{string}	Internal	Server	Error
 //	@Router			/sessions [get]
-func (h *handler) sessions(w http.ResponseWriter, _ *http.Request) error {
+func (h *handler) sessions(w http.ResponseWriter, rq *http.Request) error {
 	sessions, err := h.sessionRepo.FindAll()
 	if err != nil {
 		return browserkubehttp.NewHTTPErr(http.StatusInternalServerError, errors.WithStack(err))
 	}
+	sessions = inNamespace(sessions, h.envConfig.Namespace(rq.Context()))
 	sort.Slice(sessions, func(i, j int) bool {
 		ts1 := sessions[i].Browser.CreationTimestamp
 		ts2 := sessions[j].Browser.CreationTimestamp
@@ -305,35 +363,39 @@ func (h *handler) resultByID(w http.ResponseWriter, rq *http.Request) error {
 //	@Success		200	{object}	Status
 //	@Failure		500	{string}	Internal	Server	Error
 //	@Router			/status [get]
-func (h *handler) status(w http.ResponseWriter, _ *http.Request) error {
-	qCurrent, qMax, err := h.sessionRepo.Quota()
+func (h *handler) status(w http.ResponseWriter, rq *http.Request) error {
+	st, err := h.getStatus(h.envConfig.Namespace(rq.Context()))
 	if err != nil {
 		return browserkubehttp.NewHTTPErr(http.StatusInternalServerError, errors.WithStack(err))
 	}
-	var qConnecting, qQueued, qRunning int
-	sessions, err := h.sessionRepo.FindAll()
-	if err != nil {
-		return browserkubehttp.NewHTTPErr(http.StatusInternalServerError, errors.WithStack(err))
+	return errors.WithStack(browserkubehttp.WriteJSON(w, http.StatusOK, st))
+}
+
+// me godoc
+//
+//	@Summary		me
+//	@Description	get the caller and the actions the caller's role allows
+//	@Tags			auth
+//	@Produce		json
+//	@Success		200	{object}	Me
+//	@Router			/me [get]
+func (h *handler) me(w http.ResponseWriter, rq *http.Request) error {
+	ctx := rq.Context()
+	me := &Me{
+		User:        provision.UserFrom(ctx),
+		Role:        provision.RoleFrom(ctx),
+		Permissions: make(map[provision.Action]Permission, len(provision.Actions)),
 	}
-	for _, sess := range sessions {
-		switch sess.State {
-		case "pending":
-			if qCurrent > qMax {
-				qQueued++
-				break
-			}
-			qConnecting++
-		case "running":
-			qRunning++
-		}
+	if identity := browserkubehttp.IdentityFrom(ctx); identity != nil {
+		me.Groups = identity.Groups
 	}
-
-	return errors.WithStack(browserkubehttp.WriteJSON(w, http.StatusOK,
-		&Status{
-			QuotesLimit: qMax,
-			MaxTimeout:  time.Minute,
-			Stats:       StatusStats{All: qCurrent, Connecting: qConnecting, Queued: qQueued, Running: qRunning},
-		}))
+	if tenant := provision.TenantFrom(ctx); tenant != nil {
+		me.Tenant = tenant.Name
+	}
+	for _, action := range provision.Actions {
+		me.Permissions[action] = Permission{Own: me.Role.Allows(action, true), Others: me.Role.Allows(action, false)}
+	}
+	return errors.WithStack(browserkubehttp.WriteJSON(w, http.StatusOK, me))
 }
 
 // browsers godoc
@@ -350,48 +412,75 @@ func (h *handler) status(w http.ResponseWriter, _ *http.Request) error {
 func (h *handler) browsers(w http.ResponseWriter, rq *http.Request) error {
 	manualOnly, _ := strconv.ParseBool(rq.URL.Query().Get("manual"))
 
-	var mapping browserkubev1.BrowserSet
 	mappingList, err := h.provisioner.Available(rq.Context())
 	if err != nil {
 		return errors.WithStack(err)
 	}
-	if len(mappingList.Items) > 0 {
-		mapping = mappingList.Items[0]
-	}
+	browserset.Sort(mappingList.Items)
 
 	var browsers []Browser
-	for browserName, versions := range mapping.Spec.WebDriver {
-		for version, browserConfig := range versions.Versions {
-			browsers = append(browsers, Browser{
-				Name:        browserName,
-				Platform:    provision.PlatformLinux,
-				Version:     version,
-				Image:       browserConfig.Image,
-				Type:        browserkubev1.TypeWebDriver,
-				Resolutions: defaultResolutions,
-			})
-		}
-	}
-
-	if !manualOnly {
-		for browserName, versions := range mapping.Spec.Playwright {
-			for version, browserConfig := range versions.Versions {
-				browsers = append(browsers, Browser{
-					Name:        browserName,
-					Platform:    provision.PlatformLinux,
-					Version:     version,
-					Image:       browserConfig.Image,
-					Type:        browserkubev1.TypePlaywright,
-					Resolutions: defaultResolutions,
-				})
-			}
+	for _, entry := range browserset.Catalogue(mappingList.Items) {
+		if manualOnly && entry.Type != browserkubev1.TypeWebDriver {
+			continue
 		}
+		browsers = append(browsers, Browser{
+			Name:        entry.Name,
+			Platform:    provision.PlatformLinux,
+			Version:     entry.Version,
+			Image:       entry.Config.Image,
+			Type:        entry.Type,
+			BrowserSet:  entry.Set,
+			Default:     entry.Default,
+			Resolutions: defaultResolutions,
+		})
 	}
 	h.sortBrowsers(browsers)
 
 	return errors.WithStack(browserkubehttp.WriteJSON(w, http.StatusOK, browsers))
 }
 
+// updateBrowserSet godoc
+//
+//	@Summary		updateBrowserSet
+//	@Description	replace the spec of the BrowserSet of the caller's tenant
+//	@Tags			browsers
+//	@Accept			json
+//	@Param			name	path		string							true	"BrowserSet name"
+//	@Param			spec	body		browserkubev1.BrowserSetSpec	true	"BrowserSet spec"
+//	@Success		204		{string}	ok
+//	@Failure		400		{string}	Bad			request
+//	@Failure		403		{string}	Forbidden
+//	@Failure		404		{string}	NotFound
+//	@Failure		500		{string}	Internal	Server	Error
+//	@Router			/browsersets/{name} [put]
+func (h *handler) updateBrowserSet(w http.ResponseWriter, rq *http.Request) error {
+	bs := &browserkubev1.BrowserSet{ObjectMeta: metav1.ObjectMeta{Name: chi.URLParam(rq, "name")}}
+	if err := json.NewDecoder(rq.Body).Decode(&bs.Spec); err != nil {
+		return browserkubehttp.NewHTTPErr(http.StatusBadRequest, errors.Wrap(err, "unable to decode BrowserSet spec"))
+	}
+	if err := h.provisioner.Update(rq.Context(), bs); err != nil {
+		return browserkubehttp.NewHTTPErr(updateStatus(err), errors.WithStack(err))
+	}
+	h.logger.Infof("BrowserSet [%s] has been updated by [%s]", bs.Name, provision.UserFrom(rq.Context()))
+	return errors.WithStack(browserkubehttp.WriteJSON(w, http.StatusNoContent, nil))
+}
+
+// updateStatus maps errors of updating BrowserSets to response statuses,
+// e.g. specs rejected by the validating webhook are bad requests
+func updateStatus(err error) int {
+	var status apierrors.APIStatus
+	switch {
+	case errors.Is(err, provision.ErrForbidden):
+		return http.StatusForbidden
+	case apierrors.IsNotFound(err):
+		return http.StatusNotFound
+	case errors.As(err, &status) && status.Status().Code < http.StatusInternalServerError:
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
 // deleteSessionResult godoc
 //
 //	@Summary		deleteSessionResult
@@ -441,7 +530,7 @@ func (h *handler) createScreenshot(w http.ResponseWriter, rq *http.Request) erro
 
 	logger := h.logger.With("session_id", sessionID)
 
-	sess, err := h.sessionRepo.FindByID(sessionID)
+	sess, err := h.findSession(rq.Context(), sessionID)
 	if err != nil {
 		logger.Errorf("unable to find session: %v", err)
 		return browserkubehttp.NewHTTPErr(http.StatusNotFound, errors.Wrap(err, "unable to find session"))
@@ -503,7 +592,7 @@ func (h *handler) getScreenshotByID(w http.ResponseWriter, rq *http.Request) err
 		return browserkubehttp.NewHTTPErr(http.StatusBadRequest, fmt.Errorf("screenshot name not found"))
 	}
 
-	sess, err := h.sessionRepo.FindByID(sessionID)
+	sess, err := h.findSession(rq.Context(), sessionID)
 	if err != nil {
 		logger.Errorf("unable to find session: %v", err)
 		return browserkubehttp.NewHTTPErr(http.StatusNotFound, errors.Wrap(err, "unable to find session"))
@@ -691,13 +780,13 @@ func (h *handler) logs() netwebsocket.Handler {
 
 		logger = logger.With("session_id", sessionID)
 
-		sess, err := h.sessionRepo.FindByID(sessionID)
+		sess, err := h.findSession(wsconn.Request().Context(), sessionID)
 		if err != nil {
 			logger.Errorf("unable to find session: %v", err)
 			return
 		}
 
-		logs, err := h.provisioner.Logs(wsconn.Request().Context(), sess.Browser.Status.PodName, true)
+		logs, err := h.provisioner.Logs(wsconn.Request().Context(), sess.Browser, true)
 		if err != nil {
 			logger.Errorf("stream logs error: %v", err)
 			return
@@ -721,7 +810,7 @@ func (h *handler) vnc() netwebsocket.Handler {
 			return
 		}
 
-		sess, err := h.sessionRepo.FindByID(sessionID)
+		sess, err := h.findSession(wsconn.Request().Context(), sessionID)
 		if err != nil {
 			h.logger.With("request",
 				fmt.Sprintf("%s %s", wsconn.Request().Method, wsconn.Request().URL.Path)).Error("session ID not found")
@@ -737,6 +826,12 @@ func (h *handler) vnc() netwebsocket.Handler {
 		)
 		logger.Infof("vnc request: %s", host)
 
+		password, err := h.provisioner.VNCPassword(wsconn.Request().Context(), sess.Browser)
+		if err != nil {
+			logger.Errorf("unable to get vnc password: %v", err)
+			return
+		}
+
 		var dialer net.Dialer
 		conn, err := dialer.DialContext(wsconn.Request().Context(), "tcp", host)
 		if err != nil {
@@ -745,14 +840,43 @@ func (h *handler) vnc() netwebsocket.Handler {
 		}
 		defer browserkubeutil.CloseQuietly(conn)
 
+		// the backend authenticates to VNC server itself so the password is never sent to the client
+		if err = conn.SetDeadline(time.Now().Add(vncHandshakeTimeout)); err != nil {
+			logger.Errorf("vnc connection error: %v", err)
+			return
+		}
+		if err = vnc.Authenticate(conn, password); err != nil {
+			logger.Errorf("vnc authentication error: %v", err)
+			return
+		}
+		if err = conn.SetDeadline(time.Time{}); err != nil {
+			logger.Errorf("vnc connection error: %v", err)
+			return
+		}
+
 		wsconn.PayloadType = netwebsocket.BinaryFrame
+		if err = vnc.AcceptNoAuth(wsconn); err != nil {
+			logger.Errorf("vnc client handshake error: %v", err)
+			return
+		}
+
 		go func() {
 			if _, err := io.Copy(wsconn, conn); err != nil {
 				logger.Errorf("ws connection error: %s", err)
 			}
 			logger.Warnf("vnc connection closed")
 		}()
-		if _, err := io.Copy(conn, wsconn); err != nil {
+		// callers not allowed to control the session only watch it
+		viewOnly, _ := strconv.ParseBool(wsconn.Request().URL.Query().Get("viewOnly"))
+		if err = provision.CheckAction(wsconn.Request().Context(), provision.ActionControl, sess.Browser); err != nil {
+			viewOnly = true
+		}
+		if viewOnly {
+			err = vnc.CopyViewOnly(conn, wsconn)
+		} else {
+			_, err = io.Copy(conn, wsconn)
+		}
+		if err != nil {
 			logger.Errorf("ws connection error: %s", err)
 		}
 		logger.Infof("vnc client disconnected")
@@ -778,7 +902,7 @@ func (h *handler) reverseProxy(portF func(s *session.Session) string) func(http.
 		logger := h.logger.With("session", sessionID)
 
 		// load the session
-		sess, err := h.sessionRepo.FindByID(sessionID)
+		sess, err := h.findSession(r.Context(), sessionID)
 		if err != nil || sess == nil {
 			logger.Error("unable to find session")
 			r.URL.Path = "/error"
@@ -802,22 +926,41 @@ func (h *handler) reverseProxy(portF func(s *session.Session) string) func(http.
 }
 
 func (h *handler) toSession(sess *session.Session) *Session {
-	return &Session{
-		ID:               sess.ID,
-		Name:             sess.Caps.BrowserKubeOpts.Name,
-		State:            sess.State,
-		Platform:         provision.PlatformLinux,
-		Manual:           sess.Caps.BrowserKubeOpts.Manual,
-		ScreenResolution: sess.Caps.BrowserKubeOpts.ScreenResolution,
-		Browser:          sess.Browser.Spec.BrowserName,
-		BrowserVersion:   sess.Browser.Spec.BrowserVersion,
-		Image:            sess.Browser.Status.Image,
-		LogsOn:           true,
-		CreatedAt:        Timestamp(sess.Browser.CreationTimestamp.Time),
-		Type:             sess.Caps.BrowserKubeOpts.Type,
-		VideoRecOn:       sess.Caps.BrowserKubeOpts.EnableVideo,
-		VncOn:            sess.Caps.BrowserKubeOpts.EnableVNC,
-		VncPsw:           sess.Browser.Status.VncPass,
+	s := &Session{
+		ID:                sess.ID,
+		Name:              sess.Caps.BrowserKubeOpts.Name,
+		State:             sess.State,
+		Platform:          provision.PlatformLinux,
+		Manual:            sess.Caps.BrowserKubeOpts.Manual,
+		ScreenResolution:  sess.Caps.BrowserKubeOpts.ScreenResolution,
+		Browser:           sess.Browser.Spec.BrowserName,
+		BrowserVersion:    sess.Browser.Spec.BrowserVersion,
+		Image:             sess.Browser.Status.Image,
+		LogsOn:            true,
+		CreatedAt:         Timestamp(sess.Browser.CreationTimestamp.Time),
+		Type:              sess.Caps.BrowserKubeOpts.Type,
+		VideoRecOn:        sess.Caps.BrowserKubeOpts.EnableVideo,
+		VncOn:             sess.Caps.BrowserKubeOpts.EnableVNC,
+		TerminationReason: string(sess.Browser.Status.TerminationReason),
+		Owner:             provision.Owner(sess.Browser),
+	}
+	setRecording(s, sess.Browser.Status.Recording)
+	return s
+}
+
+// setRecording fills video options of the session with the ones the video is recorded with
+func setRecording(s *Session, recording *browserkubev1.BrowserRecording) {
+	if recording == nil {
+		return
+	}
+	s.VideoCodec = recording.Codec
+	s.VideoFps = int(recording.FrameRate)
+	if width, height, ok := strings.Cut(recording.VideoSize, "x"); ok {
+		w, wErr := strconv.Atoi(width)
+		h, hErr := strconv.Atoi(height)
+		if wErr == nil && hErr == nil {
+			s.VideoSize = &Resolution{Width: w, Height: h}
+		}
 	}
 }
 
@@ -828,58 +971,116 @@ func (h *handler) toSessionResult(sess *sessionresult.Result) (*SessionResult, e
 	}
 	sr := &SessionResult{
 		Session: Session{
-			State:            "Terminated",
-			ID:               sess.Name,
-			Name:             caps.BrowserKubeOpts.Name,
-			Platform:         provision.PlatformLinux,
-			Manual:           caps.BrowserKubeOpts.Manual,
-			ScreenResolution: caps.BrowserKubeOpts.ScreenResolution,
-			Browser:          sess.Spec.Browser.BrowserName,
-			BrowserVersion:   sess.Spec.Browser.BrowserVersion,
-			Image:            sess.Spec.BrowserImage,
-			VncOn:            sess.Spec.Browser.EnableVNC,
-			LogsOn:           true,
-			LogsRefAddr:      sessionresult.BrowserLogFileName,
-			CreatedAt:        Timestamp(sess.Spec.StartedAt.Time),
-			Type:             caps.BrowserKubeOpts.Type,
+			State:             "Terminated",
+			ID:                sess.Name,
+			Name:              caps.BrowserKubeOpts.Name,
+			Platform:          provision.PlatformLinux,
+			Manual:            caps.BrowserKubeOpts.Manual,
+			ScreenResolution:  caps.BrowserKubeOpts.ScreenResolution,
+			Browser:           sess.Spec.Browser.BrowserName,
+			BrowserVersion:    sess.Spec.Browser.BrowserVersion,
+			Image:             sess.Spec.BrowserImage,
+			VncOn:             sess.Spec.Browser.EnableVNC,
+			LogsOn:            true,
+			LogsRefAddr:       sessionresult.BrowserLogFileName,
+			CreatedAt:         Timestamp(sess.Spec.StartedAt.Time),
+			Type:              caps.BrowserKubeOpts.Type,
+			TerminationReason: string(sess.Spec.TerminationReason),
+			Owner:             provision.Owner(&sess.SessionResult),
 		},
 	}
 
 	if caps.BrowserKubeOpts.EnableVideo {
-		sr.Session.VideoRefAddr = sessionresult.VideoFileName
+		sr.Session.VideoRefAddr, _ = sessionresult.VideoFile(sess.Spec.Recording)
+		setRecording(&sr.Session, sess.Spec.Recording)
 	}
 
 	return sr, nil
 }
 
-func (h *handler) wsStatus(ws *websocket.Conn) error {
-	qCurrent, qMax, err := h.sessionRepo.Quota()
+func (h *handler) wsStatus(ws *websocket.Conn, namespace string) error {
+	st, err := h.getStatus(namespace)
 	if err != nil {
 		return errors.WithStack(err)
 	}
-	var qConnecting, qQueued, qRunning int
+	return errors.WithStack(ws.WriteJSON(NewWSMessage("status", st)))
+}
+
+// getStatus returns quota, sessions and queue of the tenant namespace
+func (h *handler) getStatus(namespace string) (*Status, error) {
+	qCurrent, qMax, err := h.sessionRepo.Quota(namespace)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	var qConnecting, qRunning int
 	sessions, err := h.sessionRepo.FindAll()
 	if err != nil {
-		return errors.WithStack(err)
+		return nil, errors.WithStack(err)
 	}
-	for _, sess := range sessions {
+	for _, sess := range inNamespace(sessions, namespace) {
 		switch sess.State {
 		case "pending":
-			if qCurrent > qMax {
-				qQueued++
-				break
-			}
 			qConnecting++
 		case "running":
 			qRunning++
 		}
 	}
-	wErr := ws.WriteJSON(NewWSMessage("status", &Status{
+	queue := h.queues.For(namespace).Queued()
+	return &Status{
 		QuotesLimit: qMax,
 		MaxTimeout:  time.Minute,
-		Stats:       StatusStats{All: qCurrent, Connecting: qConnecting, Queued: qQueued, Running: qRunning},
-	}))
-	return errors.WithStack(wErr)
+		Stats:       StatusStats{All: qCurrent, Connecting: qConnecting, Queued: len(queue), Running: qRunning},
+		Queue:       queue,
+	}, nil
+}
+
+// findSession loads the session if it belongs to the caller's tenant
+func (h *handler) findSession(ctx context.Context, sessionID string) (*session.Session, error) {
+	sess, err := h.sessionRepo.FindByID(sessionID)
+	if err != nil {
+		return nil, err
+	}
+	if ns := h.envConfig.Namespace(ctx); sess.Browser.Namespace != ns {
+		return nil, errors.Errorf("session [%s] isn't found in namespace [%s]", sessionID, ns)
+	}
+	return sess, nil
+}
+
+// requestedSession loads the browser of the running session or the result of the finished one.
+// Running sessions are looked up in all namespaces, provision.Authorize hides the ones of other tenants
+func (h *handler) requestedSession(rq *http.Request) metav1.Object {
+	sessionID := chi.URLParam(rq, keySessionID)
+	if sess, err := h.sessionRepo.FindByID(sessionID); err == nil && sess != nil && sess.Browser != nil {
+		return sess.Browser
+	}
+	if result, err := h.sessionResultsRepo.FindByID(rq.Context(), sessionID); err == nil && result != nil {
+		return &result.SessionResult
+	}
+	return nil
+}
+
+// knownSession responds 404 to requests to sessions which neither run nor have a result in the caller's tenant
+func (h *handler) knownSession(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, rq *http.Request) {
+		if h.requestedSession(rq) == nil {
+			_ = browserkubehttp.WriteJSON(w, http.StatusNotFound, map[string]string{
+				"error": fmt.Sprintf("session [%s] isn't found", chi.URLParam(rq, keySessionID)),
+			})
+			return
+		}
+		next.ServeHTTP(w, rq)
+	})
+}
+
+// inNamespace filters sessions of the tenant namespace
+func inNamespace(sessions []*session.Session, namespace string) []*session.Session {
+	filtered := make([]*session.Session, 0, len(sessions))
+	for _, sess := range sessions {
+		if sess.Browser.Namespace == namespace {
+			filtered = append(filtered, sess)
+		}
+	}
+	return filtered
 }
 
 func (h *handler) sortBrowsers(browsers []Browser) {
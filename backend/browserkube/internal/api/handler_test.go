@@ -1,11 +1,27 @@
 package api
 
 import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
 
+	"github.com/go-chi/chi/v5"
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
+	"github.com/browserkube/browserkube/browserkube/internal/playwright/mocks"
+	"github.com/browserkube/browserkube/browserkube/internal/provision"
 	v1 "github.com/browserkube/browserkube/operator/api/v1"
+	browserkubehttp "github.com/browserkube/browserkube/pkg/http"
+	"github.com/browserkube/browserkube/pkg/session"
+	"github.com/browserkube/browserkube/pkg/sessionresult"
+	"github.com/browserkube/browserkube/storage"
 )
 
 func Test_handler_sortBrowsers(t *testing.T) {
@@ -80,3 +96,162 @@ func Test_handler_sortBrowsers(t *testing.T) {
 		})
 	}
 }
+
+type testSessionRepo struct {
+	session.Repository
+	sessions []*session.Session
+}
+
+func (r *testSessionRepo) FindAll() ([]*session.Session, error) {
+	return r.sessions, nil
+}
+
+func (r *testSessionRepo) FindByID(id string) (*session.Session, error) {
+	for _, sess := range r.sessions {
+		if sess.ID == id {
+			return sess, nil
+		}
+	}
+	return nil, errors.Errorf("session [%s] isn't found", id)
+}
+
+// testResultsRepo finds results in the namespace of the caller's tenant like the k8s repository does
+type testResultsRepo struct {
+	sessionresult.Repository
+	cfg     *provision.Config
+	results []*sessionresult.Result
+}
+
+func (r *testResultsRepo) FindByID(ctx context.Context, name string) (*sessionresult.Result, error) {
+	for _, result := range r.results {
+		if result.Name == name && result.Namespace == r.cfg.Namespace(ctx) {
+			return result, nil
+		}
+	}
+	return nil, errors.Errorf("session result [%s] isn't found", name)
+}
+
+func testObjectMeta(name, namespace, owner string) metav1.ObjectMeta {
+	return metav1.ObjectMeta{Name: name, Namespace: namespace, Annotations: map[string]string{v1.AnnotationOwner: owner}}
+}
+
+func newTestRouter(t *testing.T, provisioner provision.Provisioner) http.Handler {
+	t.Helper()
+	cfg := &provision.Config{
+		BrowserNS:   "browserkube",
+		AuthEnabled: true,
+		Tenants:     []provision.Tenant{{Name: "team-b", Namespace: "team-b", Users: []string{"erin"}}},
+	}
+	sessionStorage, err := storage.New(context.Background(), "file://"+t.TempDir()+"/")
+	require.NoError(t, err)
+	require.NoError(t, sessionStorage.SaveFile(context.Background(), "erin-finished", "", &storage.BlobFile{
+		FileName: "browser.log", ContentType: "text/plain", Content: strings.NewReader("started"),
+	}))
+	h := &handler{
+		sessionRepo: &testSessionRepo{sessions: []*session.Session{
+			{ID: "alice-running", Browser: &v1.Browser{ObjectMeta: testObjectMeta("alice-running", "browserkube", "alice")}},
+			{ID: "erin-running", Browser: &v1.Browser{ObjectMeta: testObjectMeta("erin-running", "team-b", "erin")}},
+		}},
+		sessionResultsRepo: &testResultsRepo{cfg: cfg, results: []*sessionresult.Result{
+			{SessionResult: v1.SessionResult{ObjectMeta: testObjectMeta("alice-finished", "browserkube", "alice")}},
+			{SessionResult: v1.SessionResult{ObjectMeta: testObjectMeta("erin-finished", "team-b", "erin")}},
+		}},
+		provisioner: provisioner,
+		envConfig:   cfg,
+		auth: browserkubehttp.Credentials{
+			{Identity: browserkubehttp.Identity{User: "alice"}, Token: "alice-token"},
+			{Identity: browserkubehttp.Identity{User: "bob"}, Token: "bob-token"},
+			{Identity: browserkubehttp.Identity{User: "carol"}, Token: "carol-token"},
+			{Identity: browserkubehttp.Identity{User: "dan"}, Token: "dan-token"},
+			{Identity: browserkubehttp.Identity{User: "erin"}, Token: "erin-token"},
+		},
+		roles: &provision.RoleBindings{Bindings: []provision.RoleBinding{
+			{Role: provision.RoleOperator, Users: []string{"carol"}},
+			{Role: provision.RoleAdmin, Users: []string{"dan"}},
+		}},
+		logger:         zap.NewNop().Sugar(),
+		sessionStorage: sessionStorage,
+	}
+	r := chi.NewRouter()
+	initRoutes(r, h)
+	return r
+}
+
+func Test_handler_ownerChecks(t *testing.T) {
+	router := newTestRouter(t, nil)
+	tests := []struct {
+		name  string
+		path  string
+		token string
+		want  int
+	}{
+		{name: "own result", path: "/results/alice-finished", token: "alice-token", want: http.StatusOK},
+		{name: "result of operator", path: "/results/alice-finished", token: "carol-token", want: http.StatusOK},
+		{name: "own screenshots", path: "/sessions/alice-running/screenshots", token: "alice-token", want: http.StatusOK},
+		{name: "result", path: "/results/alice-finished", token: "bob-token", want: http.StatusForbidden},
+		{name: "commands", path: "/sessions/alice-finished/commands?pageSize=10", token: "bob-token", want: http.StatusForbidden},
+		{name: "screenshots", path: "/sessions/alice-running/screenshots", token: "bob-token", want: http.StatusForbidden},
+		{name: "screenshot", path: "/sessions/alice-running/screenshots/1.png", token: "bob-token", want: http.StatusForbidden},
+		{name: "files", path: "/sessions/alice-finished/files/video.mp4", token: "bob-token", want: http.StatusForbidden},
+		{name: "logs", path: "/logs/alice-running", token: "bob-token", want: http.StatusForbidden},
+		{name: "vnc", path: "/vnc/alice-running", token: "bob-token", want: http.StatusForbidden},
+		{name: "download", path: "/download/alice-running", token: "bob-token", want: http.StatusForbidden},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rq := httptest.NewRequest(http.MethodGet, tt.path, http.NoBody)
+			rq.Header.Set("Authorization", "Bearer "+tt.token)
+			rs := httptest.NewRecorder()
+			router.ServeHTTP(rs, rq)
+			require.Equal(t, tt.want, rs.Code, rs.Body.String())
+		})
+	}
+}
+
+func Test_handler_crossTenant(t *testing.T) {
+	router := newTestRouter(t, nil)
+	tests := []struct {
+		name  string
+		path  string
+		token string
+		want  int
+	}{
+		{name: "own tenant", path: "/sessions/erin-finished/files/browser.log", token: "erin-token", want: http.StatusOK},
+		{name: "files", path: "/sessions/erin-finished/files/browser.log", token: "carol-token", want: http.StatusNotFound},
+		{name: "commands", path: "/sessions/erin-finished/commands?pageSize=10", token: "carol-token", want: http.StatusNotFound},
+		{name: "screenshots", path: "/sessions/erin-running/screenshots", token: "carol-token", want: http.StatusNotFound},
+		{name: "result", path: "/results/erin-finished", token: "carol-token", want: http.StatusNotFound},
+		{name: "logs", path: "/logs/erin-running", token: "carol-token", want: http.StatusNotFound},
+		{name: "vnc", path: "/vnc/erin-running", token: "carol-token", want: http.StatusNotFound},
+		{name: "devtools", path: "/devtools/erin-running", token: "carol-token", want: http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rq := httptest.NewRequest(http.MethodGet, tt.path, http.NoBody)
+			rq.Header.Set("Authorization", "Bearer "+tt.token)
+			rs := httptest.NewRecorder()
+			router.ServeHTTP(rs, rq)
+			require.Equal(t, tt.want, rs.Code, rs.Body.String())
+		})
+	}
+}
+
+func Test_handler_updateBrowserSet(t *testing.T) {
+	provisioner := mocks.NewProvisioner(t)
+	provisioner.On("Update", mock.Anything, mock.MatchedBy(func(bs *v1.BrowserSet) bool {
+		return bs.Name == "default" && bs.Spec.DefaultTimezone == "Europe/Berlin"
+	})).Return(nil).Once()
+	router := newTestRouter(t, provisioner)
+
+	for token, want := range map[string]int{
+		"bob-token":   http.StatusForbidden,
+		"carol-token": http.StatusForbidden,
+		"dan-token":   http.StatusNoContent,
+	} {
+		rq := httptest.NewRequest(http.MethodPut, "/browsersets/default", strings.NewReader(`{"defaultTimezone":"Europe/Berlin"}`))
+		rq.Header.Set("Authorization", "Bearer "+token)
+		rs := httptest.NewRecorder()
+		router.ServeHTTP(rs, rq)
+		require.Equal(t, want, rs.Code, "token %s: %s", token, rs.Body.String())
+	}
+}
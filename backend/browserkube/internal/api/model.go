@@ -5,6 +5,8 @@ import (
 	"time"
 
 	"github.com/pkg/errors"
+
+	"github.com/browserkube/browserkube/browserkube/internal/provision"
 )
 
 var defaultResolutions = []string{
@@ -30,12 +32,15 @@ type (
 		Version     string   `json:"version"`
 		Image       string   `json:"image"`
 		Type        string   `json:"type"`
+		BrowserSet  string   `json:"browserSet"`
+		Default     bool     `json:"default"`
 		Resolutions []string `json:"resolutions"`
 	}
 	Status struct {
-		QuotesLimit int           `json:"quotesLimit"`
-		MaxTimeout  time.Duration `json:"maxTimeout"`
-		Stats       StatusStats   `json:"stats"`
+		QuotesLimit int                       `json:"quotesLimit"`
+		MaxTimeout  time.Duration             `json:"maxTimeout"`
+		Stats       StatusStats               `json:"stats"`
+		Queue       []provision.QueuedSession `json:"queue"`
 	}
 
 	WSMessage[T any] struct {
@@ -45,28 +50,30 @@ type (
 
 	//nolint:maligned
 	Session struct {
-		ID               string                 `json:"id,omitempty"`
-		Name             string                 `json:"name,omitempty"`
-		Image            string                 `json:"image,omitempty"`
-		Type             string                 `json:"type,omitempty"`
-		State            string                 `json:"state,omitempty"`
-		Details          map[string]interface{} `json:"details,omitempty"`
-		Platform         string                 `json:"platformName,omitempty"`
-		Manual           bool                   `json:"manual,omitempty"`
-		Browser          string                 `json:"browser,omitempty"`
-		BrowserVersion   string                 `json:"browserVersion,omitempty"`
-		VncOn            bool                   `json:"vncOn,omitempty"`
-		VncPsw           string                 `json:"vncPsw,omitempty"`
-		LogsOn           bool                   `json:"logsOn,omitempty"`
-		LogsRefAddr      string                 `json:"logsRefAddr,omitempty"`
-		VideoRecOn       bool                   `json:"videoRecOn,omitempty"`
-		VideoCodec       string                 `json:"videoCodec,omitempty"`
-		VideoName        string                 `json:"videoName,omitempty"`
-		VideoFps         int                    `json:"videoFps,omitempty"`
-		VideoRefAddr     string                 `json:"videoRefAddr,omitempty"`
-		VideoSize        *Resolution            `json:"videoSize,omitempty"`
-		ScreenResolution string                 `json:"screenResolution,omitempty"`
-		CreatedAt        Timestamp              `json:"createdAt,omitempty"`
+		ID                string                 `json:"id,omitempty"`
+		Name              string                 `json:"name,omitempty"`
+		Image             string                 `json:"image,omitempty"`
+		Type              string                 `json:"type,omitempty"`
+		State             string                 `json:"state,omitempty"`
+		Details           map[string]interface{} `json:"details,omitempty"`
+		Platform          string                 `json:"platformName,omitempty"`
+		Manual            bool                   `json:"manual,omitempty"`
+		Browser           string                 `json:"browser,omitempty"`
+		BrowserVersion    string                 `json:"browserVersion,omitempty"`
+		VncOn             bool                   `json:"vncOn,omitempty"`
+		LogsOn            bool                   `json:"logsOn,omitempty"`
+		LogsRefAddr       string                 `json:"logsRefAddr,omitempty"`
+		VideoRecOn        bool                   `json:"videoRecOn,omitempty"`
+		VideoCodec        string                 `json:"videoCodec,omitempty"`
+		VideoName         string                 `json:"videoName,omitempty"`
+		VideoFps          int                    `json:"videoFps,omitempty"`
+		VideoRefAddr      string                 `json:"videoRefAddr,omitempty"`
+		VideoSize         *Resolution            `json:"videoSize,omitempty"`
+		ScreenResolution  string                 `json:"screenResolution,omitempty"`
+		CreatedAt         Timestamp              `json:"createdAt,omitempty"`
+		TerminationReason string                 `json:"terminationReason,omitempty"`
+		// Owner is the user who created the session
+		Owner string `json:"owner,omitempty"`
 	}
 	Timestamp time.Time
 
@@ -94,6 +101,21 @@ type (
 		Commands     []CommandLog `json:"commands"`
 		NewPageToken string       `json:"newPageToken"`
 	}
+
+	// Me is the caller and the actions the caller's role allows, the UI hides the forbidden ones
+	Me struct {
+		User        string                          `json:"user,omitempty"`
+		Groups      []string                        `json:"groups,omitempty"`
+		Role        provision.Role                  `json:"role"`
+		Tenant      string                          `json:"tenant,omitempty"`
+		Permissions map[provision.Action]Permission `json:"permissions"`
+	}
+
+	// Permission tells whether the action is allowed on own sessions and on sessions of other users
+	Permission struct {
+		Own    bool `json:"own"`
+		Others bool `json:"others"`
+	}
 )
 
 func (t Timestamp) MarshalJSON() ([]byte, error) {
@@ -0,0 +1,280 @@
+package extension
+
+import (
+	"archive/zip"
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/pkg/errors"
+)
+
+// Browsers extension artifacts are built for
+const (
+	BrowserChrome  = "chrome"
+	BrowserFirefox = "firefox"
+)
+
+// Artifact formats
+const (
+	FormatCRX = "crx"
+	FormatXPI = "xpi"
+)
+
+// ErrInvalidArtifact is returned when the artifact can't be parsed or its signature is invalid
+var ErrInvalidArtifact = errors.New("extension artifact is invalid")
+
+// Manifest describes the extension artifact
+type Manifest struct {
+	ExtensionID string `json:"extensionId"`
+	Version     string `json:"version"`
+	Browser     string `json:"browser"`
+	Format      string `json:"format"`
+	// Signed is set if the artifact is signed. CRX signatures are verified on upload,
+	// XPI signatures are verified by Firefox on installation
+	Signed bool `json:"signed"`
+}
+
+// Inspect parses the CRX or XPI artifact and verifies the signatures of CRX artifacts
+func Inspect(data []byte) (*Manifest, error) {
+	if bytes.HasPrefix(data, []byte(crxMagic)) {
+		return inspectCRX(data)
+	}
+	return inspectXPI(data)
+}
+
+const (
+	crxMagic      = "Cr24"
+	crxVersion    = 3
+	crxSignedData = "CRX3 SignedData\x00"
+	crxIDLength   = 16
+
+	// CrxFileHeader and SignedData fields
+	crxFieldSHA256WithRSA   = 2
+	crxFieldSHA256WithECDSA = 3
+	crxFieldSignedData      = 10000
+	crxFieldPublicKey       = 1
+	crxFieldSignature       = 2
+	crxFieldCRXID           = 1
+)
+
+// inspectCRX verifies all proofs of the CRX3 artifact. One of the proofs has to be made by the key
+// the extension ID is derived from
+func inspectCRX(data []byte) (*Manifest, error) {
+	if len(data) < 12 {
+		return nil, invalidArtifact("CRX header is truncated")
+	}
+	if v := binary.LittleEndian.Uint32(data[4:8]); v != crxVersion {
+		return nil, invalidArtifact("CRX%d isn't supported, CRX3 is expected", v)
+	}
+	headerSize := uint64(binary.LittleEndian.Uint32(data[8:12]))
+	if uint64(len(data)-12) < headerSize {
+		return nil, invalidArtifact("CRX header is truncated")
+	}
+	header, archive := data[12:12+headerSize], data[12+headerSize:]
+
+	fields, err := protoFields(header)
+	if err != nil {
+		return nil, err
+	}
+	signedData := last(fields[crxFieldSignedData])
+	signedFields, err := protoFields(signedData)
+	if err != nil {
+		return nil, err
+	}
+	crxID := last(signedFields[crxFieldCRXID])
+	if len(crxID) != crxIDLength {
+		return nil, invalidArtifact("CRX ID is missing")
+	}
+
+	signed := new(bytes.Buffer)
+	signed.WriteString(crxSignedData)
+	_ = binary.Write(signed, binary.LittleEndian, uint32(len(signedData)))
+	signed.Write(signedData)
+	signed.Write(archive)
+	digest := sha256.Sum256(signed.Bytes())
+
+	var developerSigned bool
+	for field, verify := range map[uint64]func(key crypto.PublicKey, signature []byte) bool{
+		crxFieldSHA256WithRSA: func(key crypto.PublicKey, signature []byte) bool {
+			rsaKey, ok := key.(*rsa.PublicKey)
+			return ok && rsa.VerifyPKCS1v15(rsaKey, crypto.SHA256, digest[:], signature) == nil
+		},
+		crxFieldSHA256WithECDSA: func(key crypto.PublicKey, signature []byte) bool {
+			ecdsaKey, ok := key.(*ecdsa.PublicKey)
+			return ok && ecdsa.VerifyASN1(ecdsaKey, digest[:], signature)
+		},
+	} {
+		for _, proof := range fields[field] {
+			proofFields, pErr := protoFields(proof)
+			if pErr != nil {
+				return nil, pErr
+			}
+			rawKey := last(proofFields[crxFieldPublicKey])
+			key, kErr := x509.ParsePKIXPublicKey(rawKey)
+			if kErr != nil {
+				return nil, invalidArtifact("CRX public key is invalid: %s", kErr)
+			}
+			if !verify(key, last(proofFields[crxFieldSignature])) {
+				return nil, invalidArtifact("CRX signature is invalid")
+			}
+			keyDigest := sha256.Sum256(rawKey)
+			developerSigned = developerSigned || bytes.Equal(keyDigest[:crxIDLength], crxID)
+		}
+	}
+	if !developerSigned {
+		return nil, invalidArtifact("CRX isn't signed by the extension key")
+	}
+
+	manifest, err := readManifest(archive)
+	if err != nil {
+		return nil, err
+	}
+	return &Manifest{
+		ExtensionID: crxIDString(crxID),
+		Version:     manifest.Version,
+		Browser:     BrowserChrome,
+		Format:      FormatCRX,
+		Signed:      true,
+	}, nil
+}
+
+// inspectXPI reads the extension ID from the manifest of the XPI artifact.
+// Firefox requires the ID to install the extension from a file
+func inspectXPI(data []byte) (*Manifest, error) {
+	manifest, err := readManifest(data)
+	if err != nil {
+		return nil, err
+	}
+	var id string
+	for _, settings := range []*webExtensionSettings{manifest.BrowserSpecificSettings, manifest.Applications} {
+		if settings != nil && settings.Gecko.ID != "" {
+			id = settings.Gecko.ID
+			break
+		}
+	}
+	if id == "" {
+		return nil, invalidArtifact("XPI manifest has no gecko ID")
+	}
+
+	archive, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, invalidArtifact("XPI archive is invalid: %s", err)
+	}
+	var signed bool
+	for _, f := range archive.File {
+		if f.Name == "META-INF/mozilla.rsa" || f.Name == "META-INF/cose.sig" {
+			signed = true
+		}
+	}
+	return &Manifest{
+		ExtensionID: id,
+		Version:     manifest.Version,
+		Browser:     BrowserFirefox,
+		Format:      FormatXPI,
+		Signed:      signed,
+	}, nil
+}
+
+type webExtensionSettings struct {
+	Gecko struct {
+		ID string `json:"id"`
+	} `json:"gecko"`
+}
+
+type webExtensionManifest struct {
+	Version                 string                `json:"version"`
+	BrowserSpecificSettings *webExtensionSettings `json:"browser_specific_settings"` //nolint: tagliatelle
+	Applications            *webExtensionSettings `json:"applications"`
+}
+
+func readManifest(archive []byte) (*webExtensionManifest, error) {
+	r, err := zip.NewReader(bytes.NewReader(archive), int64(len(archive)))
+	if err != nil {
+		return nil, invalidArtifact("archive is invalid: %s", err)
+	}
+	f, err := r.Open("manifest.json")
+	if err != nil {
+		return nil, invalidArtifact("manifest.json is missing")
+	}
+	defer f.Close()
+	raw, err := io.ReadAll(f)
+	if err != nil {
+		return nil, invalidArtifact("manifest.json can't be read: %s", err)
+	}
+
+	var manifest webExtensionManifest
+	if err = json.Unmarshal(bytes.TrimPrefix(raw, []byte("\xef\xbb\xbf")), &manifest); err != nil {
+		return nil, invalidArtifact("manifest.json is invalid: %s", err)
+	}
+	if manifest.Version == "" {
+		return nil, invalidArtifact("manifest.json has no version")
+	}
+	return &manifest, nil
+}
+
+// crxIDString encodes CRX ID the way Chrome does: hex digits are mapped to a-p
+func crxIDString(crxID []byte) string {
+	id := make([]byte, 0, 2*len(crxID))
+	for _, b := range crxID {
+		id = append(id, 'a'+b>>4, 'a'+b&0x0f)
+	}
+	return string(id)
+}
+
+// protoFields returns length-delimited fields of the protobuf message by field number.
+// Fields of other wire types are skipped
+func protoFields(msg []byte) (map[uint64][][]byte, error) {
+	fields := map[uint64][][]byte{}
+	for len(msg) > 0 {
+		key, n := binary.Uvarint(msg)
+		if n <= 0 {
+			return nil, invalidArtifact("CRX header is malformed")
+		}
+		msg = msg[n:]
+		var size uint64
+		switch key & 0x07 {
+		case 0: // varint
+			if _, n = binary.Uvarint(msg); n <= 0 {
+				return nil, invalidArtifact("CRX header is malformed")
+			}
+			size = uint64(n)
+		case 1: // fixed64
+			size = 8
+		case 5: // fixed32
+			size = 4
+		case 2: // length-delimited
+			l, ln := binary.Uvarint(msg)
+			if ln <= 0 || l > uint64(len(msg)-ln) {
+				return nil, invalidArtifact("CRX header is malformed")
+			}
+			fields[key>>3] = append(fields[key>>3], msg[ln:ln+int(l)])
+			size = uint64(ln) + l
+		default:
+			return nil, invalidArtifact("CRX header is malformed")
+		}
+		if size > uint64(len(msg)) {
+			return nil, invalidArtifact("CRX header is malformed")
+		}
+		msg = msg[size:]
+	}
+	return fields, nil
+}
+
+func last(values [][]byte) []byte {
+	if len(values) == 0 {
+		return nil
+	}
+	return values[len(values)-1]
+}
+
+func invalidArtifact(format string, args ...any) error {
+	return fmt.Errorf("%w: %s", ErrInvalidArtifact, fmt.Sprintf(format, args...))
+}
@@ -0,0 +1,152 @@
+package extension
+
+import (
+	"archive/zip"
+	"bytes"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/binary"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func testZip(t *testing.T, files map[string]string) []byte {
+	t.Helper()
+	buf := new(bytes.Buffer)
+	w := zip.NewWriter(buf)
+	for name, content := range files {
+		f, err := w.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err = f.Write([]byte(content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func protoField(field uint64, value []byte) []byte {
+	msg := binary.AppendUvarint(nil, field<<3|2)
+	msg = binary.AppendUvarint(msg, uint64(len(value)))
+	return append(msg, value...)
+}
+
+// testCRX packs the archive into CRX3 signed by the key
+func testCRX(t *testing.T, key *rsa.PrivateKey, archive []byte) []byte {
+	t.Helper()
+	publicKey, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDigest := sha256.Sum256(publicKey)
+	signedData := protoField(crxFieldCRXID, keyDigest[:crxIDLength])
+
+	signed := new(bytes.Buffer)
+	signed.WriteString(crxSignedData)
+	_ = binary.Write(signed, binary.LittleEndian, uint32(len(signedData)))
+	signed.Write(signedData)
+	signed.Write(archive)
+	digest := sha256.Sum256(signed.Bytes())
+	signature, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, digest[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	header := protoField(crxFieldSHA256WithRSA,
+		append(protoField(crxFieldPublicKey, publicKey), protoField(crxFieldSignature, signature)...))
+	header = append(header, protoField(crxFieldSignedData, signedData)...)
+
+	crx := []byte(crxMagic)
+	crx = binary.LittleEndian.AppendUint32(crx, crxVersion)
+	crx = binary.LittleEndian.AppendUint32(crx, uint32(len(header)))
+	crx = append(crx, header...)
+	return append(crx, archive...)
+}
+
+func TestInspect(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	publicKey, _ := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	keyDigest := sha256.Sum256(publicKey)
+	crxArchive := testZip(t, map[string]string{"manifest.json": `{"name":"test","version":"1.2.3"}`})
+	crx := testCRX(t, key, crxArchive)
+
+	tampered := bytes.Clone(crx)
+	tampered[len(tampered)-len(crxArchive)+40] ^= 0xff
+
+	tests := []struct {
+		name    string
+		data    []byte
+		want    *Manifest
+		wantErr bool
+	}{
+		{
+			name: "signed crx",
+			data: crx,
+			want: &Manifest{ExtensionID: crxIDString(keyDigest[:crxIDLength]), Version: "1.2.3",
+				Browser: BrowserChrome, Format: FormatCRX, Signed: true},
+		},
+		{
+			name:    "tampered crx",
+			data:    tampered,
+			wantErr: true,
+		},
+		{
+			name:    "crx2",
+			data:    append([]byte(crxMagic), 2, 0, 0, 0, 0, 0, 0, 0),
+			wantErr: true,
+		},
+		{
+			name: "xpi",
+			data: testZip(t, map[string]string{
+				"manifest.json":        `{"version":"2.0","browser_specific_settings":{"gecko":{"id":"test@browserkube.io"}}}`,
+				"META-INF/mozilla.rsa": "signature",
+			}),
+			want: &Manifest{ExtensionID: "test@browserkube.io", Version: "2.0", Browser: BrowserFirefox,
+				Format: FormatXPI, Signed: true},
+		},
+		{
+			name:    "xpi without gecko id",
+			data:    testZip(t, map[string]string{"manifest.json": `{"version":"2.0"}`}),
+			wantErr: true,
+		},
+		{
+			name:    "not an archive",
+			data:    []byte("extension"),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Inspect(tt.data)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidArtifact) {
+					t.Fatalf("Inspect() error = %v, want %v", err, ErrInvalidArtifact)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Inspect() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_crxIDString(t *testing.T) {
+	if got := crxIDString([]byte{0x01, 0x23, 0x45, 0x67, 0x89, 0xab, 0xcd, 0xef}); got != "abcdefghijklmnop" {
+		t.Errorf("crxIDString() = %s", got)
+	}
+}
@@ -0,0 +1,248 @@
+package extension
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/browserkube/browserkube/browserkube/internal/provision"
+	browserkubehttp "github.com/browserkube/browserkube/pkg/http"
+	"github.com/browserkube/browserkube/pkg/opentelemetry"
+)
+
+// Headers describing the downloaded artifact. The extension installer verifies the artifact against them
+const (
+	HeaderDigest      = "Digest"
+	HeaderExtensionID = "X-Extension-Id"
+	HeaderVersion     = "X-Extension-Version"
+)
+
+const (
+	keyName    = "name"
+	keyVersion = "version"
+)
+
+func initRoutes(mux chi.Router, h *handler) {
+	mux.Group(func(r chi.Router) {
+		r.Use(opentelemetry.NewMetricsMiddleware("extensions"))
+		// the extension installer downloads artifacts anonymously
+		r.Use(browserkubehttp.Authenticate(h.auth, true))
+
+		r.Route("/extensions", func(r chi.Router) {
+			r.Get("/", browserkubehttp.Handler(h.list))
+			r.Get("/{name}", browserkubehttp.Handler(h.versions))
+			r.Post("/{name}", browserkubehttp.Handler(h.upload))
+			r.Get("/{name}/{version}", browserkubehttp.Handler(h.get))
+			r.Delete("/{name}/{version}", browserkubehttp.Handler(h.delete))
+			r.Get("/{name}/{version}/artifact", browserkubehttp.Handler(h.artifact))
+		})
+	})
+}
+
+type handler struct {
+	store     *Store
+	cfg       *Config
+	envConfig *provision.Config
+	auth      browserkubehttp.Authenticator
+	logger    *zap.SugaredLogger
+}
+
+func newHandler(
+	logger *zap.SugaredLogger,
+	store *Store,
+	cfg *Config,
+	envConfig *provision.Config,
+	auth browserkubehttp.Authenticator,
+) *handler {
+	return &handler{
+		store:     store,
+		cfg:       cfg,
+		envConfig: envConfig,
+		auth:      auth,
+		logger:    logger,
+	}
+}
+
+// list godoc
+//
+//	@Summary		list extensions
+//	@Description	returns the latest versions of all uploaded extensions
+//	@Tags			extensions
+//	@Produce		json
+//	@Success		200	{array}		Extension
+//	@Failure		500	{string}	Internal	Server	Error
+//	@Router			/extensions [get]
+func (h *handler) list(w http.ResponseWriter, rq *http.Request) error {
+	extensions, err := h.store.List(rq.Context())
+	if err != nil {
+		return browserkubehttp.NewHTTPErr(http.StatusInternalServerError, err)
+	}
+	return errors.WithStack(browserkubehttp.WriteJSON(w, http.StatusOK, extensions))
+}
+
+// versions godoc
+//
+//	@Summary		list extension versions
+//	@Description	returns all versions of the extension, the latest first
+//	@Tags			extensions
+//	@Produce		json
+//	@Param			name	path		string	true	"extension name"
+//	@Success		200		{array}		Extension
+//	@Failure		404		{string}	NotFound
+//	@Failure		500		{string}	Internal	Server	Error
+//	@Router			/extensions/{name} [get]
+func (h *handler) versions(w http.ResponseWriter, rq *http.Request) error {
+	versions, err := h.store.Versions(rq.Context(), chi.URLParam(rq, keyName))
+	if err != nil {
+		return storeErr(err)
+	}
+	return errors.WithStack(browserkubehttp.WriteJSON(w, http.StatusOK, versions))
+}
+
+// upload godoc
+//
+//	@Summary		upload extension
+//	@Description	uploads CRX or XPI artifact as a new version of the extension. CRX signatures are verified
+//	@Tags			extensions
+//	@Accept			application/octet-stream
+//	@Produce		json
+//	@Param			name		path		string	true	"extension name"
+//	@Param			artifact	body		string	true	"CRX or XPI artifact"
+//	@Success		201			{object}	Extension
+//	@Failure		400			{string}	Bad	request
+//	@Failure		403			{string}	Forbidden
+//	@Failure		409			{string}	Conflict
+//	@Failure		413			{string}	Request	Entity	Too	Large
+//	@Failure		500			{string}	Internal	Server	Error
+//	@Router			/extensions/{name} [post]
+func (h *handler) upload(w http.ResponseWriter, rq *http.Request) error {
+	user, err := h.admin(rq)
+	if err != nil {
+		return err
+	}
+	data, err := io.ReadAll(http.MaxBytesReader(w, rq.Body, h.cfg.MaxArtifactSize))
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			return browserkubehttp.NewHTTPErr(http.StatusRequestEntityTooLarge,
+				fmt.Errorf("extension artifact exceeds %d bytes", h.cfg.MaxArtifactSize))
+		}
+		return browserkubehttp.NewHTTPErr(http.StatusBadRequest, errors.WithStack(err))
+	}
+
+	ext, err := h.store.Save(rq.Context(), chi.URLParam(rq, keyName), data, user)
+	if err != nil {
+		return storeErr(err)
+	}
+	h.logger.Infof("Extension %s %s (%s) is uploaded by %q", ext.Name, ext.Version, ext.ExtensionID, user)
+	return errors.WithStack(browserkubehttp.WriteJSON(w, http.StatusCreated, ext))
+}
+
+// get godoc
+//
+//	@Summary		get extension version
+//	@Description	returns metadata of the extension version. "latest" returns the latest version
+//	@Tags			extensions
+//	@Produce		json
+//	@Param			name	path		string	true	"extension name"
+//	@Param			version	path		string	true	"extension version"
+//	@Success		200		{object}	Extension
+//	@Failure		404		{string}	NotFound
+//	@Failure		500		{string}	Internal	Server	Error
+//	@Router			/extensions/{name}/{version} [get]
+func (h *handler) get(w http.ResponseWriter, rq *http.Request) error {
+	ext, err := h.store.Get(rq.Context(), chi.URLParam(rq, keyName), chi.URLParam(rq, keyVersion))
+	if err != nil {
+		return storeErr(err)
+	}
+	return errors.WithStack(browserkubehttp.WriteJSON(w, http.StatusOK, ext))
+}
+
+// delete godoc
+//
+//	@Summary		delete extension version
+//	@Description	deletes the extension version. Browsers requesting it fail to start
+//	@Tags			extensions
+//	@Param			name	path		string	true	"extension name"
+//	@Param			version	path		string	true	"extension version"
+//	@Success		204		{string}	ok
+//	@Failure		403		{string}	Forbidden
+//	@Failure		404		{string}	NotFound
+//	@Failure		500		{string}	Internal	Server	Error
+//	@Router			/extensions/{name}/{version} [delete]
+func (h *handler) delete(w http.ResponseWriter, rq *http.Request) error {
+	user, err := h.admin(rq)
+	if err != nil {
+		return err
+	}
+	name, version := chi.URLParam(rq, keyName), chi.URLParam(rq, keyVersion)
+	if err = h.store.Delete(rq.Context(), name, version); err != nil {
+		return storeErr(err)
+	}
+	h.logger.Infof("Extension %s %s is deleted by %q", name, version, user)
+	return errors.WithStack(browserkubehttp.WriteJSON(w, http.StatusNoContent, nil))
+}
+
+// artifact godoc
+//
+//	@Summary		download extension artifact
+//	@Description	downloads CRX or XPI artifact of the extension version. "latest" returns the latest version.
+//	@Description	Digest, X-Extension-Id and X-Extension-Version headers describe the artifact
+//	@Tags			extensions
+//	@Produce		application/octet-stream
+//	@Param			name	path		string	true	"extension name"
+//	@Param			version	path		string	true	"extension version"
+//	@Success		200		{file}		binary
+//	@Failure		404		{string}	NotFound
+//	@Failure		500		{string}	Internal	Server	Error
+//	@Router			/extensions/{name}/{version}/artifact [get]
+func (h *handler) artifact(w http.ResponseWriter, rq *http.Request) error {
+	ext, err := h.store.Get(rq.Context(), chi.URLParam(rq, keyName), chi.URLParam(rq, keyVersion))
+	if err != nil {
+		return storeErr(err)
+	}
+	content, err := h.store.Artifact(rq.Context(), ext)
+	if err != nil {
+		return storeErr(err)
+	}
+	w.Header().Set("Content-Type", artifactContentTypes[ext.Format])
+	w.Header().Set("Content-Length", strconv.FormatInt(ext.Size, 10))
+	w.Header().Set(HeaderDigest, ext.Digest())
+	w.Header().Set(HeaderExtensionID, ext.ExtensionID)
+	w.Header().Set(HeaderVersion, ext.Version)
+	if _, err = io.Copy(w, content); err != nil {
+		h.logger.Errorf("failed to copy extension artifact %s %s: %v", ext.Name, ext.Version, err)
+	}
+	return nil
+}
+
+// admin returns the user of the request if it's allowed to manage extensions
+func (h *handler) admin(rq *http.Request) (string, error) {
+	user := h.envConfig.User(rq)
+	if user == "" && h.envConfig.AuthEnabled {
+		return "", browserkubehttp.NewHTTPErr(http.StatusUnauthorized, browserkubehttp.ErrUnauthenticated)
+	}
+	if len(h.cfg.Admins) > 0 && !slices.Contains(h.cfg.Admins, user) {
+		return "", browserkubehttp.NewHTTPErr(http.StatusForbidden, fmt.Errorf("user %q isn't allowed to manage extensions", user))
+	}
+	return user, nil
+}
+
+func storeErr(err error) error {
+	switch {
+	case errors.Is(err, ErrNotFound):
+		return browserkubehttp.NewHTTPErr(http.StatusNotFound, err)
+	case errors.Is(err, ErrVersionExists):
+		return browserkubehttp.NewHTTPErr(http.StatusConflict, err)
+	case errors.Is(err, ErrInvalidArtifact), errors.Is(err, ErrInvalidName):
+		return browserkubehttp.NewHTTPErr(http.StatusBadRequest, err)
+	default:
+		return browserkubehttp.NewHTTPErr(http.StatusInternalServerError, err)
+	}
+}
@@ -0,0 +1,145 @@
+package extension
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+	_ "gocloud.dev/blob/memblob"
+
+	"github.com/browserkube/browserkube/browserkube/internal/provision"
+	"github.com/browserkube/browserkube/storage"
+)
+
+func testServer(t *testing.T, admins ...string) *httptest.Server {
+	t.Helper()
+	s, err := storage.New(context.Background(), "mem://")
+	if err != nil {
+		t.Fatal(err)
+	}
+	mux := chi.NewRouter()
+	initRoutes(mux, newHandler(zap.S(), NewStore(s),
+		&Config{Admins: admins, MaxArtifactSize: 1 << 20},
+		&provision.Config{UserHeader: "X-User"}, nil))
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func do(t *testing.T, method, url, user string, body []byte) *http.Response {
+	t.Helper()
+	rq, err := http.NewRequest(method, url, bytes.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	rq.Header.Set("X-User", user)
+	rs, err := http.DefaultClient.Do(rq)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = rs.Body.Close() })
+	return rs
+}
+
+func xpi(t *testing.T, id, version string) []byte {
+	t.Helper()
+	return testZip(t, map[string]string{
+		"manifest.json": `{"version":"` + version + `","browser_specific_settings":{"gecko":{"id":"` + id + `"}}}`,
+	})
+}
+
+func TestHandler(t *testing.T) {
+	srv := testServer(t, "admin")
+	url := srv.URL + "/extensions/adblock"
+
+	if rs := do(t, http.MethodPost, url, "user", xpi(t, "adblock@test", "1.9.0")); rs.StatusCode != http.StatusForbidden {
+		t.Fatalf("upload by user = %d, want %d", rs.StatusCode, http.StatusForbidden)
+	}
+	for _, version := range []string{"1.9.0", "1.10.0"} {
+		if rs := do(t, http.MethodPost, url, "admin", xpi(t, "adblock@test", version)); rs.StatusCode != http.StatusCreated {
+			t.Fatalf("upload %s = %d, want %d", version, rs.StatusCode, http.StatusCreated)
+		}
+	}
+	// a longer name sharing the prefix must not be listed as a version of adblock
+	if rs := do(t, http.MethodPost, url+"-plus", "admin", xpi(t, "adblock-plus@test", "3.0")); rs.StatusCode != http.StatusCreated {
+		t.Fatalf("upload adblock-plus = %d, want %d", rs.StatusCode, http.StatusCreated)
+	}
+
+	for name, tt := range map[string]struct {
+		url  string
+		body []byte
+		want int
+	}{
+		"existing version": {url: url, body: xpi(t, "adblock@test", "1.9.0"), want: http.StatusConflict},
+		"other id":         {url: url, body: xpi(t, "other@test", "2.0.0"), want: http.StatusBadRequest},
+		"invalid name":     {url: srv.URL + "/extensions/AdBlock", body: xpi(t, "adblock@test", "2.0.0"), want: http.StatusBadRequest},
+		"invalid artifact": {url: url, body: []byte("extension"), want: http.StatusBadRequest},
+		"too large":        {url: url, body: make([]byte, 2<<20), want: http.StatusRequestEntityTooLarge},
+	} {
+		if rs := do(t, http.MethodPost, tt.url, "admin", tt.body); rs.StatusCode != tt.want {
+			t.Errorf("upload %s = %d, want %d", name, rs.StatusCode, tt.want)
+		}
+	}
+
+	var versions []Extension
+	rs := do(t, http.MethodGet, url, "", nil)
+	if err := json.NewDecoder(rs.Body).Decode(&versions); err != nil {
+		t.Fatal(err)
+	}
+	if len(versions) != 2 || versions[0].Version != "1.10.0" || versions[0].UploadedBy != "admin" {
+		t.Fatalf("versions = %+v, want 1.10.0 and 1.9.0", versions)
+	}
+
+	var extensions []Extension
+	rs = do(t, http.MethodGet, srv.URL+"/extensions", "", nil)
+	if err := json.NewDecoder(rs.Body).Decode(&extensions); err != nil {
+		t.Fatal(err)
+	}
+	if len(extensions) != 2 || extensions[0].Name != "adblock" || extensions[1].Name != "adblock-plus" {
+		t.Fatalf("extensions = %+v, want adblock and adblock-plus", extensions)
+	}
+
+	rs = do(t, http.MethodGet, url+"/latest/artifact", "", nil)
+	content, _ := io.ReadAll(rs.Body)
+	if rs.StatusCode != http.StatusOK || !bytes.Equal(content, xpi(t, "adblock@test", "1.10.0")) {
+		t.Fatalf("artifact = %d, want the latest version", rs.StatusCode)
+	}
+	if rs.Header.Get(HeaderDigest) != versions[0].Digest() || rs.Header.Get(HeaderExtensionID) != "adblock@test" ||
+		rs.Header.Get(HeaderVersion) != "1.10.0" {
+		t.Errorf("artifact headers = %v", rs.Header)
+	}
+
+	if rs = do(t, http.MethodDelete, url+"/1.10.0", "admin", nil); rs.StatusCode != http.StatusNoContent {
+		t.Fatalf("delete = %d, want %d", rs.StatusCode, http.StatusNoContent)
+	}
+	rs = do(t, http.MethodGet, url+"/latest", "", nil)
+	var latest Extension
+	if err := json.NewDecoder(rs.Body).Decode(&latest); err != nil || latest.Version != "1.9.0" {
+		t.Errorf("latest after delete = %+v, %v, want 1.9.0", latest, err)
+	}
+	if rs = do(t, http.MethodGet, url+"/1.10.0/artifact", "", nil); rs.StatusCode != http.StatusNotFound {
+		t.Errorf("deleted artifact = %d, want %d", rs.StatusCode, http.StatusNotFound)
+	}
+}
+
+func Test_compareVersions(t *testing.T) {
+	for _, tt := range []struct {
+		a, b string
+		want int
+	}{
+		{"1.10.0", "1.9.2", 1},
+		{"1.0", "1.0.0", -1},
+		{"2.0.0", "2.0.0", 0},
+		{"1.0.0-beta", "1.0.0-alpha", 1},
+	} {
+		if got := compareVersions(tt.a, tt.b); got != tt.want {
+			t.Errorf("compareVersions(%s, %s) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
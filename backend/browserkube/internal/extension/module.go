@@ -0,0 +1,37 @@
+package extension
+
+import (
+	"strings"
+
+	"github.com/pkg/errors"
+	"go.uber.org/fx"
+	"k8s.io/utils/env"
+)
+
+// Module serves the admin API managing extension artifacts installed into browsers by the extension installer
+var Module = fx.Options(
+	fx.Provide(NewStore, provideConfig, newHandler),
+	fx.Invoke(initRoutes),
+)
+
+// Config of the extension artifacts API
+type Config struct {
+	// Admins are users allowed to upload and delete extensions. Anyone is allowed if there are no admins
+	Admins []string
+	// MaxArtifactSize limits size of the uploaded artifact in bytes
+	MaxArtifactSize int64
+}
+
+func provideConfig() (*Config, error) {
+	maxSize, err := env.GetInt("EXTENSION_MAX_ARTIFACT_SIZE", 64<<20)
+	if err != nil {
+		return nil, errors.Wrap(err, "Unable to parse EXTENSION_MAX_ARTIFACT_SIZE")
+	}
+	cfg := &Config{MaxArtifactSize: int64(maxSize)}
+	for _, admin := range strings.Split(env.GetString("EXTENSION_ADMINS", ""), ",") {
+		if admin = strings.TrimSpace(admin); admin != "" {
+			cfg.Admins = append(cfg.Admins, admin)
+		}
+	}
+	return cfg, nil
+}
@@ -0,0 +1,268 @@
+package extension
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	browserkubev1 "github.com/browserkube/browserkube/operator/api/v1"
+	"github.com/browserkube/browserkube/storage"
+)
+
+const (
+	// artifactsFolder keeps the artifacts apart from session records if they share the bucket
+	artifactsFolder  = "extensions"
+	metadataFileName = "metadata.json"
+)
+
+var artifactContentTypes = map[string]string{
+	FormatCRX: "application/x-chrome-extension",
+	FormatXPI: "application/x-xpinstall",
+}
+
+var (
+	// ErrNotFound is returned when there is no such extension or version
+	ErrNotFound = errors.New("extension isn't found")
+	// ErrVersionExists is returned when the uploaded version is already stored. Versions are immutable
+	ErrVersionExists = errors.New("extension version already exists")
+	// ErrInvalidName is returned for extension names which can't be a part of artifact URL
+	ErrInvalidName = errors.New("extension name must consist of lower case alphanumeric characters, '-', '_' or '.'")
+)
+
+var (
+	nameRegexp    = regexp.MustCompile(`^[a-z0-9]([a-z0-9._-]*[a-z0-9])?$`)
+	versionRegexp = regexp.MustCompile(`^[0-9A-Za-z]+([.+-][0-9A-Za-z]+)*$`)
+)
+
+// Extension is a version of the uploaded extension
+type Extension struct {
+	Manifest
+	Name       string    `json:"name"`
+	SHA256     string    `json:"sha256"`
+	Size       int64     `json:"size"`
+	UploadedAt time.Time `json:"uploadedAt"`
+	UploadedBy string    `json:"uploadedBy,omitempty"`
+}
+
+// Digest returns the artifact digest in the format of HTTP Digest header
+func (e *Extension) Digest() string {
+	return "sha-256=" + e.SHA256
+}
+
+func (e *Extension) artifactFileName() string {
+	return path.Join(e.Version, "extension."+e.Format)
+}
+
+// Store keeps extension artifacts and their metadata in blob storage.
+// Artifacts are stored as extensions/<name>/<version>/extension.<format>
+type Store struct {
+	storage storage.BlobExtensionStorage
+}
+
+func NewStore(s storage.BlobExtensionStorage) *Store {
+	return &Store{storage: s}
+}
+
+// Save verifies the artifact and stores it as a new version of the extension
+func (s *Store) Save(ctx context.Context, name string, data []byte, uploadedBy string) (*Extension, error) {
+	if !nameRegexp.MatchString(name) {
+		return nil, ErrInvalidName
+	}
+	manifest, err := Inspect(data)
+	if err != nil {
+		return nil, err
+	}
+	if !versionRegexp.MatchString(manifest.Version) || manifest.Version == browserkubev1.ExtensionArtifactLatest {
+		return nil, invalidArtifact("version %s isn't supported", manifest.Version)
+	}
+	versions, err := s.Versions(ctx, name)
+	if err != nil && !errors.Is(err, ErrNotFound) {
+		return nil, err
+	}
+	for _, v := range versions {
+		if v.Version == manifest.Version {
+			return nil, fmt.Errorf("%w: %s %s", ErrVersionExists, name, v.Version)
+		}
+		if v.ExtensionID != manifest.ExtensionID {
+			return nil, invalidArtifact("extension ID %s doesn't match %s of the stored versions", manifest.ExtensionID, v.ExtensionID)
+		}
+	}
+
+	digest := sha256.Sum256(data)
+	ext := &Extension{
+		Manifest:   *manifest,
+		Name:       name,
+		SHA256:     base64.StdEncoding.EncodeToString(digest[:]),
+		Size:       int64(len(data)),
+		UploadedAt: time.Now().UTC(),
+		UploadedBy: uploadedBy,
+	}
+	metadata, err := json.Marshal(ext)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	folder := path.Join(artifactsFolder, name)
+	if err = s.storage.SaveFile(ctx, folder, ext.Version, &storage.BlobFile{
+		FileName:    path.Base(ext.artifactFileName()),
+		ContentType: artifactContentTypes[ext.Format],
+		Content:     bytes.NewReader(data),
+	}); err != nil {
+		return nil, err
+	}
+	// metadata is saved last, so versions are never listed without an artifact
+	if err = s.storage.SaveFile(ctx, folder, ext.Version, &storage.BlobFile{
+		FileName:    metadataFileName,
+		ContentType: "application/json",
+		Content:     bytes.NewReader(metadata),
+	}); err != nil {
+		return nil, err
+	}
+	return ext, nil
+}
+
+// List returns the latest versions of all extensions sorted by name
+func (s *Store) List(ctx context.Context) ([]Extension, error) {
+	files, err := s.storage.ListFileNames(ctx, artifactsFolder, "")
+	if err != nil {
+		return nil, err
+	}
+	names := map[string]struct{}{}
+	for _, f := range files {
+		if name, _, ok := strings.Cut(strings.TrimPrefix(f, "/"), "/"); ok && path.Base(f) == metadataFileName {
+			names[name] = struct{}{}
+		}
+	}
+	extensions := make([]Extension, 0, len(names))
+	for name := range names {
+		latest, gErr := s.Get(ctx, name, browserkubev1.ExtensionArtifactLatest)
+		if gErr != nil {
+			return nil, gErr
+		}
+		extensions = append(extensions, *latest)
+	}
+	sort.Slice(extensions, func(i, j int) bool { return extensions[i].Name < extensions[j].Name })
+	return extensions, nil
+}
+
+// Versions returns all versions of the extension, the latest first
+func (s *Store) Versions(ctx context.Context, name string) ([]Extension, error) {
+	if !nameRegexp.MatchString(name) {
+		return nil, fmt.Errorf("%w: %s", ErrNotFound, name)
+	}
+	folder := path.Join(artifactsFolder, name)
+	files, err := s.storage.ListFileNames(ctx, folder, "")
+	if err != nil {
+		return nil, err
+	}
+	var versions []Extension
+	for _, f := range files {
+		// listing by prefix returns files of the extensions with longer names too
+		version, file, ok := strings.Cut(strings.TrimPrefix(f, "/"), "/")
+		if !ok || file != metadataFileName || strings.HasPrefix(f, artifactsFolder+"/") {
+			continue
+		}
+		ext, rErr := s.readMetadata(ctx, folder, path.Join(version, metadataFileName))
+		if rErr != nil {
+			return nil, rErr
+		}
+		versions = append(versions, *ext)
+	}
+	if len(versions) == 0 {
+		return nil, fmt.Errorf("%w: %s", ErrNotFound, name)
+	}
+	sort.Slice(versions, func(i, j int) bool { return compareVersions(versions[i].Version, versions[j].Version) > 0 })
+	return versions, nil
+}
+
+// Get returns the version of the extension. ExtensionArtifactLatest returns the latest version
+func (s *Store) Get(ctx context.Context, name, version string) (*Extension, error) {
+	if version == browserkubev1.ExtensionArtifactLatest {
+		versions, err := s.Versions(ctx, name)
+		if err != nil {
+			return nil, err
+		}
+		return &versions[0], nil
+	}
+	if !nameRegexp.MatchString(name) || !versionRegexp.MatchString(version) {
+		return nil, fmt.Errorf("%w: %s %s", ErrNotFound, name, version)
+	}
+	return s.readMetadata(ctx, path.Join(artifactsFolder, name), path.Join(version, metadataFileName))
+}
+
+// Artifact returns content of the extension artifact
+func (s *Store) Artifact(ctx context.Context, ext *Extension) (io.Reader, error) {
+	f, err := s.storage.GetFile(ctx, path.Join(artifactsFolder, ext.Name), ext.artifactFileName())
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return nil, fmt.Errorf("%w: %s %s", ErrNotFound, ext.Name, ext.Version)
+		}
+		return nil, err
+	}
+	return f.Content, nil
+}
+
+// Delete removes the version of the extension
+func (s *Store) Delete(ctx context.Context, name, version string) error {
+	ext, err := s.Get(ctx, name, version)
+	if err != nil {
+		return err
+	}
+	folder := path.Join(artifactsFolder, name)
+	if err = s.storage.DeleteFile(ctx, folder, path.Join(ext.Version, metadataFileName)); err != nil {
+		return err
+	}
+	return s.storage.DeleteFile(ctx, folder, ext.artifactFileName())
+}
+
+func (s *Store) readMetadata(ctx context.Context, folder, fileName string) (*Extension, error) {
+	f, err := s.storage.GetFile(ctx, folder, fileName)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return nil, fmt.Errorf("%w: %s", ErrNotFound, path.Join(folder, fileName))
+		}
+		return nil, err
+	}
+	var ext Extension
+	if err = json.NewDecoder(f.Content).Decode(&ext); err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return &ext, nil
+}
+
+// compareVersions compares dot-separated extension versions numerically, e.g. 1.10.0 > 1.9.2
+func compareVersions(a, b string) int {
+	aParts, bParts := strings.Split(a, "."), strings.Split(b, ".")
+	for i := 0; i < len(aParts) || i < len(bParts); i++ {
+		var aPart, bPart string
+		if i < len(aParts) {
+			aPart = aParts[i]
+		}
+		if i < len(bParts) {
+			bPart = bParts[i]
+		}
+		aNum, aErr := strconv.Atoi(aPart)
+		bNum, bErr := strconv.Atoi(bPart)
+		switch {
+		case aErr == nil && bErr == nil && aNum != bNum:
+			if aNum > bNum {
+				return 1
+			}
+			return -1
+		case (aErr != nil || bErr != nil) && aPart != bPart:
+			return strings.Compare(aPart, bPart)
+		}
+	}
+	return 0
+}
@@ -37,6 +37,9 @@ type playwrightProxy struct {
 	provider           *sdktrace.TracerProvider
 	sessionResultsRepo sessionresult.Repository
 	sessionRecorder    storage.BlobSessionStorage
+	envConfig          *provision.Config
+	auth               browserkubehttp.Authenticator
+	roles              provision.RoleResolver
 }
 
 func newPlaywrightProxy(
@@ -44,6 +47,9 @@ func newPlaywrightProxy(
 	manager provision.Provisioner,
 	sessionResultsRepo sessionresult.Repository,
 	sessionRecorder storage.BlobSessionStorage,
+	envConfig *provision.Config,
+	auth browserkubehttp.Authenticator,
+	roles provision.RoleResolver,
 ) *playwrightProxy {
 	provider, err := opentelemetry.InitProvider("playwrightProxy")
 	if err != nil {
@@ -55,6 +61,9 @@ func newPlaywrightProxy(
 		sessionRecord:      true,
 		provider:           provider,
 		sessionResultsRepo: sessionResultsRepo,
+		envConfig:          envConfig,
+		auth:               auth,
+		roles:              roles,
 		sessionRecorder:    sessionRecorder,
 	}
 }
@@ -87,13 +96,35 @@ func (g *playwrightProxy) start(w http.ResponseWriter, rq *http.Request) error {
 			Manual:           false,
 			ScreenResolution: browserkubeOpts.ScreenResolution,
 			EnableVideo:      browserkubeOpts.EnableVideo,
+			VideoSize:        browserkubeOpts.VideoSize,
+			VideoFrameRate:   browserkubeOpts.VideoFrameRate,
+			VideoCodec:       browserkubeOpts.VideoCodec,
+			VideoCRF:         browserkubeOpts.VideoCRF,
+			VideoFormat:      browserkubeOpts.VideoFormat,
+
+			BrowserSet:         browserkubeOpts.BrowserSet,
+			BrowserSetSelector: browserkubeOpts.BrowserSetSelector,
+			SizeProfile:        browserkubeOpts.SizeProfile,
+			StartupTimeout:     browserkubeOpts.StartupTimeout,
+			IdleTimeout:        browserkubeOpts.IdleTimeout,
+			SessionTimeout:     browserkubeOpts.SessionTimeout,
+			Tenant:             browserkubeOpts.Tenant,
+
+			Proxy:                  browserkubeOpts.Proxy,
+			ProxyCredentialsSecret: browserkubeOpts.ProxyCredentialsSecret,
 		},
 	})
 	if err != nil {
+		if errors.Is(err, provision.ErrQueueTimeout) {
+			return browserkubehttp.NewHTTPErr(http.StatusServiceUnavailable, err)
+		}
+		if errors.Is(err, provision.ErrNotAllowed) {
+			return browserkubehttp.NewHTTPErr(http.StatusForbidden, err)
+		}
 		return errors.WithStack(err)
 	}
 	defer func() {
-		if dErr := g.manager.Delete(context.Background(), remote.Name); dErr != nil {
+		if dErr := g.manager.Delete(context.Background(), remote); dErr != nil {
 			logger.Errorf("unable to delete browser: %+v", dErr)
 		}
 	}()
@@ -110,9 +141,21 @@ func (g *playwrightProxy) start(w http.ResponseWriter, rq *http.Request) error {
 		logger.Errorf("error while initializing proxy. err:%w", err)
 		return err
 	}
+	if remote.Status.Proxy != nil {
+		browserProxy := remote.Status.Proxy
+		proxy.Director = func(in *http.Request, out http.Header) {
+			// launch options of the client are merged with the proxy
+			if launchOptions := in.Header.Get(LaunchOptionsHeader); launchOptions != "" {
+				out.Set(LaunchOptionsHeader, launchOptions)
+			}
+			if pErr := withLaunchProxy(out, browserProxy); pErr != nil {
+				logger.Errorf("unable to add proxy to launch options: %+v", pErr)
+			}
+		}
+	}
 	proxy.ServeHTTP(w, rq)
 
-	browserLogs, err := g.manager.Logs(rq.Context(), remote.Status.PodName, false)
+	browserLogs, err := g.manager.Logs(rq.Context(), remote, false)
 	if err != nil {
 		logger.Errorf("error while getting browser logs: %w", err)
 	}
@@ -150,6 +193,10 @@ func initHandlers(mux chi.Router, pp *playwrightProxy) {
 		} else {
 			r.Use(opentelemetry.NewMetricsMiddleware("playwrightProxy"))
 		}
-		r.HandleFunc("/playwright/{browser}", browserkubehttp.Handler(pp.start))
+		r.Use(browserkubehttp.Authenticate(pp.auth, false))
+		r.Use(provision.TenantMiddleware(pp.envConfig))
+		r.Use(provision.RoleMiddleware(pp.envConfig, pp.roles))
+		r.With(provision.Authorize(provision.ActionCreate, nil)).
+			HandleFunc("/playwright/{browser}", browserkubehttp.Handler(pp.start))
 	})
 }
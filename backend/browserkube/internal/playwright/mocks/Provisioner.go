@@ -44,13 +44,13 @@ func (_m *Provisioner) Available(ctx context.Context) (*v1.BrowserSetList, error
 	return r0, r1
 }
 
-// Delete provides a mock function with given fields: ctx, id
-func (_m *Provisioner) Delete(ctx context.Context, id string) error {
-	ret := _m.Called(ctx, id)
+// Delete provides a mock function with given fields: ctx, browser
+func (_m *Provisioner) Delete(ctx context.Context, browser *v1.Browser) error {
+	ret := _m.Called(ctx, browser)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
-		r0 = rf(ctx, id)
+	if rf, ok := ret.Get(0).(func(context.Context, *v1.Browser) error); ok {
+		r0 = rf(ctx, browser)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -58,25 +58,25 @@ func (_m *Provisioner) Delete(ctx context.Context, id string) error {
 	return r0
 }
 
-// Logs provides a mock function with given fields: ctx, id, follow
-func (_m *Provisioner) Logs(ctx context.Context, id string, follow bool) (io.ReadCloser, error) {
-	ret := _m.Called(ctx, id, follow)
+// Logs provides a mock function with given fields: ctx, browser, follow
+func (_m *Provisioner) Logs(ctx context.Context, browser *v1.Browser, follow bool) (io.ReadCloser, error) {
+	ret := _m.Called(ctx, browser, follow)
 
 	var r0 io.ReadCloser
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, bool) (io.ReadCloser, error)); ok {
-		return rf(ctx, id, follow)
+	if rf, ok := ret.Get(0).(func(context.Context, *v1.Browser, bool) (io.ReadCloser, error)); ok {
+		return rf(ctx, browser, follow)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string, bool) io.ReadCloser); ok {
-		r0 = rf(ctx, id, follow)
+	if rf, ok := ret.Get(0).(func(context.Context, *v1.Browser, bool) io.ReadCloser); ok {
+		r0 = rf(ctx, browser, follow)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(io.ReadCloser)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string, bool) error); ok {
-		r1 = rf(ctx, id, follow)
+	if rf, ok := ret.Get(1).(func(context.Context, *v1.Browser, bool) error); ok {
+		r1 = rf(ctx, browser, follow)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -124,6 +124,30 @@ func (_m *Provisioner) Update(ctx context.Context, bs *v1.BrowserSet) error {
 	return r0
 }
 
+// VNCPassword provides a mock function with given fields: ctx, browser
+func (_m *Provisioner) VNCPassword(ctx context.Context, browser *v1.Browser) (string, error) {
+	ret := _m.Called(ctx, browser)
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *v1.Browser) (string, error)); ok {
+		return rf(ctx, browser)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *v1.Browser) string); ok {
+		r0 = rf(ctx, browser)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *v1.Browser) error); ok {
+		r1 = rf(ctx, browser)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 type mockConstructorTestingTNewProvisioner interface {
 	mock.TestingT
 	Cleanup(func())
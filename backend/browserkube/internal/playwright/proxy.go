@@ -7,6 +7,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"net/http"
 	"net/url"
 	"os"
 	"path"
@@ -22,6 +23,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	browserkubev1 "github.com/browserkube/browserkube/operator/api/v1"
+	"github.com/browserkube/browserkube/operator/pkg/browserset"
 	"github.com/browserkube/browserkube/pkg/sessionresult"
 	"github.com/browserkube/browserkube/pkg/websocketproxy"
 	"github.com/browserkube/browserkube/storage"
@@ -29,6 +31,8 @@ import (
 
 const (
 	ScreenshotDir = "/screenshots"
+	// LaunchOptionsHeader carries browser launch options of Playwright run-server
+	LaunchOptionsHeader = "x-playwright-launch-options"
 )
 
 type Proxy struct {
@@ -72,6 +76,28 @@ func NewProxy(ctx context.Context, u *url.URL, sessionID string, sessionRecorder
 	return pp, nil
 }
 
+// withLaunchProxy adds the browser proxy to launch options requested by the Playwright client
+func withLaunchProxy(header http.Header, proxy *browserkubev1.BrowserProxy) error {
+	launchOptions := map[string]any{}
+	if raw := header.Get(LaunchOptionsHeader); raw != "" {
+		if err := json.Unmarshal([]byte(raw), &launchOptions); err != nil {
+			return errors.WithStack(err)
+		}
+	}
+	settings := map[string]any{"server": browserset.BrowserProxyURL(proxy)}
+	if len(proxy.NoProxy) > 0 {
+		settings["bypass"] = strings.Join(proxy.NoProxy, ",")
+	}
+	launchOptions["proxy"] = settings
+
+	raw, err := json.Marshal(launchOptions)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	header.Set(LaunchOptionsHeader, string(raw))
+	return nil
+}
+
 func (pp *Proxy) record(msg *websocketproxy.Message) error {
 	_, span := otel.Tracer("").Start(pp.ctx, msg.Method)
 	defer span.End()
@@ -260,16 +286,17 @@ func (pp *Proxy) SaveSessionResult(
 	sr := &sessionresult.Result{
 		SessionResult: browserkubev1.SessionResult{
 			ObjectMeta: metav1.ObjectMeta{
-				Name:        browser.Name,
+				Name:        sessionID,
 				Namespace:   browser.Namespace,
 				Labels:      browser.Labels,
 				Annotations: browser.Annotations,
 			},
 			Spec: browserkubev1.SessionResultSpec{
-				StartedAt:    browser.CreationTimestamp,
-				Browser:      browser.Spec,
-				BrowserImage: browser.Status.Image,
-				Files:        browserkubev1.SessionResultFiles{},
+				StartedAt:         browser.CreationTimestamp,
+				Browser:           browser.Spec,
+				BrowserImage:      browser.Status.Image,
+				Files:             browserkubev1.SessionResultFiles{},
+				TerminationReason: sessionresult.TerminationReason(browser),
 			},
 		},
 	}
@@ -0,0 +1,28 @@
+package playwright
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	browserkubev1 "github.com/browserkube/browserkube/operator/api/v1"
+)
+
+func Test_withLaunchProxy(t *testing.T) {
+	header := http.Header{}
+	header.Set(LaunchOptionsHeader, `{"headless":false,"proxy":{"server":"http://other:8080"}}`)
+	err := withLaunchProxy(header, &browserkubev1.BrowserProxy{URL: "socks5://proxy.corp:1080", NoProxy: []string{".corp", "localhost"}})
+	require.NoError(t, err)
+	require.JSONEq(t, `{"headless":false,"proxy":{"server":"socks5://proxy.corp:1080","bypass":".corp,localhost"}}`,
+		header.Get(LaunchOptionsHeader))
+
+	// credentials are added by the sidecar forwarder
+	header = http.Header{}
+	err = withLaunchProxy(header, &browserkubev1.BrowserProxy{URL: "http://proxy.corp:3128", CredentialsSecret: "proxy-auth"})
+	require.NoError(t, err)
+	require.JSONEq(t, `{"proxy":{"server":"http://127.0.0.1:3128"}}`, header.Get(LaunchOptionsHeader))
+
+	header.Set(LaunchOptionsHeader, "{")
+	require.Error(t, withLaunchProxy(header, &browserkubev1.BrowserProxy{URL: "http://proxy.corp:3128"}))
+}
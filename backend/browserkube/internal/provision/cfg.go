@@ -1,5 +1,18 @@
 package provision
 
+import "time"
+
 type Config struct {
 	BrowserNS string
+	// QueueTimeout is max time session request waits for a free capacity
+	QueueTimeout time.Duration
+	// Tenants maps teams to namespaces. Sessions of users without a tenant go to BrowserNS
+	Tenants []Tenant
+	// UserHeader is a request header carrying the authenticated user, set by the authenticating proxy.
+	// Callers are anonymous if empty. The proxy must strip the header from client requests
+	UserHeader string
+	// AuthEnabled requires credentials issued by browserkube. UserHeader isn't trusted then
+	AuthEnabled bool
+	// RolesConfigMap names the ConfigMap of BrowserNS binding users to roles. Users have DefaultRole if empty
+	RolesConfigMap string
 }
@@ -0,0 +1,80 @@
+package provisionk8s
+
+import (
+	"context"
+	"strings"
+
+	"github.com/pkg/errors"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+
+	browserkubehttp "github.com/browserkube/browserkube/pkg/http"
+)
+
+// LabelCredentials marks Secrets of the browser namespace holding credentials of browserkube users
+const LabelCredentials = "io.browserkube.credentials"
+
+// Keys of the credentials Secret. The Secret holds either a static token or SHA-256 digest of an API key
+const (
+	credentialsUserKey   = "user"
+	credentialsGroupsKey = "groups"
+	credentialsTokenKey  = "token"
+	credentialsAPIKeyKey = "sha256"
+)
+
+// secretAuthenticator authenticates callers with credentials Secrets kept in the informer cache
+type secretAuthenticator struct {
+	informer cache.SharedIndexInformer
+}
+
+func newSecretAuthenticator(clientset kubernetes.Interface, ns string) *secretAuthenticator {
+	secretsWatch := cache.NewFilteredListWatchFromClient(clientset.CoreV1().RESTClient(), "secrets", ns,
+		func(options *metav1.ListOptions) {
+			options.LabelSelector = LabelCredentials
+		})
+	return &secretAuthenticator{
+		informer: cache.NewSharedIndexInformer(secretsWatch, &apiv1.Secret{}, resyncPeriod, cache.Indexers{}),
+	}
+}
+
+func (sa *secretAuthenticator) Start(ctx context.Context) {
+	go sa.informer.Run(ctx.Done())
+}
+
+// WaitForSync waits until the credentials are loaded
+func (sa *secretAuthenticator) WaitForSync(ctx context.Context) error {
+	if !cache.WaitForCacheSync(ctx.Done(), sa.informer.HasSynced) {
+		return errors.New("unable to load credentials secrets")
+	}
+	return nil
+}
+
+func (sa *secretAuthenticator) Authenticate(ctx context.Context, user, secret string) (*browserkubehttp.Identity, error) {
+	return credentialsOf(sa.informer.GetStore().List()).Authenticate(ctx, user, secret)
+}
+
+func credentialsOf(objs []interface{}) browserkubehttp.Credentials {
+	credentials := make(browserkubehttp.Credentials, 0, len(objs))
+	for _, obj := range objs {
+		secret, ok := obj.(*apiv1.Secret)
+		if !ok || len(secret.Data[credentialsUserKey]) == 0 {
+			continue
+		}
+		c := browserkubehttp.Credential{
+			Identity: browserkubehttp.Identity{
+				User: string(secret.Data[credentialsUserKey]),
+			},
+			Token:      string(secret.Data[credentialsTokenKey]),
+			APIKeyHash: strings.TrimSpace(string(secret.Data[credentialsAPIKeyKey])),
+		}
+		for _, group := range strings.Split(string(secret.Data[credentialsGroupsKey]), ",") {
+			if group = strings.TrimSpace(group); group != "" {
+				c.Groups = append(c.Groups, group)
+			}
+		}
+		credentials = append(credentials, c)
+	}
+	return credentials
+}
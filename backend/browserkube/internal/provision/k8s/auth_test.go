@@ -0,0 +1,39 @@
+package provisionk8s
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	apiv1 "k8s.io/api/core/v1"
+
+	browserkubehttp "github.com/browserkube/browserkube/pkg/http"
+)
+
+func Test_credentialsOf(t *testing.T) {
+	credentials := credentialsOf([]interface{}{
+		&apiv1.Secret{Data: map[string][]byte{
+			credentialsUserKey:   []byte("alice"),
+			credentialsTokenKey:  []byte("alice-token"),
+			credentialsGroupsKey: []byte("qa, browserkube-admins"),
+		}},
+		&apiv1.Secret{Data: map[string][]byte{
+			credentialsUserKey:   []byte("ci"),
+			credentialsAPIKeyKey: []byte(browserkubehttp.HashAPIKey("ci-key") + "\n"),
+		}},
+		// secrets without a user are skipped
+		&apiv1.Secret{Data: map[string][]byte{credentialsTokenKey: []byte("orphan")}},
+	})
+	require.Len(t, credentials, 2)
+
+	identity, err := credentials.Authenticate(context.Background(), "alice", "alice-token")
+	require.NoError(t, err)
+	require.Equal(t, &browserkubehttp.Identity{User: "alice", Groups: []string{"qa", "browserkube-admins"}}, identity)
+
+	identity, err = credentials.Authenticate(context.Background(), "", "ci-key")
+	require.NoError(t, err)
+	require.Equal(t, "ci", identity.User)
+
+	_, err = credentials.Authenticate(context.Background(), "", "orphan")
+	require.ErrorIs(t, err, browserkubehttp.ErrUnauthenticated)
+}
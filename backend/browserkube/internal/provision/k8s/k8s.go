@@ -5,6 +5,11 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/pkg/errors"
@@ -12,22 +17,29 @@ import (
 	"go.opentelemetry.io/otel/propagation"
 	"go.uber.org/zap"
 	apiv1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/watch"
 	"k8s.io/client-go/kubernetes"
-	corev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/client-go/util/retry"
 	"k8s.io/utils/ptr"
 
 	"github.com/browserkube/browserkube/browserkube/internal/provision"
 	browserkubev1 "github.com/browserkube/browserkube/operator/api/v1"
+	"github.com/browserkube/browserkube/operator/pkg/browserset"
 	browserkubeclientv1 "github.com/browserkube/browserkube/operator/pkg/client/v1"
+	"github.com/browserkube/browserkube/operator/pkg/extensionpolicy"
 	"github.com/browserkube/browserkube/pkg/session"
 	browserkubeutil "github.com/browserkube/browserkube/pkg/util"
 )
 
 const (
+	// browserUPTimeout is used until the operator reports startup deadline of the browser
 	browserUPTimeout           = time.Minute
 	podGracefulShutdownTimeout = 30
+	sidecarClaimTimeout        = 5 * time.Second
 )
 
 // container names
@@ -40,33 +52,34 @@ type CreationErr struct {
 }
 
 // k8sWebDriverProvisioner provisioner for k8s
+// Browsers are created in the namespace of the caller's tenant
 type k8sWebDriverProvisioner struct {
 	logger            *zap.SugaredLogger
-	clientset         *kubernetes.Clientset
-	podClient         corev1.PodInterface
+	clientset         kubernetes.Interface
+	browserkubeClient browserkubeclientv1.Interface
 	envConfig         *provision.Config
-	browsersClient    browserkubeclientv1.BrowsersInterface
-	browserSetsClient browserkubeclientv1.BrowsersSetsInterface
+	queues            provision.TenantQueues
 }
 
 func newK8sWebDriverProvisioner(
 	clientset kubernetes.Interface,
 	browserkubeClient browserkubeclientv1.Interface,
 	envConfig *provision.Config,
+	queues provision.TenantQueues,
 ) *k8sWebDriverProvisioner {
 	logger := zap.S()
-	logger.Infof("Browser Namespace: %s", envConfig.BrowserNS)
+	logger.Infof("Browser Namespaces: %v", envConfig.Namespaces())
 	return &k8sWebDriverProvisioner{
 		logger:            logger,
+		clientset:         clientset,
+		browserkubeClient: browserkubeClient,
 		envConfig:         envConfig,
-		browsersClient:    browserkubeClient.Browsers(envConfig.BrowserNS),
-		browserSetsClient: browserkubeClient.BrowserSets(envConfig.BrowserNS),
-		podClient:         clientset.CoreV1().Pods(envConfig.BrowserNS),
+		queues:            queues,
 	}
 }
 
 func (kp *k8sWebDriverProvisioner) Available(ctx context.Context) (*browserkubev1.BrowserSetList, error) {
-	browserSets, err := kp.browserSetsClient.List(ctx, metav1.ListOptions{})
+	browserSets, err := kp.browserkubeClient.BrowserSets(kp.envConfig.Namespace(ctx)).List(ctx, metav1.ListOptions{})
 	if err != nil {
 		return nil, errors.WithStack(err)
 	}
@@ -78,6 +91,8 @@ func (kp *k8sWebDriverProvisioner) Provision(
 	id string,
 	opts *session.Capabilities,
 ) (*browserkubev1.Browser, error) {
+	// credentials of the capabilities aren't stored with the browser
+	opts.BrowserKubeOpts.Token = ""
 	kp.logger.Infow("Starting browser", "opts", opts)
 
 	capsRaw, err := json.Marshal(opts)
@@ -87,17 +102,67 @@ func (kp *k8sWebDriverProvisioner) Provision(
 	if opts.BrowserKubeOpts.Type == "" {
 		opts.BrowserKubeOpts.Type = browserkubev1.TypeWebDriver
 	}
-	tracingContext := propagation.MapCarrier{}
-	otel.GetTextMapPropagator().Inject(ctx, tracingContext)
+	// tenant capability routes the session to another tenant of the user
+	tenant := provision.TenantFrom(ctx)
+	if tenant == nil || opts.BrowserKubeOpts.Tenant != "" {
+		if tenant, err = kp.envConfig.Tenant(provision.UserFrom(ctx), opts.BrowserKubeOpts.Tenant); err != nil {
+			return nil, err
+		}
+	}
+	browsers := kp.browserkubeClient.Browsers(tenant.Namespace)
+	annotations := propagation.MapCarrier{}
+	otel.GetTextMapPropagator().Inject(ctx, annotations)
+	// only the owner may access the session and its result
+	if user := provision.UserFrom(ctx); user != "" {
+		annotations[browserkubev1.AnnotationOwner] = user
+	}
+
+	browser, err := kp.claimPooledBrowser(ctx, browsers, id, opts, capsRaw, annotations)
+	if err != nil {
+		kp.logger.Warnf("Unable to claim pooled browser, starting a new one: %v", err)
+	}
+	if browser == nil {
+		if browser, err = kp.createBrowser(ctx, tenant.Namespace, id, opts, capsRaw, annotations); err != nil {
+			return browser, err
+		}
+	}
+
+	switch browser.Spec.Type {
+	case browserkubev1.TypeWebDriver:
+		if err := browserkubeutil.SeleniumUP(ctx, browser.Status.SeleniumURL); err != nil {
+			return browser, errors.Wrapf(err, "Timeout on waiting of pod running: %v", err)
+		}
+	case browserkubev1.TypePlaywright:
+		address := net.JoinHostPort(browser.Status.Host, browser.Status.PortConfig.Browser)
+		if err := browserkubeutil.PlaywrightUP(ctx, address); err != nil {
+			return browser, errors.Wrapf(err, "Timeout on waiting of pod running: %v", err)
+		}
+	}
+
+	kp.logger.Debugf("Browser [%s] is UP", browser.GetName())
+	return browser, nil
+}
 
+func (kp *k8sWebDriverProvisioner) createBrowser(
+	ctx context.Context,
+	namespace string,
+	id string,
+	opts *session.Capabilities,
+	capsRaw []byte,
+	annotations map[string]string,
+) (*browserkubev1.Browser, error) {
+	timeouts, err := requestedTimeouts(&opts.BrowserKubeOpts)
+	if err != nil {
+		return nil, err
+	}
 	browser := &browserkubev1.Browser{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      id,
-			Namespace: kp.envConfig.BrowserNS,
+			Namespace: namespace,
 			Labels: map[string]string{
 				browserkubev1.LabelBrowserVisibility: "true",
 			},
-			Annotations: tracingContext,
+			Annotations: annotations,
 		},
 		Spec: browserkubev1.BrowserSpec{
 			Platform:       opts.Platform,
@@ -107,39 +172,312 @@ func (kp *k8sWebDriverProvisioner) Provision(
 			Type:           opts.BrowserKubeOpts.Type,
 			Caps:           json.RawMessage(capsRaw),
 
-			EnableVNC:   opts.BrowserKubeOpts.EnableVNC,
-			EnableVideo: opts.BrowserKubeOpts.EnableVideo,
-			Extensions:  opts.BrowserKubeOpts.Extensions,
+			EnableVNC:        opts.BrowserKubeOpts.EnableVNC,
+			EnableVideo:      opts.BrowserKubeOpts.EnableVideo,
+			ScreenResolution: opts.BrowserKubeOpts.ScreenResolution,
+			Extensions:       opts.BrowserKubeOpts.Extensions,
+
+			BrowserSet:         opts.BrowserKubeOpts.BrowserSet,
+			BrowserSetSelector: opts.BrowserKubeOpts.BrowserSetSelector,
+			SizeProfile:        opts.BrowserKubeOpts.SizeProfile,
+			Timeouts:           timeouts,
+			Network:            requestedNetwork(&opts.BrowserKubeOpts),
+			Recording:          requestedRecording(&opts.BrowserKubeOpts),
+			Proxy:              requestedProxy(&opts.BrowserKubeOpts),
 		},
 	}
 
-	browser, err = kp.browsersClient.Create(ctx, browser)
+	if err = kp.checkExtensions(ctx, browser); err != nil {
+		return nil, err
+	}
+
+	// wait for a free slot if browsers quota of the tenant is exceeded
+	browsers := kp.browserkubeClient.Browsers(namespace)
+	err = kp.queues.For(namespace).Do(ctx, id, func() error {
+		created, cErr := browsers.Create(ctx, browser)
+		if cErr != nil {
+			if isQuotaExceeded(cErr) {
+				return provision.ErrNoCapacity
+			}
+			return errors.WithStack(cErr)
+		}
+		browser = created
+		return nil
+	})
 	if err != nil {
-		return nil, errors.WithStack(err)
+		return nil, err
 	}
-	if browser, err = kp.waitForBrowser(ctx, browser, browserUPTimeout); err != nil {
+	if browser, err = kp.waitForBrowser(ctx, browsers, browser, browserUPTimeout); err != nil {
 		return browser, errors.WithStack(err)
 	}
+	return browser, nil
+}
 
-	if browser.Spec.Type == browserkubev1.TypeWebDriver {
-		if err := browserkubeutil.SeleniumUP(ctx, browser.Status.SeleniumURL); err != nil {
-			return browser, errors.Wrapf(err, "Timeout on waiting of pod running: %v", err)
+// checkExtensions rejects extensions which aren't allowed by ExtensionPolicies before the browser is created.
+// The browser is matched with the BrowserSet it would be resolved from, the operator checks it again on admission
+func (kp *k8sWebDriverProvisioner) checkExtensions(ctx context.Context, browser *browserkubev1.Browser) error {
+	if len(browser.Spec.Extensions) == 0 {
+		return nil
+	}
+	policies, err := kp.browserkubeClient.ExtensionPolicies(browser.Namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return errors.Wrap(err, "extension policies can't be loaded")
+	}
+
+	// the set is left empty if the browser can't be resolved, the operator reports the reason then
+	var browserSet string
+	if sets, sErr := kp.browserkubeClient.BrowserSets(browser.Namespace).List(ctx, metav1.ListOptions{}); sErr == nil {
+		selected, selErr := browserset.Select(sets.Items, browser.Spec.BrowserSet, browser.Spec.BrowserSetSelector)
+		if selErr == nil {
+			resolved, rErr := browserset.Resolve(selected, browser.Spec.Type, strings.ToLower(browser.Spec.BrowserName),
+				browser.Spec.BrowserVersion)
+			if rErr == nil {
+				browserSet = resolved.Set.Name
+			}
 		}
 	}
+	if err = extensionpolicy.Check(policies.Items, browser, browserSet); err != nil {
+		return fmt.Errorf("%w: %w", provision.ErrNotAllowed, err)
+	}
+	return nil
+}
 
-	kp.logger.Debugf("Browser [%s] is UP", browser.GetName())
-	return browser, nil
+// requestedTimeouts parses timeouts requested by the session. Returns nil if there are none
+func requestedTimeouts(opts *session.BrowserKubeOpts) (*browserkubev1.BrowserTimeouts, error) {
+	if opts.StartupTimeout == "" && opts.IdleTimeout == "" && opts.SessionTimeout == "" {
+		return nil, nil
+	}
+	parse := func(name, value string) (*metav1.Duration, error) {
+		if value == "" {
+			return nil, nil
+		}
+		d, err := time.ParseDuration(value)
+		if err != nil {
+			return nil, errors.Wrapf(err, "incorrect %s", name)
+		}
+		return &metav1.Duration{Duration: d}, nil
+	}
+
+	var err error
+	timeouts := &browserkubev1.BrowserTimeouts{}
+	if timeouts.Startup, err = parse("startupTimeout", opts.StartupTimeout); err != nil {
+		return nil, err
+	}
+	if timeouts.Idle, err = parse("idleTimeout", opts.IdleTimeout); err != nil {
+		return nil, err
+	}
+	if timeouts.Session, err = parse("sessionTimeout", opts.SessionTimeout); err != nil {
+		return nil, err
+	}
+	return timeouts, nil
+}
+
+// requestedNetwork returns network egress requested by the session. Returns nil if there is none
+func requestedNetwork(opts *session.BrowserKubeOpts) *browserkubev1.BrowserNetwork {
+	if len(opts.AllowedCIDRs) == 0 && len(opts.AllowedDomains) == 0 {
+		return nil
+	}
+	return &browserkubev1.BrowserNetwork{
+		AllowedCIDRs:   opts.AllowedCIDRs,
+		AllowedDomains: opts.AllowedDomains,
+	}
+}
+
+// requestedRecording returns video recording options requested by the session or nil if there are none
+func requestedRecording(opts *session.BrowserKubeOpts) *browserkubev1.BrowserRecording {
+	if opts.VideoSize == "" && opts.VideoFrameRate == 0 && opts.VideoCodec == "" && opts.VideoCRF == nil &&
+		opts.VideoFormat == "" {
+		return nil
+	}
+	return &browserkubev1.BrowserRecording{
+		VideoSize: opts.VideoSize,
+		FrameRate: opts.VideoFrameRate,
+		Codec:     opts.VideoCodec,
+		CRF:       opts.VideoCRF,
+		Format:    opts.VideoFormat,
+	}
+}
+
+// requestedProxy returns upstream proxy requested by the session or nil if there is none
+func requestedProxy(opts *session.BrowserKubeOpts) *browserkubev1.BrowserProxy {
+	if opts.Proxy == "" {
+		return nil
+	}
+	return &browserkubev1.BrowserProxy{
+		URL:               opts.Proxy,
+		NoProxy:           opts.NoProxy,
+		CredentialsSecret: opts.ProxyCredentialsSecret,
+	}
+}
+
+// isQuotaExceeded checks whether creation has been rejected by ResourceQuota admission
+func isQuotaExceeded(err error) bool {
+	return apierrors.IsForbidden(err) && strings.Contains(err.Error(), "exceeded quota")
+}
+
+// claimPooledBrowser takes an idle browser started by BrowserPool if there is a suitable one.
+// Claiming is an update guarded by resource version, so concurrent sessions never get the same browser.
+// Returns nil if there is no idle browser to claim
+func (kp *k8sWebDriverProvisioner) claimPooledBrowser(
+	ctx context.Context,
+	browsers browserkubeclientv1.BrowsersInterface,
+	id string,
+	opts *session.Capabilities,
+	capsRaw []byte,
+	annotations map[string]string,
+) (*browserkubev1.Browser, error) {
+	pooled, err := browsers.List(ctx, metav1.ListOptions{
+		LabelSelector: labels.SelectorFromSet(labels.Set{
+			browserkubev1.LabelPoolState: browserkubev1.PoolStateIdle,
+		}).String(),
+	})
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	candidates := make([]*browserkubev1.Browser, 0, len(pooled.Items))
+	for i := range pooled.Items {
+		if isPooledBrowserSuitable(&pooled.Items[i], opts) {
+			candidates = append(candidates, &pooled.Items[i])
+		}
+	}
+	// the youngest browsers go first. They are the farthest from the idle timeout of the pool
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[j].CreationTimestamp.Before(&candidates[i].CreationTimestamp)
+	})
+
+	for _, candidate := range candidates {
+		browser := candidate.DeepCopy()
+		browser.Labels[browserkubev1.LabelPoolState] = browserkubev1.PoolStateClaimed
+		browser.Labels[browserkubev1.LabelBrowserVisibility] = "true"
+		browser.Labels[browserkubev1.LabelSessionID] = id
+		if browser.Annotations == nil {
+			browser.Annotations = map[string]string{}
+		}
+		for k, v := range annotations {
+			browser.Annotations[k] = v
+		}
+		// claimed browser must survive the pool deletion
+		ownerRefs := browser.OwnerReferences[:0]
+		for _, ref := range browser.OwnerReferences {
+			if ref.Kind != "BrowserPool" {
+				ownerRefs = append(ownerRefs, ref)
+			}
+		}
+		browser.OwnerReferences = ownerRefs
+		browser.Spec.Caps = capsRaw
+
+		claimed, uErr := browsers.Update(ctx, browser)
+		if uErr != nil {
+			if apierrors.IsConflict(uErr) || apierrors.IsNotFound(uErr) {
+				// claimed by someone else or deleted meanwhile
+				continue
+			}
+			return nil, errors.WithStack(uErr)
+		}
+		kp.logger.Infof("Pooled browser [%s] claimed by session [%s]", claimed.Name, id)
+		if err := notifyClaimed(ctx, claimed); err != nil {
+			// the browser is still usable, it's terminated by the idle timeout of the pool
+			kp.logger.Warnf("Unable to start timeouts of pooled browser [%s]: %v", claimed.Name, err)
+		}
+		return claimed, nil
+	}
+	return nil, nil
+}
+
+// notifyClaimed tells the sidecar of the pooled browser to start the session timeouts.
+// Playwright sessions connect to the browser directly, so the sidecar doesn't see their start otherwise
+func notifyClaimed(ctx context.Context, browser *browserkubev1.Browser) error {
+	ctx, cancel := context.WithTimeout(ctx, sidecarClaimTimeout)
+	defer cancel()
+
+	u := &url.URL{
+		Scheme: "http",
+		Host:   net.JoinHostPort(browser.Status.Host, browser.Status.PortConfig.Sidecar),
+		Path:   "/claim",
+	}
+	rq, err := http.NewRequestWithContext(ctx, http.MethodPost, u.String(), http.NoBody)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	rs, err := http.DefaultClient.Do(rq)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	defer rs.Body.Close()
+	if rs.StatusCode != http.StatusNoContent {
+		return errors.Errorf("unexpected status code: %d", rs.StatusCode)
+	}
+	return nil
+}
+
+// isPooledBrowserSuitable checks whether pooled browser has been started with the requested options
+func isPooledBrowserSuitable(b *browserkubev1.Browser, opts *session.Capabilities) bool {
+	if !b.DeletionTimestamp.IsZero() || b.Status.Phase != browserkubev1.PhaseRunning {
+		return false
+	}
+	if !strings.EqualFold(b.Spec.BrowserName, opts.BrowserName) {
+		return false
+	}
+	if opts.Platform != "" && !strings.EqualFold(opts.Platform, provision.PlatformLinux) {
+		return false
+	}
+	bType := b.Spec.Type
+	if bType == "" {
+		bType = browserkubev1.TypeWebDriver
+	}
+	if bType != opts.BrowserKubeOpts.Type {
+		return false
+	}
+	if opts.BrowserVersion == "" {
+		// default version is requested. Pool has to be created for the default version as well
+		if b.Annotations[browserkubev1.AnnotationPoolBrowserVersion] != "" {
+			return false
+		}
+	} else if opts.BrowserVersion != b.Spec.BrowserVersion {
+		return false
+	}
+	// pooled browsers can't be checked against set selector
+	if opts.BrowserKubeOpts.BrowserSetSelector != "" {
+		return false
+	}
+	if opts.BrowserKubeOpts.BrowserSet != "" && opts.BrowserKubeOpts.BrowserSet != b.Status.BrowserSet {
+		return false
+	}
+	// timeouts of pooled browsers are applied at the start already
+	if opts.BrowserKubeOpts.StartupTimeout != "" || opts.BrowserKubeOpts.IdleTimeout != "" ||
+		opts.BrowserKubeOpts.SessionTimeout != "" {
+		return false
+	}
+	// network policy of pooled browsers is created at the start already
+	if requestedNetwork(&opts.BrowserKubeOpts) != nil {
+		return false
+	}
+	// recorder of pooled browsers is started with the BrowserSet options
+	if requestedRecording(&opts.BrowserKubeOpts) != nil {
+		return false
+	}
+	// proxy of pooled browsers is configured with the BrowserSet one
+	if requestedProxy(&opts.BrowserKubeOpts) != nil {
+		return false
+	}
+	return b.Spec.Timezone == opts.Timezone &&
+		b.Spec.EnableVNC == opts.BrowserKubeOpts.EnableVNC &&
+		b.Spec.EnableVideo == opts.BrowserKubeOpts.EnableVideo &&
+		b.Spec.ScreenResolution == opts.BrowserKubeOpts.ScreenResolution &&
+		b.Spec.SizeProfile == opts.BrowserKubeOpts.SizeProfile &&
+		len(opts.BrowserKubeOpts.Extensions) == 0
 }
 
-func (kp *k8sWebDriverProvisioner) Delete(ctx context.Context, id string) error {
-	kp.logger.Infof("Deleting Browser [%s]", id)
-	return errors.WithStack(kp.browsersClient.Delete(ctx, id, metav1.DeleteOptions{
+func (kp *k8sWebDriverProvisioner) Delete(ctx context.Context, browser *browserkubev1.Browser) error {
+	kp.logger.Infof("Deleting Browser [%s/%s]", browser.Namespace, browser.Name)
+	return errors.WithStack(kp.browserkubeClient.Browsers(browser.Namespace).Delete(ctx, browser.Name, metav1.DeleteOptions{
 		GracePeriodSeconds: ptr.To(int64(podGracefulShutdownTimeout)),
 	}))
 }
 
-func (kp *k8sWebDriverProvisioner) Logs(ctx context.Context, name string, follow bool) (io.ReadCloser, error) {
-	req := kp.podClient.GetLogs(name, &apiv1.PodLogOptions{
+func (kp *k8sWebDriverProvisioner) Logs(ctx context.Context, browser *browserkubev1.Browser, follow bool) (io.ReadCloser, error) {
+	req := kp.clientset.CoreV1().Pods(browser.Namespace).GetLogs(browser.Status.PodName, &apiv1.PodLogOptions{
 		Container:  browserContainerName,
 		Follow:     follow,
 		Previous:   false,
@@ -149,31 +487,62 @@ func (kp *k8sWebDriverProvisioner) Logs(ctx context.Context, name string, follow
 	return logs, errors.WithStack(err)
 }
 
-// Update sends a MergeType PATCH request to k8s API to update our running browserset resource
-func (kp *k8sWebDriverProvisioner) Update(ctx context.Context, bs *browserkubev1.BrowserSet) error {
-	b, err := json.Marshal(bs)
-	if err != nil {
-		return err
+// VNCPassword reads VNC password of the browser from the Secret created by the operator.
+// Empty password is returned for browsers without VNC
+func (kp *k8sWebDriverProvisioner) VNCPassword(ctx context.Context, browser *browserkubev1.Browser) (string, error) {
+	if browser.Status.VNCSecret == "" {
+		return "", nil
 	}
-	err = kp.browserSetsClient.Patch(ctx, bs.Name, b, metav1.PatchOptions{})
+	secret, err := kp.clientset.CoreV1().Secrets(browser.Namespace).Get(ctx, browser.Status.VNCSecret, metav1.GetOptions{})
 	if err != nil {
-		return errors.WithStack(err)
+		return "", errors.WithStack(err)
 	}
-	return nil
+	password, ok := secret.Data[browserkubev1.VNCSecretKey]
+	if !ok {
+		return "", errors.Errorf("VNC password isn't found in secret %s", secret.Name)
+	}
+	return string(password), nil
 }
 
-func (kp *k8sWebDriverProvisioner) waitForBrowser(ctx context.Context, browser *browserkubev1.Browser, timeout time.Duration) (*browserkubev1.Browser, error) {
-	pWatch, err := kp.browsersClient.WatchByName(ctx, browser.Name)
+// Update replaces the spec of the BrowserSet in the namespace of the caller's tenant.
+// The caller's role has to allow editing BrowserSets
+func (kp *k8sWebDriverProvisioner) Update(ctx context.Context, bs *browserkubev1.BrowserSet) error {
+	if err := provision.CheckAction(ctx, provision.ActionEditBrowserSets, nil); err != nil {
+		return err
+	}
+	sets := kp.browserkubeClient.BrowserSets(kp.envConfig.Namespace(ctx))
+	return errors.WithStack(retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		current, err := sets.Get(ctx, bs.Name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		current.Spec = bs.Spec
+		_, err = sets.Update(ctx, current)
+		return err
+	}))
+}
+
+func (kp *k8sWebDriverProvisioner) waitForBrowser(
+	ctx context.Context,
+	browsers browserkubeclientv1.BrowsersInterface,
+	browser *browserkubev1.Browser,
+	timeout time.Duration,
+) (*browserkubev1.Browser, error) {
+	pWatch, err := browsers.WatchByName(ctx, browser.Name)
 	if err != nil {
 		return nil, errors.Wrapf(err, "Unable to find browser to pWatch: %v", err)
 	}
 	timer := time.NewTimer(timeout)
-	defer timer.Stop()
+	defer func() { timer.Stop() }()
 
 	var lastState *browserkubev1.Browser
+	var deadline time.Time
 	for {
 		select {
 		case <-timer.C:
+			if msg := notReadyMessage(lastState); msg != "" {
+				return lastState, errors.Errorf("timeout exception while waiting for browser: %s", msg)
+			}
 			return lastState, errors.New("timeout exception while waiting for browser")
 		case ev := <-pWatch.ResultChan():
 			p, ok := ev.Object.(*browserkubev1.Browser)
@@ -181,9 +550,18 @@ func (kp *k8sWebDriverProvisioner) waitForBrowser(ctx context.Context, browser *
 				continue
 			}
 			if ev.Type == watch.Deleted {
+				if cond := meta.FindStatusCondition(p.Status.Conditions, browserkubev1.ConditionTerminated); cond != nil {
+					return nil, errors.Errorf("browser has been deleted after creation: %s", cond.Message)
+				}
 				return nil, errors.New("browser has been deleted after creation")
 			}
 			lastState = p
+			// operator reports startup deadline resolved from BrowserSet and session timeouts
+			if d := p.Status.StartupDeadline; d != nil && !d.Time.Equal(deadline) {
+				deadline = d.Time
+				timer.Stop()
+				timer = time.NewTimer(time.Until(deadline))
+			}
 			switch p.Status.Phase {
 			case browserkubev1.PhaseRunning:
 				return p, nil
@@ -191,6 +569,9 @@ func (kp *k8sWebDriverProvisioner) waitForBrowser(ctx context.Context, browser *
 				// no status yet
 				continue
 			case browserkubev1.PhaseFailed:
+				if p.Status.Reason != "" && p.Status.Message != "" {
+					return nil, &CreationErr{error: errors.Errorf("%s: %s", p.Status.Reason, p.Status.Message)}
+				}
 				if p.Status.Reason != "" {
 					return nil, &CreationErr{error: errors.New(string(p.Status.Reason))}
 				}
@@ -203,3 +584,14 @@ func (kp *k8sWebDriverProvisioner) waitForBrowser(ctx context.Context, browser *
 		}
 	}
 }
+
+// notReadyMessage describes the most specific reason why the browser hasn't started yet
+func notReadyMessage(browser *browserkubev1.Browser) string {
+	if browser == nil {
+		return ""
+	}
+	if cond := browser.NotReadyCondition(); cond != nil {
+		return fmt.Sprintf("%s %s: %s", cond.Type, cond.Reason, cond.Message)
+	}
+	return ""
+}
@@ -0,0 +1,485 @@
+package provisionk8s
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	apiv1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/utils/ptr"
+
+	"github.com/browserkube/browserkube/browserkube/internal/provision"
+	"github.com/browserkube/browserkube/browserkube/internal/provision/k8s/mocks"
+	v1 "github.com/browserkube/browserkube/operator/api/v1"
+	"github.com/browserkube/browserkube/pkg/session"
+)
+
+func pooledBrowser(name, version string, created time.Time) v1.Browser {
+	return v1.Browser{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              name,
+			CreationTimestamp: metav1.NewTime(created),
+			Labels: map[string]string{
+				v1.LabelPool:      "chrome-pool",
+				v1.LabelPoolState: v1.PoolStateIdle,
+			},
+			Annotations: map[string]string{
+				v1.AnnotationPoolBrowserVersion: version,
+			},
+			OwnerReferences: []metav1.OwnerReference{{Kind: "BrowserPool", Name: "chrome-pool"}},
+		},
+		Spec: v1.BrowserSpec{
+			BrowserName:    "chrome",
+			BrowserVersion: "120.0",
+			Type:           v1.TypeWebDriver,
+		},
+		Status: v1.BrowserStatus{Phase: v1.PhaseRunning},
+	}
+}
+
+func Test_isPooledBrowserSuitable(t *testing.T) {
+	tests := []struct {
+		name    string
+		browser v1.Browser
+		opts    *session.Capabilities
+		want    bool
+	}{
+		{
+			name:    "default version requested, default version pool",
+			browser: pooledBrowser("b1", "", time.Now()),
+			opts: &session.Capabilities{
+				BrowserName:     "Chrome",
+				BrowserKubeOpts: session.BrowserKubeOpts{Type: v1.TypeWebDriver},
+			},
+			want: true,
+		},
+		{
+			name:    "default version requested, pinned version pool",
+			browser: pooledBrowser("b1", "120.0", time.Now()),
+			opts: &session.Capabilities{
+				BrowserName:     "chrome",
+				BrowserKubeOpts: session.BrowserKubeOpts{Type: v1.TypeWebDriver},
+			},
+			want: false,
+		},
+		{
+			name:    "exact version requested",
+			browser: pooledBrowser("b1", "", time.Now()),
+			opts: &session.Capabilities{
+				BrowserName:     "chrome",
+				BrowserVersion:  "120.0",
+				BrowserKubeOpts: session.BrowserKubeOpts{Type: v1.TypeWebDriver},
+			},
+			want: true,
+		},
+		{
+			name:    "VNC is not enabled in pool",
+			browser: pooledBrowser("b1", "", time.Now()),
+			opts: &session.Capabilities{
+				BrowserName:     "chrome",
+				BrowserKubeOpts: session.BrowserKubeOpts{Type: v1.TypeWebDriver, EnableVNC: true},
+			},
+			want: false,
+		},
+		{
+			name:    "extensions can't be installed into running browser",
+			browser: pooledBrowser("b1", "", time.Now()),
+			opts: &session.Capabilities{
+				BrowserName: "chrome",
+				BrowserKubeOpts: session.BrowserKubeOpts{
+					Type:       v1.TypeWebDriver,
+					Extensions: []v1.BrowserExtension{{ExtensionID: "ext"}},
+				},
+			},
+			want: false,
+		},
+		{
+			name:    "network policy can't be applied to running browser",
+			browser: pooledBrowser("b1", "", time.Now()),
+			opts: &session.Capabilities{
+				BrowserName: "chrome",
+				BrowserKubeOpts: session.BrowserKubeOpts{
+					Type:           v1.TypeWebDriver,
+					AllowedDomains: []string{"example.com"},
+				},
+			},
+			want: false,
+		},
+		{
+			name: "browser is not running yet",
+			browser: func() v1.Browser {
+				b := pooledBrowser("b1", "", time.Now())
+				b.Status.Phase = v1.PhasePending
+				return b
+			}(),
+			opts: &session.Capabilities{
+				BrowserName:     "chrome",
+				BrowserKubeOpts: session.BrowserKubeOpts{Type: v1.TypeWebDriver},
+			},
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, isPooledBrowserSuitable(&tt.browser, tt.opts))
+		})
+	}
+}
+
+func Test_k8sWebDriverProvisioner_claimPooledBrowser(t *testing.T) {
+	now := time.Now()
+	opts := &session.Capabilities{
+		BrowserName:     "chrome",
+		BrowserKubeOpts: session.BrowserKubeOpts{Type: v1.TypeWebDriver},
+	}
+	conflict := apierrors.NewConflict(schema.GroupResource{Resource: "browsers"}, "new", nil)
+
+	tests := []struct {
+		name        string
+		wantName    string
+		wantErr     bool
+		prepareFunc func(browsers *mocks.BrowsersInterface)
+	}{
+		{
+			name:     "claims the youngest idle browser",
+			wantName: "new",
+			prepareFunc: func(browsers *mocks.BrowsersInterface) {
+				browsers.On("List", mock.Anything, mock.Anything).Return(&v1.BrowserList{Items: []v1.Browser{
+					pooledBrowser("new", "", now),
+					pooledBrowser("old", "", now.Add(-time.Minute)),
+				}}, nil)
+				browsers.On("Update", mock.Anything, mock.MatchedBy(func(b *v1.Browser) bool {
+					return b.Name == "new" &&
+						b.Labels[v1.LabelPoolState] == v1.PoolStateClaimed &&
+						b.Labels[v1.LabelBrowserVisibility] == "true" &&
+						b.Labels[v1.LabelSessionID] == "session-1" &&
+						len(b.OwnerReferences) == 0
+				})).Return(func(_ context.Context, b *v1.Browser) (*v1.Browser, error) {
+					return b, nil
+				})
+			},
+		},
+		{
+			name:     "skips browser claimed concurrently",
+			wantName: "old",
+			prepareFunc: func(browsers *mocks.BrowsersInterface) {
+				browsers.On("List", mock.Anything, mock.Anything).Return(&v1.BrowserList{Items: []v1.Browser{
+					pooledBrowser("old", "", now.Add(-time.Minute)),
+					pooledBrowser("new", "", now),
+				}}, nil)
+				browsers.On("Update", mock.Anything, mock.MatchedBy(func(b *v1.Browser) bool {
+					return b.Name == "new"
+				})).Return(nil, conflict)
+				browsers.On("Update", mock.Anything, mock.MatchedBy(func(b *v1.Browser) bool {
+					return b.Name == "old"
+				})).Return(func(_ context.Context, b *v1.Browser) (*v1.Browser, error) {
+					return b, nil
+				})
+			},
+		},
+		{
+			name: "no idle browsers",
+			prepareFunc: func(browsers *mocks.BrowsersInterface) {
+				browsers.On("List", mock.Anything, mock.Anything).Return(&v1.BrowserList{}, nil)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			browsers := mocks.NewBrowsersInterface(t)
+			tt.prepareFunc(browsers)
+
+			kp := &k8sWebDriverProvisioner{
+				logger: zap.S(),
+			}
+			got, err := kp.claimPooledBrowser(context.Background(), browsers, "session-1", opts, []byte("{}"), nil)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			if tt.wantName == "" {
+				require.Nil(t, got)
+				return
+			}
+			require.Equal(t, tt.wantName, got.Name)
+			require.Equal(t, "session-1", got.SessionID())
+		})
+	}
+}
+
+func Test_k8sWebDriverProvisioner_checkExtensions(t *testing.T) {
+	sets := &v1.BrowserSetList{Items: []v1.BrowserSet{{
+		ObjectMeta: metav1.ObjectMeta{Name: "internal", Namespace: "team-a"},
+		Spec: v1.BrowserSetSpec{WebDriver: map[string]v1.BrowsersConfig{
+			"chrome": {DefaultVersion: "120.0", Versions: map[string]v1.BrowserConfig{"120.0": {Image: "chrome:120.0"}}},
+		}},
+	}}}
+	policies := &v1.ExtensionPolicyList{Items: []v1.ExtensionPolicy{{
+		ObjectMeta: metav1.ObjectMeta{Name: "internal", Namespace: "team-a"},
+		Spec: v1.ExtensionPolicySpec{
+			Selector: &metav1.LabelSelector{MatchLabels: map[string]string{v1.LabelBrowserSet: "internal"}},
+			Allowed:  []v1.AllowedExtension{{Artifact: "sso-helper", Versions: ">=1.2"}},
+		},
+	}}}
+
+	tests := []struct {
+		name       string
+		extensions []v1.BrowserExtension
+		wantErr    string
+	}{
+		{
+			name:       "allowed in the resolved set",
+			extensions: []v1.BrowserExtension{{Artifact: "sso-helper", Version: "1.3"}},
+		},
+		{
+			name: "rejected",
+			extensions: []v1.BrowserExtension{
+				{Artifact: "sso-helper", Version: "1.1"},
+				{ExtensionID: "cjpalhdlnbpafiamejdnhcphjbkeiagm"},
+			},
+			wantErr: "session isn't allowed: extensions aren't allowed by extension policies: " +
+				"artifact sso-helper 1.1, cjpalhdlnbpafiamejdnhcphjbkeiagm",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := mocks.NewInterface(t)
+			setsClient := mocks.NewBrowsersSetsInterface(t)
+			policiesClient := mocks.NewExtensionPoliciesInterface(t)
+			client.On("BrowserSets", "team-a").Return(setsClient)
+			client.On("ExtensionPolicies", "team-a").Return(policiesClient)
+			setsClient.On("List", mock.Anything, mock.Anything).Return(sets, nil)
+			policiesClient.On("List", mock.Anything, mock.Anything).Return(policies, nil)
+
+			kp := &k8sWebDriverProvisioner{logger: zap.S(), browserkubeClient: client}
+			err := kp.checkExtensions(context.Background(), &v1.Browser{
+				ObjectMeta: metav1.ObjectMeta{Name: "browser", Namespace: "team-a"},
+				Spec: v1.BrowserSpec{
+					BrowserName: "chrome",
+					Type:        v1.TypeWebDriver,
+					Extensions:  tt.extensions,
+				},
+			})
+			if tt.wantErr == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorIs(t, err, provision.ErrNotAllowed)
+			require.EqualError(t, err, tt.wantErr)
+		})
+	}
+}
+
+func Test_k8sWebDriverProvisioner_Update(t *testing.T) {
+	cfg := &provision.Config{BrowserNS: "browserkube"}
+	ctx := provision.WithTenant(context.Background(), &provision.Tenant{Name: "team-a", Namespace: "team-a"})
+	updated := &v1.BrowserSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "default"},
+		Spec:       v1.BrowserSetSpec{DefaultTimezone: "Europe/Berlin"},
+	}
+
+	kp := &k8sWebDriverProvisioner{logger: zap.S(), browserkubeClient: mocks.NewInterface(t), envConfig: cfg}
+	err := kp.Update(provision.WithRole(ctx, provision.RoleOperator), updated)
+	require.ErrorIs(t, err, provision.ErrForbidden)
+
+	client := mocks.NewInterface(t)
+	setsClient := mocks.NewBrowsersSetsInterface(t)
+	client.On("BrowserSets", "team-a").Return(setsClient)
+	setsClient.On("Get", mock.Anything, "default", mock.Anything).Return(func(context.Context, string, metav1.GetOptions) (*v1.BrowserSet, error) {
+		return &v1.BrowserSet{
+			ObjectMeta: metav1.ObjectMeta{Name: "default", Namespace: "team-a", ResourceVersion: "7"},
+			Spec: v1.BrowserSetSpec{
+				DefaultTimezone: "UTC",
+				WebDriver:       map[string]v1.BrowsersConfig{"chrome": {}},
+			},
+		}, nil
+	})
+	conflict := apierrors.NewConflict(schema.GroupResource{Resource: "browsersets"}, "default", nil)
+	setsClient.On("Update", mock.Anything, mock.Anything).Return(nil, conflict).Once()
+	setsClient.On("Update", mock.Anything, mock.MatchedBy(func(bs *v1.BrowserSet) bool {
+		// the spec is replaced, metadata of the stored set is kept
+		return bs.ResourceVersion == "7" && bs.Spec.DefaultTimezone == "Europe/Berlin" && bs.Spec.WebDriver == nil
+	})).Return(updated, nil).Once()
+
+	kp = &k8sWebDriverProvisioner{logger: zap.S(), browserkubeClient: client, envConfig: cfg}
+	require.NoError(t, kp.Update(provision.WithRole(ctx, provision.RoleAdmin), updated))
+}
+
+func Test_k8sWebDriverProvisioner_waitForBrowser(t *testing.T) {
+	imagePullBackOff := metav1.Condition{
+		Type: v1.ConditionImagePulled, Status: metav1.ConditionFalse,
+		Reason: "ImagePullBackOff", Message: "container browser: Back-off pulling image",
+	}
+	tests := []struct {
+		name    string
+		events  []watch.Event
+		timeout time.Duration
+		wantErr string
+	}{
+		{
+			name: "running",
+			events: []watch.Event{
+				{Type: watch.Modified, Object: &v1.Browser{Status: v1.BrowserStatus{Phase: v1.PhaseRunning}}},
+			},
+		},
+		{
+			name: "timeout surfaces not ready condition",
+			events: []watch.Event{
+				{Type: watch.Modified, Object: &v1.Browser{Status: v1.BrowserStatus{
+					Phase: v1.PhasePending,
+					Conditions: []metav1.Condition{
+						{Type: v1.ConditionScheduled, Status: metav1.ConditionTrue, Reason: v1.ConditionReasonPodScheduled},
+						imagePullBackOff,
+					},
+				}}},
+			},
+			wantErr: "ImagePulled ImagePullBackOff: container browser: Back-off pulling image",
+		},
+		{
+			name: "startup deadline reported by operator",
+			events: []watch.Event{
+				{Type: watch.Modified, Object: &v1.Browser{Status: v1.BrowserStatus{
+					Phase:           v1.PhasePending,
+					StartupDeadline: ptr.To(metav1.NewTime(time.Now().Add(10 * time.Millisecond))),
+				}}},
+			},
+			timeout: time.Hour,
+			wantErr: "timeout exception while waiting for browser",
+		},
+		{
+			name: "failed",
+			events: []watch.Event{
+				{Type: watch.Modified, Object: &v1.Browser{Status: v1.BrowserStatus{
+					Phase: v1.PhaseFailed, Reason: v1.ReasonVersionNotSupported, Message: "version 1.0 isn't found",
+				}}},
+			},
+			wantErr: "Version isn't supported: version 1.0 isn't found",
+		},
+		{
+			name: "deleted",
+			events: []watch.Event{
+				{Type: watch.Deleted, Object: &v1.Browser{Status: v1.BrowserStatus{
+					Conditions: []metav1.Condition{{
+						Type: v1.ConditionTerminated, Status: metav1.ConditionTrue,
+						Reason: v1.ConditionReasonStartTimeout, Message: "browser hasn't started in 5m0s",
+					}},
+				}}},
+			},
+			wantErr: "browser has been deleted after creation: browser hasn't started in 5m0s",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			watcher := watch.NewFakeWithChanSize(len(tt.events), false)
+			for _, ev := range tt.events {
+				watcher.Action(ev.Type, ev.Object)
+			}
+			browsers := mocks.NewBrowsersInterface(t)
+			browsers.On("WatchByName", mock.Anything, "browser").Return(watcher, nil)
+
+			kp := &k8sWebDriverProvisioner{
+				logger: zap.S(),
+			}
+			timeout := tt.timeout
+			if timeout == 0 {
+				timeout = 100 * time.Millisecond
+			}
+			_, err := kp.waitForBrowser(context.Background(), browsers,
+				&v1.Browser{ObjectMeta: metav1.ObjectMeta{Name: "browser"}}, timeout)
+			if tt.wantErr == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
+
+func Test_requestedTimeouts(t *testing.T) {
+	timeouts, err := requestedTimeouts(&session.BrowserKubeOpts{})
+	require.NoError(t, err)
+	require.Nil(t, timeouts)
+
+	timeouts, err = requestedTimeouts(&session.BrowserKubeOpts{IdleTimeout: "2m", SessionTimeout: "1h30m"})
+	require.NoError(t, err)
+	require.Equal(t, &v1.BrowserTimeouts{
+		Idle:    &metav1.Duration{Duration: 2 * time.Minute},
+		Session: &metav1.Duration{Duration: 90 * time.Minute},
+	}, timeouts)
+
+	_, err = requestedTimeouts(&session.BrowserKubeOpts{StartupTimeout: "soon"})
+	require.ErrorContains(t, err, "incorrect startupTimeout")
+}
+
+func Test_requestedNetwork(t *testing.T) {
+	require.Nil(t, requestedNetwork(&session.BrowserKubeOpts{}))
+	require.Equal(t, &v1.BrowserNetwork{
+		AllowedCIDRs:   []string{"203.0.113.0/24"},
+		AllowedDomains: []string{"example.com"},
+	}, requestedNetwork(&session.BrowserKubeOpts{
+		AllowedCIDRs:   []string{"203.0.113.0/24"},
+		AllowedDomains: []string{"example.com"},
+	}))
+}
+
+func Test_requestedRecording(t *testing.T) {
+	require.Nil(t, requestedRecording(&session.BrowserKubeOpts{}))
+	require.Equal(t, &v1.BrowserRecording{
+		VideoSize: "1280x720",
+		Format:    "webm",
+		CRF:       ptr.To[int32](30),
+	}, requestedRecording(&session.BrowserKubeOpts{
+		VideoSize:   "1280x720",
+		VideoFormat: "webm",
+		VideoCRF:    ptr.To[int32](30),
+	}))
+}
+
+func Test_requestedProxy(t *testing.T) {
+	require.Nil(t, requestedProxy(&session.BrowserKubeOpts{NoProxy: []string{".corp"}}))
+	require.Equal(t, &v1.BrowserProxy{
+		URL:               "http://proxy.corp:3128",
+		NoProxy:           []string{".corp"},
+		CredentialsSecret: "proxy-auth",
+	}, requestedProxy(&session.BrowserKubeOpts{
+		Proxy:                  "http://proxy.corp:3128",
+		NoProxy:                []string{".corp"},
+		ProxyCredentialsSecret: "proxy-auth",
+	}))
+}
+
+func Test_k8sWebDriverProvisioner_VNCPassword(t *testing.T) {
+	secret := &apiv1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "browser-session-vnc", Namespace: "browsers"},
+		Data:       map[string][]byte{v1.VNCSecretKey: []byte("s3cr3tpw")},
+	}
+	kp := &k8sWebDriverProvisioner{
+		clientset: fake.NewSimpleClientset(secret),
+	}
+
+	password, err := kp.VNCPassword(context.Background(), &v1.Browser{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "browsers"},
+		Status:     v1.BrowserStatus{VNCSecret: "browser-session-vnc"},
+	})
+	require.NoError(t, err)
+	require.Equal(t, "s3cr3tpw", password)
+
+	password, err = kp.VNCPassword(context.Background(), &v1.Browser{})
+	require.NoError(t, err)
+	require.Empty(t, password)
+
+	_, err = kp.VNCPassword(context.Background(), &v1.Browser{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "browsers"},
+		Status:     v1.BrowserStatus{VNCSecret: "browser-missing-vnc"},
+	})
+	require.Error(t, err)
+}
@@ -126,6 +126,36 @@ func (_m *BrowsersInterface) List(ctx context.Context, opts metav1.ListOptions)
 	return r0, r1
 }
 
+// Update provides a mock function with given fields: _a0, _a1
+func (_m *BrowsersInterface) Update(_a0 context.Context, _a1 *v1.Browser) (*v1.Browser, error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Update")
+	}
+
+	var r0 *v1.Browser
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *v1.Browser) (*v1.Browser, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *v1.Browser) *v1.Browser); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*v1.Browser)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *v1.Browser) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Watch provides a mock function with given fields: ctx, pts
 func (_m *BrowsersInterface) Watch(ctx context.Context, pts metav1.ListOptions) (watch.Interface, error) {
 	ret := _m.Called(ctx, pts)
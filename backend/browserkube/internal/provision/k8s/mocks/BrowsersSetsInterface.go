@@ -97,6 +97,36 @@ func (_m *BrowsersSetsInterface) Patch(ctx context.Context, resourceName string,
 	return r0
 }
 
+// Update provides a mock function with given fields: ctx, bs
+func (_m *BrowsersSetsInterface) Update(ctx context.Context, bs *apiv1.BrowserSet) (*apiv1.BrowserSet, error) {
+	ret := _m.Called(ctx, bs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Update")
+	}
+
+	var r0 *apiv1.BrowserSet
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *apiv1.BrowserSet) (*apiv1.BrowserSet, error)); ok {
+		return rf(ctx, bs)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *apiv1.BrowserSet) *apiv1.BrowserSet); ok {
+		r0 = rf(ctx, bs)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*apiv1.BrowserSet)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *apiv1.BrowserSet) error); ok {
+		r1 = rf(ctx, bs)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Watch provides a mock function with given fields: ctx, pts
 func (_m *BrowsersSetsInterface) Watch(ctx context.Context, pts v1.ListOptions) (watch.Interface, error) {
 	ret := _m.Called(ctx, pts)
@@ -54,6 +54,26 @@ func (_m *Interface) Browsers(namespace string) v1.BrowsersInterface {
 	return r0
 }
 
+// ExtensionPolicies provides a mock function with given fields: namespace
+func (_m *Interface) ExtensionPolicies(namespace string) v1.ExtensionPoliciesInterface {
+	ret := _m.Called(namespace)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ExtensionPolicies")
+	}
+
+	var r0 v1.ExtensionPoliciesInterface
+	if rf, ok := ret.Get(0).(func(string) v1.ExtensionPoliciesInterface); ok {
+		r0 = rf(namespace)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(v1.ExtensionPoliciesInterface)
+		}
+	}
+
+	return r0
+}
+
 // RESTClient provides a mock function with given fields:
 func (_m *Interface) RESTClient() rest.Interface {
 	ret := _m.Called()
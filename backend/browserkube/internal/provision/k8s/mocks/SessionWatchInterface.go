@@ -45,9 +45,9 @@ func (_m *SessionWatchInterface) Exists(sessionID string) (bool, error) {
 	return r0, r1
 }
 
-// GetQuotas provides a mock function with given fields:
-func (_m *SessionWatchInterface) GetQuotas() (resource.Quantity, resource.Quantity) {
-	ret := _m.Called()
+// GetQuotas provides a mock function with given fields: namespace
+func (_m *SessionWatchInterface) GetQuotas(namespace string) (resource.Quantity, resource.Quantity) {
+	ret := _m.Called(namespace)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetQuotas")
@@ -55,17 +55,17 @@ func (_m *SessionWatchInterface) GetQuotas() (resource.Quantity, resource.Quanti
 
 	var r0 resource.Quantity
 	var r1 resource.Quantity
-	if rf, ok := ret.Get(0).(func() (resource.Quantity, resource.Quantity)); ok {
-		return rf()
+	if rf, ok := ret.Get(0).(func(string) (resource.Quantity, resource.Quantity)); ok {
+		return rf(namespace)
 	}
-	if rf, ok := ret.Get(0).(func() resource.Quantity); ok {
-		r0 = rf()
+	if rf, ok := ret.Get(0).(func(string) resource.Quantity); ok {
+		r0 = rf(namespace)
 	} else {
 		r0 = ret.Get(0).(resource.Quantity)
 	}
 
-	if rf, ok := ret.Get(1).(func() resource.Quantity); ok {
-		r1 = rf()
+	if rf, ok := ret.Get(1).(func(string) resource.Quantity); ok {
+		r1 = rf(namespace)
 	} else {
 		r1 = ret.Get(1).(resource.Quantity)
 	}
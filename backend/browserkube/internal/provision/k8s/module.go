@@ -6,12 +6,14 @@ import (
 
 	"github.com/pkg/errors"
 	"go.uber.org/fx"
+	"go.uber.org/zap"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/client-go/rest"
 
 	"github.com/browserkube/browserkube/browserkube/internal/provision"
 	browserkubeclientv1 "github.com/browserkube/browserkube/operator/pkg/client/v1"
+	browserkubehttp "github.com/browserkube/browserkube/pkg/http"
 	wdsession "github.com/browserkube/browserkube/pkg/session"
 	"github.com/browserkube/browserkube/pkg/sessionresult"
 )
@@ -31,17 +33,21 @@ var Module = fx.Options(
 	fx.Provide(
 		provideClientSet,
 		provideProvisioner,
+		provideSessionWatch,
 		provideSessionRepository,
+		provideSessionQueue,
 		provideResultsRepository,
+		provideAuthenticator,
+		provideRoleResolver,
 	),
 )
 
-func provideSessionRepository(lc fx.Lifecycle,
+func provideSessionWatch(lc fx.Lifecycle,
 	clientset *kubernetes.Clientset,
 	browserkubeClient browserkubeclientv1.Interface,
 	env *provision.Config,
-) (wdsession.Repository, error) {
-	sw, err := newSessionWatch(clientset, browserkubeClient, env.BrowserNS)
+) (*tenantWatch, error) {
+	sw, err := newTenantWatch(clientset, browserkubeClient, env.Namespaces())
 	if err != nil {
 		return nil, errors.WithStack(err)
 	}
@@ -57,20 +63,84 @@ func provideSessionRepository(lc fx.Lifecycle,
 			return nil
 		},
 	})
-	return newK8SessionRepository(sw), nil
+	return sw, nil
+}
+
+func provideSessionRepository(tw *tenantWatch) wdsession.Repository {
+	return newK8SessionRepository(tw)
+}
+
+func provideSessionQueue(tw *tenantWatch, env *provision.Config) (provision.TenantQueues, error) {
+	queues := provision.TenantQueues{}
+	for _, ns := range env.Namespaces() {
+		sw := tw.Namespace(ns)
+		queue := provision.NewSessionQueue(sw.IsNewSessionAllowed, env.QueueTimeout)
+		if err := sw.OnCapacityChange(queue.Notify); err != nil {
+			return nil, err
+		}
+		queues[ns] = queue
+	}
+	return queues, nil
 }
 
 func provideResultsRepository(browserkubeClient browserkubeclientv1.Interface, env *provision.Config,
 ) sessionresult.Repository {
-	return newK8sResultsRepository(browserkubeClient.SessionResults(env.BrowserNS))
+	return newK8sResultsRepository(browserkubeClient.SessionResults, env)
 }
 
 func provideProvisioner(
 	clientset *kubernetes.Clientset,
 	browserkubeClient browserkubeclientv1.Interface,
 	envConf *provision.Config,
+	queues provision.TenantQueues,
 ) provision.Provisioner {
-	return newK8sWebDriverProvisioner(clientset, browserkubeClient, envConf)
+	return newK8sWebDriverProvisioner(clientset, browserkubeClient, envConf, queues)
+}
+
+// provideAuthenticator returns nil if authentication is disabled
+func provideAuthenticator(lc fx.Lifecycle, clientset *kubernetes.Clientset, env *provision.Config,
+) browserkubehttp.Authenticator {
+	if !env.AuthEnabled {
+		return nil
+	}
+	auth := newSecretAuthenticator(clientset, env.BrowserNS)
+	watchCtx, cancelFunc := context.WithCancel(context.Background())
+	lc.Append(fx.Hook{
+		OnStart: func(ctx context.Context) error {
+			auth.Start(watchCtx)
+			return auth.WaitForSync(ctx)
+		},
+		OnStop: func(ctx context.Context) error {
+			cancelFunc()
+			return nil
+		},
+	})
+	return auth
+}
+
+// provideRoleResolver returns nil if role bindings aren't configured, users have the default role then
+func provideRoleResolver(lc fx.Lifecycle, clientset *kubernetes.Clientset, env *provision.Config,
+	logger *zap.SugaredLogger,
+) (provision.RoleResolver, error) {
+	if env.RolesConfigMap == "" {
+		return nil, nil
+	}
+	roles, err := newConfigMapRoles(clientset, env.BrowserNS, env.RolesConfigMap, logger)
+	if err != nil {
+		return nil, err
+	}
+	watchCtx, cancelFunc := context.WithCancel(context.Background())
+	lc.Append(fx.Hook{
+		OnStart: func(ctx context.Context) error {
+			roles.Start(watchCtx)
+			return roles.WaitForSync(ctx)
+		},
+		OnStop: func(ctx context.Context) error {
+			cancelFunc()
+			return nil
+		},
+	})
+	return roles, nil
 }
 
 func provideClientSet() (*kubernetes.Clientset, browserkubeclientv1.Interface, error) {
@@ -35,8 +35,8 @@ func (pps *k8sSessionRepository) FindAll() ([]*session.Session, error) {
 	return sessions, nil
 }
 
-func (pps *k8sSessionRepository) Quota() (int, int, error) {
-	currentQ, maxQ := pps.sessionWatch.GetQuotas()
+func (pps *k8sSessionRepository) Quota(namespace string) (int, int, error) {
+	currentQ, maxQ := pps.sessionWatch.GetQuotas(namespace)
 	current, _ := currentQ.AsInt64()
 	maxQuotaInt, _ := maxQ.AsInt64()
 	return int(current), int(maxQuotaInt), nil
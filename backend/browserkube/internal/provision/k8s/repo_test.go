@@ -120,7 +120,7 @@ func Test_k8sSessionRepository_Quota(t *testing.T) {
 			name:    "Quota: success",
 			wantErr: false,
 			prepareFunc: func(mockSessionWatch *mocks.SessionWatchInterface) {
-				mockSessionWatch.On("GetQuotas").Return(resource.Quantity{}, resource.Quantity{}).Maybe()
+				mockSessionWatch.On("GetQuotas", "browserkube").Return(resource.Quantity{}, resource.Quantity{}).Maybe()
 			},
 		},
 	}
@@ -131,7 +131,7 @@ func Test_k8sSessionRepository_Quota(t *testing.T) {
 
 			repo := newK8SessionRepository(mockSessionWatch)
 
-			currentQ, maxQ, err := repo.Quota()
+			currentQ, maxQ, err := repo.Quota("browserkube")
 			if !tt.wantErr {
 				require.NoError(t, err)
 				require.NotNil(t, currentQ)
@@ -4,24 +4,37 @@ import (
 	"context"
 
 	"github.com/pkg/errors"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/client-go/util/retry"
 
+	"github.com/browserkube/browserkube/browserkube/internal/provision"
+	browserkubev1 "github.com/browserkube/browserkube/operator/api/v1"
 	browserkubeclientv1 "github.com/browserkube/browserkube/operator/pkg/client/v1"
 	"github.com/browserkube/browserkube/pkg/sessionresult"
 	browserkubeutil "github.com/browserkube/browserkube/pkg/util"
 )
 
+// k8sResultsRepository keeps session results in the namespace of the caller's tenant
 type k8sResultsRepository struct {
-	resultsClient browserkubeclientv1.SessionResultsInterface
+	resultsClient func(namespace string) browserkubeclientv1.SessionResultsInterface
+	envConfig     *provision.Config
 }
 
-func newK8sResultsRepository(resultsClient browserkubeclientv1.SessionResultsInterface) sessionresult.Repository {
-	return &k8sResultsRepository{resultsClient: resultsClient}
+func newK8sResultsRepository(
+	resultsClient func(namespace string) browserkubeclientv1.SessionResultsInterface,
+	envConfig *provision.Config,
+) sessionresult.Repository {
+	return &k8sResultsRepository{resultsClient: resultsClient, envConfig: envConfig}
+}
+
+func (pps *k8sResultsRepository) client(ctx context.Context) browserkubeclientv1.SessionResultsInterface {
+	return pps.resultsClient(pps.envConfig.Namespace(ctx))
 }
 
 func (pps *k8sResultsRepository) FindByID(ctx context.Context, id string) (*sessionresult.Result, error) {
-	res, err := pps.resultsClient.List(ctx, metav1.ListOptions{
+	res, err := pps.client(ctx).List(ctx, metav1.ListOptions{
 		FieldSelector: fields.OneTermEqualSelector("metadata.name", id).String(),
 	})
 	if err != nil {
@@ -38,7 +51,7 @@ func (pps *k8sResultsRepository) FindByID(ctx context.Context, id string) (*sess
 }
 
 func (pps *k8sResultsRepository) FindAll(ctx context.Context, limit int, continueToken string) (*browserkubeutil.Page[*sessionresult.Result], error) {
-	res, err := pps.resultsClient.List(ctx, metav1.ListOptions{
+	res, err := pps.client(ctx).List(ctx, metav1.ListOptions{
 		Limit:                int64(limit),
 		Continue:             continueToken,
 		ResourceVersion:      "",
@@ -60,7 +73,16 @@ func (pps *k8sResultsRepository) FindAll(ctx context.Context, limit int, continu
 }
 
 func (pps *k8sResultsRepository) Create(ctx context.Context, req *sessionresult.Result) (*sessionresult.Result, error) {
-	res, err := pps.resultsClient.Create(ctx, &req.SessionResult)
+	// results are kept next to the browser they've been created for
+	namespace := req.Namespace
+	if namespace == "" {
+		namespace = pps.envConfig.Namespace(ctx)
+	}
+	client := pps.resultsClient(namespace)
+	res, err := client.Create(ctx, &req.SessionResult)
+	if apierrors.IsAlreadyExists(err) {
+		res, err = updateResult(ctx, client, req)
+	}
 	if err != nil {
 		return nil, errors.WithStack(err)
 	}
@@ -68,8 +90,32 @@ func (pps *k8sResultsRepository) Create(ctx context.Context, req *sessionresult.
 	return &sessionresult.Result{SessionResult: *res}, nil
 }
 
+// updateResult replaces the result the operator has created for the session it has terminated
+// with the one of the backend, so that collected files aren't lost. The termination reason of the operator is kept
+func updateResult(
+	ctx context.Context,
+	client browserkubeclientv1.SessionResultsInterface,
+	req *sessionresult.Result,
+) (*browserkubev1.SessionResult, error) {
+	var res *browserkubev1.SessionResult
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		existing, err := client.Get(ctx, req.Name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		updated := req.SessionResult.DeepCopy()
+		updated.ResourceVersion = existing.ResourceVersion
+		if existing.Spec.TerminationReason != "" {
+			updated.Spec.TerminationReason = existing.Spec.TerminationReason
+		}
+		res, err = client.Update(ctx, updated)
+		return err
+	})
+	return res, err
+}
+
 func (pps *k8sResultsRepository) Delete(ctx context.Context, name string, opts metav1.DeleteOptions) error {
-	err := pps.resultsClient.Delete(ctx, name, opts)
+	err := pps.client(ctx).Delete(ctx, name, opts)
 	if err != nil {
 		return errors.WithStack(err)
 	}
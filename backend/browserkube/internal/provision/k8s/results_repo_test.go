@@ -7,10 +7,14 @@ import (
 
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
+	"github.com/browserkube/browserkube/browserkube/internal/provision"
 	"github.com/browserkube/browserkube/browserkube/internal/provision/k8s/mocks"
 	v1 "github.com/browserkube/browserkube/operator/api/v1"
+	browserkubeclientv1 "github.com/browserkube/browserkube/operator/pkg/client/v1"
+	"github.com/browserkube/browserkube/pkg/sessionresult"
 )
 
 func Test_k8sResultsRepository_FindByID(t *testing.T) {
@@ -74,7 +78,8 @@ func Test_k8sResultsRepository_FindByID(t *testing.T) {
 			mockSessionResults := mocks.NewSessionResultsInterface(t)
 			tt.prepareFunc(mockSessionResults)
 
-			repo := newK8sResultsRepository(mockSessionResults)
+			repo := newK8sResultsRepository(func(string) browserkubeclientv1.SessionResultsInterface { return mockSessionResults },
+				&provision.Config{BrowserNS: "browserkube"})
 
 			result, err := repo.FindByID(tt.args.ctx, tt.args.id)
 			if !tt.wantErr {
@@ -175,7 +180,8 @@ func Test_k8sResultsRepository_FindAll(t *testing.T) {
 			mockSessionResults := mocks.NewSessionResultsInterface(t)
 			tt.prepareFunc(mockSessionResults)
 
-			repo := newK8sResultsRepository(mockSessionResults)
+			repo := newK8sResultsRepository(func(string) browserkubeclientv1.SessionResultsInterface { return mockSessionResults },
+				&provision.Config{BrowserNS: "browserkube"})
 
 			result, err := repo.FindAll(tt.args.ctx, tt.args.limit, tt.args.continueToken)
 			if !tt.wantErr && len(result.Items) > 0 {
@@ -190,3 +196,33 @@ func Test_k8sResultsRepository_FindAll(t *testing.T) {
 		})
 	}
 }
+
+func Test_k8sResultsRepository_Create(t *testing.T) {
+	req := &sessionresult.Result{SessionResult: v1.SessionResult{
+		ObjectMeta: metav1.ObjectMeta{Name: "1", Namespace: "browserkube"},
+		Spec: v1.SessionResultSpec{
+			TerminationReason: v1.TerminationReasonClientQuit,
+			Files:             v1.SessionResultFiles{BrowserLog: "browser.log", Video: "video.mp4"},
+		},
+	}}
+	mockSessionResults := mocks.NewSessionResultsInterface(t)
+	alreadyExists := apierrors.NewAlreadyExists(v1.GroupVersion.WithResource("sessionresults").GroupResource(), "1")
+	mockSessionResults.On("Create", mock.Anything, &req.SessionResult).Return(nil, alreadyExists)
+	// the result created by the operator gets the files and keeps the reason
+	mockSessionResults.On("Get", mock.Anything, "1", metav1.GetOptions{}).Return(&v1.SessionResult{
+		ObjectMeta: metav1.ObjectMeta{Name: "1", Namespace: "browserkube", ResourceVersion: "7"},
+		Spec:       v1.SessionResultSpec{TerminationReason: v1.TerminationReasonIdleTimeout},
+	}, nil)
+	mockSessionResults.On("Update", mock.Anything, mock.Anything).Return(
+		func(_ context.Context, res *v1.SessionResult) (*v1.SessionResult, error) {
+			return res, nil
+		})
+
+	repo := newK8sResultsRepository(func(string) browserkubeclientv1.SessionResultsInterface { return mockSessionResults },
+		&provision.Config{BrowserNS: "browserkube"})
+	result, err := repo.Create(context.Background(), req)
+	require.NoError(t, err)
+	require.Equal(t, "7", result.ResourceVersion)
+	require.Equal(t, v1.TerminationReasonIdleTimeout, result.Spec.TerminationReason)
+	require.Equal(t, req.Spec.Files, result.Spec.Files)
+}
@@ -0,0 +1,95 @@
+package provisionk8s
+
+import (
+	"context"
+	"sync/atomic"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+	"sigs.k8s.io/yaml"
+
+	"github.com/browserkube/browserkube/browserkube/internal/provision"
+	browserkubehttp "github.com/browserkube/browserkube/pkg/http"
+)
+
+// rolesKey is the key of the roles ConfigMap holding the role bindings
+const rolesKey = "roles.yaml"
+
+// configMapRoles resolves roles with bindings of the ConfigMap kept in the informer cache.
+// Users have the default role while the ConfigMap is missing or invalid
+type configMapRoles struct {
+	informer cache.SharedIndexInformer
+	bindings atomic.Pointer[provision.RoleBindings]
+	logger   *zap.SugaredLogger
+}
+
+func newConfigMapRoles(clientset kubernetes.Interface, ns, name string, logger *zap.SugaredLogger) (*configMapRoles, error) {
+	rolesWatch := cache.NewFilteredListWatchFromClient(clientset.CoreV1().RESTClient(), "configmaps", ns,
+		func(options *metav1.ListOptions) {
+			options.FieldSelector = fields.OneTermEqualSelector("metadata.name", name).String()
+		})
+	r := &configMapRoles{
+		informer: cache.NewSharedIndexInformer(rolesWatch, &apiv1.ConfigMap{}, resyncPeriod, cache.Indexers{}),
+		logger:   logger,
+	}
+	r.bindings.Store(&provision.RoleBindings{})
+	_, err := r.informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: r.update,
+		UpdateFunc: func(_, obj interface{}) {
+			r.update(obj)
+		},
+		DeleteFunc: func(interface{}) {
+			r.bindings.Store(&provision.RoleBindings{})
+		},
+	})
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return r, nil
+}
+
+func (r *configMapRoles) Start(ctx context.Context) {
+	go r.informer.Run(ctx.Done())
+}
+
+// WaitForSync waits until the role bindings are loaded
+func (r *configMapRoles) WaitForSync(ctx context.Context) error {
+	if !cache.WaitForCacheSync(ctx.Done(), r.informer.HasSynced) {
+		return errors.New("unable to load role bindings")
+	}
+	return nil
+}
+
+func (r *configMapRoles) RoleOf(identity *browserkubehttp.Identity) provision.Role {
+	return r.bindings.Load().RoleOf(identity)
+}
+
+func (r *configMapRoles) update(obj interface{}) {
+	cm, ok := obj.(*apiv1.ConfigMap)
+	if !ok {
+		return
+	}
+	bindings, err := roleBindingsOf(cm)
+	if err != nil {
+		// previous bindings are kept
+		r.logger.Errorf("Invalid role bindings of ConfigMap [%s/%s]: %v", cm.Namespace, cm.Name, err)
+		return
+	}
+	r.bindings.Store(bindings)
+}
+
+func roleBindingsOf(cm *apiv1.ConfigMap) (*provision.RoleBindings, error) {
+	bindings := &provision.RoleBindings{}
+	if err := yaml.UnmarshalStrict([]byte(cm.Data[rolesKey]), bindings); err != nil {
+		return nil, errors.WithStack(err)
+	}
+	if err := bindings.Validate(); err != nil {
+		return nil, err
+	}
+	return bindings, nil
+}
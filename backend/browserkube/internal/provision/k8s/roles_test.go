@@ -0,0 +1,31 @@
+package provisionk8s
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	apiv1 "k8s.io/api/core/v1"
+
+	"github.com/browserkube/browserkube/browserkube/internal/provision"
+	browserkubehttp "github.com/browserkube/browserkube/pkg/http"
+)
+
+func Test_roleBindingsOf(t *testing.T) {
+	bindings, err := roleBindingsOf(&apiv1.ConfigMap{Data: map[string]string{rolesKey: `
+defaultRole: viewer
+bindings:
+  - role: admin
+    groups: [browserkube-admins]
+  - role: user
+    users: [alice, bob]
+`}})
+	require.NoError(t, err)
+	require.Equal(t, provision.RoleViewer, bindings.RoleOf(&browserkubehttp.Identity{User: "carol"}))
+	require.Equal(t, provision.RoleUser, bindings.RoleOf(&browserkubehttp.Identity{User: "bob"}))
+	require.Equal(t, provision.RoleAdmin, bindings.RoleOf(&browserkubehttp.Identity{User: "dan", Groups: []string{"browserkube-admins"}}))
+
+	_, err = roleBindingsOf(&apiv1.ConfigMap{Data: map[string]string{rolesKey: "bindings: [{role: root, users: [bob]}]"}})
+	require.Error(t, err)
+	_, err = roleBindingsOf(&apiv1.ConfigMap{Data: map[string]string{rolesKey: "binding: []"}})
+	require.Error(t, err)
+}
@@ -0,0 +1,118 @@
+package provisionk8s
+
+import (
+	"context"
+	"sync"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/client-go/kubernetes"
+
+	browserkubeclientv1 "github.com/browserkube/browserkube/operator/pkg/client/v1"
+	"github.com/browserkube/browserkube/pkg/session"
+)
+
+// tenantWatch aggregates session watches of the browser namespace and tenant namespaces
+type tenantWatch struct {
+	namespaces []string
+	watches    map[string]*sessionWatch
+}
+
+func newTenantWatch(
+	clientset *kubernetes.Clientset,
+	browserkubeClient browserkubeclientv1.Interface,
+	namespaces []string,
+) (*tenantWatch, error) {
+	tw := &tenantWatch{
+		namespaces: namespaces,
+		watches:    make(map[string]*sessionWatch, len(namespaces)),
+	}
+	for _, ns := range namespaces {
+		sw, err := newSessionWatch(clientset, browserkubeClient, ns)
+		if err != nil {
+			return nil, errors.Wrapf(err, "unable to watch namespace [%s]", ns)
+		}
+		tw.watches[ns] = sw
+	}
+	return tw, nil
+}
+
+func (tw *tenantWatch) Start(ctx context.Context) {
+	for _, sw := range tw.watches {
+		sw.Start(ctx)
+	}
+}
+
+// Namespace returns session watch of the namespace
+func (tw *tenantWatch) Namespace(ns string) *sessionWatch {
+	return tw.watches[ns]
+}
+
+// Watch merges session changes of all namespaces
+func (tw *tenantWatch) Watch(ctx context.Context) <-chan *session.Session {
+	sCh := make(chan *session.Session)
+	var wg sync.WaitGroup
+	for _, sw := range tw.watches {
+		wg.Add(1)
+		go func(in <-chan *session.Session) {
+			defer wg.Done()
+			// drain until the namespace watch is closed, so the broadcaster is never blocked
+			for s := range in {
+				select {
+				case sCh <- s:
+				case <-ctx.Done():
+				}
+			}
+		}(sw.Watch(ctx))
+	}
+	go func() {
+		wg.Wait()
+		close(sCh)
+	}()
+	return sCh
+}
+
+// GetSessions loads sessions of all namespaces
+func (tw *tenantWatch) GetSessions() []string {
+	var sessions []string
+	for _, ns := range tw.namespaces {
+		sessions = append(sessions, tw.watches[ns].GetSessions()...)
+	}
+	return sessions
+}
+
+// LoadByID loads session by ID from whichever namespace it runs in.
+// Requests are authorized with provision.Authorize, which hides sessions of other tenants from the caller
+func (tw *tenantWatch) LoadByID(sessionID string) (*session.Session, error) {
+	for _, ns := range tw.namespaces {
+		exists, err := tw.watches[ns].Exists(sessionID)
+		if err != nil {
+			return nil, err
+		}
+		if exists {
+			return tw.watches[ns].LoadByID(sessionID)
+		}
+	}
+	return nil, errors.Errorf("Browser [%s] is not accessible", sessionID)
+}
+
+// Exists checks whether browser for the given session ID exists in any namespace.
+// It doesn't check the caller's tenant, see LoadByID
+func (tw *tenantWatch) Exists(sessionID string) (bool, error) {
+	for _, ns := range tw.namespaces {
+		exists, err := tw.watches[ns].Exists(sessionID)
+		if err != nil || exists {
+			return exists, err
+		}
+	}
+	return false, nil
+}
+
+// GetQuotas returns sessions quota of the namespace
+func (tw *tenantWatch) GetQuotas(namespace string) (resource.Quantity, resource.Quantity) {
+	sw, ok := tw.watches[namespace]
+	if !ok {
+		return resource.Quantity{}, resource.Quantity{}
+	}
+	return sw.GetQuotas()
+}
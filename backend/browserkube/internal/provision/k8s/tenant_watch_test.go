@@ -0,0 +1,62 @@
+package provisionk8s
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+
+	v1 "github.com/browserkube/browserkube/operator/api/v1"
+)
+
+func newTestSessionWatch(t *testing.T, browsers ...*v1.Browser) *sessionWatch {
+	t.Helper()
+	informer := cache.NewSharedIndexInformer(&cache.ListWatch{}, &v1.Browser{}, 0,
+		cache.Indexers{sessionsIndex: indexSessions})
+	for _, b := range browsers {
+		require.NoError(t, informer.GetIndexer().Add(b))
+	}
+	return &sessionWatch{browsersInformer: informer}
+}
+
+func testBrowser(namespace, id string) *v1.Browser {
+	return &v1.Browser{ObjectMeta: metav1.ObjectMeta{
+		Name:      id,
+		Namespace: namespace,
+		Labels: map[string]string{
+			v1.LabelBrowserVisibility: "true",
+			v1.LabelSessionID:         id,
+		},
+	}}
+}
+
+func Test_tenantWatch(t *testing.T) {
+	tw := &tenantWatch{
+		namespaces: []string{"browserkube", "team-a"},
+		watches: map[string]*sessionWatch{
+			"browserkube": newTestSessionWatch(t, testBrowser("browserkube", "s1")),
+			"team-a":      newTestSessionWatch(t, testBrowser("team-a", "s2"), testBrowser("team-a", "s3")),
+		},
+	}
+
+	require.ElementsMatch(t, []string{"s1", "s2", "s3"}, tw.GetSessions())
+
+	sess, err := tw.LoadByID("s2")
+	require.NoError(t, err)
+	require.Equal(t, "team-a", sess.Browser.Namespace)
+
+	exists, err := tw.Exists("s1")
+	require.NoError(t, err)
+	require.True(t, exists)
+
+	exists, err = tw.Exists("s4")
+	require.NoError(t, err)
+	require.False(t, exists)
+	_, err = tw.LoadByID("s4")
+	require.Error(t, err)
+
+	used, hard := tw.GetQuotas("team-b")
+	require.True(t, used.IsZero())
+	require.True(t, hard.IsZero())
+}
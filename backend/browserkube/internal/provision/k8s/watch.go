@@ -54,20 +54,7 @@ func newSessionWatch(
 	// Note that when we finally process the item from the workqueue, we might see a newer version
 	// of the Browser than the version which was responsible for triggering the update.
 	browsersInformer := cache.NewSharedIndexInformer(browsersWatch, &browserkubev1.Browser{}, resyncPeriod,
-		cache.Indexers{
-			sessionsIndex: func(obj interface{}) ([]string, error) {
-				browser, ok := obj.(*browserkubev1.Browser)
-				if ok {
-					if lv := browser.Labels[browserkubev1.LabelBrowserVisibility]; lv != "true" {
-						return []string{}, nil
-					}
-					if sess := browser.Name; sess != "" {
-						return []string{sess}, nil
-					}
-				}
-				return []string{}, nil
-			},
-		})
+		cache.Indexers{sessionsIndex: indexSessions})
 
 	broadcaster := broadcast.NewBroadcaster[*session.Session](broadcastBuflen)
 	broadcastF := func(obj interface{}) {
@@ -117,6 +104,20 @@ func newSessionWatch(
 	}, nil
 }
 
+// indexSessions indexes visible browsers by session ID
+func indexSessions(obj interface{}) ([]string, error) {
+	browser, ok := obj.(*browserkubev1.Browser)
+	if ok {
+		if lv := browser.Labels[browserkubev1.LabelBrowserVisibility]; lv != "true" {
+			return []string{}, nil
+		}
+		if sess := browser.SessionID(); sess != "" {
+			return []string{sess}, nil
+		}
+	}
+	return []string{}, nil
+}
+
 func (ac *sessionWatch) Start(ctx context.Context) {
 	go ac.quotaInformer.Run(ctx.Done())
 	go ac.browsersInformer.Run(ctx.Done())
@@ -133,6 +134,23 @@ func (ac *sessionWatch) Start(ctx context.Context) {
 	}
 }
 
+// OnCapacityChange registers a callback invoked when sessions quota or number of browsers changes
+func (ac *sessionWatch) OnCapacityChange(f func()) error {
+	if _, err := ac.quotaInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    func(interface{}) { f() },
+		UpdateFunc: func(interface{}, interface{}) { f() },
+		DeleteFunc: func(interface{}) { f() },
+	}); err != nil {
+		return errors.WithStack(err)
+	}
+	if _, err := ac.browsersInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		DeleteFunc: func(interface{}) { f() },
+	}); err != nil {
+		return errors.WithStack(err)
+	}
+	return nil
+}
+
 func (ac *sessionWatch) Watch(ctx context.Context) <-chan *session.Session {
 	sCh := make(chan *session.Session)
 	ac.broadcast.Register(sCh)
@@ -220,7 +238,7 @@ func asSession(browser *browserkubev1.Browser) (*session.Session, error) {
 		}
 	}
 	return &session.Session{
-		ID:      browser.Name,
+		ID:      browser.SessionID(),
 		State:   asStatus(browser),
 		Browser: browser,
 		Caps:    &caps,
@@ -249,5 +267,5 @@ type SessionWatchInterface interface {
 	GetSessions() []string
 	LoadByID(sessionID string) (*session.Session, error)
 	Exists(sessionID string) (bool, error)
-	GetQuotas() (resource.Quantity, resource.Quantity)
+	GetQuotas(namespace string) (resource.Quantity, resource.Quantity)
 }
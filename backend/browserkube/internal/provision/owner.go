@@ -0,0 +1,21 @@
+package provision
+
+import (
+	"context"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	browserkubev1 "github.com/browserkube/browserkube/operator/api/v1"
+)
+
+// Owner returns the user the browser or the result of the session has been created by
+func Owner(obj metav1.Object) string {
+	return obj.GetAnnotations()[browserkubev1.AnnotationOwner]
+}
+
+// isOwner checks whether the caller owns the session.
+// Sessions without an owner, e.g. created while authentication has been disabled, are shared by the tenant
+func isOwner(ctx context.Context, obj metav1.Object) bool {
+	owner := Owner(obj)
+	return owner == "" || owner == UserFrom(ctx)
+}
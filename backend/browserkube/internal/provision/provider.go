@@ -4,17 +4,23 @@ import (
 	"context"
 	"io"
 
+	"github.com/pkg/errors"
+
 	browserkubev1 "github.com/browserkube/browserkube/operator/api/v1"
 	"github.com/browserkube/browserkube/pkg/session"
 )
 
 const PlatformLinux = "linux"
 
+// ErrNotAllowed is returned when the session requests options which aren't allowed, e.g. extensions rejected by ExtensionPolicies
+var ErrNotAllowed = errors.New("session isn't allowed")
+
 //go:generate mockery --name Provisioner --filename ../../playwright/mocks/Provisioner.go
 type Provisioner interface {
 	Provision(ctx context.Context, name string, opts *session.Capabilities) (*browserkubev1.Browser, error)
-	Delete(ctx context.Context, id string) error
-	Logs(ctx context.Context, id string, follow bool) (io.ReadCloser, error)
+	Delete(ctx context.Context, browser *browserkubev1.Browser) error
+	Logs(ctx context.Context, browser *browserkubev1.Browser, follow bool) (io.ReadCloser, error)
 	Available(ctx context.Context) (*browserkubev1.BrowserSetList, error)
 	Update(ctx context.Context, bs *browserkubev1.BrowserSet) error
+	VNCPassword(ctx context.Context, browser *browserkubev1.Browser) (string, error)
 }
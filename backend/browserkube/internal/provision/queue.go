@@ -0,0 +1,171 @@
+package provision
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/browserkube/browserkube/pkg/util/broadcast"
+)
+
+const (
+	// queuePollInterval is a safety net in case capacity change notification has been missed
+	queuePollInterval = 5 * time.Second
+	queueBroadcastLen = 10
+)
+
+var (
+	// ErrQueueTimeout is returned when session request waits for a free capacity longer than allowed
+	ErrQueueTimeout = errors.New("timeout while waiting for a free browser slot")
+	// ErrNoCapacity should be returned by the creation func when browser can't be created because of quota
+	ErrNoCapacity = errors.New("no free capacity for a new browser")
+)
+
+// QueuedSession is a session request waiting for a free capacity
+type QueuedSession struct {
+	ID       string    `json:"id"`
+	Position int       `json:"position"`
+	QueuedAt time.Time `json:"queuedAt"`
+}
+
+// SessionQueue serves session requests in FIFO order once there is a free capacity
+type SessionQueue interface {
+	// Do waits for the session turn and free capacity and runs the creation func.
+	// Creation is retried with the next capacity change if it returns ErrNoCapacity
+	Do(ctx context.Context, id string, create func() error) error
+	// Queued returns session requests waiting in the queue. The first one goes first
+	Queued() []QueuedSession
+	// Watch notifies about queue changes until the context is done
+	Watch(ctx context.Context) <-chan []QueuedSession
+	// Notify signals that capacity might have changed
+	Notify()
+}
+
+type queuedRQ struct {
+	id       string
+	queuedAt time.Time
+}
+
+type sessionQueue struct {
+	mu       sync.Mutex
+	waiting  []*queuedRQ
+	changed  chan struct{}
+	capacity func() bool
+	maxWait  time.Duration
+
+	broadcast broadcast.Broadcaster[[]QueuedSession]
+}
+
+// NewSessionQueue creates new queue. Capacity func reports whether a new browser can be created
+func NewSessionQueue(capacity func() bool, maxWait time.Duration) SessionQueue {
+	return &sessionQueue{
+		changed:   make(chan struct{}),
+		capacity:  capacity,
+		maxWait:   maxWait,
+		broadcast: broadcast.NewBroadcaster[[]QueuedSession](queueBroadcastLen),
+	}
+}
+
+func (q *sessionQueue) Do(ctx context.Context, id string, create func() error) error {
+	rq := q.enqueue(id)
+	defer q.dequeue(rq)
+
+	timeout := time.NewTimer(q.maxWait)
+	defer timeout.Stop()
+	poll := time.NewTicker(queuePollInterval)
+	defer poll.Stop()
+
+	for {
+		q.mu.Lock()
+		first := q.waiting[0] == rq
+		changed := q.changed
+		q.mu.Unlock()
+
+		if first && q.capacity() {
+			if err := create(); !errors.Is(err, ErrNoCapacity) {
+				return err
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return errors.WithStack(ctx.Err())
+		case <-timeout.C:
+			return errors.Wrapf(ErrQueueTimeout, "session [%s] waited for %s", id, q.maxWait)
+		case <-changed:
+		case <-poll.C:
+		}
+	}
+}
+
+func (q *sessionQueue) Queued() []QueuedSession {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.snapshot()
+}
+
+func (q *sessionQueue) Watch(ctx context.Context) <-chan []QueuedSession {
+	qCh := make(chan []QueuedSession)
+	q.broadcast.Register(qCh)
+	go func() {
+		<-ctx.Done()
+		q.broadcast.Deregister(qCh)
+		close(qCh)
+	}()
+	return qCh
+}
+
+func (q *sessionQueue) Notify() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.notify()
+}
+
+func (q *sessionQueue) enqueue(id string) *queuedRQ {
+	rq := &queuedRQ{id: id, queuedAt: time.Now()}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.waiting = append(q.waiting, rq)
+	q.broadcast.TrySubmit(q.snapshot())
+	return rq
+}
+
+func (q *sessionQueue) dequeue(rq *queuedRQ) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	for i, w := range q.waiting {
+		if w == rq {
+			q.waiting = append(q.waiting[:i], q.waiting[i+1:]...)
+			break
+		}
+	}
+	// the next one in the queue might be able to proceed
+	q.notify()
+	q.broadcast.TrySubmit(q.snapshot())
+}
+
+// notify wakes up all the waiters. Must be called under lock
+func (q *sessionQueue) notify() {
+	close(q.changed)
+	q.changed = make(chan struct{})
+}
+
+// snapshot must be called under lock
+func (q *sessionQueue) snapshot() []QueuedSession {
+	queued := make([]QueuedSession, 0, len(q.waiting))
+	for i, w := range q.waiting {
+		queued = append(queued, QueuedSession{ID: w.id, Position: i + 1, QueuedAt: w.queuedAt})
+	}
+	return queued
+}
+
+// TenantQueues keeps a session queue per tenant namespace. Tenants don't wait for each other's capacity
+type TenantQueues map[string]SessionQueue
+
+// For returns the session queue of the namespace
+func (q TenantQueues) For(namespace string) SessionQueue {
+	return q[namespace]
+}
@@ -0,0 +1,113 @@
+package provision
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_sessionQueue_Do(t *testing.T) {
+	t.Run("creates immediately when capacity is available", func(t *testing.T) {
+		q := NewSessionQueue(func() bool { return true }, time.Second)
+		var created bool
+		err := q.Do(context.Background(), "s1", func() error {
+			created = true
+			return nil
+		})
+		require.NoError(t, err)
+		require.True(t, created)
+		require.Empty(t, q.Queued())
+	})
+
+	t.Run("returns creation error", func(t *testing.T) {
+		q := NewSessionQueue(func() bool { return true }, time.Second)
+		err := q.Do(context.Background(), "s1", func() error {
+			return errors.New("boom")
+		})
+		require.EqualError(t, err, "boom")
+	})
+
+	t.Run("times out when there is no capacity", func(t *testing.T) {
+		q := NewSessionQueue(func() bool { return false }, 50*time.Millisecond)
+		err := q.Do(context.Background(), "s1", func() error {
+			t.Fatal("must not be called")
+			return nil
+		})
+		require.ErrorIs(t, err, ErrQueueTimeout)
+		require.Empty(t, q.Queued())
+	})
+
+	t.Run("serves sessions in FIFO order", func(t *testing.T) {
+		var capacity atomic.Bool
+		q := NewSessionQueue(capacity.Load, 5*time.Second)
+
+		var (
+			mu    sync.Mutex
+			order []string
+			wg    sync.WaitGroup
+		)
+		for _, id := range []string{"s1", "s2", "s3"} {
+			wg.Add(1)
+			go func(id string) {
+				defer wg.Done()
+				assert.NoError(t, q.Do(context.Background(), id, func() error {
+					mu.Lock()
+					defer mu.Unlock()
+					order = append(order, id)
+					return nil
+				}))
+			}(id)
+			// make sure requests are queued in order
+			require.Eventually(t, func() bool {
+				queued := q.Queued()
+				return len(queued) > 0 && queued[len(queued)-1].ID == id
+			}, time.Second, time.Millisecond)
+		}
+		queued := q.Queued()
+		require.Len(t, queued, 3)
+		require.Equal(t, 1, queued[0].Position)
+		require.Equal(t, "s1", queued[0].ID)
+
+		capacity.Store(true)
+		q.Notify()
+		wg.Wait()
+		require.Equal(t, []string{"s1", "s2", "s3"}, order)
+	})
+
+	t.Run("retries creation rejected by quota", func(t *testing.T) {
+		q := NewSessionQueue(func() bool { return true }, 5*time.Second)
+		var attempts atomic.Int32
+		done := make(chan error)
+		go func() {
+			done <- q.Do(context.Background(), "s1", func() error {
+				if attempts.Add(1) == 1 {
+					return ErrNoCapacity
+				}
+				return nil
+			})
+		}()
+		require.Eventually(t, func() bool { return attempts.Load() == 1 }, time.Second, time.Millisecond)
+		q.Notify()
+		require.NoError(t, <-done)
+		require.Equal(t, int32(2), attempts.Load())
+	})
+
+	t.Run("leaves the queue on context cancel", func(t *testing.T) {
+		q := NewSessionQueue(func() bool { return false }, 5*time.Second)
+		ctx, cancel := context.WithCancel(context.Background())
+		done := make(chan error)
+		go func() {
+			done <- q.Do(ctx, "s1", func() error { return nil })
+		}()
+		require.Eventually(t, func() bool { return len(q.Queued()) == 1 }, time.Second, time.Millisecond)
+		cancel()
+		require.ErrorIs(t, <-done, context.Canceled)
+		require.Empty(t, q.Queued())
+	})
+}
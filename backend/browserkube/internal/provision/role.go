@@ -0,0 +1,231 @@
+package provision
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	browserkubehttp "github.com/browserkube/browserkube/pkg/http"
+)
+
+// ErrForbidden is returned when the role of the caller doesn't allow the action
+var ErrForbidden = errors.New("action isn't allowed")
+
+// Role grants actions to browserkube users. Each role includes actions of the previous ones
+type Role string
+
+const (
+	// RoleNone is the role of anonymous callers while authentication is enabled
+	RoleNone Role = ""
+	// RoleViewer lists sessions and results and watches sessions shared by the tenant
+	RoleViewer Role = "viewer"
+	// RoleUser creates sessions and watches, controls, terminates and deletes results of own sessions
+	RoleUser Role = "user"
+	// RoleOperator watches, controls, terminates and deletes results of sessions of other users
+	RoleOperator Role = "operator"
+	// RoleAdmin edits BrowserSets
+	RoleAdmin Role = "admin"
+)
+
+// DefaultRole is the role of users without role bindings
+const DefaultRole = RoleUser
+
+var roleRanks = map[Role]int{RoleViewer: 1, RoleUser: 2, RoleOperator: 3, RoleAdmin: 4}
+
+// Valid checks the role is known
+func (r Role) Valid() bool {
+	_, ok := roleRanks[r]
+	return ok
+}
+
+func (r Role) includes(other Role) bool {
+	return other != RoleNone && roleRanks[r] >= roleRanks[other]
+}
+
+func maxRole(a, b Role) Role {
+	if roleRanks[b] > roleRanks[a] {
+		return b
+	}
+	return a
+}
+
+// Action is an operation of browserkube users
+type Action string
+
+const (
+	// ActionView lists sessions and results and watches sessions: logs, screenshots, files and view-only VNC
+	ActionView Action = "view"
+	// ActionCreate creates sessions including manual sessions of the UI
+	ActionCreate Action = "create"
+	// ActionControl sends WebDriver commands, VNC input, devtools and clipboard requests to the session
+	ActionControl Action = "control"
+	// ActionTerminate terminates the session
+	ActionTerminate Action = "terminate"
+	// ActionDeleteResult deletes the session result and its artifacts
+	ActionDeleteResult Action = "deleteResult"
+	// ActionEditBrowserSets changes BrowserSets of the tenant
+	ActionEditBrowserSets Action = "editBrowserSets"
+)
+
+// Actions lists all actions
+var Actions = []Action{
+	ActionView, ActionCreate, ActionControl, ActionTerminate, ActionDeleteResult, ActionEditBrowserSets,
+}
+
+// grant is the least role allowed to perform the action on own sessions and on sessions of other users
+type grant struct {
+	own, others Role
+}
+
+var grants = map[Action]grant{
+	ActionView:            {own: RoleViewer, others: RoleOperator},
+	ActionCreate:          {own: RoleUser, others: RoleUser},
+	ActionControl:         {own: RoleUser, others: RoleOperator},
+	ActionTerminate:       {own: RoleUser, others: RoleOperator},
+	ActionDeleteResult:    {own: RoleUser, others: RoleOperator},
+	ActionEditBrowserSets: {own: RoleAdmin, others: RoleAdmin},
+}
+
+// Allows checks whether the role allows the action on own sessions or on sessions of other users
+func (r Role) Allows(action Action, own bool) bool {
+	g, ok := grants[action]
+	if !ok {
+		return false
+	}
+	if own {
+		return r.includes(g.own)
+	}
+	return r.includes(g.others)
+}
+
+// RoleBinding grants the role to the users and members of the groups
+type RoleBinding struct {
+	Role   Role     `json:"role"`
+	Users  []string `json:"users,omitempty"`
+	Groups []string `json:"groups,omitempty"`
+}
+
+// RoleBindings maps users to roles
+type RoleBindings struct {
+	// DefaultRole is the role of users without bindings, DefaultRole is used if empty
+	DefaultRole Role          `json:"defaultRole,omitempty"`
+	Bindings    []RoleBinding `json:"bindings,omitempty"`
+}
+
+// Validate checks the bindings refer to known roles
+func (rb *RoleBindings) Validate() error {
+	if rb.DefaultRole != RoleNone && !rb.DefaultRole.Valid() {
+		return errors.Errorf("unknown default role [%s]", rb.DefaultRole)
+	}
+	for _, b := range rb.Bindings {
+		if !b.Role.Valid() {
+			return errors.Errorf("unknown role [%s]", b.Role)
+		}
+	}
+	return nil
+}
+
+// RoleOf returns the highest of the default role, the roles granted by the identity provider and the bound roles
+func (rb *RoleBindings) RoleOf(identity *browserkubehttp.Identity) Role {
+	role := rb.DefaultRole
+	if role == RoleNone {
+		role = DefaultRole
+	}
+	for _, r := range identity.Roles {
+		role = maxRole(role, Role(r))
+	}
+	for _, b := range rb.Bindings {
+		if slices.Contains(b.Users, identity.User) || slices.ContainsFunc(b.Groups, func(g string) bool {
+			return slices.Contains(identity.Groups, g)
+		}) {
+			role = maxRole(role, b.Role)
+		}
+	}
+	return role
+}
+
+// RoleResolver resolves roles of the callers
+type RoleResolver interface {
+	RoleOf(identity *browserkubehttp.Identity) Role
+}
+
+type roleCtxKey struct{}
+
+// WithRole stores the caller's role in the context
+func WithRole(ctx context.Context, role Role) context.Context {
+	return context.WithValue(ctx, roleCtxKey{}, role)
+}
+
+// RoleFrom returns the caller's role stored in the context
+func RoleFrom(ctx context.Context) Role {
+	role, _ := ctx.Value(roleCtxKey{}).(Role)
+	return role
+}
+
+// ResolveRole stores the role of the caller in the context. The caller is the authenticated identity or the user
+// stored in the context if authentication is disabled. Users have the default role if roles is nil
+func (c *Config) ResolveRole(ctx context.Context, roles RoleResolver) context.Context {
+	if roles == nil {
+		roles = &RoleBindings{}
+	}
+	identity := browserkubehttp.IdentityFrom(ctx)
+	if identity == nil {
+		if c.AuthEnabled {
+			return WithRole(ctx, RoleNone)
+		}
+		identity = &browserkubehttp.Identity{User: UserFrom(ctx)}
+	}
+	return WithRole(ctx, roles.RoleOf(identity))
+}
+
+// RoleMiddleware resolves the role of the caller and stores it in the request context.
+// It goes after TenantMiddleware
+func RoleMiddleware(c *Config, roles RoleResolver) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, rq *http.Request) {
+			next.ServeHTTP(w, rq.WithContext(c.ResolveRole(rq.Context(), roles)))
+		})
+	}
+}
+
+// CheckAction returns ErrForbidden if the caller's role doesn't allow the action on the session.
+// obj is the browser or the result of the session, nil if the action doesn't refer to a session
+func CheckAction(ctx context.Context, action Action, obj metav1.Object) error {
+	role := RoleFrom(ctx)
+	if role.Allows(action, obj == nil || isOwner(ctx, obj)) {
+		return nil
+	}
+	if obj == nil {
+		return errors.Wrapf(ErrForbidden, "role [%s] can't %s", role, action)
+	}
+	return errors.Wrapf(ErrForbidden, "role [%s] can't %s session [%s] of another user", role, action, obj.GetName())
+}
+
+// Authorize rejects requests the caller's role doesn't allow. session loads the browser or the result
+// of the requested session, it's nil for actions not referring to a session. Requests to unknown sessions are
+// authorized as requests to own ones, the handler responds to them. Sessions of other tenants are not found
+func Authorize(action Action, session func(rq *http.Request) metav1.Object) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, rq *http.Request) {
+			var obj metav1.Object
+			if session != nil {
+				obj = session(rq)
+			}
+			if obj != nil && !InTenant(rq.Context(), obj) {
+				_ = browserkubehttp.WriteJSON(w, http.StatusNotFound, map[string]string{
+					"error": fmt.Sprintf("session [%s] isn't found", obj.GetName()),
+				})
+				return
+			}
+			if err := CheckAction(rq.Context(), action, obj); err != nil {
+				_ = browserkubehttp.WriteJSON(w, http.StatusForbidden, map[string]string{"error": err.Error()})
+				return
+			}
+			next.ServeHTTP(w, rq)
+		})
+	}
+}
@@ -0,0 +1,161 @@
+package provision
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	browserkubev1 "github.com/browserkube/browserkube/operator/api/v1"
+	browserkubehttp "github.com/browserkube/browserkube/pkg/http"
+)
+
+func testRoleBindings() *RoleBindings {
+	return &RoleBindings{Bindings: []RoleBinding{
+		{Role: RoleViewer, Users: []string{"vera"}},
+		{Role: RoleOperator, Users: []string{"carol"}},
+		{Role: RoleAdmin, Groups: []string{"browserkube-admins"}},
+	}}
+}
+
+func TestRoleBindings_RoleOf(t *testing.T) {
+	tests := []struct {
+		name     string
+		bindings *RoleBindings
+		identity browserkubehttp.Identity
+		want     Role
+	}{
+		{name: "default", bindings: testRoleBindings(), identity: browserkubehttp.Identity{User: "bob"}, want: RoleUser},
+		{name: "user binding", bindings: testRoleBindings(), identity: browserkubehttp.Identity{User: "carol"}, want: RoleOperator},
+		{
+			name:     "group binding",
+			bindings: testRoleBindings(),
+			identity: browserkubehttp.Identity{User: "dan", Groups: []string{"qa", "browserkube-admins"}},
+			want:     RoleAdmin,
+		},
+		{
+			name:     "role of identity provider",
+			bindings: testRoleBindings(),
+			identity: browserkubehttp.Identity{User: "bob", Roles: []string{"operator"}},
+			want:     RoleOperator,
+		},
+		// bindings grant roles, but don't take the default one away
+		{name: "lower than default", bindings: testRoleBindings(), identity: browserkubehttp.Identity{User: "vera"}, want: RoleUser},
+		{
+			name:     "viewers by default",
+			bindings: &RoleBindings{DefaultRole: RoleViewer, Bindings: testRoleBindings().Bindings},
+			identity: browserkubehttp.Identity{User: "vera"},
+			want:     RoleViewer,
+		},
+		{name: "unknown roles are ignored", bindings: &RoleBindings{}, identity: browserkubehttp.Identity{Roles: []string{"root"}}, want: RoleUser},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, tt.bindings.RoleOf(&tt.identity))
+		})
+	}
+}
+
+func TestRoleBindings_Validate(t *testing.T) {
+	require.NoError(t, testRoleBindings().Validate())
+	require.Error(t, (&RoleBindings{DefaultRole: "root"}).Validate())
+	require.Error(t, (&RoleBindings{Bindings: []RoleBinding{{Role: "root", Users: []string{"bob"}}}}).Validate())
+}
+
+func TestAuthorize(t *testing.T) {
+	browsers := map[string]*browserkubev1.Browser{
+		"owned": {ObjectMeta: metav1.ObjectMeta{
+			Name:        "owned",
+			Namespace:   "browserkube",
+			Annotations: map[string]string{browserkubev1.AnnotationOwner: "alice"},
+		}},
+		"shared": {ObjectMeta: metav1.ObjectMeta{Name: "shared", Namespace: "browserkube"}},
+		"team-b": {ObjectMeta: metav1.ObjectMeta{
+			Name:        "team-b",
+			Namespace:   "team-b",
+			Annotations: map[string]string{browserkubev1.AnnotationOwner: "bob"},
+		}},
+	}
+	cfg := testConfig()
+	cfg.AuthEnabled = true
+	session := func(rq *http.Request) metav1.Object {
+		if b, ok := browsers[rq.URL.Query().Get("session")]; ok {
+			return b
+		}
+		return nil
+	}
+	credentials := browserkubehttp.Credentials{
+		{Identity: browserkubehttp.Identity{User: "alice"}, Token: "alice-token"},
+		{Identity: browserkubehttp.Identity{User: "bob"}, Token: "bob-token"},
+		{Identity: browserkubehttp.Identity{User: "carol"}, Token: "carol-token"},
+		{Identity: browserkubehttp.Identity{User: "vera"}, Token: "vera-token"},
+		{Identity: browserkubehttp.Identity{User: "dan", Groups: []string{"browserkube-admins"}}, Token: "dan-token"},
+	}
+	bindings := testRoleBindings()
+	bindings.DefaultRole = RoleViewer
+	bindings.Bindings = append(bindings.Bindings, RoleBinding{Role: RoleUser, Users: []string{"alice", "bob"}})
+
+	tests := []struct {
+		action  Action
+		session string
+		token   string
+		want    int
+	}{
+		{action: ActionControl, session: "owned", token: "alice-token", want: http.StatusOK},
+		{action: ActionControl, session: "owned", token: "bob-token", want: http.StatusForbidden},
+		{action: ActionControl, session: "owned", want: http.StatusForbidden},
+		{action: ActionControl, session: "shared", token: "bob-token", want: http.StatusOK},
+		{action: ActionControl, session: "unknown", token: "bob-token", want: http.StatusOK},
+		{action: ActionView, session: "owned", token: "alice-token", want: http.StatusOK},
+		{action: ActionView, session: "owned", token: "bob-token", want: http.StatusForbidden},
+		{action: ActionView, session: "owned", want: http.StatusForbidden},
+		{action: ActionView, session: "owned", token: "vera-token", want: http.StatusForbidden},
+		{action: ActionView, session: "shared", token: "vera-token", want: http.StatusOK},
+		{action: ActionView, session: "owned", token: "carol-token", want: http.StatusOK},
+		{action: ActionControl, session: "shared", token: "vera-token", want: http.StatusForbidden},
+		{action: ActionCreate, token: "vera-token", want: http.StatusForbidden},
+		{action: ActionCreate, token: "bob-token", want: http.StatusOK},
+		{action: ActionTerminate, session: "owned", token: "carol-token", want: http.StatusOK},
+		{action: ActionDeleteResult, session: "owned", token: "bob-token", want: http.StatusForbidden},
+		{action: ActionDeleteResult, session: "owned", token: "carol-token", want: http.StatusOK},
+		{action: ActionEditBrowserSets, token: "carol-token", want: http.StatusForbidden},
+		// sessions of other tenants aren't found even by operators
+		{action: ActionControl, session: "team-b", token: "bob-token", want: http.StatusOK},
+		{action: ActionView, session: "team-b", token: "alice-token", want: http.StatusNotFound},
+		{action: ActionView, session: "team-b", token: "carol-token", want: http.StatusNotFound},
+		{action: ActionEditBrowserSets, token: "dan-token", want: http.StatusOK},
+	}
+	for _, tt := range tests {
+		sessionFunc := session
+		if tt.session == "" {
+			sessionFunc = nil
+		}
+		handler := browserkubehttp.Authenticate(credentials, true)(TenantMiddleware(cfg)(RoleMiddleware(cfg, bindings)(
+			Authorize(tt.action, sessionFunc)(http.HandlerFunc(func(w http.ResponseWriter, rq *http.Request) {})))))
+
+		rq := httptest.NewRequest(http.MethodGet, "/vnc?session="+tt.session, http.NoBody)
+		if tt.token != "" {
+			rq.Header.Set("Authorization", "Bearer "+tt.token)
+		}
+		// the user header isn't trusted while authentication is enabled
+		rq.Header.Set("X-Forwarded-User", "alice")
+		rs := httptest.NewRecorder()
+		handler.ServeHTTP(rs, rq)
+		require.Equal(t, tt.want, rs.Code, "action %s, session %s, token %s", tt.action, tt.session, tt.token)
+	}
+}
+
+func TestConfig_ResolveRole(t *testing.T) {
+	// the user header is trusted while authentication is disabled
+	cfg := testConfig()
+	ctx := cfg.ResolveRole(WithUser(context.Background(), "carol"), testRoleBindings())
+	require.Equal(t, RoleOperator, RoleFrom(ctx))
+
+	require.Equal(t, DefaultRole, RoleFrom(cfg.ResolveRole(context.Background(), nil)))
+
+	cfg.AuthEnabled = true
+	require.Equal(t, RoleNone, RoleFrom(cfg.ResolveRole(WithUser(context.Background(), "carol"), testRoleBindings())))
+}
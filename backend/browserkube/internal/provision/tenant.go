@@ -0,0 +1,171 @@
+package provision
+
+import (
+	"context"
+	"net/http"
+	"slices"
+
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	browserkubehttp "github.com/browserkube/browserkube/pkg/http"
+)
+
+// DefaultTenant is the name of the tenant owning the browser namespace
+const DefaultTenant = "default"
+
+var (
+	// ErrUnknownTenant is returned when the requested tenant isn't configured
+	ErrUnknownTenant = errors.New("unknown tenant")
+	// ErrTenantForbidden is returned when the user isn't a member of the requested tenant
+	ErrTenantForbidden = errors.New("tenant isn't allowed for the user")
+)
+
+// Tenant is a team running browsers in its own namespace with its own BrowserSets and quota
+type Tenant struct {
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+	// Users are members of the tenant. Tenant without users can be requested by anyone
+	Users []string `json:"users,omitempty"`
+}
+
+func (t *Tenant) allows(user string) bool {
+	return len(t.Users) == 0 || slices.Contains(t.Users, user)
+}
+
+// DefaultTenant returns the tenant owning the browser namespace
+func (c *Config) DefaultTenant() *Tenant {
+	return &Tenant{Name: DefaultTenant, Namespace: c.BrowserNS}
+}
+
+// Namespaces returns the browser namespace and namespaces of all tenants
+func (c *Config) Namespaces() []string {
+	namespaces := []string{c.BrowserNS}
+	for _, t := range c.Tenants {
+		if !slices.Contains(namespaces, t.Namespace) {
+			namespaces = append(namespaces, t.Namespace)
+		}
+	}
+	return namespaces
+}
+
+// Tenant resolves the tenant sessions of the user go to.
+// The requested tenant wins if the user is allowed to use it, otherwise the first tenant the user is a member of is used.
+// Users without a tenant get the default one
+func (c *Config) Tenant(user, requested string) (*Tenant, error) {
+	if requested != "" {
+		if requested == DefaultTenant {
+			return c.DefaultTenant(), nil
+		}
+		for i := range c.Tenants {
+			t := &c.Tenants[i]
+			if t.Name != requested {
+				continue
+			}
+			if !t.allows(user) {
+				return nil, errors.Wrapf(ErrTenantForbidden, "tenant [%s], user [%s]", requested, user)
+			}
+			return t, nil
+		}
+		return nil, errors.Wrapf(ErrUnknownTenant, "tenant [%s]", requested)
+	}
+	if user != "" {
+		for i := range c.Tenants {
+			if slices.Contains(c.Tenants[i].Users, user) {
+				return &c.Tenants[i], nil
+			}
+		}
+	}
+	return c.DefaultTenant(), nil
+}
+
+type userCtxKey struct{}
+
+type tenantCtxKey struct{}
+
+type namespacesCtxKey struct{}
+
+// WithUser stores the authenticated user in the context
+func WithUser(ctx context.Context, user string) context.Context {
+	return context.WithValue(ctx, userCtxKey{}, user)
+}
+
+// UserFrom returns the authenticated user stored in the context
+func UserFrom(ctx context.Context) string {
+	user, _ := ctx.Value(userCtxKey{}).(string)
+	return user
+}
+
+// WithTenant stores the caller's tenant in the context
+func WithTenant(ctx context.Context, tenant *Tenant) context.Context {
+	return context.WithValue(ctx, tenantCtxKey{}, tenant)
+}
+
+// TenantFrom returns the caller's tenant stored in the context or nil if there is none
+func TenantFrom(ctx context.Context) *Tenant {
+	tenant, _ := ctx.Value(tenantCtxKey{}).(*Tenant)
+	return tenant
+}
+
+// Namespace returns namespace of the caller's tenant, the browser namespace is used if the context has no tenant
+func (c *Config) Namespace(ctx context.Context) string {
+	if tenant := TenantFrom(ctx); tenant != nil {
+		return tenant.Namespace
+	}
+	return c.BrowserNS
+}
+
+// User returns the caller of the request: the authenticated identity or the user header if authentication is disabled
+// and the header is configured
+func (c *Config) User(rq *http.Request) string {
+	if identity := browserkubehttp.IdentityFrom(rq.Context()); identity != nil {
+		return identity.User
+	}
+	if c.AuthEnabled || c.UserHeader == "" {
+		return ""
+	}
+	return rq.Header.Get(c.UserHeader)
+}
+
+// UserNamespaces returns namespaces of the tenants the user may use: the default tenant, tenants without users and
+// tenants the user is a member of
+func (c *Config) UserNamespaces(user string) []string {
+	namespaces := []string{c.BrowserNS}
+	for _, t := range c.Tenants {
+		if t.allows(user) && !slices.Contains(namespaces, t.Namespace) {
+			namespaces = append(namespaces, t.Namespace)
+		}
+	}
+	return namespaces
+}
+
+// WithCaller stores the user, the user's default tenant and namespaces of the tenants the user may use in the context
+func (c *Config) WithCaller(ctx context.Context, user string) (context.Context, error) {
+	tenant, err := c.Tenant(user, "")
+	if err != nil {
+		return ctx, err
+	}
+	ctx = context.WithValue(ctx, namespacesCtxKey{}, c.UserNamespaces(user))
+	return WithTenant(WithUser(ctx, user), tenant), nil
+}
+
+// InTenant checks whether the browser or the result of the session belongs to a tenant the caller may use.
+// Sessions of other tenants are hidden from the caller
+func InTenant(ctx context.Context, obj metav1.Object) bool {
+	namespaces, _ := ctx.Value(namespacesCtxKey{}).([]string)
+	return slices.Contains(namespaces, obj.GetNamespace())
+}
+
+// TenantMiddleware resolves the caller's tenant and stores both in the request context
+func TenantMiddleware(c *Config) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, rq *http.Request) {
+			ctx, err := c.WithCaller(rq.Context(), c.User(rq))
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusForbidden)
+				return
+			}
+			next.ServeHTTP(w, rq.WithContext(ctx))
+		})
+	}
+}
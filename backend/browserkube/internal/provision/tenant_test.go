@@ -0,0 +1,87 @@
+package provision
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func testConfig() *Config {
+	return &Config{
+		BrowserNS:  "browserkube",
+		UserHeader: "X-Forwarded-User",
+		Tenants: []Tenant{
+			{Name: "team-a", Namespace: "team-a", Users: []string{"alice", "bob"}},
+			{Name: "team-b", Namespace: "team-b", Users: []string{"bob"}},
+			{Name: "shared", Namespace: "browserkube"},
+		},
+	}
+}
+
+func TestConfig_Tenant(t *testing.T) {
+	tests := []struct {
+		name      string
+		user      string
+		requested string
+		want      string
+		wantErr   error
+	}{
+		{name: "anonymous", want: "browserkube"},
+		{name: "user without tenant", user: "eve", want: "browserkube"},
+		{name: "member", user: "alice", want: "team-a"},
+		{name: "first tenant of the user", user: "bob", want: "team-a"},
+		{name: "requested tenant", user: "bob", requested: "team-b", want: "team-b"},
+		{name: "open tenant", user: "eve", requested: "shared", want: "browserkube"},
+		{name: "default tenant", user: "alice", requested: DefaultTenant, want: "browserkube"},
+		{name: "not a member", user: "alice", requested: "team-b", wantErr: ErrTenantForbidden},
+		{name: "unknown", user: "alice", requested: "team-c", wantErr: ErrUnknownTenant},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := testConfig().Tenant(tt.user, tt.requested)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got.Namespace)
+		})
+	}
+}
+
+func TestConfig_Namespaces(t *testing.T) {
+	require.Equal(t, []string{"browserkube", "team-a", "team-b"}, testConfig().Namespaces())
+}
+
+func TestConfig_UserNamespaces(t *testing.T) {
+	cfg := testConfig()
+	require.Equal(t, []string{"browserkube", "team-a", "team-b"}, cfg.UserNamespaces("bob"))
+	require.Equal(t, []string{"browserkube", "team-a"}, cfg.UserNamespaces("alice"))
+	require.Equal(t, []string{"browserkube"}, cfg.UserNamespaces("eve"))
+}
+
+func TestTenantMiddleware(t *testing.T) {
+	cfg := testConfig()
+	var gotUser, gotNamespace string
+	handler := TenantMiddleware(cfg)(http.HandlerFunc(func(w http.ResponseWriter, rq *http.Request) {
+		gotUser = UserFrom(rq.Context())
+		gotNamespace = cfg.Namespace(rq.Context())
+	}))
+
+	rq := httptest.NewRequest(http.MethodGet, "/sessions", http.NoBody)
+	rq.Header.Set("X-Forwarded-User", "alice")
+	handler.ServeHTTP(httptest.NewRecorder(), rq)
+	require.Equal(t, "alice", gotUser)
+	require.Equal(t, "team-a", gotNamespace)
+
+	require.Equal(t, "browserkube", cfg.Namespace(context.Background()))
+
+	// the header isn't trusted unless configured
+	cfg.UserHeader = ""
+	handler.ServeHTTP(httptest.NewRecorder(), rq)
+	require.Empty(t, gotUser)
+	require.Equal(t, "browserkube", gotNamespace)
+}
@@ -34,7 +34,7 @@ func fetchLogsHook(serviceProvider provision.Provisioner, store storage.BlobSess
 		return func(ctx *wd.Context, s *session.Session) error {
 			log := zap.S().With("sessionId", s.ID)
 
-			podLogs, err := serviceProvider.Logs(ctx, s.Browser.Status.PodName, false)
+			podLogs, err := serviceProvider.Logs(ctx, s.Browser, false)
 			if err != nil {
 				log.Errorf("Unable to get pod logs: %v", err)
 				return next(ctx, s)
@@ -60,7 +60,8 @@ func fetchVideoHook(client *http.Client, store storage.BlobSessionStorage) func(
 				return next(ctx, s)
 			}
 
-			baseURL.Path = path.Join(sessionresult.VideosPath, sessionresult.VideoFileName)
+			fileName, contentType := sessionresult.VideoFile(s.Browser.Status.Recording)
+			baseURL.Path = path.Join(sessionresult.VideosPath, fileName)
 
 			req, err := http.NewRequestWithContext(ctx, http.MethodGet, baseURL.String(), nil)
 			if err != nil {
@@ -79,8 +80,8 @@ func fetchVideoHook(client *http.Client, store storage.BlobSessionStorage) func(
 			}
 
 			if err := store.SaveFile(ctx, s.ID, "", &storage.BlobFile{
-				FileName:    sessionresult.VideoFileName,
-				ContentType: "video/mp4",
+				FileName:    fileName,
+				ContentType: contentType,
 				Content:     resp.Body,
 			}); err != nil {
 				log.Errorf("failed to save video: %v", err)
@@ -0,0 +1,256 @@
+package retention
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"sort"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.uber.org/zap"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/browserkube/browserkube/browserkube/internal/provision"
+	browserkubev1 "github.com/browserkube/browserkube/operator/api/v1"
+	browserkubeclientv1 "github.com/browserkube/browserkube/operator/pkg/client/v1"
+	"github.com/browserkube/browserkube/storage"
+)
+
+const listPageSize = 500
+
+// Actions taken on expired results
+const (
+	actionDelete  = "delete"
+	actionArchive = "archive"
+)
+
+// collector removes session results and their artifacts not kept by retention policies
+type collector struct {
+	cfg       *Config
+	envConfig *provision.Config
+	results   func(namespace string) browserkubeclientv1.SessionResultsInterface
+	store     storage.BlobSessionStorage
+	archive   storage.BlobSessionArchiveStorage
+	metrics   *metrics
+	now       func() time.Time
+}
+
+// Start runs collection every configured interval until the context is canceled
+func (c *collector) Start(ctx context.Context) {
+	ticker := time.NewTicker(c.cfg.Interval)
+	defer ticker.Stop()
+
+	for {
+		c.Run(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Run collects session results in namespaces of all tenants
+func (c *collector) Run(ctx context.Context) {
+	tenants := make(map[string]string, len(c.envConfig.Tenants)+1)
+	tenants[c.envConfig.BrowserNS] = provision.DefaultTenant
+	for _, t := range c.envConfig.Tenants {
+		tenants[t.Namespace] = t.Name
+	}
+
+	for _, namespace := range c.envConfig.Namespaces() {
+		if err := c.collect(ctx, namespace, tenants[namespace]); err != nil {
+			zap.S().With("namespace", namespace).Errorf("session results collection failed: %+v", err)
+		}
+	}
+}
+
+func (c *collector) collect(ctx context.Context, namespace, tenant string) error {
+	results, err := c.list(ctx, namespace)
+	if err != nil {
+		return err
+	}
+
+	// every result is governed by the first matching policy only
+	groups := make([][]candidate, len(c.cfg.Policies))
+	for i := range results {
+		for pi := range c.cfg.Policies {
+			if c.cfg.Policies[pi].matches(tenant, &results[i]) {
+				groups[pi] = append(groups[pi], candidate{result: &results[i], size: -1})
+				break
+			}
+		}
+	}
+
+	for pi, candidates := range groups {
+		policy := &c.cfg.Policies[pi]
+		sort.SliceStable(candidates, func(i, j int) bool {
+			return finishedAt(candidates[i].result).After(finishedAt(candidates[j].result))
+		})
+		if policy.MaxSize != nil {
+			for i := range candidates {
+				if candidates[i].size, err = c.store.SessionSize(ctx, candidates[i].result.Name); err != nil {
+					return errors.WithStack(err)
+				}
+			}
+		}
+
+		for _, e := range policy.expired(candidates, c.now()) {
+			if err = c.expire(ctx, namespace, policy, e); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (c *collector) list(ctx context.Context, namespace string) ([]browserkubev1.SessionResult, error) {
+	var (
+		results       []browserkubev1.SessionResult
+		continueToken string
+	)
+	for {
+		page, err := c.results(namespace).List(ctx, metav1.ListOptions{
+			Limit:    listPageSize,
+			Continue: continueToken,
+		})
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+		results = append(results, page.Items...)
+		if page.Continue == "" {
+			return results, nil
+		}
+		continueToken = page.Continue
+	}
+}
+
+func (c *collector) expire(ctx context.Context, namespace string, policy *Policy, e expiry) error {
+	name := e.result.Name
+	if e.size < 0 {
+		size, err := c.store.SessionSize(ctx, name)
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		e.size = size
+	}
+
+	action := actionDelete
+	if policy.Archive {
+		action = actionArchive
+	}
+	log := zap.S().With("namespace", namespace, "sessionId", name, "policy", policy.Name,
+		"reason", e.reason, "action", action, "bytes", e.size)
+
+	if c.cfg.DryRun {
+		log.Info("session result would be removed (dry run)")
+		c.metrics.record(ctx, namespace, policy.Name, action, true, e.size)
+		return nil
+	}
+
+	files, err := c.store.ListFileNames(ctx, name, "")
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	if policy.Archive {
+		if err = c.archiveResult(ctx, namespace, e.result, files); err != nil {
+			return err
+		}
+	}
+	for _, file := range files {
+		if err = c.store.DeleteFile(ctx, name, file); err != nil {
+			return errors.WithStack(err)
+		}
+	}
+	err = c.results(namespace).Delete(ctx, name, metav1.DeleteOptions{})
+	if err != nil && !apierrors.IsNotFound(err) {
+		return errors.WithStack(err)
+	}
+
+	log.Info("session result removed")
+	c.metrics.record(ctx, namespace, policy.Name, action, false, e.size)
+	return nil
+}
+
+// archiveResult saves the result and its files into the archive storage as <namespace>/<session>.zip
+// using the same layout as the session-archiver job
+func (c *collector) archiveResult(ctx context.Context, namespace string, result *browserkubev1.SessionResult, files []string) error {
+	buf := new(bytes.Buffer)
+	zipW := zip.NewWriter(buf)
+
+	w, err := zipW.Create(result.Name + "/sessionresult.json")
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	if err = json.NewEncoder(w).Encode(result); err != nil {
+		return errors.WithStack(err)
+	}
+
+	for _, name := range files {
+		file, fErr := c.store.GetFile(ctx, result.Name, name)
+		if fErr != nil {
+			return errors.WithStack(fErr)
+		}
+		if w, err = zipW.Create(result.Name + "/data/" + file.FileName); err != nil {
+			return errors.WithStack(err)
+		}
+		if _, err = io.Copy(w, file.Content); err != nil {
+			return errors.WithStack(err)
+		}
+	}
+	if err = zipW.Close(); err != nil {
+		return errors.WithStack(err)
+	}
+
+	return errors.WithStack(c.archive.SaveFile(ctx, namespace, "", &storage.BlobFile{
+		FileName:    result.Name + ".zip",
+		ContentType: "application/zip",
+		Content:     buf,
+	}))
+}
+
+type metrics struct {
+	reclaimedBytes metric.Int64Counter
+	removedResults metric.Int64Counter
+}
+
+func newMetrics() (*metrics, error) {
+	meter := otel.Meter("")
+	reclaimedBytes, err := meter.Int64Counter(
+		"browserkube.retention.reclaimed_bytes",
+		metric.WithDescription("Size of session artifacts removed by retention policies"),
+		metric.WithUnit("By"),
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "retention.reclaimed_bytes metric registration failed")
+	}
+
+	removedResults, err := meter.Int64Counter(
+		"browserkube.retention.removed_results",
+		metric.WithDescription("Number of session results removed by retention policies"),
+		metric.WithUnit("result"),
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "retention.removed_results metric registration failed")
+	}
+	return &metrics{reclaimedBytes: reclaimedBytes, removedResults: removedResults}, nil
+}
+
+func (m *metrics) record(ctx context.Context, namespace, policy, action string, dryRun bool, size int64) {
+	attrs := metric.WithAttributes(
+		attribute.String("namespace", namespace),
+		attribute.String("policy", policy),
+		attribute.String("action", action),
+		attribute.Bool("dry_run", dryRun),
+	)
+	m.removedResults.Add(ctx, 1, attrs)
+	m.reclaimedBytes.Add(ctx, size, attrs)
+}
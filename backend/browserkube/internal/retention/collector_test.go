@@ -0,0 +1,181 @@
+package retention
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/browserkube/browserkube/browserkube/internal/provision"
+	"github.com/browserkube/browserkube/browserkube/internal/provision/k8s/mocks"
+	browserkubev1 "github.com/browserkube/browserkube/operator/api/v1"
+	browserkubeclientv1 "github.com/browserkube/browserkube/operator/pkg/client/v1"
+	"github.com/browserkube/browserkube/storage"
+)
+
+var now = time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+func testResult(name, team string, age time.Duration) browserkubev1.SessionResult {
+	return browserkubev1.SessionResult{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Labels: map[string]string{"team": team}},
+		Spec:       browserkubev1.SessionResultSpec{FinishedAt: metav1.NewTime(now.Add(-age))},
+	}
+}
+
+func testPolicy(t *testing.T, p Policy) *Policy {
+	t.Helper()
+	require.NoError(t, p.init())
+	return &p
+}
+
+func Test_Policy_expired(t *testing.T) {
+	results := []browserkubev1.SessionResult{
+		testResult("s1", "qa", time.Hour),
+		testResult("s2", "qa", 2*time.Hour),
+		testResult("s3", "qa", 3*time.Hour),
+		testResult("s4", "qa", 48*time.Hour),
+	}
+	candidates := make([]candidate, len(results))
+	for i := range results {
+		candidates[i] = candidate{result: &results[i], size: 100}
+	}
+	names := func(expired []expiry) map[string]string {
+		res := map[string]string{}
+		for _, e := range expired {
+			res[e.result.Name] = e.reason
+		}
+		return res
+	}
+	maxSize := resource.MustParse("250")
+
+	tests := []struct {
+		name   string
+		policy Policy
+		want   map[string]string
+	}{
+		{
+			name:   "no limits",
+			policy: Policy{Name: "keep"},
+			want:   map[string]string{},
+		},
+		{
+			name:   "max age",
+			policy: Policy{Name: "age", MaxAge: &metav1.Duration{Duration: 24 * time.Hour}},
+			want:   map[string]string{"s4": reasonAge},
+		},
+		{
+			name:   "max count",
+			policy: Policy{Name: "count", MaxCount: 2},
+			want:   map[string]string{"s3": reasonCount, "s4": reasonCount},
+		},
+		{
+			name:   "max size",
+			policy: Policy{Name: "size", MaxSize: &maxSize},
+			want:   map[string]string{"s3": reasonSize, "s4": reasonSize},
+		},
+		{
+			name: "age wins over count",
+			policy: Policy{Name: "mixed", MaxAge: &metav1.Duration{Duration: 24 * time.Hour},
+				MaxCount: 3},
+			want: map[string]string{"s4": reasonAge},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := testPolicy(t, tt.policy)
+			require.Equal(t, tt.want, names(p.expired(candidates, now)))
+		})
+	}
+}
+
+func Test_Policy_matches(t *testing.T) {
+	res := testResult("s1", "qa", time.Hour)
+
+	require.True(t, testPolicy(t, Policy{Name: "all"}).matches("default", &res))
+	require.True(t, testPolicy(t, Policy{Name: "qa", Selector: "team=qa"}).matches("default", &res))
+	require.False(t, testPolicy(t, Policy{Name: "dev", Selector: "team=dev"}).matches("default", &res))
+	require.False(t, testPolicy(t, Policy{Name: "tenant", Tenants: []string{"team-a"}}).matches("default", &res))
+
+	require.Error(t, (&Policy{Name: "bad", Selector: "team in ("}).init())
+	require.Error(t, (&Policy{}).init())
+}
+
+func newTestStorage(t *testing.T) storage.Storage {
+	t.Helper()
+	store, err := storage.New(context.Background(), "file://"+t.TempDir()+"/")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = store.Close() })
+	return store
+}
+
+func Test_collector_Run(t *testing.T) {
+	ctx := context.Background()
+	store := newTestStorage(t)
+	archive := newTestStorage(t)
+	for _, id := range []string{"s1", "s2", "s3"} {
+		require.NoError(t, store.SaveFile(ctx, id, "", &storage.BlobFile{
+			FileName: "browser.log",
+			Content:  bytes.NewBufferString("log of " + id),
+		}))
+	}
+
+	results := mocks.NewSessionResultsInterface(t)
+	results.On("List", mock.Anything, mock.Anything).Return(&browserkubev1.SessionResultList{
+		Items: []browserkubev1.SessionResult{
+			testResult("s1", "qa", time.Hour),
+			testResult("s2", "qa", 48*time.Hour),
+			testResult("s3", "dev", 48*time.Hour),
+		},
+	}, nil)
+
+	newCollector := func(dryRun bool) *collector {
+		m, err := newMetrics()
+		require.NoError(t, err)
+		return &collector{
+			cfg: &Config{
+				DryRun: dryRun,
+				Policies: []Policy{
+					*testPolicy(t, Policy{Name: "qa", Selector: "team=qa", Archive: true,
+						MaxAge: &metav1.Duration{Duration: 24 * time.Hour}}),
+				},
+			},
+			envConfig: &provision.Config{BrowserNS: "browserkube"},
+			results: func(namespace string) browserkubeclientv1.SessionResultsInterface {
+				require.Equal(t, "browserkube", namespace)
+				return results
+			},
+			store:   store,
+			archive: archive,
+			metrics: m,
+			now:     func() time.Time { return now },
+		}
+	}
+
+	// dry run keeps everything
+	newCollector(true).Run(ctx)
+	exists, err := store.Exists(ctx, "s2", "browser.log")
+	require.NoError(t, err)
+	require.True(t, exists)
+
+	results.On("Delete", mock.Anything, "s2", mock.Anything).Return(nil).Once()
+	newCollector(false).Run(ctx)
+
+	exists, err = store.Exists(ctx, "s2", "browser.log")
+	require.NoError(t, err)
+	require.False(t, exists)
+	exists, err = archive.Exists(ctx, "browserkube", "s2.zip")
+	require.NoError(t, err)
+	require.True(t, exists)
+
+	// results not matching the policy are kept
+	for _, id := range []string{"s1", "s3"} {
+		exists, err = store.Exists(ctx, id, "browser.log")
+		require.NoError(t, err)
+		require.True(t, exists)
+	}
+}
@@ -0,0 +1,92 @@
+package retention
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+	"k8s.io/utils/env"
+
+	"github.com/browserkube/browserkube/browserkube/internal/provision"
+	browserkubeclientv1 "github.com/browserkube/browserkube/operator/pkg/client/v1"
+	"github.com/browserkube/browserkube/storage"
+)
+
+// Module periodically removes session results and their artifacts according to retention policies
+var Module = fx.Options(
+	fx.Provide(provideConfig),
+	fx.Invoke(startCollector),
+)
+
+func provideConfig() (*Config, error) {
+	enabled, err := env.GetBool("RETENTION_ENABLED", false)
+	if err != nil {
+		return nil, errors.Wrap(err, "Unable to parse RETENTION_ENABLED")
+	}
+	dryRun, err := env.GetBool("RETENTION_DRY_RUN", false)
+	if err != nil {
+		return nil, errors.Wrap(err, "Unable to parse RETENTION_DRY_RUN")
+	}
+	interval, err := time.ParseDuration(env.GetString("RETENTION_INTERVAL", "1h"))
+	if err != nil {
+		return nil, errors.Wrap(err, "Unable to parse RETENTION_INTERVAL")
+	}
+	cfg := &Config{
+		Enabled:  enabled,
+		Interval: interval,
+		DryRun:   dryRun,
+	}
+	if policies := env.GetString("RETENTION_POLICIES", ""); policies != "" {
+		if err = json.Unmarshal([]byte(policies), &cfg.Policies); err != nil {
+			return nil, errors.Wrap(err, "Unable to parse RETENTION_POLICIES")
+		}
+	}
+	for i := range cfg.Policies {
+		if err = cfg.Policies[i].init(); err != nil {
+			return nil, err
+		}
+	}
+	return cfg, nil
+}
+
+func startCollector(lc fx.Lifecycle,
+	cfg *Config,
+	envConfig *provision.Config,
+	browserkubeClient browserkubeclientv1.Interface,
+	store storage.BlobSessionStorage,
+	archive storage.BlobSessionArchiveStorage,
+) error {
+	if !cfg.Enabled {
+		return nil
+	}
+	m, err := newMetrics()
+	if err != nil {
+		return err
+	}
+	c := &collector{
+		cfg:       cfg,
+		envConfig: envConfig,
+		results:   browserkubeClient.SessionResults,
+		store:     store,
+		archive:   archive,
+		metrics:   m,
+		now:       time.Now,
+	}
+
+	collectCtx, cancelFunc := context.WithCancel(context.Background())
+	lc.Append(fx.Hook{
+		OnStart: func(ctx context.Context) error {
+			zap.S().Infof("Starting session results retention, dry run: %t", cfg.DryRun)
+			go c.Start(collectCtx)
+			return nil
+		},
+		OnStop: func(ctx context.Context) error {
+			cancelFunc()
+			return nil
+		},
+	})
+	return nil
+}
@@ -0,0 +1,118 @@
+package retention
+
+import (
+	"slices"
+	"time"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	browserkubev1 "github.com/browserkube/browserkube/operator/api/v1"
+)
+
+// Expiry reasons
+const (
+	reasonAge   = "age"
+	reasonCount = "count"
+	reasonSize  = "size"
+)
+
+// Config of the session results garbage collection
+type Config struct {
+	Enabled bool
+	// Interval between collection runs
+	Interval time.Duration
+	// DryRun only reports results which would be removed
+	DryRun bool
+	// Policies are matched in order, the first matching policy decides how long a result is kept.
+	// Results matching no policy are kept forever
+	Policies []Policy
+}
+
+// Policy limits session results matching its selector
+type Policy struct {
+	Name string `json:"name"`
+	// Selector is a label selector of session results. Empty selector matches all results
+	Selector string `json:"selector,omitempty"`
+	// Tenants the policy is applied to. Policy without tenants is applied to all of them
+	Tenants []string `json:"tenants,omitempty"`
+	// MaxAge of a result since the session has finished
+	MaxAge *metav1.Duration `json:"maxAge,omitempty"`
+	// MaxCount of results kept per namespace
+	MaxCount int `json:"maxCount,omitempty"`
+	// MaxSize of artifacts of the results kept per namespace
+	MaxSize *resource.Quantity `json:"maxSize,omitempty"`
+	// Archive results into the archive storage before they're deleted
+	Archive bool `json:"archive,omitempty"`
+
+	selector labels.Selector
+}
+
+func (p *Policy) init() error {
+	if p.Name == "" {
+		return errors.New("retention policy name is required")
+	}
+	selector, err := labels.Parse(p.Selector)
+	if err != nil {
+		return errors.Wrapf(err, "retention policy [%s] has invalid selector", p.Name)
+	}
+	p.selector = selector
+	return nil
+}
+
+func (p *Policy) matches(tenant string, result *browserkubev1.SessionResult) bool {
+	if len(p.Tenants) > 0 && !slices.Contains(p.Tenants, tenant) {
+		return false
+	}
+	return p.selector.Matches(labels.Set(result.Labels))
+}
+
+// candidate is a session result with the size of its artifacts. Size is negative until it's known
+type candidate struct {
+	result *browserkubev1.SessionResult
+	size   int64
+}
+
+type expiry struct {
+	candidate
+	reason string
+}
+
+// expired returns candidates the policy doesn't keep. Candidates have to be sorted newest first
+func (p *Policy) expired(candidates []candidate, now time.Time) []expiry {
+	var (
+		res      []expiry
+		kept     int
+		keptSize int64
+		overSize bool
+	)
+	for _, c := range candidates {
+		var reason string
+		switch {
+		case p.MaxAge != nil && now.Sub(finishedAt(c.result)) > p.MaxAge.Duration:
+			reason = reasonAge
+		case p.MaxCount > 0 && kept >= p.MaxCount:
+			reason = reasonCount
+		case p.MaxSize != nil && (overSize || keptSize+c.size > p.MaxSize.Value()):
+			// everything older than the result which doesn't fit goes away as well
+			overSize = true
+			reason = reasonSize
+		default:
+			kept++
+			keptSize += c.size
+			continue
+		}
+		res = append(res, expiry{candidate: c, reason: reason})
+	}
+	return res
+}
+
+// finishedAt returns time the session has finished at, results of unfinished sessions fall back to creation time
+func finishedAt(result *browserkubev1.SessionResult) time.Time {
+	if !result.Spec.FinishedAt.IsZero() {
+		return result.Spec.FinishedAt.Time
+	}
+	return result.CreationTimestamp.Time
+}
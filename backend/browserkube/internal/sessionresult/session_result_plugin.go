@@ -47,16 +47,17 @@ func quitSessionHandler(sessionResultsRepo sessionresult.Repository, store stora
 			sr := &sessionresult.Result{
 				SessionResult: browserkubev1.SessionResult{
 					ObjectMeta: metav1.ObjectMeta{
-						Name:        s.Browser.Name,
+						Name:        s.ID,
 						Namespace:   s.Browser.Namespace,
 						Labels:      s.Browser.Labels,
 						Annotations: s.Browser.Annotations,
 					},
 					Spec: browserkubev1.SessionResultSpec{
-						StartedAt:    s.Browser.CreationTimestamp,
-						Browser:      s.Browser.Spec,
-						BrowserImage: s.Browser.Status.Image,
-						Files:        browserkubev1.SessionResultFiles{},
+						StartedAt:         s.Browser.CreationTimestamp,
+						Browser:           s.Browser.Spec,
+						BrowserImage:      s.Browser.Status.Image,
+						Files:             browserkubev1.SessionResultFiles{},
+						TerminationReason: sessionresult.TerminationReason(s.Browser),
 					},
 				},
 			}
@@ -64,8 +65,12 @@ func quitSessionHandler(sessionResultsRepo sessionresult.Repository, store stora
 				sr.SessionResult.Spec.FinishedAt = *s.Browser.DeletionTimestamp
 			}
 
-			if s.Browser.Spec.EnableVideo && sessionFileExists(store, sessionresult.VideoFileName, s.ID) {
-				sr.Spec.Files.Video = path.Join(s.ID, sessionresult.VideoFileName)
+			if s.Browser.Spec.EnableVideo {
+				videoFileName, _ := sessionresult.VideoFile(s.Browser.Status.Recording)
+				if sessionFileExists(store, videoFileName, s.ID) {
+					sr.Spec.Files.Video = path.Join(s.ID, videoFileName)
+					sr.Spec.Recording = s.Browser.Status.Recording
+				}
 			}
 
 			if sessionFileExists(store, sessionresult.BrowserLogFileName, s.ID) {
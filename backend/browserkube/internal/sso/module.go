@@ -0,0 +1,76 @@
+package sso
+
+import (
+	"crypto/rand"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/pkg/errors"
+	"go.uber.org/fx"
+	"k8s.io/utils/env"
+
+	"github.com/browserkube/browserkube/browserkube/internal/provision"
+	browserkubehttp "github.com/browserkube/browserkube/pkg/http"
+)
+
+// Module logs users of the UI in with an OIDC provider. Disabled unless OIDC_ISSUER is set
+var Module = fx.Options(
+	fx.Provide(provideConfig, newSessions),
+	fx.Invoke(initRoutes),
+)
+
+func provideConfig(envConfig *provision.Config) (*Config, error) {
+	cfg := &Config{
+		Issuer:       env.GetString("OIDC_ISSUER", ""),
+		ClientID:     env.GetString("OIDC_CLIENT_ID", ""),
+		ClientSecret: env.GetString("OIDC_CLIENT_SECRET", ""),
+		RedirectURL:  env.GetString("OIDC_REDIRECT_URL", ""),
+		UserClaim:    env.GetString("OIDC_USER_CLAIM", "email"),
+		GroupsClaim:  env.GetString("OIDC_GROUPS_CLAIM", "groups"),
+		SessionKey:   []byte(env.GetString("OIDC_SESSION_SECRET", "")),
+	}
+	if cfg.Issuer == "" {
+		return cfg, nil
+	}
+	if !envConfig.AuthEnabled {
+		return nil, errors.New("OIDC_ISSUER requires AUTH_ENABLED")
+	}
+	if cfg.ClientID == "" || cfg.RedirectURL == "" {
+		return nil, errors.New("OIDC_CLIENT_ID and OIDC_REDIRECT_URL are required")
+	}
+	for _, scope := range strings.Split(env.GetString("OIDC_SCOPES", "openid,profile,email"), ",") {
+		if scope = strings.TrimSpace(scope); scope != "" {
+			cfg.Scopes = append(cfg.Scopes, scope)
+		}
+	}
+	if groupRoles := env.GetString("OIDC_GROUP_ROLES", ""); groupRoles != "" {
+		if err := json.Unmarshal([]byte(groupRoles), &cfg.GroupRoles); err != nil {
+			return nil, errors.Wrap(err, "Unable to parse OIDC_GROUP_ROLES")
+		}
+	}
+	var err error
+	if cfg.SessionTTL, err = time.ParseDuration(env.GetString("OIDC_SESSION_TTL", "12h")); err != nil {
+		return nil, errors.Wrap(err, "Unable to parse OIDC_SESSION_TTL")
+	}
+	if len(cfg.SessionKey) == 0 {
+		// sessions don't survive restarts then
+		cfg.SessionKey = make([]byte, 32)
+		if _, err = rand.Read(cfg.SessionKey); err != nil {
+			return nil, errors.WithStack(err)
+		}
+	}
+	return cfg, nil
+}
+
+func initRoutes(mux chi.Router, s *Sessions) {
+	if s == nil {
+		return
+	}
+	mux.Route("/auth", func(r chi.Router) {
+		r.Get("/login", browserkubehttp.Handler(s.login))
+		r.Get("/callback", browserkubehttp.Handler(s.callback))
+		r.HandleFunc("/logout", s.logout)
+	})
+}
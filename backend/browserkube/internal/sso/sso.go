@@ -0,0 +1,284 @@
+package sso
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/coreos/go-oidc/v3/oidc"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+	"golang.org/x/oauth2"
+
+	browserkubehttp "github.com/browserkube/browserkube/pkg/http"
+)
+
+const (
+	// SessionCookie holds the identity of the user logged in with the OIDC provider
+	SessionCookie = "browserkube_session"
+	stateCookie   = "browserkube_oidc"
+	stateTTL      = 10 * time.Minute
+)
+
+// Config of the OIDC client
+type Config struct {
+	Issuer       string
+	ClientID     string
+	ClientSecret string
+	// RedirectURL is the external URL of the callback, e.g. https://browserkube.example.com/browserkube/auth/callback
+	RedirectURL string
+	Scopes      []string
+	// UserClaim names the user, the subject is used if the ID token doesn't have the claim
+	UserClaim   string
+	GroupsClaim string
+	// GroupRoles maps groups of the user to browserkube roles
+	GroupRoles map[string]string
+	// SessionKey signs the session cookie
+	SessionKey []byte
+	SessionTTL time.Duration
+}
+
+// Sessions logs users in with the authorization code flow of the OIDC provider and keeps
+// their identity in a signed session cookie
+type Sessions struct {
+	cfg    *Config
+	client *http.Client
+	signer *signer
+	logger *zap.SugaredLogger
+	now    func() time.Time
+
+	// the provider is discovered on the first login so that the backend starts while the provider is unavailable
+	mu       sync.Mutex
+	provider *oidc.Provider
+}
+
+func newSessions(cfg *Config, client *http.Client, logger *zap.SugaredLogger) *Sessions {
+	if cfg.Issuer == "" {
+		return nil
+	}
+	return &Sessions{
+		cfg:    cfg,
+		client: client,
+		signer: &signer{key: cfg.SessionKey},
+		logger: logger,
+		now:    time.Now,
+	}
+}
+
+type session struct {
+	browserkubehttp.Identity
+	Expires int64 `json:"exp"`
+}
+
+type loginState struct {
+	State    string `json:"state"`
+	Nonce    string `json:"nonce"`
+	Verifier string `json:"verifier"`
+	Redirect string `json:"redirect"`
+	Expires  int64  `json:"exp"`
+}
+
+// Middleware stores the identity of the session cookie in the request context.
+// Requests without a valid cookie are passed to the handler, e.g. to authenticate their credentials.
+// Cookies are sent by pages of other origins of the same site too, e.g. with websocket handshakes,
+// so requests of other origins than the UI one are rejected.
+// Middleware is a no-op if the single sign-on is disabled
+func (s *Sessions) Middleware(next http.Handler) http.Handler {
+	if s == nil {
+		return next
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, rq *http.Request) {
+		if identity := s.identity(rq); identity != nil {
+			if !s.allowsOrigin(rq.Header.Get("Origin")) {
+				http.Error(w, "cross-origin requests aren't allowed", http.StatusForbidden)
+				return
+			}
+			rq = rq.WithContext(browserkubehttp.WithIdentity(rq.Context(), identity))
+		}
+		next.ServeHTTP(w, rq)
+	})
+}
+
+// allowsOrigin checks the origin is the one of the UI, i.e. of the callback URL.
+// Requests without the origin are sent by the UI itself
+func (s *Sessions) allowsOrigin(origin string) bool {
+	if origin == "" {
+		return true
+	}
+	ui, err := url.Parse(s.cfg.RedirectURL)
+	if err != nil {
+		return false
+	}
+	return strings.EqualFold(origin, ui.Scheme+"://"+ui.Host)
+}
+
+func (s *Sessions) identity(rq *http.Request) *browserkubehttp.Identity {
+	cookie, err := rq.Cookie(SessionCookie)
+	if err != nil {
+		return nil
+	}
+	var sess session
+	if err = s.signer.decode(SessionCookie, cookie.Value, &sess); err != nil {
+		s.logger.Debugf("Invalid session cookie: %v", err)
+		return nil
+	}
+	if sess.User == "" || s.now().Unix() >= sess.Expires {
+		return nil
+	}
+	return &sess.Identity
+}
+
+func (s *Sessions) oauth2Config(ctx context.Context) (*oauth2.Config, *oidc.IDTokenVerifier, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.provider == nil {
+		provider, err := oidc.NewProvider(oidc.ClientContext(ctx, s.client), s.cfg.Issuer)
+		if err != nil {
+			return nil, nil, errors.Wrapf(err, "unable to discover OIDC provider [%s]", s.cfg.Issuer)
+		}
+		s.provider = provider
+	}
+	return &oauth2.Config{
+		ClientID:     s.cfg.ClientID,
+		ClientSecret: s.cfg.ClientSecret,
+		Endpoint:     s.provider.Endpoint(),
+		RedirectURL:  s.cfg.RedirectURL,
+		Scopes:       s.cfg.Scopes,
+	}, s.provider.Verifier(&oidc.Config{ClientID: s.cfg.ClientID, Now: s.now}), nil
+}
+
+// login redirects the user to the provider. The redirect query parameter is the UI page the user returns to
+func (s *Sessions) login(w http.ResponseWriter, rq *http.Request) error {
+	config, _, err := s.oauth2Config(rq.Context())
+	if err != nil {
+		return browserkubehttp.NewHTTPErr(http.StatusBadGateway, err)
+	}
+	state := loginState{
+		State:    oauth2.GenerateVerifier(),
+		Nonce:    oauth2.GenerateVerifier(),
+		Verifier: oauth2.GenerateVerifier(),
+		Redirect: localRedirect(rq.URL.Query().Get("redirect")),
+		Expires:  s.now().Add(stateTTL).Unix(),
+	}
+	value, err := s.signer.encode(stateCookie, &state)
+	if err != nil {
+		return err
+	}
+	s.setCookie(w, stateCookie, value, int(stateTTL.Seconds()))
+	http.Redirect(w, rq, config.AuthCodeURL(state.State,
+		oidc.Nonce(state.Nonce), oauth2.S256ChallengeOption(state.Verifier)), http.StatusFound)
+	return nil
+}
+
+// callback exchanges the authorization code for the ID token of the user and starts the session
+func (s *Sessions) callback(w http.ResponseWriter, rq *http.Request) error {
+	cookie, err := rq.Cookie(stateCookie)
+	if err != nil {
+		return browserkubehttp.NewHTTPErr(http.StatusBadRequest, errors.New("login hasn't been started"))
+	}
+	s.setCookie(w, stateCookie, "", -1)
+	var state loginState
+	if err = s.signer.decode(stateCookie, cookie.Value, &state); err != nil {
+		return browserkubehttp.NewHTTPErr(http.StatusBadRequest, err)
+	}
+	query := rq.URL.Query()
+	if s.now().Unix() >= state.Expires || query.Get("state") != state.State {
+		return browserkubehttp.NewHTTPErr(http.StatusBadRequest, errors.New("login state mismatch"))
+	}
+	if providerErr := query.Get("error"); providerErr != "" {
+		return browserkubehttp.NewHTTPErr(http.StatusUnauthorized,
+			errors.Errorf("login failed: %s %s", providerErr, query.Get("error_description")))
+	}
+
+	config, verifier, err := s.oauth2Config(rq.Context())
+	if err != nil {
+		return browserkubehttp.NewHTTPErr(http.StatusBadGateway, err)
+	}
+	ctx := oidc.ClientContext(rq.Context(), s.client)
+	token, err := config.Exchange(ctx, query.Get("code"), oauth2.VerifierOption(state.Verifier))
+	if err != nil {
+		return browserkubehttp.NewHTTPErr(http.StatusUnauthorized, errors.Wrap(err, "unable to exchange authorization code"))
+	}
+	rawIDToken, ok := token.Extra("id_token").(string)
+	if !ok {
+		return browserkubehttp.NewHTTPErr(http.StatusUnauthorized, errors.New("token response has no ID token"))
+	}
+	idToken, err := verifier.Verify(ctx, rawIDToken)
+	if err != nil {
+		return browserkubehttp.NewHTTPErr(http.StatusUnauthorized, errors.Wrap(err, "invalid ID token"))
+	}
+	if idToken.Nonce != state.Nonce {
+		return browserkubehttp.NewHTTPErr(http.StatusUnauthorized, errors.New("ID token nonce mismatch"))
+	}
+	var claims map[string]interface{}
+	if err = idToken.Claims(&claims); err != nil {
+		return browserkubehttp.NewHTTPErr(http.StatusUnauthorized, errors.WithStack(err))
+	}
+
+	sess := session{Identity: s.identityOf(idToken.Subject, claims), Expires: s.now().Add(s.cfg.SessionTTL).Unix()}
+	value, err := s.signer.encode(SessionCookie, &sess)
+	if err != nil {
+		return err
+	}
+	s.logger.Infof("User [%s] has logged in", sess.User)
+	s.setCookie(w, SessionCookie, value, int(s.cfg.SessionTTL.Seconds()))
+	http.Redirect(w, rq, state.Redirect, http.StatusFound)
+	return nil
+}
+
+func (s *Sessions) logout(w http.ResponseWriter, rq *http.Request) {
+	s.setCookie(w, SessionCookie, "", -1)
+	http.Redirect(w, rq, localRedirect(rq.URL.Query().Get("redirect")), http.StatusFound)
+}
+
+// identityOf maps claims of the ID token to the identity of the user
+func (s *Sessions) identityOf(subject string, claims map[string]interface{}) browserkubehttp.Identity {
+	identity := browserkubehttp.Identity{User: subject}
+	if user, ok := claims[s.cfg.UserClaim].(string); ok && user != "" {
+		identity.User = user
+	}
+	switch groups := claims[s.cfg.GroupsClaim].(type) {
+	case string:
+		identity.Groups = []string{groups}
+	case []interface{}:
+		for _, group := range groups {
+			if g, ok := group.(string); ok {
+				identity.Groups = append(identity.Groups, g)
+			}
+		}
+	}
+	for _, group := range identity.Groups {
+		if role, ok := s.cfg.GroupRoles[group]; ok && !slices.Contains(identity.Roles, role) {
+			identity.Roles = append(identity.Roles, role)
+		}
+	}
+	return identity
+}
+
+// setCookie sets the cookie for maxAge seconds, negative maxAge removes it
+func (s *Sessions) setCookie(w http.ResponseWriter, name, value string, maxAge int) {
+	http.SetCookie(w, &http.Cookie{
+		Name:     name,
+		Value:    value,
+		Path:     "/",
+		MaxAge:   maxAge,
+		HttpOnly: true,
+		Secure:   strings.HasPrefix(s.cfg.RedirectURL, "https://"),
+		SameSite: http.SameSiteLaxMode,
+	})
+}
+
+// localRedirect returns the path if it's local to the host, the root page otherwise.
+// It prevents the login from redirecting users to other sites
+func localRedirect(path string) string {
+	u, err := url.Parse(path)
+	if err != nil || path == "" || u.IsAbs() || u.Host != "" || !strings.HasPrefix(u.Path, "/") ||
+		strings.HasPrefix(path, "//") || strings.Contains(path, `\`) {
+		return "/"
+	}
+	return path
+}
@@ -0,0 +1,231 @@
+package sso
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-jose/go-jose/v4"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	browserkubehttp "github.com/browserkube/browserkube/pkg/http"
+)
+
+// mockProvider is a minimal OIDC provider issuing ID tokens of a single user
+type mockProvider struct {
+	*httptest.Server
+	key    *rsa.PrivateKey
+	claims map[string]interface{}
+
+	nonce     string
+	challenge string
+}
+
+func newMockProvider(t *testing.T, claims map[string]interface{}) *mockProvider {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	p := &mockProvider{key: key, claims: claims}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, rq *http.Request) {
+		_ = browserkubehttp.WriteJSON(w, http.StatusOK, map[string]interface{}{
+			"issuer":                                p.URL,
+			"authorization_endpoint":                p.URL + "/authorize",
+			"token_endpoint":                        p.URL + "/token",
+			"jwks_uri":                              p.URL + "/keys",
+			"id_token_signing_alg_values_supported": []string{"RS256"},
+		})
+	})
+	mux.HandleFunc("/keys", func(w http.ResponseWriter, rq *http.Request) {
+		_ = browserkubehttp.WriteJSON(w, http.StatusOK, jose.JSONWebKeySet{Keys: []jose.JSONWebKey{
+			{Key: &p.key.PublicKey, KeyID: "test", Algorithm: string(jose.RS256), Use: "sig"},
+		}})
+	})
+	mux.HandleFunc("/authorize", func(w http.ResponseWriter, rq *http.Request) {
+		query := rq.URL.Query()
+		p.nonce = query.Get("nonce")
+		p.challenge = query.Get("code_challenge")
+		http.Redirect(w, rq, query.Get("redirect_uri")+"?"+url.Values{
+			"code":  {"code-1"},
+			"state": {query.Get("state")},
+		}.Encode(), http.StatusFound)
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, rq *http.Request) {
+		require.NoError(t, rq.ParseForm())
+		sum := sha256.Sum256([]byte(rq.PostForm.Get("code_verifier")))
+		if rq.PostForm.Get("code") != "code-1" || base64.RawURLEncoding.EncodeToString(sum[:]) != p.challenge {
+			_ = browserkubehttp.WriteJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_grant"})
+			return
+		}
+		_ = browserkubehttp.WriteJSON(w, http.StatusOK, map[string]interface{}{
+			"access_token": "access-token",
+			"token_type":   "Bearer",
+			"expires_in":   3600,
+			"id_token":     p.idToken(t),
+		})
+	})
+	p.Server = httptest.NewServer(mux)
+	t.Cleanup(p.Close)
+	return p
+}
+
+func (p *mockProvider) idToken(t *testing.T) string {
+	signer, err := jose.NewSigner(jose.SigningKey{
+		Algorithm: jose.RS256,
+		Key:       jose.JSONWebKey{Key: p.key, KeyID: "test"},
+	}, nil)
+	require.NoError(t, err)
+	claims := map[string]interface{}{
+		"iss":   p.URL,
+		"aud":   "browserkube",
+		"sub":   "c3f1",
+		"nonce": p.nonce,
+		"iat":   time.Now().Unix(),
+		"exp":   time.Now().Add(time.Hour).Unix(),
+	}
+	for k, v := range p.claims {
+		claims[k] = v
+	}
+	payload, err := json.Marshal(claims)
+	require.NoError(t, err)
+	jws, err := signer.Sign(payload)
+	require.NoError(t, err)
+	token, err := jws.CompactSerialize()
+	require.NoError(t, err)
+	return token
+}
+
+func newTestSessions(issuer string) *Sessions {
+	return newSessions(&Config{
+		Issuer:      issuer,
+		ClientID:    "browserkube",
+		RedirectURL: "http://browserkube.local/browserkube/auth/callback",
+		Scopes:      []string{"openid", "email"},
+		UserClaim:   "email",
+		GroupsClaim: "groups",
+		GroupRoles:  map[string]string{"browserkube-admins": "admin", "qa": "user"},
+		SessionKey:  []byte("secret"),
+		SessionTTL:  time.Hour,
+	}, http.DefaultClient, zap.S())
+}
+
+func cookieOf(t *testing.T, rs *http.Response, name string) *http.Cookie {
+	for _, c := range rs.Cookies() {
+		if c.Name == name {
+			return c
+		}
+	}
+	require.Failf(t, "cookie isn't set", "cookie %s", name)
+	return nil
+}
+
+func TestSessions_Login(t *testing.T) {
+	provider := newMockProvider(t, map[string]interface{}{
+		"email":  "alice@example.com",
+		"groups": []string{"qa", "browserkube-admins", "everyone"},
+	})
+	s := newTestSessions(provider.URL)
+	mux := chi.NewRouter()
+	initRoutes(mux, s)
+
+	// the UI sends the user to the login
+	rs := httptest.NewRecorder()
+	mux.ServeHTTP(rs, httptest.NewRequest(http.MethodGet, "/auth/login?redirect=/sessions/42", http.NoBody))
+	require.Equal(t, http.StatusFound, rs.Code)
+	state := cookieOf(t, rs.Result(), stateCookie)
+
+	// the provider authenticates the user and redirects to the callback
+	noRedirects := &http.Client{CheckRedirect: func(*http.Request, []*http.Request) error {
+		return http.ErrUseLastResponse
+	}}
+	authorize, err := noRedirects.Get(rs.Header().Get("Location"))
+	require.NoError(t, err)
+	defer authorize.Body.Close()
+	callback, err := url.Parse(authorize.Header.Get("Location"))
+	require.NoError(t, err)
+	require.Equal(t, "/browserkube/auth/callback", callback.Path)
+
+	// the callback is rejected without the state of the login
+	rs = httptest.NewRecorder()
+	mux.ServeHTTP(rs, httptest.NewRequest(http.MethodGet, "/auth/callback?"+callback.RawQuery, http.NoBody))
+	require.Equal(t, http.StatusBadRequest, rs.Code)
+
+	rq := httptest.NewRequest(http.MethodGet, "/auth/callback?"+callback.RawQuery, http.NoBody)
+	rq.AddCookie(state)
+	rs = httptest.NewRecorder()
+	mux.ServeHTTP(rs, rq)
+	require.Equal(t, http.StatusFound, rs.Code, rs.Body.String())
+	require.Equal(t, "/sessions/42", rs.Header().Get("Location"))
+	session := cookieOf(t, rs.Result(), SessionCookie)
+	require.True(t, session.HttpOnly)
+
+	// the session cookie authenticates requests of the UI
+	var got *browserkubehttp.Identity
+	handler := s.Middleware(http.HandlerFunc(func(w http.ResponseWriter, rq *http.Request) {
+		got = browserkubehttp.IdentityFrom(rq.Context())
+	}))
+	rq = httptest.NewRequest(http.MethodGet, "/sessions", http.NoBody)
+	rq.AddCookie(session)
+	handler.ServeHTTP(httptest.NewRecorder(), rq)
+	require.Equal(t, &browserkubehttp.Identity{
+		User:   "alice@example.com",
+		Groups: []string{"qa", "browserkube-admins", "everyone"},
+		Roles:  []string{"user", "admin"},
+	}, got)
+
+	// the UI origin is allowed, pages of other origins of the site are rejected
+	rq = httptest.NewRequest(http.MethodGet, "/vnc/42", http.NoBody)
+	rq.AddCookie(session)
+	rq.Header.Set("Origin", "http://browserkube.local")
+	handler.ServeHTTP(httptest.NewRecorder(), rq)
+	require.NotNil(t, got)
+	got = nil
+	rq.Header.Set("Origin", "http://app.local")
+	rs = httptest.NewRecorder()
+	handler.ServeHTTP(rs, rq)
+	require.Equal(t, http.StatusForbidden, rs.Code)
+	require.Nil(t, got)
+
+	// tampered cookies are ignored
+	got = nil
+	rq = httptest.NewRequest(http.MethodGet, "/sessions", http.NoBody)
+	rq.AddCookie(&http.Cookie{Name: SessionCookie, Value: "x" + session.Value})
+	handler.ServeHTTP(httptest.NewRecorder(), rq)
+	require.Nil(t, got)
+
+	// expired sessions are ignored
+	s.now = func() time.Time { return time.Now().Add(2 * time.Hour) }
+	rq = httptest.NewRequest(http.MethodGet, "/sessions", http.NoBody)
+	rq.AddCookie(session)
+	handler.ServeHTTP(httptest.NewRecorder(), rq)
+	require.Nil(t, got)
+}
+
+func TestSessions_identityOf(t *testing.T) {
+	s := newTestSessions("http://issuer.local")
+	require.Equal(t, browserkubehttp.Identity{User: "c3f1", Groups: []string{"qa"}, Roles: []string{"user"}},
+		s.identityOf("c3f1", map[string]interface{}{"groups": "qa"}))
+}
+
+func Test_localRedirect(t *testing.T) {
+	tests := map[string]string{
+		"":                      "/",
+		"/sessions/42?tab=logs": "/sessions/42?tab=logs",
+		"https://evil.com":      "/",
+		"//evil.com":            "/",
+		`/\evil.com`:            "/",
+		"sessions":              "/",
+	}
+	for path, want := range tests {
+		require.Equal(t, want, localRedirect(path), path)
+	}
+}
@@ -11,16 +11,22 @@ import (
 
 	"github.com/browserkube/browserkube/browserkube/internal/provision"
 	browserkubev1 "github.com/browserkube/browserkube/operator/api/v1"
+	browserkubehttp "github.com/browserkube/browserkube/pkg/http"
 	"github.com/browserkube/browserkube/pkg/session"
 	"github.com/browserkube/browserkube/pkg/wd"
 	"github.com/browserkube/browserkube/pkg/wd/wdproto"
 )
 
-func provideK8SProxyPlugin(serviceProvider provision.Provisioner) wd.PluginOpts {
+func provideK8SProxyPlugin(
+	serviceProvider provision.Provisioner,
+	auth browserkubehttp.Authenticator,
+	roles provision.RoleResolver,
+	envConfig *provision.Config,
+) wd.PluginOpts {
 	return wd.PluginOpts{
 		Weight: 1,
 		Opts: []wd.PluginOpt{
-			wd.WithBeforeSessionCreated(provisionBrowserHandler(serviceProvider)),
+			wd.WithBeforeSessionCreated(provisionBrowserHandler(serviceProvider, auth, roles, envConfig)),
 			// wd.WithAfterSessionCreated(maximizeWindowOnStart()), //nolint:bodyclose
 			wd.WithQuitSession(quitSessionHandler(serviceProvider)),
 		},
@@ -32,7 +38,7 @@ func quitSessionHandler(serviceProvider provision.Provisioner) func(next wd.OnSe
 	return func(next wd.OnSessionQuit) wd.OnSessionQuit {
 		return func(ctx *wd.Context, sess *session.Session) error {
 			go func(srv *browserkubev1.Browser) {
-				if dErr := serviceProvider.Delete(context.Background(), srv.Name); dErr != nil {
+				if dErr := serviceProvider.Delete(context.Background(), srv); dErr != nil {
 					zap.S().Error("Unable to delete provider", dErr)
 				}
 			}(sess.Browser)
@@ -56,16 +62,33 @@ func maximizeWindowOnStart() func(next wd.OnAfterSessionStart) wd.OnAfterSession
 }
 
 // provisionBrowserHandler provisions a browser pod before the session creation
-func provisionBrowserHandler(serviceProvider provision.Provisioner) func(next wd.OnBeforeSessionStart) wd.OnBeforeSessionStart {
+func provisionBrowserHandler(
+	serviceProvider provision.Provisioner,
+	auth browserkubehttp.Authenticator,
+	roles provision.RoleResolver,
+	envConfig *provision.Config,
+) func(next wd.OnBeforeSessionStart) wd.OnBeforeSessionStart {
 	return func(next wd.OnBeforeSessionStart) wd.OnBeforeSessionStart {
 		return func(ctx *wd.Context, prq *httputil.ProxyRequest, sessionRQ *wdproto.NewSessionRQ, sessionID string) error {
+			if err := authenticateCaps(ctx, auth, roles, envConfig, &sessionRQ.Capabilities.BrowserKubeOpts); err != nil {
+				if errors.Is(err, browserkubehttp.ErrUnauthenticated) {
+					return wdproto.NewSessionNotCreatedStatusErr(http.StatusUnauthorized, err)
+				}
+				return wdproto.NewSessionNotCreatedErr(err)
+			}
+			if err := provision.CheckAction(ctx, provision.ActionCreate, nil); err != nil {
+				return wdproto.NewSessionNotCreatedStatusErr(http.StatusForbidden, err)
+			}
 			remoteSelenium, err := serviceProvider.Provision(ctx, sessionID, &sessionRQ.Capabilities)
 			if err != nil {
 				if remoteSelenium != nil {
-					if dErr := serviceProvider.Delete(context.Background(), remoteSelenium.Name); dErr != nil {
+					if dErr := serviceProvider.Delete(context.Background(), remoteSelenium); dErr != nil {
 						return errors.WithStack(dErr)
 					}
 				}
+				if errors.Is(err, provision.ErrQueueTimeout) || errors.Is(err, provision.ErrNotAllowed) {
+					return wdproto.NewSessionNotCreatedErr(err)
+				}
 				return errors.WithStack(err)
 			}
 
@@ -81,6 +104,33 @@ func provisionBrowserHandler(serviceProvider provision.Provisioner) func(next wd
 	}
 }
 
+// authenticateCaps authenticates the session with the user and token capabilities if the request has no credentials
+// and resolves the role of the caller
+func authenticateCaps(
+	ctx *wd.Context,
+	auth browserkubehttp.Authenticator,
+	roles provision.RoleResolver,
+	envConfig *provision.Config,
+	opts *session.BrowserKubeOpts,
+) error {
+	if auth == nil || browserkubehttp.IdentityFrom(ctx) != nil {
+		return nil
+	}
+	if opts.Token == "" {
+		return browserkubehttp.ErrUnauthenticated
+	}
+	identity, err := auth.Authenticate(ctx, opts.User, opts.Token)
+	if err != nil {
+		return err
+	}
+	callerCtx, err := envConfig.WithCaller(browserkubehttp.WithIdentity(ctx.Context, identity), identity.User)
+	if err != nil {
+		return err
+	}
+	ctx.WithContext(envConfig.ResolveRole(callerCtx, roles))
+	return nil
+}
+
 func maximize(ctx *wd.Context, sessionID string) error {
 	sessionRemote, found := getBrowser(ctx)
 	if !found {
@@ -1,12 +1,17 @@
 package wd
 
 import (
+	"net/http"
 	"sort"
+	"strings"
 
 	"github.com/go-chi/chi/v5"
 	"go.uber.org/fx"
 	"go.uber.org/zap"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
+	"github.com/browserkube/browserkube/browserkube/internal/provision"
+	"github.com/browserkube/browserkube/browserkube/internal/sso"
 	browserkubehttp "github.com/browserkube/browserkube/pkg/http"
 	"github.com/browserkube/browserkube/pkg/opentelemetry"
 	"github.com/browserkube/browserkube/pkg/session"
@@ -45,15 +50,30 @@ func initRoutes(params inputParams) {
 		} else {
 			r.Use(opentelemetry.NewMetricsMiddleware("proxy"))
 		}
+		// the UI creates and manages sessions of the logged in user
+		r.Use(params.SSO.Middleware)
+		// new sessions may be authenticated with the token capability, the role is checked on provisioning then
+		r.With(browserkubehttp.Authenticate(params.Auth, true), provision.TenantMiddleware(params.EnvConfig),
+			provision.RoleMiddleware(params.EnvConfig, params.Roles)).
+			HandleFunc("/wd/hub/session", proxy.StartSessionHandler)
 
-		// V2
-		r.HandleFunc("/api/browsers", proxy.CreateWDSession)
-		r.HandleFunc("/api/browsers/*", proxy.DeleteWDSession)
-		//
-		r.HandleFunc("/wd/hub/session", proxy.StartSessionHandler)
-		r.HandleFunc("/wd/hub/session/*", proxy.ProxySessionHandler)
-		r.HandleFunc("/wd/hub/bidi/{sessionID}", proxy.ProxyBidirectionalSession)
-		r.HandleFunc("/wd/hub/cdp/{sessionID}", proxy.ProxyCDPSession)
+		r.Group(func(r chi.Router) {
+			r.Use(browserkubehttp.Authenticate(params.Auth, false))
+			r.Use(provision.TenantMiddleware(params.EnvConfig))
+			r.Use(provision.RoleMiddleware(params.EnvConfig, params.Roles))
+
+			// V2
+			r.With(provision.Authorize(provision.ActionCreate, nil)).HandleFunc("/api/browsers", proxy.CreateWDSession)
+			r.With(authorizeSession(params.SessionRepo, provision.ActionTerminate, func(rq *http.Request) string {
+				return strings.TrimPrefix(rq.URL.Path, "/api/browsers/")
+			})).HandleFunc("/api/browsers/*", proxy.DeleteWDSession)
+			//
+			r.With(authorizeCommand(params.SessionRepo)).HandleFunc("/wd/hub/session/*", proxy.ProxySessionHandler)
+			r.With(authorizeSession(params.SessionRepo, provision.ActionControl, sessionIDParam)).
+				HandleFunc("/wd/hub/bidi/{sessionID}", proxy.ProxyBidirectionalSession)
+			r.With(authorizeSession(params.SessionRepo, provision.ActionControl, sessionIDParam)).
+				HandleFunc("/wd/hub/cdp/{sessionID}", proxy.ProxyCDPSession)
+		})
 	})
 
 	// downloads server
@@ -63,14 +83,60 @@ func initRoutes(params inputParams) {
 		} else {
 			r.Use(opentelemetry.NewMetricsMiddleware("downloads"))
 		}
+		r.Use(params.SSO.Middleware)
+		r.Use(browserkubehttp.Authenticate(params.Auth, false))
+		r.Use(provision.TenantMiddleware(params.EnvConfig))
+		r.Use(provision.RoleMiddleware(params.EnvConfig, params.Roles))
+
+		r.With(authorizeSession(params.SessionRepo, provision.ActionView, sessionIDParam)).
+			Handle("/wd/hub/session/{sessionID}/browserkube/downloads/*", browserkubehttp.Handler(proxy.ProxyDownloads))
+	})
+}
 
-		params.Mux.Handle("/wd/hub/session/{sessionID}/browserkube/downloads/*", browserkubehttp.Handler(proxy.ProxyDownloads))
+// authorizeSession rejects requests to the session the caller's role doesn't allow and requests to sessions of
+// other tenants
+func authorizeSession(sessionRepo session.Repository, action provision.Action, sessionID func(rq *http.Request) string,
+) func(http.Handler) http.Handler {
+	return provision.Authorize(action, func(rq *http.Request) metav1.Object {
+		return findBrowser(sessionRepo, sessionID(rq))
 	})
 }
 
+// authorizeCommand rejects WebDriver commands the caller's role doesn't allow. Deleting the session terminates it
+func authorizeCommand(sessionRepo session.Repository) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, rq *http.Request) {
+			sessionID, command, _ := wd.ParseSessionPath(rq.URL.Path)
+			action := provision.ActionControl
+			if rq.Method == http.MethodDelete && command == "" {
+				action = provision.ActionTerminate
+			}
+			provision.Authorize(action, func(*http.Request) metav1.Object {
+				return findBrowser(sessionRepo, sessionID)
+			})(next).ServeHTTP(w, rq)
+		})
+	}
+}
+
+func findBrowser(sessionRepo session.Repository, sessionID string) metav1.Object {
+	sess, err := sessionRepo.FindByID(sessionID)
+	if err != nil || sess == nil || sess.Browser == nil {
+		return nil
+	}
+	return sess.Browser
+}
+
+func sessionIDParam(rq *http.Request) string {
+	return chi.URLParam(rq, "sessionID")
+}
+
 type inputParams struct {
 	fx.In
 	Mux         chi.Router
 	SessionRepo session.Repository
+	EnvConfig   *provision.Config
+	Auth        browserkubehttp.Authenticator
+	Roles       provision.RoleResolver
+	SSO         *sso.Sessions
 	PluginOpts  []wd.PluginOpts `group:"wd-extensions"`
 }
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"encoding/json"
 	"github.com/browserkube/browserkube/browserkube/internal/screenshot"
 	"net/http"
 	"os"
@@ -13,6 +14,7 @@ import (
 	_ "github.com/browserkube/browserkube/browserkube/docs"
 	"github.com/browserkube/browserkube/browserkube/internal/api"
 	"github.com/browserkube/browserkube/browserkube/internal/api/swagger"
+	"github.com/browserkube/browserkube/browserkube/internal/extension"
 	"github.com/browserkube/browserkube/browserkube/internal/playwright"
 	"github.com/browserkube/browserkube/browserkube/internal/provision"
 	provisionk8s "github.com/browserkube/browserkube/browserkube/internal/provision/k8s"
@@ -20,7 +22,9 @@ import (
 	"github.com/browserkube/browserkube/browserkube/internal/reportlog"
 	"github.com/browserkube/browserkube/browserkube/internal/reportportal"
 	"github.com/browserkube/browserkube/browserkube/internal/reportvideo"
+	"github.com/browserkube/browserkube/browserkube/internal/retention"
 	"github.com/browserkube/browserkube/browserkube/internal/sessionresult"
+	"github.com/browserkube/browserkube/browserkube/internal/sso"
 	"github.com/browserkube/browserkube/browserkube/internal/wd"
 	browserkubeapp "github.com/browserkube/browserkube/pkg/app"
 	browserkubehttp "github.com/browserkube/browserkube/pkg/http"
@@ -73,6 +77,9 @@ func main() {
 		reportcommand.Module,
 
 		sessionresult.Module,
+		retention.Module,
+		extension.Module,
+		sso.Module,
 
 		// main ui module
 		api.Module,
@@ -80,11 +87,25 @@ func main() {
 }
 
 func provideProvisionConfig() (*provision.Config, error) {
+	queueTimeout, err := time.ParseDuration(env.GetString("SESSION_QUEUE_TIMEOUT", "5m"))
+	if err != nil {
+		return nil, errors.Wrap(err, "Unable to parse SESSION_QUEUE_TIMEOUT")
+	}
 	cfg := &provision.Config{
-		BrowserNS: env.GetString("BROWSER_NS", ""),
+		BrowserNS:      env.GetString("BROWSER_NS", ""),
+		QueueTimeout:   queueTimeout,
+		UserHeader:     env.GetString("USER_HEADER", ""),
+		RolesConfigMap: env.GetString("ROLES_CONFIGMAP", ""),
+	}
+	if cfg.AuthEnabled, err = env.GetBool("AUTH_ENABLED", false); err != nil {
+		return nil, errors.Wrap(err, "Unable to parse AUTH_ENABLED")
+	}
+	if tenants := env.GetString("TENANTS", ""); tenants != "" {
+		if err = json.Unmarshal([]byte(tenants), &cfg.Tenants); err != nil {
+			return nil, errors.Wrap(err, "Unable to parse TENANTS")
+		}
 	}
 	if cfg.BrowserNS == "" {
-		var err error
 		cfg.BrowserNS, err = getCurrentNamespace()
 		if err != nil {
 			return nil, errors.Wrapf(err, "Unable to find out current namespace: %v", err)
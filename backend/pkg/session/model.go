@@ -41,6 +41,40 @@ type BrowserKubeOpts struct {
 	Manual           bool              `json:"manual,omitempty"           schema:"-"`
 	EnableVideo      bool              `json:"enableVideo,omitempty"      schema:"enableVideo"`
 	ScreenResolution string            `json:"screenResolution,omitempty" schema:"screenResolution"`
+	// BrowserSet pins the BrowserSet the browser is taken from
+	BrowserSet string `json:"browserSet,omitempty" schema:"browserSet"`
+	// BrowserSetSelector is a label selector limiting BrowserSets the browser is taken from
+	BrowserSetSelector string `json:"browserSetSelector,omitempty" schema:"browserSetSelector"`
+	// SizeProfile selects resources preset defined by BrowserSet
+	SizeProfile string `json:"sizeProfile,omitempty" schema:"sizeProfile"`
+	// StartupTimeout, IdleTimeout and SessionTimeout override the timeouts configured by BrowserSet, e.g. "5m".
+	// They can't exceed the bounds set by BrowserSet
+	StartupTimeout string `json:"startupTimeout,omitempty" schema:"startupTimeout"`
+	IdleTimeout    string `json:"idleTimeout,omitempty"    schema:"idleTimeout"`
+	SessionTimeout string `json:"sessionTimeout,omitempty" schema:"sessionTimeout"`
+	// AllowedCIDRs and AllowedDomains narrow network egress allowed by BrowserSet network policy
+	//nolint: tagliatelle
+	AllowedCIDRs   []string `json:"allowedCIDRs,omitempty"   schema:"-"`
+	AllowedDomains []string `json:"allowedDomains,omitempty" schema:"-"`
+
+	// VideoSize, VideoFrameRate, VideoCodec, VideoCRF and VideoFormat override video recording options
+	// configured by BrowserSet, e.g. "1280x720", 24, "libvpx-vp9", 30, "webm"
+	VideoSize      string `json:"videoSize,omitempty"      schema:"videoSize"`
+	VideoFrameRate int32  `json:"videoFrameRate,omitempty" schema:"videoFrameRate"`
+	VideoCodec     string `json:"videoCodec,omitempty"     schema:"videoCodec"`
+	VideoCRF       *int32 `json:"videoCRF,omitempty"       schema:"videoCRF"` //nolint: tagliatelle
+	VideoFormat    string `json:"videoFormat,omitempty"    schema:"videoFormat"`
+
+	// Proxy, NoProxy and ProxyCredentialsSecret send browser traffic through the upstream proxy, e.g.
+	// "http://proxy.corp:3128". Credentials are read from the Secret of the session namespace
+	// and only if BrowserSet configures the same proxy with the same Secret.
+	// The proxy can't be overridden if BrowserSet forces its own one
+	Proxy                  string   `json:"proxy,omitempty"                  schema:"proxy"`
+	NoProxy                []string `json:"noProxy,omitempty"                schema:"-"`
+	ProxyCredentialsSecret string   `json:"proxyCredentialsSecret,omitempty" schema:"proxyCredentialsSecret"`
+
+	// Tenant routes the session to a tenant namespace other than the default tenant of the user
+	Tenant string `json:"tenant,omitempty" schema:"tenant"`
 
 	//nolint: tagliatelle
 	EnableVNC  bool                             `json:"enableVNC,omitempty"  schema:"enableVNC"`
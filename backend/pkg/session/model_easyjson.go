@@ -183,6 +183,111 @@ func easyjsonC80ae7adDecodeGithubComBrowserkubeBrowserkubePkgSession1(in *jlexer
 			out.EnableVideo = bool(in.Bool())
 		case "screenResolution":
 			out.ScreenResolution = string(in.String())
+		case "browserSet":
+			out.BrowserSet = string(in.String())
+		case "browserSetSelector":
+			out.BrowserSetSelector = string(in.String())
+		case "sizeProfile":
+			out.SizeProfile = string(in.String())
+		case "startupTimeout":
+			out.StartupTimeout = string(in.String())
+		case "idleTimeout":
+			out.IdleTimeout = string(in.String())
+		case "sessionTimeout":
+			out.SessionTimeout = string(in.String())
+		case "allowedCIDRs":
+			if in.IsNull() {
+				in.Skip()
+				out.AllowedCIDRs = nil
+			} else {
+				in.Delim('[')
+				if out.AllowedCIDRs == nil {
+					if !in.IsDelim(']') {
+						out.AllowedCIDRs = make([]string, 0, 4)
+					} else {
+						out.AllowedCIDRs = []string{}
+					}
+				} else {
+					out.AllowedCIDRs = (out.AllowedCIDRs)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v1 string
+					v1 = string(in.String())
+					out.AllowedCIDRs = append(out.AllowedCIDRs, v1)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "allowedDomains":
+			if in.IsNull() {
+				in.Skip()
+				out.AllowedDomains = nil
+			} else {
+				in.Delim('[')
+				if out.AllowedDomains == nil {
+					if !in.IsDelim(']') {
+						out.AllowedDomains = make([]string, 0, 4)
+					} else {
+						out.AllowedDomains = []string{}
+					}
+				} else {
+					out.AllowedDomains = (out.AllowedDomains)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v2 string
+					v2 = string(in.String())
+					out.AllowedDomains = append(out.AllowedDomains, v2)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "videoSize":
+			out.VideoSize = string(in.String())
+		case "videoFrameRate":
+			out.VideoFrameRate = int32(in.Int32())
+		case "videoCodec":
+			out.VideoCodec = string(in.String())
+		case "videoCRF":
+			if in.IsNull() {
+				in.Skip()
+				out.VideoCRF = nil
+			} else {
+				if out.VideoCRF == nil {
+					out.VideoCRF = new(int32)
+				}
+				*out.VideoCRF = int32(in.Int32())
+			}
+		case "videoFormat":
+			out.VideoFormat = string(in.String())
+		case "proxy":
+			out.Proxy = string(in.String())
+		case "noProxy":
+			if in.IsNull() {
+				in.Skip()
+				out.NoProxy = nil
+			} else {
+				in.Delim('[')
+				if out.NoProxy == nil {
+					if !in.IsDelim(']') {
+						out.NoProxy = make([]string, 0, 4)
+					} else {
+						out.NoProxy = []string{}
+					}
+				} else {
+					out.NoProxy = (out.NoProxy)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v3 string
+					v3 = string(in.String())
+					out.NoProxy = append(out.NoProxy, v3)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "proxyCredentialsSecret":
+			out.ProxyCredentialsSecret = string(in.String())
+		case "tenant":
+			out.Tenant = string(in.String())
 		case "enableVNC":
 			out.EnableVNC = bool(in.Bool())
 		case "extensions":
@@ -201,9 +306,9 @@ func easyjsonC80ae7adDecodeGithubComBrowserkubeBrowserkubePkgSession1(in *jlexer
 					out.Extensions = (out.Extensions)[:0]
 				}
 				for !in.IsDelim(']') {
-					var v1 _v1.BrowserExtension
-					easyjsonC80ae7adDecodeGithubComBrowserkubeBrowserkubeOperatorApiV1(in, &v1)
-					out.Extensions = append(out.Extensions, v1)
+					var v4 _v1.BrowserExtension
+					easyjsonC80ae7adDecodeGithubComBrowserkubeBrowserkubeOperatorApiV1(in, &v4)
+					out.Extensions = append(out.Extensions, v4)
 					in.WantComma()
 				}
 				in.Delim(']')
@@ -309,6 +414,203 @@ func easyjsonC80ae7adEncodeGithubComBrowserkubeBrowserkubePkgSession1(out *jwrit
 		}
 		out.String(string(in.ScreenResolution))
 	}
+	if in.BrowserSet != "" {
+		const prefix string = ",\"browserSet\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.BrowserSet))
+	}
+	if in.BrowserSetSelector != "" {
+		const prefix string = ",\"browserSetSelector\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.BrowserSetSelector))
+	}
+	if in.SizeProfile != "" {
+		const prefix string = ",\"sizeProfile\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.SizeProfile))
+	}
+	if in.StartupTimeout != "" {
+		const prefix string = ",\"startupTimeout\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.StartupTimeout))
+	}
+	if in.IdleTimeout != "" {
+		const prefix string = ",\"idleTimeout\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.IdleTimeout))
+	}
+	if in.SessionTimeout != "" {
+		const prefix string = ",\"sessionTimeout\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.SessionTimeout))
+	}
+	if len(in.AllowedCIDRs) != 0 {
+		const prefix string = ",\"allowedCIDRs\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		{
+			out.RawByte('[')
+			for v5, v6 := range in.AllowedCIDRs {
+				if v5 > 0 {
+					out.RawByte(',')
+				}
+				out.String(string(v6))
+			}
+			out.RawByte(']')
+		}
+	}
+	if len(in.AllowedDomains) != 0 {
+		const prefix string = ",\"allowedDomains\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		{
+			out.RawByte('[')
+			for v7, v8 := range in.AllowedDomains {
+				if v7 > 0 {
+					out.RawByte(',')
+				}
+				out.String(string(v8))
+			}
+			out.RawByte(']')
+		}
+	}
+	if in.VideoSize != "" {
+		const prefix string = ",\"videoSize\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.VideoSize))
+	}
+	if in.VideoFrameRate != 0 {
+		const prefix string = ",\"videoFrameRate\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Int32(int32(in.VideoFrameRate))
+	}
+	if in.VideoCodec != "" {
+		const prefix string = ",\"videoCodec\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.VideoCodec))
+	}
+	if in.VideoCRF != nil {
+		const prefix string = ",\"videoCRF\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Int32(int32(*in.VideoCRF))
+	}
+	if in.VideoFormat != "" {
+		const prefix string = ",\"videoFormat\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.VideoFormat))
+	}
+	if in.Proxy != "" {
+		const prefix string = ",\"proxy\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Proxy))
+	}
+	if len(in.NoProxy) != 0 {
+		const prefix string = ",\"noProxy\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		{
+			out.RawByte('[')
+			for v9, v10 := range in.NoProxy {
+				if v9 > 0 {
+					out.RawByte(',')
+				}
+				out.String(string(v10))
+			}
+			out.RawByte(']')
+		}
+	}
+	if in.ProxyCredentialsSecret != "" {
+		const prefix string = ",\"proxyCredentialsSecret\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.ProxyCredentialsSecret))
+	}
+	if in.Tenant != "" {
+		const prefix string = ",\"tenant\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Tenant))
+	}
 	if in.EnableVNC {
 		const prefix string = ",\"enableVNC\":"
 		if first {
@@ -329,11 +631,11 @@ func easyjsonC80ae7adEncodeGithubComBrowserkubeBrowserkubePkgSession1(out *jwrit
 		}
 		{
 			out.RawByte('[')
-			for v2, v3 := range in.Extensions {
-				if v2 > 0 {
+			for v11, v12 := range in.Extensions {
+				if v11 > 0 {
 					out.RawByte(',')
 				}
-				easyjsonC80ae7adEncodeGithubComBrowserkubeBrowserkubeOperatorApiV1(out, v3)
+				easyjsonC80ae7adEncodeGithubComBrowserkubeBrowserkubeOperatorApiV1(out, v12)
 			}
 			out.RawByte(']')
 		}
@@ -391,6 +693,8 @@ func easyjsonC80ae7adDecodeGithubComBrowserkubeBrowserkubeOperatorApiV1(in *jlex
 			out.UpdateURL = string(in.String())
 		case "version":
 			out.Version = string(in.String())
+		case "artifact":
+			out.Artifact = string(in.String())
 		default:
 			in.SkipRecursive()
 		}
@@ -432,6 +736,16 @@ func easyjsonC80ae7adEncodeGithubComBrowserkubeBrowserkubeOperatorApiV1(out *jwr
 		}
 		out.String(string(in.Version))
 	}
+	if in.Artifact != "" {
+		const prefix string = ",\"artifact\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Artifact))
+	}
 	out.RawByte('}')
 }
 
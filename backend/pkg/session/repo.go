@@ -11,5 +11,6 @@ type Repository interface {
 	Delete(id string) error
 	Save(s *Session) error
 	Watch(ctx context.Context) <-chan *Session
-	Quota() (int, int, error)
+	// Quota returns used and max number of browsers in the namespace
+	Quota(namespace string) (int, int, error)
 }
@@ -22,6 +22,23 @@ const (
 	MessageLogFileName = "message.log"
 )
 
+// VideoFile returns name and content type of the video recorded with the given options
+func VideoFile(recording *browserkubev1.BrowserRecording) (string, string) {
+	if recording == nil || recording.Format == "" {
+		return VideoFileName, "video/mp4"
+	}
+	return "video." + recording.Format, "video/" + recording.Format
+}
+
+// TerminationReason returns why the session of the browser has ended. Sessions the operator hasn't terminated
+// are closed by clients
+func TerminationReason(browser *browserkubev1.Browser) browserkubev1.TerminationReason {
+	if browser.Status.TerminationReason != "" {
+		return browser.Status.TerminationReason
+	}
+	return browserkubev1.TerminationReasonClientQuit
+}
+
 type Repository interface {
 	Create(ctx context.Context, req *Result) (*Result, error)
 	FindAll(ctx context.Context, limit int, continueToken string) (*browserkubeutil.Page[*Result], error)
@@ -13,6 +13,7 @@ var Module = fx.Options(
 	fx.Provide(
 		provideSessionRecordStorage,
 		provideSessionArchiveStorage,
+		provideExtensionStorage,
 	),
 )
 
@@ -25,3 +26,9 @@ func provideSessionArchiveStorage() (storage.BlobSessionArchiveStorage, error) {
 	blobURL := env.GetString("BLOB_URL_ARCHIVE", "")
 	return storage.New(context.Background(), blobURL)
 }
+
+// provideExtensionStorage keeps uploaded extension artifacts in the session records bucket unless another one is set
+func provideExtensionStorage() (storage.BlobExtensionStorage, error) {
+	blobURL := env.GetString("BLOB_URL_EXTENSIONS", env.GetString("BLOB_URL", ""))
+	return storage.New(context.Background(), blobURL)
+}
@@ -2,6 +2,7 @@ package browserkubeutil
 
 import (
 	"context"
+	"net"
 	"net/http"
 	"net/url"
 	"path"
@@ -44,3 +45,25 @@ func SeleniumUP(ctx context.Context, u string) error {
 	}
 	return nil
 }
+
+// PlaywrightUP waits until Playwright server accepts connections on the given address
+func PlaywrightUP(ctx context.Context, address string) error {
+	logger := zap.S()
+
+	dialer := &net.Dialer{Timeout: seleniumUPRetryTimeout}
+	if _, err := RetryWithTimeout(
+		seleniumUpTimeout, 0, seleniumUPRetryTimeout,
+		func() (interface{}, error) {
+			conn, err := dialer.DialContext(ctx, "tcp", address)
+			if err != nil {
+				return nil, errors.WithStack(err)
+			}
+			if err := conn.Close(); err != nil {
+				logger.Error(err)
+			}
+			return nil, nil
+		}); err != nil {
+		return errors.Wrapf(err, "Browser wait has failed with error: %v", err)
+	}
+	return nil
+}
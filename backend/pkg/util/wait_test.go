@@ -2,6 +2,7 @@ package browserkubeutil
 
 import (
 	"context"
+	"net"
 	"testing"
 )
 
@@ -33,3 +34,15 @@ func TestSeleniumUP(t *testing.T) {
 		})
 	}
 }
+
+func TestPlaywrightUP(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+
+	if err := PlaywrightUP(context.Background(), l.Addr().String()); err != nil {
+		t.Errorf("PlaywrightUP() unexpected error = %v", err)
+	}
+}
@@ -0,0 +1,234 @@
+// Package vnc implements the handshake part of RFB protocol (RFC 6143) to proxy VNC connections.
+// The proxy authenticates to VNC server itself and offers no authentication to the client,
+// so VNC password never leaves the backend
+package vnc
+
+import (
+	"bytes"
+	"crypto/des" //nolint:gosec // DES is mandated by VNC authentication
+	"encoding/binary"
+	"fmt"
+	"io"
+
+	"github.com/pkg/errors"
+)
+
+// RFB security types
+const (
+	securityTypeInvalid = 0
+	securityTypeNone    = 1
+	securityTypeVNCAuth = 2
+)
+
+const (
+	versionLength   = 12
+	challengeLength = 16
+	// maxReasonLength limits failure reason sent by the server
+	maxReasonLength = 4096
+)
+
+// protocol minor versions supported, major is always 3
+const (
+	version33 = 3
+	version37 = 7
+	version38 = 8
+)
+
+// Authenticate performs handshake with VNC server up to the initialization phase.
+// VNC authentication is used with the password if the server doesn't allow to connect without one
+func Authenticate(server io.ReadWriter, password string) error {
+	version, err := readVersion(server)
+	if err != nil {
+		return errors.WithMessage(err, "unable to read server version")
+	}
+	if _, err = io.WriteString(server, formatVersion(version)); err != nil {
+		return errors.WithStack(err)
+	}
+
+	securityType, err := selectSecurityType(server, version)
+	if err != nil {
+		return err
+	}
+	if securityType == securityTypeVNCAuth {
+		if err = authenticateVNC(server, password); err != nil {
+			return err
+		}
+	}
+	// security result is sent for VNC authentication only before 3.8
+	if version < version38 && securityType == securityTypeNone {
+		return nil
+	}
+	return readSecurityResult(server, version)
+}
+
+// AcceptNoAuth performs handshake with VNC client up to the initialization phase offering no authentication
+func AcceptNoAuth(client io.ReadWriter) error {
+	if _, err := io.WriteString(client, formatVersion(version38)); err != nil {
+		return errors.WithStack(err)
+	}
+	version, err := readVersion(client)
+	if err != nil {
+		return errors.WithMessage(err, "unable to read client version")
+	}
+
+	if version == version33 {
+		return errors.WithStack(binary.Write(client, binary.BigEndian, uint32(securityTypeNone)))
+	}
+	if _, err = client.Write([]byte{1, securityTypeNone}); err != nil {
+		return errors.WithStack(err)
+	}
+	selected := make([]byte, 1)
+	if _, err = io.ReadFull(client, selected); err != nil {
+		return errors.WithStack(err)
+	}
+	if selected[0] != securityTypeNone {
+		return errors.Errorf("client selected unsupported security type: %d", selected[0])
+	}
+	if version == version38 {
+		return errors.WithStack(binary.Write(client, binary.BigEndian, uint32(0)))
+	}
+	return nil
+}
+
+// readVersion reads ProtocolVersion message and returns the minor version to talk.
+// Unknown versions are treated as 3.3 and versions newer than 3.8 as 3.8 as RFC requires
+func readVersion(r io.Reader) (int, error) {
+	buf := make([]byte, versionLength)
+	if _, err := io.ReadFull(r, buf); err != nil {
+		return 0, errors.WithStack(err)
+	}
+	var major, minor int
+	if _, err := fmt.Sscanf(string(buf), "RFB %03d.%03d\n", &major, &minor); err != nil {
+		return 0, errors.Errorf("malformed protocol version: %q", buf)
+	}
+	switch {
+	case major != 3:
+		return 0, errors.Errorf("unsupported protocol version: %q", buf)
+	case minor >= version38:
+		return version38, nil
+	case minor == version37:
+		return version37, nil
+	default:
+		return version33, nil
+	}
+}
+
+func formatVersion(minor int) string {
+	return fmt.Sprintf("RFB 003.%03d\n", minor)
+}
+
+// selectSecurityType reads security types offered by the server and selects the one to use
+func selectSecurityType(server io.ReadWriter, version int) (byte, error) {
+	if version == version33 {
+		// the server decides on its own
+		var securityType uint32
+		if err := binary.Read(server, binary.BigEndian, &securityType); err != nil {
+			return 0, errors.WithStack(err)
+		}
+		switch securityType {
+		case securityTypeInvalid:
+			return 0, readFailureReason(server)
+		case securityTypeNone, securityTypeVNCAuth:
+			return byte(securityType), nil
+		}
+		return 0, errors.Errorf("unsupported security type: %d", securityType)
+	}
+
+	count := make([]byte, 1)
+	if _, err := io.ReadFull(server, count); err != nil {
+		return 0, errors.WithStack(err)
+	}
+	if count[0] == 0 {
+		return 0, readFailureReason(server)
+	}
+	types := make([]byte, count[0])
+	if _, err := io.ReadFull(server, types); err != nil {
+		return 0, errors.WithStack(err)
+	}
+
+	var selected byte
+	switch {
+	case bytes.IndexByte(types, securityTypeNone) >= 0:
+		selected = securityTypeNone
+	case bytes.IndexByte(types, securityTypeVNCAuth) >= 0:
+		selected = securityTypeVNCAuth
+	default:
+		return 0, errors.Errorf("unsupported security types: %v", types)
+	}
+	if _, err := server.Write([]byte{selected}); err != nil {
+		return 0, errors.WithStack(err)
+	}
+	return selected, nil
+}
+
+// authenticateVNC responds to the server challenge encrypted with the password
+func authenticateVNC(server io.ReadWriter, password string) error {
+	challenge := make([]byte, challengeLength)
+	if _, err := io.ReadFull(server, challenge); err != nil {
+		return errors.WithStack(err)
+	}
+	response, err := encryptChallenge(challenge, password)
+	if err != nil {
+		return err
+	}
+	_, err = server.Write(response)
+	return errors.WithStack(err)
+}
+
+// encryptChallenge encrypts the challenge with DES using the password as a key.
+// Password is truncated or zero-padded to 8 bytes and bits of each byte are reversed
+func encryptChallenge(challenge []byte, password string) ([]byte, error) {
+	key := make([]byte, des.BlockSize)
+	copy(key, password)
+	for i, b := range key {
+		key[i] = reverseBits(b)
+	}
+	cipher, err := des.NewCipher(key) //nolint:gosec // DES is mandated by VNC authentication
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	response := make([]byte, len(challenge))
+	for i := 0; i < len(challenge); i += des.BlockSize {
+		cipher.Encrypt(response[i:i+des.BlockSize], challenge[i:i+des.BlockSize])
+	}
+	return response, nil
+}
+
+func reverseBits(b byte) byte {
+	var r byte
+	for i := 0; i < 8; i++ {
+		r = r<<1 | b&1
+		b >>= 1
+	}
+	return r
+}
+
+func readSecurityResult(server io.Reader, version int) error {
+	var result uint32
+	if err := binary.Read(server, binary.BigEndian, &result); err != nil {
+		return errors.WithStack(err)
+	}
+	if result == 0 {
+		return nil
+	}
+	if version == version38 {
+		return errors.WithMessage(readFailureReason(server), "authentication failed")
+	}
+	return errors.New("authentication failed")
+}
+
+// readFailureReason reads the reason string sent by the server on failures
+func readFailureReason(r io.Reader) error {
+	var length uint32
+	if err := binary.Read(r, binary.BigEndian, &length); err != nil {
+		return errors.WithStack(err)
+	}
+	if length > maxReasonLength {
+		return errors.Errorf("failure reason is too long: %d bytes", length)
+	}
+	reason := make([]byte, length)
+	if _, err := io.ReadFull(r, reason); err != nil {
+		return errors.WithStack(err)
+	}
+	return errors.New(string(reason))
+}
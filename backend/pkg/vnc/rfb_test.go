@@ -0,0 +1,173 @@
+package vnc
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// fakeServer serves RFB handshake of the given version requiring VNC authentication with the password
+func fakeServer(t *testing.T, conn net.Conn, version, password string) <-chan error {
+	t.Helper()
+	done := make(chan error, 1)
+	go func() {
+		defer conn.Close()
+		done <- func() error {
+			if _, err := io.WriteString(conn, version); err != nil {
+				return err
+			}
+			clientVersion := make([]byte, versionLength)
+			if _, err := io.ReadFull(conn, clientVersion); err != nil {
+				return err
+			}
+			if string(clientVersion) != version {
+				return io.ErrUnexpectedEOF
+			}
+			if version == formatVersion(version33) {
+				if err := binary.Write(conn, binary.BigEndian, uint32(securityTypeVNCAuth)); err != nil {
+					return err
+				}
+			} else {
+				if _, err := conn.Write([]byte{2, 16, securityTypeVNCAuth}); err != nil {
+					return err
+				}
+				selected := make([]byte, 1)
+				if _, err := io.ReadFull(conn, selected); err != nil {
+					return err
+				}
+			}
+
+			challenge := []byte("0123456789abcdef")
+			if _, err := conn.Write(challenge); err != nil {
+				return err
+			}
+			response := make([]byte, challengeLength)
+			if _, err := io.ReadFull(conn, response); err != nil {
+				return err
+			}
+			expected, err := encryptChallenge(challenge, password)
+			if err != nil {
+				return err
+			}
+			if bytes.Equal(response, expected) {
+				return binary.Write(conn, binary.BigEndian, uint32(0))
+			}
+			if err := binary.Write(conn, binary.BigEndian, uint32(1)); err != nil {
+				return err
+			}
+			if version == formatVersion(version38) {
+				reason := "password check failed"
+				if err := binary.Write(conn, binary.BigEndian, uint32(len(reason))); err != nil {
+					return err
+				}
+				_, err = io.WriteString(conn, reason)
+				return err
+			}
+			return nil
+		}()
+	}()
+	return done
+}
+
+func TestAuthenticate(t *testing.T) {
+	tests := []struct {
+		name     string
+		version  string
+		password string
+		wantErr  string
+	}{
+		{name: "3.8", version: formatVersion(version38), password: "s3cr3tpw"},
+		{name: "3.7", version: formatVersion(version37), password: "s3cr3tpw"},
+		{name: "3.3", version: formatVersion(version33), password: "s3cr3tpw"},
+		{name: "wrong password", version: formatVersion(version38), password: "wrong", wantErr: "password check failed"},
+		{name: "wrong password 3.3", version: formatVersion(version33), password: "wrong", wantErr: "authentication failed"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, server := net.Pipe()
+			defer client.Close()
+			done := fakeServer(t, server, tt.version, "s3cr3tpw")
+
+			err := Authenticate(client, tt.password)
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+			require.NoError(t, <-done)
+		})
+	}
+}
+
+func TestAuthenticate_NoAuth(t *testing.T) {
+	client, server := net.Pipe()
+	defer client.Close()
+	go func() {
+		defer server.Close()
+		_, _ = io.WriteString(server, "RFB 003.889\n")
+		_, _ = io.ReadFull(server, make([]byte, versionLength))
+		_, _ = server.Write([]byte{2, securityTypeVNCAuth, securityTypeNone})
+		_, _ = io.ReadFull(server, make([]byte, 1))
+		_ = binary.Write(server, binary.BigEndian, uint32(0))
+	}()
+
+	require.NoError(t, Authenticate(client, ""))
+}
+
+func TestAcceptNoAuth(t *testing.T) {
+	tests := []struct {
+		name    string
+		version string
+		want    []byte
+	}{
+		{name: "3.8", version: formatVersion(version38), want: []byte{1, securityTypeNone, 0, 0, 0, 0}},
+		{name: "3.7", version: formatVersion(version37), want: []byte{1, securityTypeNone}},
+		{name: "3.3", version: formatVersion(version33), want: []byte{0, 0, 0, securityTypeNone}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, proxy := net.Pipe()
+			defer client.Close()
+			done := make(chan error, 1)
+			go func() {
+				defer proxy.Close()
+				done <- AcceptNoAuth(proxy)
+			}()
+
+			version := make([]byte, versionLength)
+			_, err := io.ReadFull(client, version)
+			require.NoError(t, err)
+			require.Equal(t, formatVersion(version38), string(version))
+			_, err = io.WriteString(client, tt.version)
+			require.NoError(t, err)
+
+			got := make([]byte, 2)
+			if tt.version == formatVersion(version33) {
+				got = make([]byte, 4)
+			}
+			_, err = io.ReadFull(client, got)
+			require.NoError(t, err)
+			if tt.version != formatVersion(version33) {
+				_, err = client.Write([]byte{securityTypeNone})
+				require.NoError(t, err)
+				if tt.version == formatVersion(version38) {
+					result := make([]byte, 4)
+					_, err = io.ReadFull(client, result)
+					require.NoError(t, err)
+					got = append(got, result...)
+				}
+			}
+			require.Equal(t, tt.want, got)
+			require.NoError(t, <-done)
+		})
+	}
+}
+
+func TestReverseBits(t *testing.T) {
+	require.Equal(t, byte(0b10000000), reverseBits(0b00000001))
+	require.Equal(t, byte(0b00001101), reverseBits(0b10110000))
+}
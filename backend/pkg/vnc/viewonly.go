@@ -0,0 +1,121 @@
+package vnc
+
+import (
+	"bufio"
+	"encoding/binary"
+	"io"
+
+	"github.com/pkg/errors"
+)
+
+// RFB client message types
+const (
+	msgSetPixelFormat           = 0
+	msgSetEncodings             = 2
+	msgFramebufferUpdateRequest = 3
+	msgKeyEvent                 = 4
+	msgPointerEvent             = 5
+	msgClientCutText            = 6
+	msgEnableContinuousUpdates  = 150
+	msgClientFence              = 248
+	msgSetDesktopSize           = 251
+)
+
+// maxCutTextLength limits clipboard text of the client, it's dropped anyway
+const maxCutTextLength = 1 << 20
+
+// CopyViewOnly copies client messages to the server after the handshake dropping key and pointer events,
+// clipboard and desktop size changes, so that the client only watches the screen.
+// The client always shares the desktop not to disconnect other clients
+func CopyViewOnly(server io.Writer, client io.Reader) error {
+	r := bufio.NewReader(client)
+	// ClientInit
+	if _, err := r.ReadByte(); err != nil {
+		return eof(err)
+	}
+	if _, err := server.Write([]byte{1}); err != nil {
+		return errors.WithStack(err)
+	}
+	for {
+		msg, input, err := readClientMessage(r)
+		if err != nil {
+			return eof(err)
+		}
+		if input {
+			continue
+		}
+		if _, err = server.Write(msg); err != nil {
+			return errors.WithStack(err)
+		}
+	}
+}
+
+// readClientMessage reads a client message and tells whether it's an input one
+func readClientMessage(r *bufio.Reader) (msg []byte, input bool, err error) {
+	msgType, err := r.Peek(1)
+	if err != nil {
+		return nil, false, err
+	}
+	switch msgType[0] {
+	case msgSetPixelFormat:
+		msg, err = readN(r, 20)
+	case msgSetEncodings:
+		if msg, err = readN(r, 4); err == nil {
+			msg, err = readMore(r, msg, 4*int(binary.BigEndian.Uint16(msg[2:])))
+		}
+	case msgFramebufferUpdateRequest, msgEnableContinuousUpdates:
+		msg, err = readN(r, 10)
+	case msgKeyEvent:
+		msg, err = readN(r, 8)
+		input = true
+	case msgPointerEvent:
+		msg, err = readN(r, 6)
+		input = true
+	case msgClientCutText:
+		if msg, err = readN(r, 8); err == nil {
+			// negative length is used by the extended clipboard
+			length := int32(binary.BigEndian.Uint32(msg[4:]))
+			if length < 0 {
+				length = -length
+			}
+			if length > maxCutTextLength {
+				return nil, false, errors.Errorf("client cut text is too long: %d bytes", length)
+			}
+			msg, err = readMore(r, msg, int(length))
+		}
+		input = true
+	case msgClientFence:
+		if msg, err = readN(r, 9); err == nil {
+			msg, err = readMore(r, msg, int(msg[8]))
+		}
+	case msgSetDesktopSize:
+		if msg, err = readN(r, 8); err == nil {
+			msg, err = readMore(r, msg, 16*int(msg[6]))
+		}
+		input = true
+	default:
+		return nil, false, errors.Errorf("unsupported client message type: %d", msgType[0])
+	}
+	return msg, input, err
+}
+
+func readN(r io.Reader, n int) ([]byte, error) {
+	return readMore(r, make([]byte, 0, n), n)
+}
+
+func readMore(r io.Reader, msg []byte, n int) ([]byte, error) {
+	start := len(msg)
+	msg = append(msg, make([]byte, n)...)
+	if _, err := io.ReadFull(r, msg[start:]); err != nil {
+		return nil, err
+	}
+	return msg, nil
+}
+
+// eof returns nil when the client has closed the connection
+func eof(err error) error {
+	if errors.Is(err, io.EOF) {
+		return nil
+	}
+	return errors.WithStack(err)
+}
@@ -0,0 +1,46 @@
+package vnc
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCopyViewOnly(t *testing.T) {
+	var client, want bytes.Buffer
+	// ClientInit asking for exclusive access
+	client.WriteByte(0)
+	want.WriteByte(1)
+
+	forwarded := [][]byte{
+		append([]byte{msgSetPixelFormat, 0, 0, 0}, make([]byte, 16)...),
+		{msgSetEncodings, 0, 0, 2, 0, 0, 0, 0, 0xff, 0xff, 0xff, 0x21},
+		{msgFramebufferUpdateRequest, 1, 0, 0, 0, 0, 4, 0, 3, 0},
+		{msgClientFence, 0, 0, 0, 0, 0, 0, 1, 2, 'a', 'b'},
+	}
+	dropped := [][]byte{
+		{msgKeyEvent, 1, 0, 0, 0, 0, 0xff, 0x0d},
+		{msgPointerEvent, 1, 0, 10, 0, 20},
+		{msgClientCutText, 0, 0, 0, 0, 0, 0, 4, 't', 'e', 'x', 't'},
+		append([]byte{msgSetDesktopSize, 0, 4, 0, 3, 0, 1, 0}, make([]byte, 16)...),
+	}
+	for i := range forwarded {
+		client.Write(dropped[i])
+		client.Write(forwarded[i])
+		want.Write(forwarded[i])
+	}
+
+	var server bytes.Buffer
+	require.NoError(t, CopyViewOnly(&server, &client))
+	require.Equal(t, want.Bytes(), server.Bytes())
+}
+
+func TestCopyViewOnly_UnsupportedMessage(t *testing.T) {
+	var server bytes.Buffer
+	require.Error(t, CopyViewOnly(&server, bytes.NewReader([]byte{1, 42, 0, 0})))
+	// truncated messages aren't forwarded
+	server.Reset()
+	require.Error(t, CopyViewOnly(&server, bytes.NewReader([]byte{1, msgKeyEvent, 1})))
+	require.Equal(t, []byte{1}, server.Bytes())
+}
@@ -235,8 +235,9 @@ func (p *ProxyManager) DeleteWDSession(w http.ResponseWriter, rq *http.Request)
 
 // StartSessionHandler starts a session
 func (p *ProxyManager) StartSessionHandler(w http.ResponseWriter, rq *http.Request) {
+	// session creation isn't canceled with the request, but keeps request values like the caller's tenant
 	innerCtx, cancel := context.WithCancel(
-		trace.ContextWithSpanContext(context.Background(),
+		trace.ContextWithSpanContext(context.WithoutCancel(rq.Context()),
 			trace.SpanContextFromContext(rq.Context())))
 	defer cancel()
 	ctx := &Context{Context: innerCtx}
@@ -253,8 +254,15 @@ func (p *ProxyManager) StartSessionHandler(w http.ResponseWriter, rq *http.Reque
 				wdproto.BadGatewayError(w, err)
 				return
 			}
-			prq.Out.Body = io.NopCloser(payload)
-			p.log.Info("capabilities", payload.String())
+			// credentials of the caller aren't sent to the browser
+			forwarded, err := withoutToken(payload.Bytes())
+			if err != nil {
+				wdproto.BadGatewayError(w, err)
+				return
+			}
+			prq.Out.Body = io.NopCloser(bytes.NewReader(forwarded))
+			prq.Out.ContentLength = int64(len(forwarded))
+			prq.Out.Header.Del("Authorization")
 
 			prq.Out.Header.Set("sessionID", sessionID)
 
@@ -262,8 +270,17 @@ func (p *ProxyManager) StartSessionHandler(w http.ResponseWriter, rq *http.Reque
 				wdproto.BadGatewayError(w, err)
 				return
 			}
+			// the token capability mustn't get into logs
+			logged := startSessionRQ.Capabilities
+			logged.BrowserKubeOpts.Token = ""
+			p.log.Info("capabilities", logged.String())
 
 			if err := p.beforeSessionHook(ctx, prq, &startSessionRQ, sessionID); err != nil {
+				var sncErr *wdproto.SessionNotCreatedErr
+				if errors.As(err, &sncErr) {
+					wdproto.SessionNotCreatedError(w, err)
+					return
+				}
 				wdproto.BadGatewayError(w, err)
 				return
 			}
@@ -472,8 +489,10 @@ func (p *ProxyManager) isQuit(method, command string) bool {
 	return method == http.MethodDelete && command == ""
 }
 
+// cleanupOriginHeaders removes headers of the client the browser mustn't get, including credentials of the caller
 func (p *ProxyManager) cleanupOriginHeaders(out *http.Request) {
 	out.Header.Del("Origin")
+	out.Header.Del("Authorization")
 	for name := range out.Header {
 		if strings.HasPrefix(name, "Access-Control") {
 			out.Header.Del(name)
@@ -504,6 +523,37 @@ func adjustCapabilities(rq *wdproto.NewSessionRQ) error {
 	return nil
 }
 
+// withoutToken removes the token of browserkube:options from every capabilities object of the new session request
+func withoutToken(payload []byte) ([]byte, error) {
+	var rq map[string]interface{}
+	decoder := json.NewDecoder(bytes.NewReader(payload))
+	decoder.UseNumber()
+	if err := decoder.Decode(&rq); err != nil {
+		return nil, errors.WithStack(err)
+	}
+	capsList := []interface{}{rq["desiredCapabilities"]}
+	if w3c, ok := rq["capabilities"].(map[string]interface{}); ok {
+		capsList = append(capsList, w3c["alwaysMatch"])
+		if firstMatch, ok := w3c["firstMatch"].([]interface{}); ok {
+			capsList = append(capsList, firstMatch...)
+		}
+	}
+	removed := false
+	for _, caps := range capsList {
+		capsMap, _ := caps.(map[string]interface{})
+		opts, ok := capsMap["browserkube:options"].(map[string]interface{})
+		if _, found := opts["token"]; ok && found {
+			delete(opts, "token")
+			removed = true
+		}
+	}
+	if !removed {
+		return payload, nil
+	}
+	forwarded, err := json.Marshal(rq)
+	return forwarded, errors.WithStack(err)
+}
+
 func adjustUploadPath(rq *httputil.ProxyRequest) {
 	seUploadPath, uploadPath := "/se/file", "/file"
 	if strings.HasSuffix(rq.In.URL.Path, seUploadPath) {
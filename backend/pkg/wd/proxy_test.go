@@ -71,12 +71,17 @@ func TestProxyManager_cleanupOriginHeaders(t *testing.T) {
 			name: "access control header",
 			args: args{out: getDummyRequest(t, map[string]string{"Access-Control-Allow-Origin": "any"})},
 		},
+		{
+			name: "authorization header",
+			args: args{out: getDummyRequest(t, map[string]string{"Authorization": "Bearer token"})},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			p := &ProxyManager{}
 			p.cleanupOriginHeaders(tt.args.out)
 			require.NotContains(t, tt.args.out.Header, "Origin")
+			require.NotContains(t, tt.args.out.Header, "Authorization")
 			for h := range tt.args.out.Header {
 				require.False(t, strings.HasPrefix(h, "Access-Control"))
 			}
@@ -84,6 +89,26 @@ func TestProxyManager_cleanupOriginHeaders(t *testing.T) {
 	}
 }
 
+func Test_withoutToken(t *testing.T) {
+	payload := []byte(`{"capabilities":{"alwaysMatch":{"browserName":"chrome","browserkube:options":{"token":"secret","enableVNC":true}},` +
+		`"firstMatch":[{"browserkube:options":{"token":"secret"}},{}]},` +
+		`"desiredCapabilities":{"goog:chromeOptions":{"args":["--headless"]},"browserkube:options":{"token":"secret"}}}`)
+	forwarded, err := withoutToken(payload)
+	require.NoError(t, err)
+	require.JSONEq(t, `{"capabilities":{"alwaysMatch":{"browserName":"chrome","browserkube:options":{"enableVNC":true}},`+
+		`"firstMatch":[{"browserkube:options":{}},{}]},`+
+		`"desiredCapabilities":{"goog:chromeOptions":{"args":["--headless"]},"browserkube:options":{}}}`, string(forwarded))
+
+	// payloads without the token are forwarded as is
+	payload = []byte(`{"capabilities": {"alwaysMatch": {"browserName": "chrome"}}}`)
+	forwarded, err = withoutToken(payload)
+	require.NoError(t, err)
+	require.Equal(t, payload, forwarded)
+
+	_, err = withoutToken([]byte(`{`))
+	require.Error(t, err)
+}
+
 func getDummyRequest(t *testing.T, headers map[string]string) *http.Request {
 	rq, err := http.NewRequest("GET", "", nil)
 	require.NoError(t, err)
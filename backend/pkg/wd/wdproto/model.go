@@ -28,6 +28,34 @@ type Error struct {
 	Stacktrace string
 }
 
+// W3CError is an error payload as defined by W3C WebDriver spec
+type W3CError struct {
+	Error      string `json:"error"`
+	Message    string `json:"message"`
+	Stacktrace string `json:"stacktrace"`
+}
+
+// SessionNotCreatedErr signals that a new session can't be created, e.g. there are no free browsers
+type SessionNotCreatedErr struct {
+	error
+	// status is the HTTP status of the response, http.StatusInternalServerError if zero
+	status int
+}
+
+func NewSessionNotCreatedErr(err error) error {
+	return &SessionNotCreatedErr{error: err}
+}
+
+// NewSessionNotCreatedStatusErr signals that a new session can't be created and responds with the status,
+// e.g. http.StatusForbidden if the caller isn't allowed to create sessions
+func NewSessionNotCreatedStatusErr(status int, err error) error {
+	return &SessionNotCreatedErr{error: err, status: status}
+}
+
+func (e *SessionNotCreatedErr) Unwrap() error {
+	return e.error
+}
+
 //go:generate easyjson
 //easyjson:json
 type NewSessionRQ struct {
@@ -180,6 +208,27 @@ func (wd *WebDriver) executeCommand(ctx context.Context, method, command string,
 	return nil
 }
 
+// SessionNotCreatedError writes 'session not created' error as defined by W3C WebDriver spec
+func SessionNotCreatedError(w http.ResponseWriter, err error) {
+	logger := zap.S()
+	logger.Errorf("Session not created: %+v", err)
+	status := http.StatusInternalServerError
+	var sncErr *SessionNotCreatedErr
+	if errors.As(err, &sncErr) && sncErr.status != 0 {
+		status = sncErr.status
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if encErr := json.NewEncoder(w).Encode(&Response{
+		Value: W3CError{
+			Error:   "session not created",
+			Message: err.Error(),
+		},
+	}); encErr != nil {
+		logger.Errorf("Session not created: %v", encErr)
+	}
+}
+
 func BadGatewayError(w http.ResponseWriter, err error) {
 	logger := zap.S()
 	logger.Errorf("Bad Gateway Error: %+v", err)
@@ -0,0 +1,34 @@
+package wdproto
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/pkg/errors"
+)
+
+func TestSessionNotCreatedError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{name: "no status", err: NewSessionNotCreatedErr(errors.New("no free browsers")), want: http.StatusInternalServerError},
+		{name: "forbidden", err: NewSessionNotCreatedStatusErr(http.StatusForbidden, errors.New("forbidden")), want: http.StatusForbidden},
+		{
+			name: "wrapped",
+			err:  errors.WithStack(NewSessionNotCreatedStatusErr(http.StatusUnauthorized, errors.New("invalid credentials"))),
+			want: http.StatusUnauthorized,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rs := httptest.NewRecorder()
+			SessionNotCreatedError(rs, tt.err)
+			if rs.Code != tt.want {
+				t.Errorf("SessionNotCreatedError() status = %d, want %d", rs.Code, tt.want)
+			}
+		})
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"os"
 	"path"
 	"path/filepath"
+	"strings"
 	"time"
 
 	"github.com/go-chi/chi/v5"
@@ -26,7 +27,7 @@ func main() {
 			provideConfig,
 			newWDProxy,
 		),
-		fx.Invoke(initHandlers),
+		fx.Invoke(initHandlers, startProxyForwarder),
 	)
 }
 
@@ -35,7 +36,15 @@ type conf struct {
 	recorderURL    *url.URL
 	idleTimeout    time.Duration
 	sessionTimeout time.Duration
+	// pooled browsers start timeouts when they are claimed by a session
+	pooled         bool
 	browserHomeDir string
+	// terminationMessagePath is where the reason of the session end is written to
+	terminationMessagePath string
+	// browserProxy is the proxy new sessions are configured with
+	browserProxy *browserProxy
+	// upstreamProxy is the proxy browser traffic is forwarded to, including credentials
+	upstreamProxy *url.URL
 }
 
 func provideConfig() (*conf, error) {
@@ -62,15 +71,54 @@ func provideConfig() (*conf, error) {
 		return nil, errors.WithStack(err)
 	}
 
+	bProxy, uProxy, err := provideProxyConfig()
+	if err != nil {
+		return nil, err
+	}
+
 	return &conf{
-		proxyURL:       u,
-		recorderURL:    recURL,
-		idleTimeout:    iTimeout,
-		sessionTimeout: sTimeout,
-		browserHomeDir: browserHomeDir,
+		proxyURL:               u,
+		recorderURL:            recURL,
+		idleTimeout:            iTimeout,
+		sessionTimeout:         sTimeout,
+		pooled:                 os.Getenv("POOLED") == "true",
+		browserHomeDir:         browserHomeDir,
+		terminationMessagePath: browserkubeutil.FirstNonEmpty(os.Getenv("TERMINATION_MESSAGE_PATH"), "/dev/termination-log"),
+		browserProxy:           bProxy,
+		upstreamProxy:          uProxy,
 	}, nil
 }
 
+// provideProxyConfig reads the proxy browsers are configured with and the upstream proxy the traffic is forwarded to.
+// Both are nil unless set by the operator
+func provideProxyConfig() (*browserProxy, *url.URL, error) {
+	rawBrowserProxy := os.Getenv("BROWSER_PROXY")
+	if rawBrowserProxy == "" {
+		return nil, nil, nil
+	}
+	bURL, err := url.Parse(rawBrowserProxy)
+	if err != nil {
+		return nil, nil, errors.WithStack(err)
+	}
+	bProxy := &browserProxy{url: bURL, browserName: os.Getenv("BROWSER_NAME")}
+	if noProxy := os.Getenv("BROWSER_NO_PROXY"); noProxy != "" {
+		bProxy.noProxy = strings.Split(noProxy, ",")
+	}
+
+	rawUpstream := os.Getenv("UPSTREAM_PROXY")
+	if rawUpstream == "" {
+		return bProxy, nil, nil
+	}
+	uProxy, err := url.Parse(rawUpstream)
+	if err != nil {
+		return nil, nil, errors.WithStack(err)
+	}
+	if username := os.Getenv("UPSTREAM_PROXY_USERNAME"); username != "" {
+		uProxy.User = url.UserPassword(username, os.Getenv("UPSTREAM_PROXY_PASSWORD"))
+	}
+	return bProxy, uProxy, nil
+}
+
 func initHandlers(mux chi.Router, c *conf, proxy *wdProxy) {
 	// downloads server
 	mux.Handle("/downloads/*", http.HandlerFunc(func(w http.ResponseWriter, rq *http.Request) {
@@ -94,6 +142,7 @@ func initHandlers(mux chi.Router, c *conf, proxy *wdProxy) {
 		}).ServeHTTP(w, rq)
 	})
 
+	mux.Post("/claim", proxy.ClaimHandler)
 	mux.HandleFunc("/wd/hub/session", proxy.StartSessionHandler)
 	mux.HandleFunc("/wd/hub/session/*", proxy.ProxySessionHandler)
 	mux.HandleFunc("/wd/hub/bidi/{sessionID}", proxy.ProxyBidirectionalSession)
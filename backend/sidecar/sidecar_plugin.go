@@ -9,6 +9,7 @@ import (
 	"net/http"
 	"net/http/httputil"
 	"net/url"
+	"os"
 	"path"
 	"strconv"
 	"strings"
@@ -19,6 +20,7 @@ import (
 	"go.uber.org/fx"
 	"go.uber.org/zap"
 
+	browserkubev1 "github.com/browserkube/browserkube/operator/api/v1"
 	browserkubeutil "github.com/browserkube/browserkube/pkg/util"
 	"github.com/browserkube/browserkube/pkg/wd"
 	"github.com/browserkube/browserkube/pkg/wd/wdproto"
@@ -48,6 +50,11 @@ type wdProxy struct {
 	browserHomeDir string
 	logger         *zap.SugaredLogger
 	counter        atomic.Int32
+	// terminationMessagePath is the file kubelet reports as the termination message of the sidecar container
+	terminationMessagePath string
+	terminated             atomic.Bool
+	// browserProxy is added to capabilities of new sessions if the browser is configured with a proxy
+	browserProxy *browserProxy
 }
 
 func newWDProxy(c *conf, quit fx.Shutdowner) *wdProxy {
@@ -55,8 +62,19 @@ func newWDProxy(c *conf, quit fx.Shutdowner) *wdProxy {
 	sessionID := browserkubeutil.NewTypedAtomic[*session]()
 	bidiURL := browserkubeutil.NewTypedAtomic[*bidiConfig]()
 	proxyURL := c.proxyURL
-	timeoutCloseFunc := func() {
-		logger.Warn("Closing session due to timeout")
+	p := &wdProxy{
+		proxyURL:               proxyURL,
+		sessionID:              sessionID,
+		bidiURL:                bidiURL,
+		logger:                 logger,
+		browserHomeDir:         c.browserHomeDir,
+		counter:                atomic.Int32{},
+		terminationMessagePath: c.terminationMessagePath,
+		browserProxy:           c.browserProxy,
+	}
+	timeoutCloseFunc := func(reason browserkubev1.TerminationReason) {
+		logger.Warnf("Closing session due to timeout: %s", reason)
+		p.terminate(reason)
 		sessID := sessionID.Load()
 		if sessID == nil {
 			return
@@ -65,22 +83,57 @@ func newWDProxy(c *conf, quit fx.Shutdowner) *wdProxy {
 			logger.Errorf("unable to quit webdriver: %s", err.Error())
 		}
 	}
-	idleTimer := newIdleTimer(logger, quit, c.idleTimeout, timeoutCloseFunc)
-	sessionTimer := newIdleTimer(logger, quit, c.sessionTimeout, timeoutCloseFunc)
-	return &wdProxy{
-		idleTimer:      idleTimer,
-		sessionTimeout: sessionTimer,
-		proxyURL:       proxyURL,
-		sessionID:      sessionID,
-		bidiURL:        bidiURL,
-		logger:         logger,
-		browserHomeDir: c.browserHomeDir,
-		counter:        atomic.Int32{},
+	p.idleTimer = newIdleTimer(logger, quit, c.idleTimeout, browserkubev1.TerminationReasonIdleTimeout, timeoutCloseFunc)
+	p.sessionTimeout = newIdleTimer(logger, quit, c.sessionTimeout, browserkubev1.TerminationReasonSessionTimeout, timeoutCloseFunc)
+	if c.pooled {
+		// idle pooled browsers are kept by the pool, timeouts start with the session
+		p.idleTimer.Stop()
+		p.sessionTimeout.Stop()
+	}
+	return p
+}
+
+// startTimeouts (re)starts idle and session timeouts.
+// Pooled browsers are started long before their session, so timeouts count from the session start
+func (p *wdProxy) startTimeouts() {
+	p.idleTimer.Reset()
+	p.sessionTimeout.Reset()
+}
+
+// ClaimHandler starts timeouts of the pooled browser claimed by a session.
+// Playwright sessions connect to the browser directly and never start a session through the sidecar
+func (p *wdProxy) ClaimHandler(w http.ResponseWriter, _ *http.Request) {
+	p.startTimeouts()
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// terminate records why the session has ended as the termination message of the sidecar.
+// Only the first reason is recorded, e.g. idle timer firing after the client quit doesn't override it
+func (p *wdProxy) terminate(reason browserkubev1.TerminationReason) {
+	if !p.terminated.CompareAndSwap(false, true) || p.terminationMessagePath == "" {
+		return
+	}
+	if err := os.WriteFile(p.terminationMessagePath, []byte(reason), 0o644); err != nil {
+		p.logger.Errorf("unable to write termination message: %s", err.Error())
 	}
 }
 
 // StartSessionHandler starts a session
 func (p *wdProxy) StartSessionHandler(w http.ResponseWriter, rq *http.Request) {
+	p.startTimeouts()
+	if p.browserProxy != nil {
+		body, err := io.ReadAll(rq.Body)
+		if err == nil {
+			body, err = p.browserProxy.withProxy(body)
+		}
+		if err != nil {
+			wdproto.BadGatewayError(w, errors.Wrap(err, "unable to add proxy to capabilities"))
+			return
+		}
+		rq.Body = io.NopCloser(bytes.NewReader(body))
+		rq.ContentLength = int64(len(body))
+		rq.Header.Set("Content-Length", strconv.Itoa(len(body)))
+	}
 	(&httputil.ReverseProxy{
 		Rewrite: func(prq *httputil.ProxyRequest) {
 			if s := p.sessionID.Load(); s != nil && s.ID != "" {
@@ -175,6 +228,10 @@ func (p *wdProxy) ProxySessionHandler(w http.ResponseWriter, rq *http.Request) {
 			p.logger.With("session", p.sessionID.Load()).Infof("Proxying [%s] request to [%s]", prq.Out.Method, prq.Out.URL.String())
 		},
 		ModifyResponse: func(rs *http.Response) error {
+			if _, command, err := wd.ParseSessionPath(rq.URL.Path); err == nil && command == "" &&
+				rq.Method == http.MethodDelete && rs.StatusCode == http.StatusOK {
+				p.terminate(browserkubev1.TerminationReasonClientQuit)
+			}
 			newCommand := p.counter.Add(1)
 			rs.Header.Set("commandID", strconv.FormatInt(int64(newCommand), 10))
 			return nil
@@ -224,11 +281,17 @@ func (p *wdProxy) ProxyCDPSession(w http.ResponseWriter, rq *http.Request) {
 	proxy.ServeHTTP(w, rq)
 }
 
-func newIdleTimer(logger *zap.SugaredLogger, quit fx.Shutdowner, timeout time.Duration, callback func()) *idleTimer {
+func newIdleTimer(
+	logger *zap.SugaredLogger,
+	quit fx.Shutdowner,
+	timeout time.Duration,
+	reason browserkubev1.TerminationReason,
+	callback func(browserkubev1.TerminationReason),
+) *idleTimer {
 	return &idleTimer{
 		timeout: timeout,
 		timer: time.AfterFunc(timeout, func() {
-			callback()
+			callback(reason)
 			if err := quit.Shutdown(); err != nil {
 				logger.Errorf("shutdown error: %+v", err)
 			}
@@ -2,9 +2,20 @@ package main
 
 import (
 	"net/http"
+	"net/http/httptest"
 	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
 
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+
+	browserkubev1 "github.com/browserkube/browserkube/operator/api/v1"
+	browserkubeutil "github.com/browserkube/browserkube/pkg/util"
 	"github.com/browserkube/browserkube/pkg/wd/wdproto"
 )
 
@@ -66,6 +77,41 @@ func Test_replaceWebsocketUrl(t *testing.T) {
 	}
 }
 
+func Test_wdProxy_terminate(t *testing.T) {
+	driver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, rq *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer driver.Close()
+
+	terminationLog := filepath.Join(t.TempDir(), "termination-log")
+	p := &wdProxy{
+		idleTimer:              &idleTimer{timer: time.NewTimer(time.Hour), timeout: time.Hour},
+		sessionID:              browserkubeutil.NewTypedAtomic[*session](),
+		proxyURL:               must(url.Parse(driver.URL)),
+		logger:                 zap.S(),
+		terminationMessagePath: terminationLog,
+	}
+	p.sessionID.Set(&session{ID: "driver-session"})
+
+	// commands don't terminate the session
+	p.ProxySessionHandler(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/wd/hub/session/123/url", nil))
+	if _, err := os.Stat(terminationLog); !os.IsNotExist(err) {
+		t.Fatalf("termination message is written on command: %v", err)
+	}
+
+	p.ProxySessionHandler(httptest.NewRecorder(), httptest.NewRequest(http.MethodDelete, "/wd/hub/session/123", nil))
+	// the first reason wins
+	p.terminate(browserkubev1.TerminationReasonIdleTimeout)
+
+	got, err := os.ReadFile(terminationLog)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != string(browserkubev1.TerminationReasonClientQuit) {
+		t.Errorf("termination message = %s, want %s", got, browserkubev1.TerminationReasonClientQuit)
+	}
+}
+
 func must[T any](v T, err error) T {
 	if err != nil {
 		panic(err)
@@ -73,3 +119,68 @@ func must[T any](v T, err error) T {
 
 	return v
 }
+
+func Test_wdProxy_StartSessionHandler_resetsTimeouts(t *testing.T) {
+	driver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, rq *http.Request) {
+		_, _ = w.Write([]byte(`{"value":{"sessionId":"driver-session","capabilities":{}}}`))
+	}))
+	defer driver.Close()
+
+	// timers of a pooled browser which is about to time out
+	var fired atomic.Bool
+	expiring := func() *idleTimer {
+		return &idleTimer{timer: time.AfterFunc(50*time.Millisecond, func() { fired.Store(true) }), timeout: time.Hour}
+	}
+	p := &wdProxy{
+		idleTimer:      expiring(),
+		sessionTimeout: expiring(),
+		sessionID:      browserkubeutil.NewTypedAtomic[*session](),
+		bidiURL:        browserkubeutil.NewTypedAtomic[*bidiConfig](),
+		proxyURL:       must(url.Parse(driver.URL)),
+		logger:         zap.S(),
+	}
+	rs := httptest.NewRecorder()
+	p.StartSessionHandler(rs, httptest.NewRequest(http.MethodPost, "/wd/hub/session", strings.NewReader(`{}`)))
+	if rs.Code != http.StatusOK {
+		t.Fatalf("StartSessionHandler() status = %d", rs.Code)
+	}
+
+	time.Sleep(100 * time.Millisecond)
+	if fired.Load() {
+		t.Error("timeouts aren't reset when the session starts")
+	}
+}
+
+type testShutdowner struct {
+	down atomic.Bool
+}
+
+func (s *testShutdowner) Shutdown(...fx.ShutdownOption) error {
+	s.down.Store(true)
+	return nil
+}
+
+func Test_wdProxy_pooledTimeouts(t *testing.T) {
+	quit := &testShutdowner{}
+	p := newWDProxy(&conf{
+		proxyURL:       must(url.Parse("http://localhost:4444")),
+		idleTimeout:    50 * time.Millisecond,
+		sessionTimeout: time.Hour,
+		pooled:         true,
+	}, quit)
+
+	time.Sleep(150 * time.Millisecond)
+	if quit.down.Load() {
+		t.Fatal("idle pooled browser is shut down before it's claimed")
+	}
+
+	rs := httptest.NewRecorder()
+	p.ClaimHandler(rs, httptest.NewRequest(http.MethodPost, "/claim", http.NoBody))
+	if rs.Code != http.StatusNoContent {
+		t.Fatalf("ClaimHandler() status = %d", rs.Code)
+	}
+	time.Sleep(150 * time.Millisecond)
+	if !quit.down.Load() {
+		t.Error("idle timeout isn't started by the claim")
+	}
+}
@@ -0,0 +1,303 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+	"golang.org/x/net/proxy"
+)
+
+// browserProxy is the proxy browsers of the pod are configured with
+type browserProxy struct {
+	url         *url.URL
+	noProxy     []string
+	browserName string
+}
+
+// optionsKey returns vendor capability holding command line arguments of chromium based browsers.
+// Returns empty string for the browsers configured with W3C proxy capability
+func (p *browserProxy) optionsKey() string {
+	switch strings.ToLower(p.browserName) {
+	case "chrome", "chromium", "googlechrome":
+		return "goog:chromeOptions"
+	case "microsoftedge", "msedge", "edge":
+		return "ms:edgeOptions"
+	default:
+		return ""
+	}
+}
+
+// withProxy adds browser proxy settings to the new session request
+func (p *browserProxy) withProxy(body []byte) ([]byte, error) {
+	var payload map[string]any
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return nil, errors.WithStack(err)
+	}
+	caps, ok := payload["capabilities"].(map[string]any)
+	if !ok {
+		caps = map[string]any{}
+		payload["capabilities"] = caps
+	}
+	for _, c := range p.targets(caps) {
+		p.apply(c)
+	}
+	// legacy JSON wire protocol clients
+	if desired, ok := payload["desiredCapabilities"].(map[string]any); ok {
+		p.apply(desired)
+	}
+	return json.Marshal(payload)
+}
+
+// targets returns the capabilities the proxy settings are added to. Settings go to firstMatch entries
+// which already have the capability set by the client since alwaysMatch can't shadow them
+func (p *browserProxy) targets(caps map[string]any) []map[string]any {
+	key := p.optionsKey()
+	if key == "" {
+		key = "proxy"
+	}
+	var targets []map[string]any
+	firstMatch, _ := caps["firstMatch"].([]any)
+	for _, m := range firstMatch {
+		if entry, ok := m.(map[string]any); ok && entry[key] != nil {
+			targets = append(targets, entry)
+		}
+	}
+	if len(targets) > 0 {
+		return targets
+	}
+	alwaysMatch, ok := caps["alwaysMatch"].(map[string]any)
+	if !ok {
+		alwaysMatch = map[string]any{}
+		caps["alwaysMatch"] = alwaysMatch
+	}
+	return []map[string]any{alwaysMatch}
+}
+
+func (p *browserProxy) apply(caps map[string]any) {
+	if key := p.optionsKey(); key != "" {
+		options, ok := caps[key].(map[string]any)
+		if !ok {
+			options = map[string]any{}
+			caps[key] = options
+		}
+		args, _ := options["args"].([]any)
+		args = append(args, "--proxy-server="+p.url.String())
+		if len(p.noProxy) > 0 {
+			args = append(args, "--proxy-bypass-list="+strings.Join(p.noProxy, ";"))
+		}
+		options["args"] = args
+		return
+	}
+
+	settings := map[string]any{"proxyType": "manual"}
+	if p.url.Scheme == "socks5" {
+		settings["socksProxy"] = p.url.Host
+		settings["socksVersion"] = 5
+	} else {
+		settings["httpProxy"] = p.url.Host
+		settings["sslProxy"] = p.url.Host
+	}
+	if len(p.noProxy) > 0 {
+		settings["noProxy"] = p.noProxy
+	}
+	caps["proxy"] = settings
+}
+
+// proxyForwarder is a local proxy which forwards browser traffic to the upstream proxy.
+// It authenticates to the upstream proxy and talks TLS to it on behalf of browsers which can't do that themselves
+type proxyForwarder struct {
+	upstream  *url.URL
+	transport *http.Transport
+	logger    *zap.SugaredLogger
+}
+
+func newProxyForwarder(upstream *url.URL) *proxyForwarder {
+	return &proxyForwarder{
+		upstream: upstream,
+		// transport authenticates to http, https and socks5 proxies with credentials of the URL
+		transport: &http.Transport{Proxy: http.ProxyURL(upstream)},
+		logger:    zap.S().With("upstream", upstream.Redacted()),
+	}
+}
+
+func (f *proxyForwarder) ServeHTTP(w http.ResponseWriter, rq *http.Request) {
+	if rq.Method == http.MethodConnect {
+		f.tunnel(w, rq)
+		return
+	}
+	if !rq.URL.IsAbs() {
+		http.Error(w, "absolute URL is expected", http.StatusBadRequest)
+		return
+	}
+	(&httputil.ReverseProxy{
+		Rewrite: func(prq *httputil.ProxyRequest) {
+			prq.Out.Host = prq.In.Host
+		},
+		Transport: f.transport,
+	}).ServeHTTP(w, rq)
+}
+
+func (f *proxyForwarder) tunnel(w http.ResponseWriter, rq *http.Request) {
+	upstream, err := f.dial(rq.Context(), rq.Host)
+	if err != nil {
+		f.logger.Errorf("unable to connect to %s: %s", rq.Host, err.Error())
+		http.Error(w, err.Error(), http.StatusBadGateway)
+		return
+	}
+	defer upstream.Close()
+
+	hijacker, ok := w.(http.Hijacker)
+	if !ok {
+		http.Error(w, "connection can't be hijacked", http.StatusInternalServerError)
+		return
+	}
+	conn, buf, err := hijacker.Hijack()
+	if err != nil {
+		f.logger.Errorf("unable to hijack connection: %s", err.Error())
+		return
+	}
+	defer conn.Close()
+	if _, err = conn.Write([]byte("HTTP/1.1 200 Connection Established\r\n\r\n")); err != nil {
+		return
+	}
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		_, _ = io.Copy(upstream, buf)
+		closeWrite(upstream)
+	}()
+	go func() {
+		defer wg.Done()
+		_, _ = io.Copy(conn, upstream)
+		closeWrite(conn)
+	}()
+	wg.Wait()
+}
+
+// dial opens a tunnel to the address through the upstream proxy
+func (f *proxyForwarder) dial(ctx context.Context, addr string) (net.Conn, error) {
+	if f.upstream.Scheme == "socks5" {
+		dialer, err := proxy.FromURL(f.upstream, &net.Dialer{Timeout: 30 * time.Second})
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+		if ctxDialer, ok := dialer.(proxy.ContextDialer); ok {
+			return ctxDialer.DialContext(ctx, "tcp", addr)
+		}
+		return dialer.Dial("tcp", addr)
+	}
+
+	dialer := &net.Dialer{Timeout: 30 * time.Second}
+	conn, err := dialer.DialContext(ctx, "tcp", f.upstream.Host)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	if f.upstream.Scheme == "https" {
+		tlsConn := tls.Client(conn, &tls.Config{ServerName: f.upstream.Hostname(), MinVersion: tls.VersionTLS12})
+		if err = tlsConn.HandshakeContext(ctx); err != nil {
+			_ = conn.Close()
+			return nil, errors.WithStack(err)
+		}
+		conn = tlsConn
+	}
+
+	connectRQ := &http.Request{
+		Method: http.MethodConnect,
+		URL:    &url.URL{Opaque: addr},
+		Host:   addr,
+		Header: http.Header{},
+	}
+	if user := f.upstream.User; user != nil {
+		password, _ := user.Password()
+		credentials := base64.StdEncoding.EncodeToString([]byte(user.Username() + ":" + password))
+		connectRQ.Header.Set("Proxy-Authorization", "Basic "+credentials)
+	}
+	if err = connectRQ.Write(conn); err != nil {
+		_ = conn.Close()
+		return nil, errors.WithStack(err)
+	}
+	br := bufio.NewReader(conn)
+	rs, err := http.ReadResponse(br, connectRQ)
+	if err != nil {
+		_ = conn.Close()
+		return nil, errors.WithStack(err)
+	}
+	_ = rs.Body.Close()
+	if rs.StatusCode != http.StatusOK {
+		_ = conn.Close()
+		return nil, errors.Errorf("upstream proxy responded with %s", rs.Status)
+	}
+	if br.Buffered() > 0 {
+		return &bufferedConn{Conn: conn, r: br}, nil
+	}
+	return conn, nil
+}
+
+// bufferedConn reads the data buffered while reading CONNECT response first
+type bufferedConn struct {
+	net.Conn
+	r io.Reader
+}
+
+func (c *bufferedConn) Read(b []byte) (int, error) {
+	return c.r.Read(b)
+}
+
+func (c *bufferedConn) CloseWrite() error {
+	closeWrite(c.Conn)
+	return nil
+}
+
+func closeWrite(conn net.Conn) {
+	if cw, ok := conn.(interface{ CloseWrite() error }); ok {
+		_ = cw.CloseWrite()
+	}
+}
+
+// startProxyForwarder serves the forwarder on the address browsers are configured with
+func startProxyForwarder(lc fx.Lifecycle, c *conf) {
+	if c.upstreamProxy == nil || c.browserProxy == nil {
+		return
+	}
+	logger := zap.S()
+	server := &http.Server{
+		Addr:              c.browserProxy.url.Host,
+		ReadHeaderTimeout: 10 * time.Second,
+		Handler:           newProxyForwarder(c.upstreamProxy),
+	}
+	lc.Append(fx.Hook{
+		OnStart: func(ctx context.Context) error {
+			listener, err := net.Listen("tcp", server.Addr)
+			if err != nil {
+				return fmt.Errorf("unable to start proxy forwarder: %w", err)
+			}
+			logger.Infof("Forwarding browser traffic from %s to %s", server.Addr, c.upstreamProxy.Redacted())
+			go func() {
+				if err := server.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+					logger.Error(err)
+				}
+			}()
+			return nil
+		},
+		OnStop: func(ctx context.Context) error {
+			return server.Shutdown(ctx)
+		},
+	})
+}
@@ -0,0 +1,143 @@
+package main
+
+import (
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"sync"
+	"testing"
+)
+
+func Test_browserProxy_withProxy(t *testing.T) {
+	tests := []struct {
+		name  string
+		proxy *browserProxy
+		body  string
+		want  string
+	}{
+		{
+			name: "chrome args",
+			proxy: &browserProxy{url: must(url.Parse("http://127.0.0.1:3128")), browserName: "chrome",
+				noProxy: []string{"localhost", ".corp"}},
+			body: `{"capabilities":{"alwaysMatch":{"browserName":"chrome","goog:chromeOptions":{"args":["--headless"]}}}}`,
+			want: `{"capabilities":{"alwaysMatch":{"browserName":"chrome","goog:chromeOptions":{"args":["--headless",` +
+				`"--proxy-server=http://127.0.0.1:3128","--proxy-bypass-list=localhost;.corp"]}}}}`,
+		},
+		{
+			name:  "chrome options of first match",
+			proxy: &browserProxy{url: must(url.Parse("socks5://proxy.corp:1080")), browserName: "chrome"},
+			body:  `{"capabilities":{"firstMatch":[{"goog:chromeOptions":{}},{}]}}`,
+			want: `{"capabilities":{"firstMatch":[{"goog:chromeOptions":{"args":["--proxy-server=socks5://proxy.corp:1080"]}},{}]}}`,
+		},
+		{
+			name:  "firefox socks proxy capability",
+			proxy: &browserProxy{url: must(url.Parse("socks5://proxy.corp:1080")), browserName: "firefox", noProxy: []string{".corp"}},
+			body:  `{"capabilities":{"alwaysMatch":{"browserName":"firefox"}}}`,
+			want: `{"capabilities":{"alwaysMatch":{"browserName":"firefox","proxy":{"noProxy":[".corp"],` +
+				`"proxyType":"manual","socksProxy":"proxy.corp:1080","socksVersion":5}}}}`,
+		},
+		{
+			name:  "legacy desired capabilities",
+			proxy: &browserProxy{url: must(url.Parse("http://proxy.corp:3128")), browserName: "firefox"},
+			body:  `{"desiredCapabilities":{"browserName":"firefox"}}`,
+			want: `{"capabilities":{"alwaysMatch":{"proxy":{"httpProxy":"proxy.corp:3128","proxyType":"manual",` +
+				`"sslProxy":"proxy.corp:3128"}}},"desiredCapabilities":{"browserName":"firefox","proxy":{` +
+				`"httpProxy":"proxy.corp:3128","proxyType":"manual","sslProxy":"proxy.corp:3128"}}}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.proxy.withProxy([]byte(tt.body))
+			if err != nil {
+				t.Fatal(err)
+			}
+			var gotJSON, wantJSON any
+			_ = json.Unmarshal(got, &gotJSON)
+			_ = json.Unmarshal([]byte(tt.want), &wantJSON)
+			if !reflect.DeepEqual(gotJSON, wantJSON) {
+				t.Errorf("withProxy() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+// testUpstreamProxy is an HTTP proxy accepting requests of the user:secret only
+func testUpstreamProxy(t *testing.T) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, rq *http.Request) {
+		// user:secret
+		if rq.Header.Get("Proxy-Authorization") != "Basic dXNlcjpzZWNyZXQ=" {
+			w.WriteHeader(http.StatusProxyAuthRequired)
+			return
+		}
+		if rq.Method != http.MethodConnect {
+			_, _ = io.WriteString(w, "proxied "+rq.URL.String())
+			return
+		}
+		target, err := net.Dial("tcp", rq.Host)
+		if err != nil {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		defer target.Close()
+		conn, buf, err := w.(http.Hijacker).Hijack()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		if _, err = conn.Write([]byte("HTTP/1.1 200 Connection Established\r\n\r\n")); err != nil {
+			return
+		}
+		var wg sync.WaitGroup
+		wg.Add(2)
+		go func() { defer wg.Done(); _, _ = io.Copy(target, buf); closeWrite(target) }()
+		go func() { defer wg.Done(); _, _ = io.Copy(conn, target); closeWrite(conn) }()
+		wg.Wait()
+	}))
+}
+
+func Test_proxyForwarder(t *testing.T) {
+	upstream := testUpstreamProxy(t)
+	defer upstream.Close()
+	target := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, rq *http.Request) {
+		_, _ = io.WriteString(w, "tunneled")
+	}))
+	defer target.Close()
+
+	upstreamURL := must(url.Parse(upstream.URL))
+	upstreamURL.User = url.UserPassword("user", "secret")
+	forwarder := httptest.NewServer(newProxyForwarder(upstreamURL))
+	defer forwarder.Close()
+
+	transport := target.Client().Transport.(*http.Transport).Clone()
+	transport.Proxy = http.ProxyURL(must(url.Parse(forwarder.URL)))
+	client := &http.Client{Transport: transport}
+
+	for rqURL, want := range map[string]string{
+		"http://example.test/page": "proxied http://example.test/page",
+		target.URL:                 "tunneled",
+	} {
+		rs, err := client.Get(rqURL)
+		if err != nil {
+			t.Fatalf("GET %s: %v", rqURL, err)
+		}
+		body, _ := io.ReadAll(rs.Body)
+		_ = rs.Body.Close()
+		if string(body) != want {
+			t.Errorf("GET %s = %s, want %s", rqURL, body, want)
+		}
+	}
+
+	// wrong credentials
+	upstreamURL.User = url.UserPassword("user", "wrong")
+	rejecting := httptest.NewServer(newProxyForwarder(upstreamURL))
+	defer rejecting.Close()
+	transport.Proxy = http.ProxyURL(must(url.Parse(rejecting.URL)))
+	if _, err := client.Get(target.URL); err == nil {
+		t.Error("tunnel is opened with wrong credentials")
+	}
+}
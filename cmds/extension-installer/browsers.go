@@ -2,14 +2,24 @@ package extensioninstaller
 
 import (
 	"archive/zip"
+	"crypto/sha256"
+	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"io"
+	"log"
 	"net/http"
 	"os"
 	"path/filepath"
 )
 
+// headers of extension artifacts served by browserkube
+const (
+	headerArtifactDigest      = "Digest"
+	headerArtifactExtensionID = "X-Extension-Id"
+	headerArtifactVersion     = "X-Extension-Version"
+)
+
 const googleConnectionURL = `%s?response=redirect&os=linux&arch=x64&os_arch=x86_64` +
 	`&nacl_arch=x86-64&prod=chromium&prodchannel=unknown&prodversion=%s&lang=en-US` +
 	`&acceptformat=crx2,crx3&x=id%%3D%s%%26installsource%%3Dondemand%%26uc`
@@ -79,6 +89,84 @@ func installFirefoxExtension(extensionID, updateURL string) error {
 	return nil
 }
 
+// installArtifact installs the extension artifact uploaded to browserkube.
+// Artifact digest and extension ID are verified. Returns ID of the installed extension
+func installArtifact(browserName, extensionID, artifactURL string) (string, error) {
+	log.Printf("Installing artifact %s for %s", artifactURL, browserName)
+	content, artifactID, version, err := getArtifact(artifactURL)
+	if err != nil {
+		return "", err
+	}
+	if extensionID != "" && extensionID != artifactID {
+		return "", fmt.Errorf("artifact %s is extension %s, %s is expected", artifactURL, artifactID, extensionID)
+	}
+
+	switch browserName {
+	case browserNameChrome:
+		if err = os.MkdirAll(linuxChromePluginLoc, os.ModePerm); err != nil {
+			return "", fmt.Errorf("error while creating folder:%w", err)
+		}
+		filepath := fmt.Sprintf("/opt/extensions/%s.crx", artifactID)
+		if err = os.WriteFile(filepath, content, 0o644); err != nil {
+			return "", fmt.Errorf("error while writing to file: %w", err)
+		}
+		// installed from the local file instead of the store
+		b, err := json.Marshal(map[string]string{"external_crx": filepath, "external_version": version})
+		if err != nil {
+			return "", fmt.Errorf("error while marshalling json:%w", err)
+		}
+		if err = os.WriteFile(fmt.Sprintf("%s/%s.json", linuxChromePluginLoc, artifactID), b, 0o644); err != nil {
+			return "", fmt.Errorf("error while creating file: %w", err)
+		}
+		if err = unzip(filepath, fmt.Sprintf("%s/%s", linuxChromePluginLoc, artifactID)); err != nil {
+			return "", fmt.Errorf("error while unzipping file:%w", err)
+		}
+		if err = os.RemoveAll(fmt.Sprintf("%s/%s/_metadata", linuxChromePluginLoc, artifactID)); err != nil {
+			return "", fmt.Errorf("error while removing package _metadata: %w", err)
+		}
+	case browserNameFirefox:
+		if err = os.MkdirAll(fmt.Sprintf("%s/distribution/extensions", linuxFirefoxInstallationLoc), 0o777); err != nil {
+			return "", fmt.Errorf("error while creating folder: %w", err)
+		}
+		if err = os.WriteFile(fmt.Sprintf(linuxFirefoxPluginLoc+"/%s.xpi", artifactID), content, 0o644); err != nil {
+			return "", fmt.Errorf("error while writing to file: %w", err)
+		}
+		if err = os.WriteFile(fmt.Sprintf("%s/distribution/extensions/%s.xpi", linuxFirefoxInstallationLoc, artifactID), content, 0o644); err != nil {
+			return "", fmt.Errorf("error while writing to file: %w", err)
+		}
+	default:
+		return "", fmt.Errorf("browser name %s could not be recognized", browserName)
+	}
+	return artifactID, nil
+}
+
+// getArtifact downloads the artifact and verifies it against the digest provided by browserkube.
+// Returns the artifact, ID and version of the extension
+func getArtifact(artifactURL string) (content []byte, id, version string, err error) {
+	resp, err := http.Get(artifactURL)
+	if err != nil {
+		return nil, "", "", fmt.Errorf("error while sending request: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, "", "", fmt.Errorf("artifact %s can't be downloaded: %s", artifactURL, resp.Status)
+	}
+	content, err = io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, "", "", fmt.Errorf("error while reading artifact: %w", err)
+	}
+
+	digest := sha256.Sum256(content)
+	if want := resp.Header.Get(headerArtifactDigest); want != "sha-256="+base64.StdEncoding.EncodeToString(digest[:]) {
+		return nil, "", "", fmt.Errorf("artifact %s digest doesn't match %s", artifactURL, want)
+	}
+	id, version = resp.Header.Get(headerArtifactExtensionID), resp.Header.Get(headerArtifactVersion)
+	if id == "" || version == "" {
+		return nil, "", "", fmt.Errorf("artifact %s has no extension ID or version", artifactURL)
+	}
+	return content, id, version, nil
+}
+
 func getFile(outputLoc, url string) error {
 	resp, err := http.Get(url)
 	if err != nil {
@@ -0,0 +1,209 @@
+package extensioninstaller
+
+import (
+	"bytes"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"log"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+
+	"github.com/urfave/cli/v2"
+)
+
+const (
+	flagBundles      = "bundles"
+	flagOutput       = "output"
+	flagCertsPath    = "certsPath"
+	flagSystemBundle = "systemBundle"
+)
+
+// CA trust layout, mounted to the browser container by the operator
+const (
+	systemBundlePath     = "/etc/ssl/certs/ca-certificates.crt"
+	outputSystemBundle   = "ca-certificates.crt"
+	outputNSSDB          = "nssdb"
+	outputPolicies       = "policies"
+	outputCerts          = "certs"
+	firefoxPoliciesFile  = "policies.json"
+	nssCertTrustSettings = "C,,"
+)
+
+func NewCAInstallerApp() *cli.App {
+	return &cli.App{
+		Name:      "ca-installer",
+		Usage:     "made for using as initContainer installing custom CA certificates to browser trust stores",
+		Action:    InstallCACertificates,
+		Reader:    os.Stdin,
+		Writer:    os.Stdout,
+		ErrWriter: os.Stderr,
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:     flagBundles,
+				Usage:    "directory of PEM encoded CA bundles",
+				Required: true,
+			},
+			&cli.StringFlag{
+				Name:     flagOutput,
+				Usage:    "directory the trust stores are written to",
+				Required: true,
+			},
+			&cli.StringFlag{
+				Name:     flagCertsPath,
+				Usage:    "path the certificates directory is mounted to in the browser container",
+				Required: true,
+			},
+			&cli.StringFlag{
+				Name:  flagSystemBundle,
+				Usage: "system bundle extended with the custom CAs, the operator copies it from the browser image",
+				Value: systemBundlePath,
+			},
+		},
+	}
+}
+
+// InstallCACertificates writes the system bundle extended with the custom CAs,
+// NSS database used by Chrome and Firefox enterprise policy installing the custom CAs
+func InstallCACertificates(ctx *cli.Context) error {
+	output := ctx.String(flagOutput)
+	certs, err := readCertificates(ctx.String(flagBundles))
+	if err != nil {
+		return err
+	}
+	log.Printf("Installing %d CA certificates", len(certs))
+
+	for _, dir := range []string{outputCerts, outputPolicies, outputNSSDB} {
+		if err = os.MkdirAll(filepath.Join(output, dir), 0o777); err != nil {
+			return fmt.Errorf("error while creating folder: %w", err)
+		}
+	}
+
+	systemBundle, err := os.ReadFile(ctx.String(flagSystemBundle))
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("error while reading system bundle: %w", err)
+	}
+	bundle := bytes.NewBuffer(systemBundle)
+	certPaths := make([]string, 0, len(certs))
+	for i, cert := range certs {
+		encoded := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw})
+		bundle.WriteString("\n")
+		bundle.Write(encoded)
+
+		name := fmt.Sprintf("ca-%d.crt", i)
+		if err = os.WriteFile(filepath.Join(output, outputCerts, name), encoded, 0o644); err != nil {
+			return fmt.Errorf("error while writing certificate: %w", err)
+		}
+		certPaths = append(certPaths, filepath.Join(ctx.String(flagCertsPath), name))
+	}
+	if err = os.WriteFile(filepath.Join(output, outputSystemBundle), bundle.Bytes(), 0o644); err != nil {
+		return fmt.Errorf("error while writing system bundle: %w", err)
+	}
+
+	policies, err := json.Marshal(map[string]any{
+		"policies": map[string]any{
+			"Certificates": map[string]any{"Install": certPaths},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("error while marshalling json: %w", err)
+	}
+	if err = os.WriteFile(filepath.Join(output, outputPolicies, firefoxPoliciesFile), policies, 0o644); err != nil {
+		return fmt.Errorf("error while writing Firefox policies: %w", err)
+	}
+
+	return createNSSDB(filepath.Join(output, outputNSSDB), filepath.Join(output, outputCerts), len(certs))
+}
+
+// readCertificates reads CA certificates of all bundles in the directory
+func readCertificates(dir string) ([]*x509.Certificate, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("error while reading bundles: %w", err)
+	}
+	names := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		// projected volumes keep the files in hidden folders
+		if !entry.IsDir() && entry.Name()[0] != '.' {
+			names = append(names, entry.Name())
+		}
+	}
+	sort.Strings(names)
+
+	var certs []*x509.Certificate
+	for _, name := range names {
+		data, rErr := os.ReadFile(filepath.Join(dir, name))
+		if rErr != nil {
+			return nil, fmt.Errorf("error while reading bundle %s: %w", name, rErr)
+		}
+		bundleCerts, pErr := parseCertificates(data)
+		if pErr != nil {
+			return nil, fmt.Errorf("bundle %s is invalid: %w", name, pErr)
+		}
+		certs = append(certs, bundleCerts...)
+	}
+	if len(certs) == 0 {
+		return nil, fmt.Errorf("no CA certificates found in %s", dir)
+	}
+	return certs, nil
+}
+
+func parseCertificates(data []byte) ([]*x509.Certificate, error) {
+	var certs []*x509.Certificate
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		certs = append(certs, cert)
+	}
+	if len(certs) == 0 {
+		return nil, fmt.Errorf("no PEM encoded certificates")
+	}
+	return certs, nil
+}
+
+// createNSSDB creates NSS database trusting the certificates to issue server certificates.
+// Database is writable by anyone since browsers run as a user other than the installer
+func createNSSDB(dir, certsDir string, count int) error {
+	db := "sql:" + dir
+	if err := certutil("-N", "-d", db, "--empty-password"); err != nil {
+		return err
+	}
+	for i := 0; i < count; i++ {
+		name := fmt.Sprintf("ca-%d.crt", i)
+		if err := certutil("-A", "-d", db, "-n", "browserkube-"+name, "-t", nssCertTrustSettings,
+			"-i", filepath.Join(certsDir, name)); err != nil {
+			return err
+		}
+	}
+	files, err := os.ReadDir(dir)
+	if err != nil {
+		return fmt.Errorf("error while reading NSS database: %w", err)
+	}
+	for _, f := range files {
+		if err = os.Chmod(filepath.Join(dir, f.Name()), 0o666); err != nil {
+			return fmt.Errorf("error while changing NSS database permissions: %w", err)
+		}
+	}
+	return os.Chmod(dir, 0o777)
+}
+
+func certutil(args ...string) error {
+	out, err := exec.Command("certutil", args...).CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("certutil %v failed: %w: %s", args, err, out)
+	}
+	return nil
+}
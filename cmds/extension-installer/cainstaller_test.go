@@ -0,0 +1,105 @@
+package extensioninstaller
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func testCertificate(t *testing.T, name string) []byte {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: name},
+		NotBefore:             time.Now(),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+}
+
+func TestParseCertificates(t *testing.T) {
+	key := pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: []byte("key")})
+	tests := []struct {
+		name    string
+		data    []byte
+		want    []string
+		wantErr bool
+	}{
+		{name: "bundle", data: append(testCertificate(t, "root"), testCertificate(t, "intermediate")...), want: []string{"root", "intermediate"}},
+		{name: "other blocks are skipped", data: append(key, testCertificate(t, "root")...), want: []string{"root"}},
+		{name: "no certificates", data: key, wantErr: true},
+		{name: "not PEM", data: []byte("certificate"), wantErr: true},
+		{name: "invalid certificate", data: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: []byte("der")}), wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			certs, err := parseCertificates(tt.data)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseCertificates() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if len(certs) != len(tt.want) {
+				t.Fatalf("parseCertificates() = %d certificates, want %d", len(certs), len(tt.want))
+			}
+			for i, cert := range certs {
+				if cert.Subject.CommonName != tt.want[i] {
+					t.Errorf("certificate %d = %s, want %s", i, cert.Subject.CommonName, tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestReadCertificates(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string][]byte{
+		"bundle-1.crt": testCertificate(t, "staging"),
+		"bundle-0.crt": testCertificate(t, "corp"),
+		// projected volumes keep the files in hidden folders and link them
+		"..data": []byte("ignored"),
+	}
+	for name, data := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), data, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.Mkdir(filepath.Join(dir, "..2024_01_01"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	certs, err := readCertificates(dir)
+	if err != nil {
+		t.Fatalf("readCertificates() error = %v", err)
+	}
+	if len(certs) != 2 || certs[0].Subject.CommonName != "corp" || certs[1].Subject.CommonName != "staging" {
+		t.Errorf("readCertificates() = %v", certs)
+	}
+
+	if err = os.WriteFile(filepath.Join(dir, "bundle-2.crt"), []byte("invalid"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = readCertificates(dir); err == nil {
+		t.Error("readCertificates() accepts a bundle without certificates")
+	}
+
+	if _, err = readCertificates(t.TempDir()); err == nil {
+		t.Error("readCertificates() accepts a directory without bundles")
+	}
+}
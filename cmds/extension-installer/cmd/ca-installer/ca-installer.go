@@ -0,0 +1,15 @@
+package main
+
+import (
+	"log"
+	"os"
+
+	extensioninstaller "github.com/browserkube/browserkube/extension-installer"
+)
+
+func main() {
+	app := extensioninstaller.NewCAInstallerApp()
+	if err := app.Run(os.Args); err != nil {
+		log.Fatal(err)
+	}
+}
@@ -4,7 +4,6 @@ import (
 	"fmt"
 	"log"
 	"os"
-	"strings"
 
 	"github.com/urfave/cli/v2"
 )
@@ -13,6 +12,7 @@ const (
 	flagExtensionID = "extensionId"
 	flagUpdateURL   = "updateUrl"
 	flagBrowserName = "browserName"
+	flagArtifactURL = "artifactUrl"
 )
 
 // browser plugin constants
@@ -32,11 +32,6 @@ const (
 	browserNameFirefox   = "firefox"
 )
 
-const (
-	whitelistChromeEnv  = "BROWSERKUBE-BROWSER-EXTENSION-CONFIG-WHITELIST-CHROME"
-	whitelistFirefoxEnv = "BROWSERKUBE-BROWSER-EXTENSION-CONFIG-WHITELIST-FIREFOX"
-)
-
 func NewApp() *cli.App {
 	return &cli.App{
 		Name:      "extension-installer",
@@ -64,6 +59,11 @@ func NewApp() *cli.App {
 				Usage:    "update url for firefox browser",
 				Required: true,
 			},
+			&cli.StringSliceFlag{
+				Name:    flagArtifactURL,
+				Aliases: []string{"a"},
+				Usage:   "url of the extension artifact uploaded to browserkube, public stores are used if empty",
+			},
 		},
 	}
 }
@@ -72,27 +72,35 @@ func PreInstall(ctx *cli.Context) error {
 	extensionID := ctx.StringSlice(flagExtensionID)
 	updateUrl := ctx.StringSlice(flagUpdateURL)
 	browserName := ctx.StringSlice(flagBrowserName)
-	log.Printf("Current Config : %s, %s, %s", extensionID, updateUrl, browserName)
+	artifactURL := ctx.StringSlice(flagArtifactURL)
+	log.Printf("Current Config : %s, %s, %s, %s", extensionID, updateUrl, browserName, artifactURL)
 
+	if len(artifactURL) == 0 {
+		artifactURL = make([]string, len(extensionID))
+	}
 	if len(updateUrl) != len(browserName) ||
-		len(updateUrl) != len(extensionID) {
+		len(updateUrl) != len(extensionID) ||
+		len(artifactURL) != len(extensionID) {
 		return fmt.Errorf("invalid format")
 	}
 	if err := os.MkdirAll("/opt/extensions", os.ModePerm); err != nil {
 		log.Printf("error while creating extensions folder:%s", err.Error())
 		return nil
 	}
-	whiteList := getWhitelist()
 	for i := range extensionID {
+		if artifactURL[i] != "" {
+			id, err := installArtifact(browserName[i], extensionID[i], artifactURL[i])
+			if err != nil {
+				return err
+			}
+			extensionID[i] = id
+			continue
+		}
 		switch browserName[i] {
 		case browserNameChrome:
 			if extensionID[i] == "" {
 				return fmt.Errorf("cannot install plugin on chrome. extensionId is missing")
 			}
-			if !checkSlice(whiteList[browserNameChrome], extensionID[i]) {
-				log.Printf("WARNING: Extension with ID :%s is not included in whitelist", extensionID[i])
-				continue
-			}
 			err := installChromeExtension(extensionID[i])
 			if err != nil {
 				log.Println(err.Error())
@@ -104,10 +112,6 @@ func PreInstall(ctx *cli.Context) error {
 			if updateUrl[i] == "" {
 				return fmt.Errorf("cannot install plugin on firefox. updateUrl is missing")
 			}
-			if !checkSlice(whiteList[browserNameFirefox], extensionID[i]) {
-				log.Printf("WARNING: Extension with ID :%s is not included in whitelist", extensionID[i])
-				continue
-			}
 			err := installFirefoxExtension(extensionID[i], updateUrl[i])
 			if err != nil {
 				log.Println(err.Error())
@@ -147,10 +151,3 @@ func checkFolders(extensionID, browserName []string) error {
 	}
 	return nil
 }
-
-func getWhitelist() map[string][]string {
-	whitelist := make(map[string][]string)
-	whitelist[browserNameChrome] = strings.Split(os.Getenv(whitelistChromeEnv), ",")
-	whitelist[browserNameFirefox] = strings.Split(os.Getenv(whitelistFirefoxEnv), ",")
-	return whitelist
-}
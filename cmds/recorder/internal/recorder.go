@@ -13,6 +13,8 @@ const (
 	flagFrameRate  = "frame-rate"
 	flagDisplayNum = "display-num"
 	flagCodec      = "codec"
+	flagCRF        = "crf"
+	flagFormat     = "format"
 	flagFilePath   = "file-path"
 )
 
@@ -49,6 +51,15 @@ func NewApp() *cli.App {
 				Value:   "libx264",
 				Usage:   "video encoder codec",
 			},
+			&cli.StringFlag{
+				Name:  flagCRF,
+				Usage: "constant rate factor of the encoder, encoder default is used if empty",
+			},
+			&cli.StringFlag{
+				Name:  flagFormat,
+				Value: defaultFormat,
+				Usage: "video container format: mp4 or webm",
+			},
 			&cli.StringFlag{
 				Name:    flagFilePath,
 				Aliases: []string{"f"},
@@ -9,6 +9,7 @@ import (
 	"os"
 	"os/exec"
 	"path"
+	"strings"
 	"time"
 
 	"github.com/urfave/cli/v2"
@@ -27,6 +28,8 @@ done
 `
 	ffmpegCmd = "ffmpeg"
 
+	defaultFormat = "mp4"
+
 	noDisplayFound = "exit status 2"
 )
 
@@ -35,6 +38,7 @@ type Config struct {
 	FrameRate         string
 	DisplayNum        string
 	Codec             string
+	CRF               string
 	FileName          string
 	SaveVideoEndpoint string
 	SessionID         string
@@ -42,27 +46,41 @@ type Config struct {
 }
 
 func getConfig(ctx *cli.Context) (*Config, error) {
+	format := ctx.String(flagFormat)
+	if format == "" {
+		format = defaultFormat
+	}
 	return &Config{
 		VideoSize:  ctx.String(flagVideoSize),
 		FrameRate:  ctx.String(flagFrameRate),
 		DisplayNum: ctx.String(flagDisplayNum),
 		Codec:      ctx.String(flagCodec),
-		FileName:   "video.mp4",
+		CRF:        ctx.String(flagCRF),
+		FileName:   "video." + format,
 		FilePath:   ctx.String(flagFilePath),
 	}, nil
 }
 
 func buildArgs(cfg *Config) []string {
-	return []string{
+	args := []string{
 		"-y", "-nostdin", "-f", "x11grab", "-draw_mouse", "0",
 		"-video_size", cfg.VideoSize,
 		"-r", cfg.FrameRate,
 		"-i", ":" + cfg.DisplayNum + ".0",
 		"-codec:v", cfg.Codec,
+	}
+	if cfg.CRF != "" {
+		args = append(args, "-crf", cfg.CRF)
+		if strings.HasPrefix(cfg.Codec, "libvpx") {
+			// vpx encoders need zero bitrate for constant quality mode
+			args = append(args, "-b:v", "0")
+		}
+	}
+	return append(args,
 		"-pix_fmt", "yuv420p",
 		"-vf", "pad=ceil(iw/2)*2:ceil(ih/2)*2",
 		path.Join(cfg.FilePath, cfg.FileName),
-	}
+	)
 }
 
 func shellSync(command string, args ...string) (string, string, error) {
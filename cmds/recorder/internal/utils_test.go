@@ -28,6 +28,8 @@ func prepareTestCtx(t *testing.T) *cli.Context {
 	set.String(flagFrameRate, frameRate, "")
 	set.String(flagDisplayNum, displayNum, "")
 	set.String(flagCodec, codec, "")
+	set.String(flagCRF, "", "")
+	set.String(flagFormat, defaultFormat, "")
 	set.String(flagFilePath, filePath, "")
 
 	return cli.NewContext(nil, set, nil)
@@ -45,6 +47,23 @@ func Test_SetConfig(t *testing.T) {
 	assert.Equal(t, expected, args)
 }
 
+func Test_SetConfigWebm(t *testing.T) {
+	ctx := prepareTestCtx(t)
+	assert.NoError(t, ctx.Set(flagCodec, "libvpx-vp9"))
+	assert.NoError(t, ctx.Set(flagCRF, "30"))
+	assert.NoError(t, ctx.Set(flagFormat, "webm"))
+	cfg, err := getConfig(ctx)
+	assert.NoError(t, err)
+	args := buildArgs(cfg)
+
+	expected := []string{
+		"-y", "-nostdin", "-f", "x11grab", "-draw_mouse", "0", "-video_size", videoSize,
+		"-r", frameRate, "-i", fmt.Sprintf(":%s.0", displayNum), "-codec:v", "libvpx-vp9", "-crf", "30", "-b:v", "0",
+		"-pix_fmt", "yuv420p", "-vf", "pad=ceil(iw/2)*2:ceil(ih/2)*2", "/home/videos/video.webm",
+	}
+	assert.Equal(t, expected, args)
+}
+
 func Test_shellAsync(t *testing.T) {
 	cmd, err := shellAsync("go", "version")
 	assert.NoError(t, err)
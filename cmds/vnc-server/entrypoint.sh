@@ -3,7 +3,7 @@
 PORT=${PORT:-"5900"}
 DISPLAY=${DISPLAY:-":0"}
 DISPLAY_ARG=${DISPLAY_ARG:-"WAIT:127.0.0.1:0"}
-VNCPASS=${VNCPASS:-"browserkube"}
+VNCPASS=${VNCPASS:?"VNCPASS has to be provided"}
 x11vnc \
   -xkb \
   -xrandr \
@@ -26,12 +26,14 @@ type Storage interface {
 	Exists(ctx context.Context, sessionID, filename string) (bool, error)
 
 	SizeUsed() (int64, error)
+	SessionSize(ctx context.Context, sessionID string) (int64, error)
 
 	Close() error
 }
 
 type BlobSessionStorage Storage
 type BlobSessionArchiveStorage Storage
+type BlobExtensionStorage Storage
 
 type blobStorage struct {
 	bucket *blob.Bucket
@@ -189,6 +191,25 @@ func (s *blobStorage) SizeUsed() (int64, error) {
 	return sizeTotal, err
 }
 
+// SessionSize returns total size of files stored for the given sessionID
+func (s *blobStorage) SessionSize(ctx context.Context, sessionID string) (int64, error) {
+	iter := s.bucket.List(&blob.ListOptions{Prefix: sessionID + "/"})
+	var result int64
+	for {
+		obj, err := iter.Next(ctx)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return 0, errors.WithStack(err)
+		}
+
+		result += obj.Size
+	}
+
+	return result, nil
+}
+
 // Close releases any used resources.
 func (s *blobStorage) Close() error {
 	return errors.WithStack(s.bucket.Close())
@@ -15,6 +15,12 @@ export const lang = {
   },
   sessionDetails: {
     noLogs: 'There are no logs saved',
+    terminationReasons: {
+      IdleTimeout: 'Idle timeout',
+      SessionTimeout: 'Max session duration exceeded',
+      ClientQuit: 'Closed by client',
+      BrowserCrash: 'Browser crashed',
+    } as Record<string, string>,
   },
   liveSession: {
     startTime: '00:00:00',
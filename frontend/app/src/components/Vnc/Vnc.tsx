@@ -1,8 +1,5 @@
-import { useRef, type ElementRef, useMemo } from 'react';
+import { useRef, type ElementRef } from 'react';
 import { VncScreen } from 'react-vnc';
-import { useSelector } from 'react-redux';
-import { getActiveSessionId } from '@redux/sessionDetails/selectors';
-import { getSessions } from '@redux/sessions/sessionsSelectors';
 import styles from './Vnc.module.scss';
 
 export interface VncProps {
@@ -12,18 +9,9 @@ export interface VncProps {
 }
 
 export const Vnc = ({ vncUrl, locked, isExpand }: VncProps) => {
-  const activeSessionId = useSelector(getActiveSessionId);
-  const sessions = useSelector(getSessions);
   const vncScreenRef = useRef<ElementRef<typeof VncScreen>>(null);
   const isValidUrl = () => vncUrl.startsWith('ws://') || vncUrl.startsWith('wss://');
 
-  const vncPsw = useMemo(() => {
-    if (!activeSessionId) {
-      return '';
-    }
-    return sessions.byId[activeSessionId].vncPsw;
-  }, [activeSessionId]);
-
   return (
     <div className={isExpand ? styles.vnc_extended : styles.vnc}>
       {isValidUrl() ? (
@@ -31,9 +19,6 @@ export const Vnc = ({ vncUrl, locked, isExpand }: VncProps) => {
           viewOnly={locked}
           rfbOptions={{
             shared: false,
-            credentials: {
-              password: vncPsw ?? '',
-            },
           }}
           url={vncUrl}
           scaleViewport
@@ -21,6 +21,7 @@ const getRequestConfig = (params?: AxiosRequestConfig['params']): AxiosRequestCo
   return {
     headers: {
       'Content-Type': 'application/json',
+      'X-Requested-With': 'XMLHttpRequest',
     },
     timeout: hasTimeoutInParams ? params.timeout : Number(process.env.REACT_APP_REQUEST_CONFIG_TIMEOUT),
     params,
@@ -36,7 +37,17 @@ const getErrorMessage = (error: AxiosError, url: string) => {
   return `[${errorRequest}${errorCodeTemplate}]${errorMessageTemplate}`;
 };
 
+// the backend responds 401 while the user isn't logged in with the single sign-on
+const redirectToLogin = () => {
+  const redirect = `${window.location.pathname}${window.location.search}`;
+  window.location.assign(getRequestUrl(`/auth/login?redirect=${encodeURIComponent(redirect)}`));
+};
+
 const errorHandler = (error: AxiosError, url: string) => {
+  if (error.response?.status === 401) {
+    redirectToLogin();
+    return;
+  }
   toast.error(getErrorMessage(error, url));
   if (process.env.NODE_ENV === 'development') {
     console.error(error);
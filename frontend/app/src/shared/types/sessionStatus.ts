@@ -5,8 +5,15 @@ export interface SessionStats {
   queued?: number;
 }
 
+export interface QueuedSession {
+  id: string;
+  position: number;
+  queuedAt: string;
+}
+
 export interface SessionStatus {
   quotesLimit: number;
   maxTimeout: number;
   stats: SessionStats;
+  queue?: QueuedSession[];
 }
@@ -8,12 +8,12 @@ export interface Session {
   browserVersion: string;
   manual: boolean;
   vncOn: boolean;
-  vncPsw: string;
   logsOn: boolean;
   createdAt: number;
   platformName: string;
   screenResolution: string;
   sessionType: string;
+  terminationReason?: string;
 }
 
 export interface Sessions {
@@ -1,4 +1,5 @@
 import styles from '@app/styles/details.module.scss';
+import { lang } from '@app/constants';
 import { getStringFormated } from '@shared/utils/getStringFormated';
 
 import { type Session } from '@shared/types/sessions';
@@ -14,7 +15,7 @@ const titleStyle = {
 } as const;
 
 export const DetailsInfo = ({ session }: { session: Session }) => {
-  const { name, platformName, browser, browserVersion, type, createdAt, manual } = session;
+  const { name, platformName, browser, browserVersion, type, createdAt, manual, terminationReason } = session;
 
   return (
     <div style={detailsTextStyle}>
@@ -50,6 +51,12 @@ export const DetailsInfo = ({ session }: { session: Session }) => {
         <div style={titleStyle}>Duration at:</div>
         <div>no info yet</div>
       </div>
+      {terminationReason && (
+        <div className={styles.detail_line}>
+          <div style={titleStyle}>Termination reason:</div>
+          <div>{lang.sessionDetails.terminationReasons[terminationReason] ?? terminationReason}</div>
+        </div>
+      )}
     </div>
   );
 };
@@ -50,3 +50,161 @@ Create the name of the service account to use
     {{ default "default" .Values.rbac.serviceAccount.serviceAccountName }}
 {{- end -}}
 {{- end -}}
+
+
+{{/*
+Rules of the operator manager role, granted in the operator namespace and in namespaces of tenants
+*/}}
+{{- define "browserkube.operatorManagerRules" -}}
+- apiGroups:
+    - api.browserkube.io
+  resources:
+    - browsers
+  verbs:
+    - create
+    - delete
+    - get
+    - list
+    - patch
+    - update
+    - watch
+- apiGroups:
+    - api.browserkube.io
+  resources:
+    - browsers/finalizers
+  verbs:
+    - update
+- apiGroups:
+    - api.browserkube.io
+  resources:
+    - browsers/status
+  verbs:
+    - get
+    - patch
+    - update
+- apiGroups:
+    - api.browserkube.io
+  resources:
+    - browsersets
+  verbs:
+    - get
+    - list
+    - watch
+- apiGroups:
+    - api.browserkube.io
+  resources:
+    - extensionpolicies
+  verbs:
+    - get
+    - list
+    - watch
+- apiGroups:
+    - api.browserkube.io
+  resources:
+    - browserpools
+  verbs:
+    - get
+    - list
+    - watch
+- apiGroups:
+    - api.browserkube.io
+  resources:
+    - browserpools/finalizers
+  verbs:
+    - update
+- apiGroups:
+    - api.browserkube.io
+  resources:
+    - browserpools/status
+  verbs:
+    - get
+    - patch
+    - update
+- apiGroups:
+    - api.browserkube.io
+  resources:
+    - sessionresults
+  verbs:
+    - create
+    - delete
+    - get
+    - list
+    - patch
+    - update
+    - watch
+- apiGroups:
+    - ""
+  resources:
+    - pods
+  verbs:
+    - create
+    - delete
+    - get
+    - list
+    - patch
+    - update
+    - watch
+- apiGroups:
+    - ""
+  resources:
+    - pods/status
+  verbs:
+    - get
+- apiGroups:
+    - ""
+  resources:
+    - events
+  verbs:
+    - create
+    - patch
+- apiGroups:
+    - ""
+  resources:
+    - secrets
+  verbs:
+    - create
+    - get
+    - list
+    - watch
+- apiGroups:
+    - networking.k8s.io
+  resources:
+    - networkpolicies
+  verbs:
+    - create
+    - get
+    - list
+    - watch
+{{- end -}}
+
+{{/*
+Rules of the backend role, granted in the browsers namespace and in namespaces of tenants
+*/}}
+{{- define "browserkube.backendRules" -}}
+- apiGroups: [""]
+  resources: [ "pods","pods/log","resourcequotas", "secrets", "configmaps" ]
+  verbs: [ "get", "list", "watch" ]
+- apiGroups:
+    - "api.browserkube.io"
+  resources: ["browsers", "browsersets"]
+  verbs: [ "get", "list", "watch", "create", "update", "patch", "delete", "deletecollection" ]
+- apiGroups:
+    - "api.browserkube.io"
+  resources: [ "extensionpolicies" ]
+  verbs: [ "get", "list", "watch" ]
+- apiGroups:
+    - "api.browserkube.io"
+  resources: [ "sessionresults"]
+  verbs: [ "get", "list", "watch", "create", "update", "patch", "delete", "deletecollection"  ]
+{{- end -}}
+
+{{/*
+Namespaces browsers run in: the browsers namespace and namespaces of tenants
+*/}}
+{{- define "browserkube.browserNamespaces" -}}
+{{- $namespaces := list .Values.browsers.namespace -}}
+{{- range .Values.tenants }}
+{{- $namespaces = append $namespaces .namespace -}}
+{{- end -}}
+{{- $namespaces | uniq | join " " -}}
+{{- end -}}
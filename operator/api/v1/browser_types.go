@@ -41,16 +41,89 @@ type BrowserSpec struct {
 	ScreenResolution string             `json:"screenResolution,omitempty"`
 	Extensions       []BrowserExtension `json:"extensions,omitempty"`
 
+	// BrowserSet restricts configuration lookup to the BrowserSet with the given name
+	// +optional
+	BrowserSet string `json:"browserSet,omitempty"`
+	// BrowserSetSelector restricts configuration lookup to the BrowserSets matching the label selector
+	// +optional
+	BrowserSetSelector string `json:"browserSetSelector,omitempty"`
+	// SizeProfile selects one of BrowserSet's size profiles
+	// +optional
+	SizeProfile string `json:"sizeProfile,omitempty"`
+	// Timeouts requested by the session. They are bound by BrowserSet maxTimeouts
+	// +optional
+	Timeouts *BrowserTimeouts `json:"timeouts,omitempty"`
+	// Network narrows network egress allowed by the BrowserSet network policy
+	// +optional
+	Network *BrowserNetwork `json:"network,omitempty"`
+	// Recording overrides video recording options configured by the BrowserSet
+	// +optional
+	Recording *BrowserRecording `json:"recording,omitempty"`
+	// Proxy overrides the upstream proxy configured by the BrowserSet unless the set forces its proxy
+	// +optional
+	Proxy *BrowserProxy `json:"proxy,omitempty"`
+
 	// +optional
 	Caps []byte `json:"caps,omitempty"`
 }
 
+// BrowserNetwork is the network egress requested by the session
+type BrowserNetwork struct {
+	// AllowedCIDRs the browser may connect to. They must be within the ones allowed by the BrowserSet
+	// +optional
+	AllowedCIDRs []string `json:"allowedCIDRs,omitempty"`
+	// AllowedDomains the browser may connect to. They must be allowed by the BrowserSet
+	// +optional
+	AllowedDomains []string `json:"allowedDomains,omitempty"`
+}
+
+// BrowserRecording configures video recording of the browser screen
+type BrowserRecording struct {
+	// VideoSize is the size of the recorded area, e.g. 1280x720. Defaults to the screen resolution
+	// +optional
+	VideoSize string `json:"videoSize,omitempty"`
+	// FrameRate of the video
+	// +optional
+	FrameRate int32 `json:"frameRate,omitempty"`
+	// Codec is the video encoder, e.g. libx264 for mp4 or libvpx-vp9 for webm. Defaults to the first codec of the format
+	// +optional
+	Codec string `json:"codec,omitempty"`
+	// CRF is the constant rate factor of the encoder, lower values mean better quality and bigger files
+	// +optional
+	CRF *int32 `json:"crf,omitempty"`
+	// Format is the container format of the video: mp4 or webm
+	// +optional
+	Format string `json:"format,omitempty"`
+}
+
+// BrowserProxy is the upstream proxy browser traffic is sent through
+type BrowserProxy struct {
+	// URL of the proxy, e.g. http://proxy.corp:3128, https://proxy.corp:3129 or socks5://proxy.corp:1080.
+	// Credentials can't be part of the URL, use CredentialsSecret instead
+	URL string `json:"url"`
+	// NoProxy are the hosts, domain suffixes (e.g. .corp) and CIDRs reached directly
+	// +optional
+	NoProxy []string `json:"noProxy,omitempty"`
+	// CredentialsSecret is the name of the Secret in the browser namespace
+	// holding proxy credentials under ProxyUsernameKey and ProxyPasswordKey
+	// +optional
+	CredentialsSecret string `json:"credentialsSecret,omitempty"`
+}
+
 type BrowserExtension struct {
 	ExtensionID string `json:"extensionId,omitempty"`
 	UpdateURL   string `json:"updateUrl,omitempty"`
-	Version     string `json:"version,omitempty"`
+	// Version of the artifact, the latest uploaded version is installed by default
+	Version string `json:"version,omitempty"`
+	// Artifact is the name of the extension uploaded to browserkube. Artifacts are installed from browserkube
+	// instead of the public stores. ExtensionID is verified against the artifact if set
+	// +optional
+	Artifact string `json:"artifact,omitempty"`
 }
 
+// ExtensionArtifactLatest is the version of the latest uploaded extension artifact
+const ExtensionArtifactLatest = "latest"
+
 const (
 	TypeWebDriver  = "WEBDRIVER"
 	TypePlaywright = "PLAYWRIGHT"
@@ -68,7 +141,39 @@ type BrowserStatus struct {
 	SeleniumURL string     `json:"seleniumURL,omitempty"`
 	PortConfig  PortConfig `json:"portConfig,omitempty"`
 	Image       string     `json:"image,omitempty"`
-	VncPass     string     `json:"vncPass,omitempty"`
+	// VNCSecret is the name of the Secret holding the VNC password under VNCSecretKey
+	// +optional
+	VNCSecret string `json:"vncSecret,omitempty"`
+	// NetworkPolicy is the name of the NetworkPolicy restricting network egress of the browser
+	// +optional
+	NetworkPolicy string `json:"networkPolicy,omitempty"`
+	// BrowserSet the browser configuration is taken from
+	BrowserSet string `json:"browserSet,omitempty"`
+	// Timeouts effective for the browser
+	// +optional
+	Timeouts *BrowserTimeouts `json:"timeouts,omitempty"`
+	// Recording options effective for the browser. Set only if video is recorded
+	// +optional
+	Recording *BrowserRecording `json:"recording,omitempty"`
+	// Proxy effective for the browser
+	// +optional
+	Proxy *BrowserProxy `json:"proxy,omitempty"`
+	// StartupDeadline is the time the browser is deleted at unless it is running
+	// +optional
+	StartupDeadline *metav1.Time `json:"startupDeadline,omitempty"`
+	// SessionDeadline is the time the session is closed at regardless of its activity
+	// +optional
+	SessionDeadline *metav1.Time `json:"sessionDeadline,omitempty"`
+	// TerminationReason tells why the session of the terminated browser has ended
+	// +optional
+	TerminationReason TerminationReason `json:"terminationReason,omitempty"`
+	// Conditions describe browser pod lifecycle in detail
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	// +patchStrategy=merge
+	// +patchMergeKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type"`
 }
 type Reason string
 
@@ -77,9 +182,72 @@ const (
 	ReasonPlatformNotSupported = "Platform isn't supported"
 	ReasonConfigNotFound       = "Browser config isn't found"
 	ReasonUnknownSessionType   = "Session type unknown"
+	ReasonSizeProfileNotFound  = "Size profile isn't found"
+	ReasonTimeoutNotAllowed    = "Timeout isn't allowed"
+	ReasonInvalidPodOverlay    = "Pod template overlay is invalid"
+	ReasonNetworkNotAllowed    = "Network access isn't allowed"
+	ReasonInvalidRecording     = "Recording options are invalid"
+	ReasonProxyNotAllowed      = "Proxy isn't allowed"
+	ReasonInvalidCABundle      = "CA bundle is invalid"
+	ReasonInvalidExtension     = "Extension is invalid"
+	ReasonExtensionNotAllowed  = "Extension isn't allowed"
 	ReasonUnknown              = "Unknown"
 )
 
+// browser condition types
+const (
+	// ConditionScheduled is true once the browser pod is bound to a node
+	ConditionScheduled = "Scheduled"
+	// ConditionImagePulled is true once images of all containers are pulled
+	ConditionImagePulled = "ImagePulled"
+	// ConditionContainersReady is true once all containers of the browser pod are ready
+	ConditionContainersReady = "ContainersReady"
+	// ConditionSessionActive is true while the browser serves a session
+	ConditionSessionActive = "SessionActive"
+	// ConditionTerminated is true once the browser is shutting down
+	ConditionTerminated = "Terminated"
+)
+
+// browser condition reasons. Reasons reported by kubelet (e.g. ImagePullBackOff) are kept as is
+const (
+	ConditionReasonPodCreated         = "PodCreated"
+	ConditionReasonPodScheduled       = "PodScheduled"
+	ConditionReasonImagesPulled       = "ImagesPulled"
+	ConditionReasonPullingImages      = "PullingImages"
+	ConditionReasonContainersReady    = "ContainersReady"
+	ConditionReasonContainersNotReady = "ContainersNotReady"
+	ConditionReasonSessionStarted     = "SessionStarted"
+	ConditionReasonWaitingForSession  = "WaitingForSession"
+	ConditionReasonSessionFinished    = "SessionFinished"
+	ConditionReasonStartTimeout       = "StartTimeout"
+	ConditionReasonSessionTimeout     = "SessionTimeout"
+	ConditionReasonDeleted            = "Deleted"
+)
+
+// TerminationReason tells why the browser session has ended
+// +kubebuilder:validation:Enum=IdleTimeout;SessionTimeout;ClientQuit;BrowserCrash
+type TerminationReason string
+
+const (
+	// TerminationReasonIdleTimeout means the session has received no commands within the idle timeout
+	TerminationReasonIdleTimeout TerminationReason = "IdleTimeout"
+	// TerminationReasonSessionTimeout means the session has exceeded its max duration
+	TerminationReasonSessionTimeout TerminationReason = "SessionTimeout"
+	// TerminationReasonClientQuit means the client has closed the session
+	TerminationReasonClientQuit TerminationReason = "ClientQuit"
+	// TerminationReasonBrowserCrash means the browser container has exited while serving the session
+	TerminationReasonBrowserCrash TerminationReason = "BrowserCrash"
+)
+
+// VNCSecretKey is the key of the VNC password in the browser VNC Secret
+const VNCSecretKey = "password"
+
+// keys of the proxy credentials in BrowserProxy CredentialsSecret
+const (
+	ProxyUsernameKey = "username"
+	ProxyPasswordKey = "password"
+)
+
 type PortConfig struct {
 	Sidecar    string `json:"sidecar,omitempty"`
 	Browser    string `json:"browser,omitempty"`
@@ -119,6 +287,29 @@ type BrowserList struct {
 	Items           []Browser `json:"items"`
 }
 
+// SessionID returns ID of the session served by the browser.
+// Pooled browsers are created before the session, so the session ID is kept in a label.
+func (b *Browser) SessionID() string {
+	if id := b.Labels[LabelSessionID]; id != "" {
+		return id
+	}
+	return b.Name
+}
+
+// NotReadyCondition returns the first unsatisfied condition of the browser startup
+// (Scheduled, ImagePulled, ContainersReady) which explains why the browser isn't running yet.
+// Returns nil if there is no such condition
+func (b *Browser) NotReadyCondition() *metav1.Condition {
+	for _, conditionType := range []string{ConditionScheduled, ConditionImagePulled, ConditionContainersReady} {
+		for i := range b.Status.Conditions {
+			if cond := &b.Status.Conditions[i]; cond.Type == conditionType && cond.Status != metav1.ConditionTrue {
+				return cond
+			}
+		}
+	}
+	return nil
+}
+
 func init() {
 	SchemeBuilder.Register(&Browser{}, &BrowserList{})
 }
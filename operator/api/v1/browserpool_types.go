@@ -0,0 +1,95 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Pool states stored in LabelPoolState of pooled browsers
+const (
+	PoolStateIdle    = "idle"
+	PoolStateClaimed = "claimed"
+)
+
+// BrowserPoolSpec defines the desired state of BrowserPool
+type BrowserPoolSpec struct {
+	BrowserName string `json:"browserName"`
+	// BrowserVersion of pooled browsers. BrowserSet's default version is used if empty
+	BrowserVersion string `json:"browserVersion,omitempty"`
+	Type           string `json:"type,omitempty"`
+	Timezone       string `json:"timeZone,omitempty"`
+
+	// nolint: tagliatelle
+	EnableVNC        bool   `json:"enableVNC,omitempty"`
+	EnableVideo      bool   `json:"enableVideo,omitempty"`
+	ScreenResolution string `json:"screenResolution,omitempty"`
+
+	// MinIdle is the number of started browsers waiting for a session
+	//+kubebuilder:validation:Minimum=0
+	MinIdle int32 `json:"minIdle"`
+	// MaxIdle is the upper bound of idle browsers. Defaults to MinIdle
+	//+kubebuilder:validation:Minimum=0
+	MaxIdle int32 `json:"maxIdle,omitempty"`
+}
+
+// BrowserPoolStatus defines the observed state of BrowserPool
+type BrowserPoolStatus struct {
+	// Idle is the number of running browsers ready to be claimed
+	Idle int32 `json:"idle"`
+	// Starting is the number of pooled browsers which are not running yet
+	Starting int32 `json:"starting"`
+	// Claimed is the number of browsers taken by sessions and not finished yet
+	Claimed int32 `json:"claimed"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:printcolumn:name="Browser",type=string,JSONPath=`.spec.browserName`
+//+kubebuilder:printcolumn:name="Version",type=string,JSONPath=`.spec.browserVersion`
+//+kubebuilder:printcolumn:name="Idle",type=integer,JSONPath=`.status.idle`
+//+kubebuilder:printcolumn:name="Claimed",type=integer,JSONPath=`.status.claimed`
+
+// BrowserPool is the Schema for the browserpools API
+type BrowserPool struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   BrowserPoolSpec   `json:"spec,omitempty"`
+	Status BrowserPoolStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// BrowserPoolList contains a list of BrowserPool
+type BrowserPoolList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []BrowserPool `json:"items"`
+}
+
+// GetMaxIdle returns upper bound of idle browsers
+func (p *BrowserPool) GetMaxIdle() int32 {
+	if p.Spec.MaxIdle < p.Spec.MinIdle {
+		return p.Spec.MinIdle
+	}
+	return p.Spec.MaxIdle
+}
+
+func init() {
+	SchemeBuilder.Register(&BrowserPool{}, &BrowserPoolList{})
+}
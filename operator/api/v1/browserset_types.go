@@ -17,10 +17,10 @@ limitations under the License.
 package v1
 
 import (
-	"time"
-
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 )
 
 // EDIT THIS FILE!  THIS IS SCAFFOLDING FOR YOU TO OWN!
@@ -31,6 +31,9 @@ type BrowserSetSpec struct {
 	// INSERT ADDITIONAL SPEC FIELDS - desired state of cluster
 	// Important: Run "make" to regenerate code after modifying this file
 	DefaultTimezone string `json:"defaultTimezone"`
+	// Priority of the set. Browsers defined by the sets with higher priority override the ones with lower
+	// +optional
+	Priority int32 `json:"priority,omitempty"`
 
 	// +optional
 	PodSpec *BrowserPodSpec `json:"podSpec"`
@@ -38,6 +41,38 @@ type BrowserSetSpec struct {
 	WebDriver map[string]BrowsersConfig `json:"webdriver,omitempty"`
 	// +optional
 	Playwright map[string]BrowsersConfig `json:"playwright,omitempty"`
+	// SizeProfiles are named resource presets sessions may pick with sizeProfile capability.
+	// Profile overrides resources configured for the browser version
+	// +optional
+	SizeProfiles map[string]BrowserResources `json:"sizeProfiles,omitempty"`
+	// Timeouts are the defaults for all browsers of the set
+	// +optional
+	Timeouts *BrowserTimeouts `json:"timeouts,omitempty"`
+	// MaxTimeouts bound timeouts sessions may request. Sessions may only shorten the timeouts which have no bound
+	// +optional
+	MaxTimeouts *BrowserTimeouts `json:"maxTimeouts,omitempty"`
+	// NetworkPolicy restricts network egress of the browsers of the set
+	// +optional
+	NetworkPolicy *BrowserNetworkPolicy `json:"networkPolicy,omitempty"`
+	// Recording are the default video recording options for all browsers of the set
+	// +optional
+	Recording *BrowserRecording `json:"recording,omitempty"`
+	// Proxy is the upstream proxy of the sessions which don't request one
+	// +optional
+	Proxy *BrowserProxy `json:"proxy,omitempty"`
+	// ForceProxy sends traffic of all sessions of the set through the configured proxy.
+	// Sessions can't request another proxy then
+	// +optional
+	ForceProxy bool `json:"forceProxy,omitempty"`
+	// CABundles are CA certificates all browsers of the set trust in addition to the image ones
+	// +optional
+	CABundles []CABundle `json:"caBundles,omitempty"`
+	// PodTemplateOverlay is a strategic merge patch of the Pod applied to every browser pod of the set,
+	// e.g. to add env variables, volumes, init containers or annotations.
+	// Containers and ports managed by browserkube can't be removed
+	// +optional
+	// +kubebuilder:pruning:PreserveUnknownFields
+	PodTemplateOverlay *runtime.RawExtension `json:"podTemplateOverlay,omitempty"`
 }
 
 type BrowserPodSpec struct {
@@ -54,6 +89,27 @@ type BrowserPodSpec struct {
 	PriorityClassName             string               `json:"priorityClassName,omitempty"`
 	Priority                      *int32               `json:"priority,omitempty"`
 	DNSConfig                     *corev1.PodDNSConfig `json:"dnsConfig,omitempty"`
+	// +optional
+	Resources *BrowserResources `json:"resources,omitempty"`
+}
+
+// BrowserResources defines compute resources of browser pod containers.
+// Resources configured for a container replace the defaults of the container completely
+type BrowserResources struct {
+	// +optional
+	Browser *corev1.ResourceRequirements `json:"browser,omitempty"`
+	// +optional
+	Sidecar *corev1.ResourceRequirements `json:"sidecar,omitempty"`
+	// +optional
+	Recorder *corev1.ResourceRequirements `json:"recorder,omitempty"`
+	// +optional
+	Clipboard *corev1.ResourceRequirements `json:"clipboard,omitempty"`
+	// VNC resources are applied to both x-server and vnc-server containers
+	// +optional
+	VNC *corev1.ResourceRequirements `json:"vnc,omitempty"`
+	// ShmSize is the size limit of /dev/shm. Defaults to 1Gi
+	// +optional
+	ShmSize *resource.Quantity `json:"shmSize,omitempty"`
 }
 
 type BrowsersConfig struct {
@@ -74,18 +130,148 @@ type BrowserConfig struct {
 	// +optional
 	Spec *BrowserPodSpec `json:"spec"`
 
+	// ImageProfile describes how to run the image. Built-in profile detected from the image registry is used
+	// for the fields which are not set. Profile flavour is required for images with unknown registry
+	// +optional
+	ImageProfile *ImageProfile `json:"imageProfile,omitempty"`
+
 	// +optional
 	EnableVideo bool `json:"enableVideo,omitempty"`
+	// Recording options of the browser version. Override BrowserSet recording options
+	// +optional
+	Recording *BrowserRecording `json:"recording,omitempty"`
 	// +optional
 	AwsAccessKeyID string `json:"awsAccessKeyID,omitempty"`
 	// +optional
 	AwsSecretAccessKey string `json:"awsSecretAccessKey,omitempty"`
 
-	// TODO not supported yet
+	// StartupTimeout limits how long the browser may stay pending. Overrides BrowserSet timeouts
+	// +optional
+	StartupTimeout *metav1.Duration `json:"startupTimeout,omitempty"`
+	// SessionDeleteTimeout is the grace period of browser pod deletion. Overrides BrowserSet timeouts
+	// +optional
+	SessionDeleteTimeout *metav1.Duration `json:"sessionDeleteTimeout,omitempty"`
+	// IdleTimeout closes the session once no commands are received for this long. Overrides BrowserSet timeouts
+	// +optional
+	IdleTimeout *metav1.Duration `json:"idleTimeout,omitempty"`
+	// SessionTimeout is the max session duration. Overrides BrowserSet timeouts
+	// +optional
+	SessionTimeout *metav1.Duration `json:"sessionTimeout,omitempty"`
+
+	// NetworkPolicy restricts network egress of the browser version. Overrides BrowserSet network policy
+	// +optional
+	NetworkPolicy *BrowserNetworkPolicy `json:"networkPolicy,omitempty"`
+
+	// Proxy of the browser version. Overrides BrowserSet proxy
+	// +optional
+	Proxy *BrowserProxy `json:"proxy,omitempty"`
+
+	// CABundles of the browser version. Added to BrowserSet CA bundles
+	// +optional
+	CABundles []CABundle `json:"caBundles,omitempty"`
+
+	// PodTemplateOverlay is a strategic merge patch of the Pod applied after the BrowserSet one
+	// +optional
+	// +kubebuilder:pruning:PreserveUnknownFields
+	PodTemplateOverlay *runtime.RawExtension `json:"podTemplateOverlay,omitempty"`
+}
+
+// Timeouts returns timeouts configured for the browser version
+func (c *BrowserConfig) Timeouts() BrowserTimeouts {
+	return BrowserTimeouts{
+		Startup:       c.StartupTimeout,
+		SessionDelete: c.SessionDeleteTimeout,
+		Idle:          c.IdleTimeout,
+		Session:       c.SessionTimeout,
+	}
+}
+
+// BrowserTimeouts limit browser lifetime
+type BrowserTimeouts struct {
+	// Startup limits how long the browser may stay pending before it is deleted
+	// +optional
+	Startup *metav1.Duration `json:"startup,omitempty"`
+	// SessionDelete is the grace period of browser pod deletion
+	// +optional
+	SessionDelete *metav1.Duration `json:"sessionDelete,omitempty"`
+	// Idle closes the session once no commands are received for this long
+	// +optional
+	Idle *metav1.Duration `json:"idle,omitempty"`
+	// Session is the max session duration
+	// +optional
+	Session *metav1.Duration `json:"session,omitempty"`
+}
+
+// BrowserNetworkPolicy configures NetworkPolicy created for every browser.
+// Browsers may reach any public address unless allowed CIDRs or domains are provided.
+// DNS and browserkube namespace are always reachable
+type BrowserNetworkPolicy struct {
+	// Enabled creates NetworkPolicy for every browser of the set.
+	// Policy is created for browsers requesting network restrictions regardless of it
+	// +optional
+	Enabled bool `json:"enabled,omitempty"`
+	// AllowedCIDRs browsers may connect to
+	// +optional
+	AllowedCIDRs []string `json:"allowedCIDRs,omitempty"`
+	// AllowedDomains browsers may connect to. Domains are resolved to addresses once the browser is created
+	// +optional
+	AllowedDomains []string `json:"allowedDomains,omitempty"`
+	// AllowClusterInternal allows private, link-local and shared address ranges
+	// which are denied by default to keep cluster-internal services unreachable
+	// +optional
+	AllowClusterInternal bool `json:"allowClusterInternal,omitempty"`
+}
+
+// Image flavours define the pod layout of browser images
+const (
+	ImageFlavourSelenium  = "selenium"
+	ImageFlavourSelenoid  = "selenoid"
+	ImageFlavourAerokube  = "aerokube"
+	ImageFlavourMicrosoft = "microsoft"
+)
+
+// CABundle references PEM encoded CA certificates kept in a ConfigMap or a Secret of the browser namespace.
+// Exactly one of ConfigMap and Secret has to be set
+type CABundle struct {
+	// ConfigMap holding the bundle
+	// +optional
+	ConfigMap string `json:"configMap,omitempty"`
+	// Secret holding the bundle
+	// +optional
+	Secret string `json:"secret,omitempty"`
+	// Key of the bundle, CABundleDefaultKey by default
+	// +optional
+	Key string `json:"key,omitempty"`
+}
+
+// CABundleDefaultKey is the key CA bundles are read from unless another one is set
+const CABundleDefaultKey = "ca.crt"
+
+// ImageProfile describes browser image layout
+type ImageProfile struct {
+	// Flavour selects the pod builder
+	// +kubebuilder:validation:Enum=selenium;selenoid;aerokube;microsoft
+	// +optional
+	Flavour string `json:"flavour,omitempty"`
+	// HomeDir of the browser user. Videos and downloads are stored there
+	// +optional
+	HomeDir string `json:"homeDir,omitempty"`
+	// DisplayNum is the X display number the browser is rendered to
+	// +optional
+	DisplayNum string `json:"displayNum,omitempty"`
+	// VNCPort the image serves VNC on
+	// +optional
+	VNCPort string `json:"vncPort,omitempty"`
+	// ReadinessPath is the HTTP path of the browser readiness probe
+	// +optional
+	ReadinessPath string `json:"readinessPath,omitempty"`
+	// ServerCommand is the shell command starting Playwright server of microsoft images.
+	// By default, the server of the image version is fetched by npx from the npm registry
 	// +optional
-	StartupTimeout time.Duration `json:"startupTimeout"`
+	ServerCommand string `json:"serverCommand,omitempty"`
+	// ExtensionDirs are the directories browser extensions are installed to, by browser name
 	// +optional
-	SessionDeleteTimeout time.Duration `json:"sessionDeleteTimeout"`
+	ExtensionDirs map[string][]string `json:"extensionDirs,omitempty"`
 }
 
 // BrowserSetStatus defines the observed state of BrowserSet
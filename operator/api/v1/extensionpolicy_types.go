@@ -0,0 +1,74 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ExtensionPolicySpec defines extensions allowed in browsers of the namespace.
+// An extension is allowed if any of the policies applying to the browser allows it.
+// Browsers no policy applies to can't have extensions
+type ExtensionPolicySpec struct {
+	// Selector restricts the policy to browsers with matching labels, e.g. io.browserkube.browser-set.
+	// The policy applies to all browsers of the namespace if empty
+	// +optional
+	Selector *metav1.LabelSelector `json:"selector,omitempty"`
+	// Browsers restricts the policy to the browser names, e.g. chrome. The policy applies to all browsers if empty
+	// +optional
+	Browsers []string `json:"browsers,omitempty"`
+	// Allowed extensions
+	Allowed []AllowedExtension `json:"allowed"`
+}
+
+// AllowedExtension matches extensions by ID or by name of the artifact uploaded to browserkube
+type AllowedExtension struct {
+	// ExtensionID of the store extension or the artifact
+	// +optional
+	ExtensionID string `json:"extensionId,omitempty"`
+	// Artifact is the name of the extension uploaded to browserkube
+	// +optional
+	Artifact string `json:"artifact,omitempty"`
+	// Versions is a comma-separated list of constraints the requested version must satisfy, e.g. ">=1.2, <2".
+	// Any version is allowed if empty. Extensions requested without a version satisfy no constraints
+	// +optional
+	Versions string `json:"versions,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:printcolumn:name="Browsers",type=string,JSONPath=`.spec.browsers`
+
+// ExtensionPolicy is the Schema for the extensionpolicies API
+type ExtensionPolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec ExtensionPolicySpec `json:"spec,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// ExtensionPolicyList contains a list of ExtensionPolicy
+type ExtensionPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ExtensionPolicy `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ExtensionPolicy{}, &ExtensionPolicyList{})
+}
@@ -9,4 +9,22 @@ const (
 	LabelValueComponentBrowserkubeBrowser = "browserkube-browser"
 
 	LabelBrowserVisibility = "visible"
+
+	// LabelBrowserSet holds name of the BrowserSet the browser configuration is taken from
+	LabelBrowserSet = "io.browserkube.browser-set"
+
+	// LabelPool holds name of the BrowserPool the browser has been started for
+	LabelPool = "io.browserkube.pool"
+	// LabelPoolState holds state of the pooled browser. See PoolStateIdle and PoolStateClaimed
+	LabelPoolState = "io.browserkube.pool-state"
+
+	// AnnotationPoolBrowserVersion holds browser version requested by BrowserPool
+	AnnotationPoolBrowserVersion = "io.browserkube.pool-browser-version"
+
+	// AnnotationImageFlavour holds flavour of the image run by the browser pod. See ImageProfile
+	AnnotationImageFlavour = "io.browserkube.image-flavour"
+
+	// AnnotationOwner holds the authenticated user the session has been created by.
+	// SessionResult inherits it from the browser
+	AnnotationOwner = "io.browserkube.owner"
 )
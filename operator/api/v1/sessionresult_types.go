@@ -33,6 +33,12 @@ type SessionResultSpec struct {
 	Browser      BrowserSpec        `json:"browser,omitempty"`
 	BrowserImage string             `json:"browserImage,omitempty"`
 	Files        SessionResultFiles `json:"files"`
+	// Recording options the video has been recorded with
+	// +optional
+	Recording *BrowserRecording `json:"recording,omitempty"`
+	// TerminationReason tells why the session has ended
+	// +optional
+	TerminationReason TerminationReason `json:"terminationReason,omitempty"`
 }
 
 type SessionResultFiles struct {
@@ -22,16 +22,32 @@ package v1
 
 import (
 	corev1 "k8s.io/api/core/v1"
-	runtime "k8s.io/apimachinery/pkg/runtime"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AllowedExtension) DeepCopyInto(out *AllowedExtension) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AllowedExtension.
+func (in *AllowedExtension) DeepCopy() *AllowedExtension {
+	if in == nil {
+		return nil
+	}
+	out := new(AllowedExtension)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Browser) DeepCopyInto(out *Browser) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Browser.
@@ -60,6 +76,56 @@ func (in *BrowserConfig) DeepCopyInto(out *BrowserConfig) {
 		*out = new(BrowserPodSpec)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.ImageProfile != nil {
+		in, out := &in.ImageProfile, &out.ImageProfile
+		*out = new(ImageProfile)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Recording != nil {
+		in, out := &in.Recording, &out.Recording
+		*out = new(BrowserRecording)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.StartupTimeout != nil {
+		in, out := &in.StartupTimeout, &out.StartupTimeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.SessionDeleteTimeout != nil {
+		in, out := &in.SessionDeleteTimeout, &out.SessionDeleteTimeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.IdleTimeout != nil {
+		in, out := &in.IdleTimeout, &out.IdleTimeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.SessionTimeout != nil {
+		in, out := &in.SessionTimeout, &out.SessionTimeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.NetworkPolicy != nil {
+		in, out := &in.NetworkPolicy, &out.NetworkPolicy
+		*out = new(BrowserNetworkPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Proxy != nil {
+		in, out := &in.Proxy, &out.Proxy
+		*out = new(BrowserProxy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CABundles != nil {
+		in, out := &in.CABundles, &out.CABundles
+		*out = make([]CABundle, len(*in))
+		copy(*out, *in)
+	}
+	if in.PodTemplateOverlay != nil {
+		in, out := &in.PodTemplateOverlay, &out.PodTemplateOverlay
+		*out = new(runtime.RawExtension)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BrowserConfig.
@@ -119,6 +185,56 @@ func (in *BrowserList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BrowserNetwork) DeepCopyInto(out *BrowserNetwork) {
+	*out = *in
+	if in.AllowedCIDRs != nil {
+		in, out := &in.AllowedCIDRs, &out.AllowedCIDRs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AllowedDomains != nil {
+		in, out := &in.AllowedDomains, &out.AllowedDomains
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BrowserNetwork.
+func (in *BrowserNetwork) DeepCopy() *BrowserNetwork {
+	if in == nil {
+		return nil
+	}
+	out := new(BrowserNetwork)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BrowserNetworkPolicy) DeepCopyInto(out *BrowserNetworkPolicy) {
+	*out = *in
+	if in.AllowedCIDRs != nil {
+		in, out := &in.AllowedCIDRs, &out.AllowedCIDRs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AllowedDomains != nil {
+		in, out := &in.AllowedDomains, &out.AllowedDomains
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BrowserNetworkPolicy.
+func (in *BrowserNetworkPolicy) DeepCopy() *BrowserNetworkPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(BrowserNetworkPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BrowserPodSpec) DeepCopyInto(out *BrowserPodSpec) {
 	*out = *in
@@ -168,6 +284,11 @@ func (in *BrowserPodSpec) DeepCopyInto(out *BrowserPodSpec) {
 		*out = new(corev1.PodDNSConfig)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(BrowserResources)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BrowserPodSpec.
@@ -180,6 +301,180 @@ func (in *BrowserPodSpec) DeepCopy() *BrowserPodSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BrowserPool) DeepCopyInto(out *BrowserPool) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+	out.Status = in.Status
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BrowserPool.
+func (in *BrowserPool) DeepCopy() *BrowserPool {
+	if in == nil {
+		return nil
+	}
+	out := new(BrowserPool)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *BrowserPool) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BrowserPoolList) DeepCopyInto(out *BrowserPoolList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]BrowserPool, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BrowserPoolList.
+func (in *BrowserPoolList) DeepCopy() *BrowserPoolList {
+	if in == nil {
+		return nil
+	}
+	out := new(BrowserPoolList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *BrowserPoolList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BrowserPoolSpec) DeepCopyInto(out *BrowserPoolSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BrowserPoolSpec.
+func (in *BrowserPoolSpec) DeepCopy() *BrowserPoolSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(BrowserPoolSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BrowserPoolStatus) DeepCopyInto(out *BrowserPoolStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BrowserPoolStatus.
+func (in *BrowserPoolStatus) DeepCopy() *BrowserPoolStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(BrowserPoolStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BrowserProxy) DeepCopyInto(out *BrowserProxy) {
+	*out = *in
+	if in.NoProxy != nil {
+		in, out := &in.NoProxy, &out.NoProxy
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BrowserProxy.
+func (in *BrowserProxy) DeepCopy() *BrowserProxy {
+	if in == nil {
+		return nil
+	}
+	out := new(BrowserProxy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BrowserRecording) DeepCopyInto(out *BrowserRecording) {
+	*out = *in
+	if in.CRF != nil {
+		in, out := &in.CRF, &out.CRF
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BrowserRecording.
+func (in *BrowserRecording) DeepCopy() *BrowserRecording {
+	if in == nil {
+		return nil
+	}
+	out := new(BrowserRecording)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BrowserResources) DeepCopyInto(out *BrowserResources) {
+	*out = *in
+	if in.Browser != nil {
+		in, out := &in.Browser, &out.Browser
+		*out = new(corev1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Sidecar != nil {
+		in, out := &in.Sidecar, &out.Sidecar
+		*out = new(corev1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Recorder != nil {
+		in, out := &in.Recorder, &out.Recorder
+		*out = new(corev1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Clipboard != nil {
+		in, out := &in.Clipboard, &out.Clipboard
+		*out = new(corev1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.VNC != nil {
+		in, out := &in.VNC, &out.VNC
+		*out = new(corev1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ShmSize != nil {
+		in, out := &in.ShmSize, &out.ShmSize
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BrowserResources.
+func (in *BrowserResources) DeepCopy() *BrowserResources {
+	if in == nil {
+		return nil
+	}
+	out := new(BrowserResources)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BrowserSet) DeepCopyInto(out *BrowserSet) {
 	*out = *in
@@ -261,6 +556,48 @@ func (in *BrowserSetSpec) DeepCopyInto(out *BrowserSetSpec) {
 			(*out)[key] = *val.DeepCopy()
 		}
 	}
+	if in.SizeProfiles != nil {
+		in, out := &in.SizeProfiles, &out.SizeProfiles
+		*out = make(map[string]BrowserResources, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+	if in.Timeouts != nil {
+		in, out := &in.Timeouts, &out.Timeouts
+		*out = new(BrowserTimeouts)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MaxTimeouts != nil {
+		in, out := &in.MaxTimeouts, &out.MaxTimeouts
+		*out = new(BrowserTimeouts)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NetworkPolicy != nil {
+		in, out := &in.NetworkPolicy, &out.NetworkPolicy
+		*out = new(BrowserNetworkPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Recording != nil {
+		in, out := &in.Recording, &out.Recording
+		*out = new(BrowserRecording)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Proxy != nil {
+		in, out := &in.Proxy, &out.Proxy
+		*out = new(BrowserProxy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CABundles != nil {
+		in, out := &in.CABundles, &out.CABundles
+		*out = make([]CABundle, len(*in))
+		copy(*out, *in)
+	}
+	if in.PodTemplateOverlay != nil {
+		in, out := &in.PodTemplateOverlay, &out.PodTemplateOverlay
+		*out = new(runtime.RawExtension)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BrowserSetSpec.
@@ -296,6 +633,26 @@ func (in *BrowserSpec) DeepCopyInto(out *BrowserSpec) {
 		*out = make([]BrowserExtension, len(*in))
 		copy(*out, *in)
 	}
+	if in.Timeouts != nil {
+		in, out := &in.Timeouts, &out.Timeouts
+		*out = new(BrowserTimeouts)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Network != nil {
+		in, out := &in.Network, &out.Network
+		*out = new(BrowserNetwork)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Recording != nil {
+		in, out := &in.Recording, &out.Recording
+		*out = new(BrowserRecording)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Proxy != nil {
+		in, out := &in.Proxy, &out.Proxy
+		*out = new(BrowserProxy)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Caps != nil {
 		in, out := &in.Caps, &out.Caps
 		*out = make([]byte, len(*in))
@@ -317,6 +674,36 @@ func (in *BrowserSpec) DeepCopy() *BrowserSpec {
 func (in *BrowserStatus) DeepCopyInto(out *BrowserStatus) {
 	*out = *in
 	out.PortConfig = in.PortConfig
+	if in.Timeouts != nil {
+		in, out := &in.Timeouts, &out.Timeouts
+		*out = new(BrowserTimeouts)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Recording != nil {
+		in, out := &in.Recording, &out.Recording
+		*out = new(BrowserRecording)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Proxy != nil {
+		in, out := &in.Proxy, &out.Proxy
+		*out = new(BrowserProxy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.StartupDeadline != nil {
+		in, out := &in.StartupDeadline, &out.StartupDeadline
+		*out = (*in).DeepCopy()
+	}
+	if in.SessionDeadline != nil {
+		in, out := &in.SessionDeadline, &out.SessionDeadline
+		*out = (*in).DeepCopy()
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BrowserStatus.
@@ -329,6 +716,41 @@ func (in *BrowserStatus) DeepCopy() *BrowserStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BrowserTimeouts) DeepCopyInto(out *BrowserTimeouts) {
+	*out = *in
+	if in.Startup != nil {
+		in, out := &in.Startup, &out.Startup
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.SessionDelete != nil {
+		in, out := &in.SessionDelete, &out.SessionDelete
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.Idle != nil {
+		in, out := &in.Idle, &out.Idle
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.Session != nil {
+		in, out := &in.Session, &out.Session
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BrowserTimeouts.
+func (in *BrowserTimeouts) DeepCopy() *BrowserTimeouts {
+	if in == nil {
+		return nil
+	}
+	out := new(BrowserTimeouts)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BrowsersConfig) DeepCopyInto(out *BrowsersConfig) {
 	*out = *in
@@ -351,6 +773,140 @@ func (in *BrowsersConfig) DeepCopy() *BrowsersConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CABundle) DeepCopyInto(out *CABundle) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CABundle.
+func (in *CABundle) DeepCopy() *CABundle {
+	if in == nil {
+		return nil
+	}
+	out := new(CABundle)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExtensionPolicy) DeepCopyInto(out *ExtensionPolicy) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExtensionPolicy.
+func (in *ExtensionPolicy) DeepCopy() *ExtensionPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(ExtensionPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ExtensionPolicy) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExtensionPolicyList) DeepCopyInto(out *ExtensionPolicyList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ExtensionPolicy, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExtensionPolicyList.
+func (in *ExtensionPolicyList) DeepCopy() *ExtensionPolicyList {
+	if in == nil {
+		return nil
+	}
+	out := new(ExtensionPolicyList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ExtensionPolicyList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExtensionPolicySpec) DeepCopyInto(out *ExtensionPolicySpec) {
+	*out = *in
+	if in.Selector != nil {
+		in, out := &in.Selector, &out.Selector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Browsers != nil {
+		in, out := &in.Browsers, &out.Browsers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Allowed != nil {
+		in, out := &in.Allowed, &out.Allowed
+		*out = make([]AllowedExtension, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExtensionPolicySpec.
+func (in *ExtensionPolicySpec) DeepCopy() *ExtensionPolicySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ExtensionPolicySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ImageProfile) DeepCopyInto(out *ImageProfile) {
+	*out = *in
+	if in.ExtensionDirs != nil {
+		in, out := &in.ExtensionDirs, &out.ExtensionDirs
+		*out = make(map[string][]string, len(*in))
+		for key, val := range *in {
+			var outVal []string
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				inVal := (*in)[key]
+				in, out := &inVal, &outVal
+				*out = make([]string, len(*in))
+				copy(*out, *in)
+			}
+			(*out)[key] = outVal
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImageProfile.
+func (in *ImageProfile) DeepCopy() *ImageProfile {
+	if in == nil {
+		return nil
+	}
+	out := new(ImageProfile)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PortConfig) DeepCopyInto(out *PortConfig) {
 	*out = *in
@@ -447,6 +1003,11 @@ func (in *SessionResultSpec) DeepCopyInto(out *SessionResultSpec) {
 	in.FinishedAt.DeepCopyInto(&out.FinishedAt)
 	in.Browser.DeepCopyInto(&out.Browser)
 	out.Files = in.Files
+	if in.Recording != nil {
+		in, out := &in.Recording, &out.Recording
+		*out = new(BrowserRecording)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SessionResultSpec.
@@ -36,6 +36,7 @@ import (
 	apiv1 "github.com/browserkube/browserkube/operator/api/v1"
 	browserkubeiov1 "github.com/browserkube/browserkube/operator/api/v1"
 	"github.com/browserkube/browserkube/operator/internal/controller"
+	"github.com/browserkube/browserkube/operator/internal/webhooks"
 )
 
 var (
@@ -55,11 +56,15 @@ func main() {
 	var metricsAddr string
 	var enableLeaderElection bool
 	var probeAddr string
+	var enableWebhooks bool
 	flag.StringVar(&metricsAddr, "metrics-bind-address", ":8080", "The address the metric endpoint binds to.")
 	flag.StringVar(&probeAddr, "health-probe-bind-address", ":8081", "The address the probe endpoint binds to.")
 	flag.BoolVar(&enableLeaderElection, "leader-elect", false,
 		"Enable leader election for controller manager. "+
 			"Enabling this will ensure there is only one active controller manager.")
+	flag.BoolVar(&enableWebhooks, "enable-webhooks", false,
+		"Enable admission webhooks for Browser, BrowserSet and ExtensionPolicy. "+
+			"Serving certificates are expected in the webhook server's cert dir.")
 	opts := zap.Options{
 		Development: true,
 	}
@@ -70,16 +75,20 @@ func main() {
 
 	ctrl.SetLogger(zap.New(zap.UseFlagOptions(&opts)))
 
-	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), ctrl.Options{
+	restConfig := ctrl.GetConfigOrDie()
+	namespaces := browsersCtlOpts.CacheNamespaces()
+	for ns := range namespaces {
+		setupLog.Info("watching namespace", "namespace", ns)
+	}
+
+	mgr, err := ctrl.NewManager(restConfig, ctrl.Options{
 		Scheme:                 scheme,
 		Metrics:                metricsserver.Options{BindAddress: metricsAddr},
 		HealthProbeBindAddress: probeAddr,
 		LeaderElection:         enableLeaderElection,
 		LeaderElectionID:       "e174e30e.browserkube.io",
 		Cache: cache.Options{
-			DefaultNamespaces: map[string]cache.Config{
-				browsersCtlOpts.OperatorNamespace: {},
-			},
+			DefaultNamespaces: namespaces,
 		},
 		// LeaderElectionReleaseOnCancel defines if the leader should step down voluntarily
 		// when the Manager ends. This requires the binary to immediately end when the
@@ -101,11 +110,33 @@ func main() {
 	if err = (controller.NewBrowserReconciler(
 		mgr.GetClient(),
 		mgr.GetScheme(),
+		mgr.GetEventRecorderFor("browser-controller"),
 		browsersCtlOpts,
 	)).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "Browser")
 		os.Exit(1)
 	}
+	if err = controller.NewBrowserPoolReconciler(
+		mgr.GetClient(),
+		mgr.GetScheme(),
+	).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "BrowserPool")
+		os.Exit(1)
+	}
+	if enableWebhooks {
+		if err = webhooks.NewBrowserSetValidator(mgr.GetClient()).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "BrowserSet")
+			os.Exit(1)
+		}
+		if err = webhooks.NewBrowserWebhook(mgr.GetClient()).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "Browser")
+			os.Exit(1)
+		}
+		if err = webhooks.NewExtensionPolicyValidator().SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "ExtensionPolicy")
+			os.Exit(1)
+		}
+	}
 	//+kubebuilder:scaffold:builder
 
 	if err := mgr.AddHealthzCheck("healthz", healthz.Ping); err != nil {
@@ -12,18 +12,13 @@ import (
 	"github.com/browserkube/browserkube/operator/internal/controller/browserimage"
 )
 
-const (
-	aerokubeDisplayNum    = "0"
-	aerokubeDisplay       = ":0"
-	aerokubeRemoteDisplay = "127.0.0.1:0"
-)
-
 type aerokubePodBuilder struct {
 	browserConfig *browserkubeapiv1.BrowserConfig
+	profile       browserimage.Profile
 }
 
 func (a *aerokubePodBuilder) Build(ctx context.Context, b *browserkubeapiv1.Browser, opts *BrowserCtrlOpts, readinessProbe *apiv1.Probe) (*apiv1.Pod, error) {
-	volumeMounts := buildVolumeMounts(browserimage.ImageTypeAerokube)
+	volumeMounts := buildVolumeMounts(a.profile.HomeDir)
 
 	spec := &apiv1.PodSpec{
 		Hostname:      b.Name,
@@ -35,7 +30,7 @@ func (a *aerokubePodBuilder) Build(ctx context.Context, b *browserkubeapiv1.Brow
 				Ports: []apiv1.ContainerPort{
 					buildContainerPort("sidecar", opts.sidecarPort),
 				},
-				Env:          buildSidecarEnvVar(opts.sidecarPort, browserimage.ImageTypeAerokube, a.browserConfig.Port, a.browserConfig.Path),
+				Env:          buildSidecarEnvVar(opts.sidecarPort, a.profile.HomeDir, a.browserConfig),
 				VolumeMounts: volumeMounts,
 				Resources:    buildResources(200, memory128Mi, 100, memory128Mi),
 			},
@@ -58,7 +53,7 @@ func (a *aerokubePodBuilder) Build(ctx context.Context, b *browserkubeapiv1.Brow
 				Ports: []apiv1.ContainerPort{
 					buildContainerPort("p", ports.Clipboard),
 				},
-				Env:   []apiv1.EnvVar{{Name: "DISPLAY", Value: aerokubeRemoteDisplay}},
+				Env:   []apiv1.EnvVar{{Name: "DISPLAY", Value: "127.0.0.1" + a.profile.Display()}},
 				Stdin: true,
 				TTY:   true,
 			},
@@ -66,45 +61,25 @@ func (a *aerokubePodBuilder) Build(ctx context.Context, b *browserkubeapiv1.Brow
 		Volumes: buildVolumes(opts),
 	}
 
+	if b.Spec.Type == browserkubeapiv1.TypePlaywright {
+		// playwright images are probed on the browser port
+		findContainer(spec.Containers, containerNameBrowser).ReadinessProbe = readinessProbe
+	}
 	if b.Spec.EnableVNC {
-		spec.Containers = append(spec.Containers,
-			apiv1.Container{
-				Name:         "x-server",
-				Image:        opts.xServerImage,
-				VolumeMounts: volumeMounts,
-				Ports: []apiv1.ContainerPort{
-					buildContainerPort("p", "6000"),
-				},
-				Env: []apiv1.EnvVar{
-					{Name: "SCREEN_RESOLUTION", Value: GetResolution(b.Spec.ScreenResolution)},
-					{Name: "DISPLAY", Value: aerokubeDisplay},
-				},
-			},
-			apiv1.Container{
-				Name:         "vnc-server",
-				Image:        opts.vncServerImage,
-				VolumeMounts: volumeMounts,
-				Ports: []apiv1.ContainerPort{
-					buildContainerPort("p", "5900"),
-				},
-				// Env: []apiv1.EnvVar{{Name: "DISPLAY", Value: a.remoteDisplay()}},
-			},
-		)
+		addContainersVNC(opts, spec, b.Name, b.Spec.ScreenResolution, a.profile.Display(), volumeMounts)
 	}
 	if a.browserConfig.EnableVideo {
-		addContainerRecorder(opts, spec, browserimage.ImageTypeAerokube, aerokubeDisplayNum, volumeMounts)
+		addContainerRecorder(opts, spec, a.browserConfig.Recording, a.profile.HomeDir, a.profile.DisplayNum, volumeMounts)
 	}
 
 	logger := log.FromContext(ctx)
 
 	if b.Spec.Extensions != nil || len(b.Spec.Extensions) != 0 {
 		logger.Info("Browser Extension Capabilities: ", "Capabilities", fmt.Sprintf("%+v", b.Spec.Extensions))
-		installPlugins(spec,
+		installPlugins(opts, spec,
 			b.Spec.BrowserName,
-			browserimage.ImageTypeAerokube,
+			a.profile.ExtensionDirs[b.Spec.BrowserName],
 			b.Spec.Extensions,
-			opts.extensionInstallerImage,
-			opts.browserExtensionConfig,
 		)
 	}
 
@@ -132,7 +107,7 @@ func (a *aerokubePodBuilder) buildBrowserEnvVars(browser browserkubeapiv1.Browse
 	}
 
 	if browser.EnableVNC {
-		vars = append(vars, apiv1.EnvVar{Name: "DISPLAY", Value: aerokubeDisplay})
+		vars = append(vars, apiv1.EnvVar{Name: "DISPLAY", Value: a.profile.Display()})
 	}
 
 	return vars
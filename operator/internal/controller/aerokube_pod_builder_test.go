@@ -0,0 +1,31 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	browserkubeapiv1 "github.com/browserkube/browserkube/operator/api/v1"
+)
+
+func TestAerokubePodBuilderReadinessProbe(t *testing.T) {
+	builder, _, err := NewPodBuilder(&browserkubeapiv1.BrowserConfig{Image: "playwright/chrome:playwright-1.44.0", Port: "4444"})
+	if err != nil {
+		t.Fatalf("NewPodBuilder() unexpected error = %v", err)
+	}
+	probe := &v1.Probe{ProbeHandler: v1.ProbeHandler{TCPSocket: &v1.TCPSocketAction{}}}
+	pod, err := builder.Build(context.Background(), &browserkubeapiv1.Browser{
+		ObjectMeta: metav1.ObjectMeta{Name: "session", Namespace: defaultNs},
+		Spec:       browserkubeapiv1.BrowserSpec{BrowserName: browserName, Type: browserkubeapiv1.TypePlaywright},
+	}, &BrowserCtrlOpts{}, probe)
+	if err != nil {
+		t.Fatalf("Build() unexpected error = %v", err)
+	}
+	for _, c := range pod.Spec.Containers {
+		if got := c.ReadinessProbe == probe; got != (c.Name == containerNameBrowser) {
+			t.Errorf("container %s has readiness probe = %t", c.Name, got)
+		}
+	}
+}
@@ -0,0 +1,123 @@
+package controller
+
+import (
+	"fmt"
+	"path/filepath"
+
+	apiv1 "k8s.io/api/core/v1"
+
+	browserkubeapiv1 "github.com/browserkube/browserkube/operator/api/v1"
+)
+
+// CA trust layout produced by the ca-installer init container
+const (
+	caBundlesVolumeName      = "ca-bundles"
+	caBundlesMountPath       = "/ca-bundles"
+	caCertificatesVolumeName = "ca-certificates"
+	caCertificatesMountPath  = "/ca-certificates"
+	caInstallerCommand       = "/usr/bin/ca-installer"
+
+	// system bundle including the roots of the browser image and the custom CAs
+	caSystemBundleFile = "ca-certificates.crt"
+	caSystemBundlePath = "/etc/ssl/certs/ca-certificates.crt"
+	// system bundle of the browser image copied before it's shadowed by the mount of the extended one
+	caImageBundleFile = "image-ca-certificates.crt"
+	// NSS database read by Chrome and Chromium from the user home
+	caNSSDBDir = "nssdb"
+	// enterprise policy installing the custom CAs to Firefox profiles
+	caFirefoxPoliciesDir  = "policies"
+	caFirefoxPoliciesPath = "/etc/firefox/policies"
+	// separate certificates referenced by Firefox policy
+	caCertsDir  = "certs"
+	caCertsPath = "/usr/local/share/browserkube/ca-certificates"
+)
+
+// addCABundles makes the browser trust CA certificates of the bundles.
+// The ca-image-bundle init container copies the system bundle of the browser image, the ca-installer init container
+// merges the bundles into it, into the NSS database and Firefox enterprise policy which are mounted to the browser
+// container then. Sidecar gets the system bundle too, so it trusts TLS proxies signed by the custom CAs
+func addCABundles(pod *apiv1.Pod, bundles []browserkubeapiv1.CABundle, homeDir, installerImage string) {
+	if len(bundles) == 0 {
+		return
+	}
+	sources := make([]apiv1.VolumeProjection, 0, len(bundles))
+	for i, bundle := range bundles {
+		items := []apiv1.KeyToPath{{Key: bundle.Key, Path: fmt.Sprintf("bundle-%d.crt", i)}}
+		if bundle.Secret != "" {
+			sources = append(sources, apiv1.VolumeProjection{Secret: &apiv1.SecretProjection{
+				LocalObjectReference: apiv1.LocalObjectReference{Name: bundle.Secret},
+				Items:                items,
+			}})
+			continue
+		}
+		sources = append(sources, apiv1.VolumeProjection{ConfigMap: &apiv1.ConfigMapProjection{
+			LocalObjectReference: apiv1.LocalObjectReference{Name: bundle.ConfigMap},
+			Items:                items,
+		}})
+	}
+	pod.Spec.Volumes = append(pod.Spec.Volumes,
+		apiv1.Volume{
+			Name:         caBundlesVolumeName,
+			VolumeSource: apiv1.VolumeSource{Projected: &apiv1.ProjectedVolumeSource{Sources: sources}},
+		},
+		apiv1.Volume{
+			Name:         caCertificatesVolumeName,
+			VolumeSource: apiv1.VolumeSource{EmptyDir: &apiv1.EmptyDirVolumeSource{}},
+		},
+	)
+
+	imageBundle := filepath.Join(caCertificatesMountPath, caImageBundleFile)
+	if browser := findContainer(pod.Spec.Containers, containerNameBrowser); browser != nil {
+		// images without the system bundle get the custom CAs only
+		pod.Spec.InitContainers = append(pod.Spec.InitContainers, apiv1.Container{
+			Name:         caImageBundleContainerName,
+			Image:        browser.Image,
+			Command:      []string{"sh", "-c", fmt.Sprintf("cp %s %s || true", caSystemBundlePath, imageBundle)},
+			VolumeMounts: []apiv1.VolumeMount{{Name: caCertificatesVolumeName, MountPath: caCertificatesMountPath}},
+		})
+	}
+	pod.Spec.InitContainers = append(pod.Spec.InitContainers, apiv1.Container{
+		Name:    caInstallerContainerName,
+		Image:   installerImage,
+		Command: []string{caInstallerCommand},
+		Args: []string{
+			"--bundles=" + caBundlesMountPath,
+			"--output=" + caCertificatesMountPath,
+			"--certsPath=" + caCertsPath,
+			"--systemBundle=" + imageBundle,
+		},
+		VolumeMounts: []apiv1.VolumeMount{
+			{Name: caBundlesVolumeName, MountPath: caBundlesMountPath, ReadOnly: true},
+			{Name: caCertificatesVolumeName, MountPath: caCertificatesMountPath},
+		},
+	})
+
+	systemBundle := apiv1.VolumeMount{
+		Name:      caCertificatesVolumeName,
+		MountPath: caSystemBundlePath,
+		SubPath:   caSystemBundleFile,
+		ReadOnly:  true,
+	}
+	for i := range pod.Spec.Containers {
+		c := &pod.Spec.Containers[i]
+		switch c.Name {
+		case containerNameBrowser:
+			c.VolumeMounts = append(c.VolumeMounts,
+				systemBundle,
+				apiv1.VolumeMount{Name: caCertificatesVolumeName, MountPath: caFirefoxPoliciesPath, SubPath: caFirefoxPoliciesDir, ReadOnly: true},
+				apiv1.VolumeMount{Name: caCertificatesVolumeName, MountPath: caCertsPath, SubPath: caCertsDir, ReadOnly: true},
+			)
+			if homeDir != "" {
+				c.VolumeMounts = append(c.VolumeMounts, apiv1.VolumeMount{
+					Name:      caCertificatesVolumeName,
+					MountPath: filepath.Join(homeDir, ".pki", caNSSDBDir),
+					SubPath:   caNSSDBDir,
+				})
+			}
+			c.Env = append(c.Env, apiv1.EnvVar{Name: "SSL_CERT_FILE", Value: caSystemBundlePath})
+		case containerNameSidecar:
+			c.VolumeMounts = append(c.VolumeMounts, systemBundle)
+			c.Env = append(c.Env, apiv1.EnvVar{Name: "SSL_CERT_FILE", Value: caSystemBundlePath})
+		}
+	}
+}
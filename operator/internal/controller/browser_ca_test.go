@@ -0,0 +1,68 @@
+package controller
+
+import (
+	"testing"
+
+	apiv1 "k8s.io/api/core/v1"
+
+	browserkubeapiv1 "github.com/browserkube/browserkube/operator/api/v1"
+)
+
+func TestAddCABundles(t *testing.T) {
+	pod := testPod(apiv1.PodStatus{})
+	pod.Spec.Containers[0].Image = "selenium/standalone-chrome:latest"
+	pod.Spec.InitContainers = []apiv1.Container{{Name: extensionInstallerContainerName}}
+	addCABundles(pod, []browserkubeapiv1.CABundle{
+		{ConfigMap: "corp-ca", Key: "ca.crt"},
+		{Secret: "staging-ca", Key: "root.pem"},
+	}, "/home/seluser", "extension-installer:latest")
+
+	if len(pod.Spec.InitContainers) != 3 || pod.Spec.InitContainers[1].Name != caImageBundleContainerName ||
+		pod.Spec.InitContainers[2].Name != caInstallerContainerName {
+		t.Fatalf("init containers = %+v", pod.Spec.InitContainers)
+	}
+	// the bundle of the browser image is extended rather than the one of the installer image
+	if got := pod.Spec.InitContainers[1].Image; got != "selenium/standalone-chrome:latest" {
+		t.Errorf("ca-image-bundle image = %s", got)
+	}
+	if got := pod.Spec.InitContainers[2].Command; len(got) != 1 || got[0] != caInstallerCommand {
+		t.Errorf("ca-installer command = %v", got)
+	}
+	if got := pod.Spec.InitContainers[2].Args; got[len(got)-1] != "--systemBundle=/ca-certificates/image-ca-certificates.crt" {
+		t.Errorf("ca-installer args = %v", got)
+	}
+
+	var sources []apiv1.VolumeProjection
+	for _, v := range pod.Spec.Volumes {
+		if v.Name == caBundlesVolumeName {
+			sources = v.Projected.Sources
+		}
+	}
+	if len(sources) != 2 || sources[0].ConfigMap.Name != "corp-ca" || sources[1].Secret.Name != "staging-ca" ||
+		sources[1].Secret.Items[0].Key != "root.pem" || sources[1].Secret.Items[0].Path != "bundle-1.crt" {
+		t.Errorf("bundle sources = %+v", sources)
+	}
+
+	mounts := map[string]apiv1.VolumeMount{}
+	for _, m := range pod.Spec.Containers[0].VolumeMounts {
+		mounts[m.MountPath] = m
+	}
+	if m := mounts["/home/seluser/.pki/nssdb"]; m.SubPath != caNSSDBDir || m.ReadOnly {
+		t.Errorf("NSS database mount = %+v", m)
+	}
+	if m := mounts[caSystemBundlePath]; m.SubPath != caSystemBundleFile {
+		t.Errorf("system bundle mount = %+v", m)
+	}
+	if m := mounts[caFirefoxPoliciesPath]; m.SubPath != caFirefoxPoliciesDir {
+		t.Errorf("Firefox policies mount = %+v", m)
+	}
+	if len(pod.Spec.Containers[1].VolumeMounts) != 1 {
+		t.Errorf("sidecar mounts = %+v", pod.Spec.Containers[1].VolumeMounts)
+	}
+
+	pod = testPod(apiv1.PodStatus{})
+	addCABundles(pod, nil, "/home/seluser", "extension-installer:latest")
+	if len(pod.Spec.InitContainers) != 0 || len(pod.Spec.Volumes) != 0 {
+		t.Errorf("pod is changed without CA bundles: %+v", pod.Spec)
+	}
+}
@@ -0,0 +1,207 @@
+package controller
+
+import (
+	"fmt"
+	"strings"
+
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	browserkubeapiv1 "github.com/browserkube/browserkube/operator/api/v1"
+)
+
+// event reasons which aren't reasons of browser conditions
+const (
+	eventReasonCreationFailed = "CreationFailed"
+	eventReasonDeletionFailed = "DeletionFailed"
+)
+
+// imagePullFailures are waiting reasons reported by kubelet when container image can't be pulled
+var imagePullFailures = map[string]bool{
+	"ErrImagePull":        true,
+	"ImagePullBackOff":    true,
+	"InvalidImageName":    true,
+	"ErrImageNeverPull":   true,
+	"RegistryUnavailable": true,
+}
+
+// containerStarting are waiting reasons of containers which are being started by kubelet
+var containerStarting = map[string]bool{
+	"ContainerCreating": true,
+	"PodInitializing":   true,
+}
+
+// podConditions derives Scheduled, ImagePulled and ContainersReady conditions from the browser pod status
+func podConditions(pod *apiv1.Pod) []metav1.Condition {
+	return []metav1.Condition{
+		scheduledCondition(pod),
+		imagePulledCondition(pod),
+		containersReadyCondition(pod),
+	}
+}
+
+func scheduledCondition(pod *apiv1.Pod) metav1.Condition {
+	cond := metav1.Condition{
+		Type:    browserkubeapiv1.ConditionScheduled,
+		Status:  metav1.ConditionUnknown,
+		Reason:  browserkubeapiv1.ConditionReasonPodCreated,
+		Message: "waiting for the pod to be scheduled",
+	}
+	for _, c := range pod.Status.Conditions {
+		if c.Type != apiv1.PodScheduled {
+			continue
+		}
+		switch c.Status {
+		case apiv1.ConditionTrue:
+			cond.Status = metav1.ConditionTrue
+			cond.Reason = browserkubeapiv1.ConditionReasonPodScheduled
+			cond.Message = fmt.Sprintf("pod %s is scheduled to node %s", pod.Name, pod.Spec.NodeName)
+		case apiv1.ConditionFalse:
+			// e.g. Unschedulable with the scheduler message about insufficient resources
+			cond.Status = metav1.ConditionFalse
+			if c.Reason != "" {
+				cond.Reason = c.Reason
+			}
+			cond.Message = c.Message
+		}
+	}
+	return cond
+}
+
+func imagePulledCondition(pod *apiv1.Pod) metav1.Condition {
+	cond := metav1.Condition{
+		Type:    browserkubeapiv1.ConditionImagePulled,
+		Status:  metav1.ConditionUnknown,
+		Reason:  browserkubeapiv1.ConditionReasonPullingImages,
+		Message: "waiting for container images to be pulled",
+	}
+	statuses := append(append([]apiv1.ContainerStatus{}, pod.Status.InitContainerStatuses...), pod.Status.ContainerStatuses...)
+	pulled := 0
+	for _, s := range statuses {
+		if w := s.State.Waiting; w != nil && imagePullFailures[w.Reason] {
+			cond.Status = metav1.ConditionFalse
+			cond.Reason = w.Reason
+			cond.Message = fmt.Sprintf("container %s: %s", s.Name, w.Message)
+			return cond
+		}
+		if s.ImageID != "" {
+			pulled++
+		}
+	}
+	if len(statuses) > 0 && pulled == len(pod.Spec.InitContainers)+len(pod.Spec.Containers) {
+		cond.Status = metav1.ConditionTrue
+		cond.Reason = browserkubeapiv1.ConditionReasonImagesPulled
+		cond.Message = "all container images are pulled"
+	}
+	return cond
+}
+
+// containersReadyCondition is Unknown until all containers are started, so starting browsers don't report
+// failures. Image pull failures are reported by ImagePulled condition
+func containersReadyCondition(pod *apiv1.Pod) metav1.Condition {
+	var notReady []string
+	starting := len(pod.Status.ContainerStatuses) < len(pod.Spec.Containers)
+	for _, s := range pod.Status.ContainerStatuses {
+		if s.Ready {
+			continue
+		}
+		w := s.State.Waiting
+		// a container which keeps failing is more specific than a generic "not ready"
+		if w != nil && w.Reason == "CrashLoopBackOff" {
+			return metav1.Condition{
+				Type:    browserkubeapiv1.ConditionContainersReady,
+				Status:  metav1.ConditionFalse,
+				Reason:  w.Reason,
+				Message: fmt.Sprintf("container %s: %s", s.Name, w.Message),
+			}
+		}
+		if s.State.Running == nil && s.State.Terminated == nil &&
+			(w == nil || containerStarting[w.Reason] || imagePullFailures[w.Reason]) {
+			starting = true
+		}
+		notReady = append(notReady, s.Name)
+	}
+	if !starting && len(notReady) == 0 {
+		return metav1.Condition{
+			Type:    browserkubeapiv1.ConditionContainersReady,
+			Status:  metav1.ConditionTrue,
+			Reason:  browserkubeapiv1.ConditionReasonContainersReady,
+			Message: "all containers are ready",
+		}
+	}
+	if starting {
+		return metav1.Condition{
+			Type:    browserkubeapiv1.ConditionContainersReady,
+			Status:  metav1.ConditionUnknown,
+			Reason:  browserkubeapiv1.ConditionReasonContainersNotReady,
+			Message: "waiting for containers to start",
+		}
+	}
+	return metav1.Condition{
+		Type:    browserkubeapiv1.ConditionContainersReady,
+		Status:  metav1.ConditionFalse,
+		Reason:  browserkubeapiv1.ConditionReasonContainersNotReady,
+		Message: fmt.Sprintf("containers are not ready: %s", strings.Join(notReady, ", ")),
+	}
+}
+
+// sessionCondition reports whether running browser serves a session. Idle pooled browsers wait for one
+func sessionCondition(browser *browserkubeapiv1.Browser) metav1.Condition {
+	if browser.Labels[browserkubeapiv1.LabelPoolState] == browserkubeapiv1.PoolStateIdle {
+		return metav1.Condition{
+			Type:    browserkubeapiv1.ConditionSessionActive,
+			Status:  metav1.ConditionFalse,
+			Reason:  browserkubeapiv1.ConditionReasonWaitingForSession,
+			Message: "pooled browser is waiting for a session",
+		}
+	}
+	return metav1.Condition{
+		Type:    browserkubeapiv1.ConditionSessionActive,
+		Status:  metav1.ConditionTrue,
+		Reason:  browserkubeapiv1.ConditionReasonSessionStarted,
+		Message: fmt.Sprintf("browser serves session %s", browser.SessionID()),
+	}
+}
+
+// terminatedConditions marks the browser as terminated and its session as finished
+func terminatedConditions(reason, message string) []metav1.Condition {
+	return []metav1.Condition{
+		{
+			Type:    browserkubeapiv1.ConditionSessionActive,
+			Status:  metav1.ConditionFalse,
+			Reason:  browserkubeapiv1.ConditionReasonSessionFinished,
+			Message: message,
+		},
+		{
+			Type:    browserkubeapiv1.ConditionTerminated,
+			Status:  metav1.ConditionTrue,
+			Reason:  reason,
+			Message: message,
+		},
+	}
+}
+
+// setConditions updates browser conditions and records an event on every status or reason transition.
+// Returns true if browser status has to be updated
+func (r *BrowserReconciler) setConditions(browser *browserkubeapiv1.Browser, conditions ...metav1.Condition) bool {
+	changed := false
+	for _, cond := range conditions {
+		cond.ObservedGeneration = browser.Generation
+		prev := meta.FindStatusCondition(browser.Status.Conditions, cond.Type)
+		transition := prev == nil || prev.Status != cond.Status || prev.Reason != cond.Reason
+		if !meta.SetStatusCondition(&browser.Status.Conditions, cond) {
+			continue
+		}
+		changed = true
+		if !transition || cond.Status == metav1.ConditionUnknown {
+			continue
+		}
+		eventType := apiv1.EventTypeNormal
+		if cond.Status == metav1.ConditionFalse && cond.Type != browserkubeapiv1.ConditionSessionActive {
+			eventType = apiv1.EventTypeWarning
+		}
+		r.Recorder.Eventf(browser, eventType, cond.Reason, "%s is %s: %s", cond.Type, cond.Status, cond.Message)
+	}
+	return changed
+}
@@ -0,0 +1,214 @@
+package controller
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	browserkubeapiv1 "github.com/browserkube/browserkube/operator/api/v1"
+)
+
+func testPod(status v1.PodStatus) *v1.Pod {
+	return &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "browser-session", Namespace: defaultNs},
+		Spec: v1.PodSpec{
+			NodeName:   "node",
+			Containers: []v1.Container{{Name: containerNameBrowser}, {Name: containerNameSidecar}},
+		},
+		Status: status,
+	}
+}
+
+func TestPodConditions(t *testing.T) {
+	scheduled := []v1.PodCondition{{Type: v1.PodScheduled, Status: v1.ConditionTrue}}
+	tests := []struct {
+		name   string
+		status v1.PodStatus
+		want   map[string]string
+		// starting pods report Unknown ContainersReady condition
+		starting bool
+	}{
+		{
+			name: "unschedulable",
+			status: v1.PodStatus{Conditions: []v1.PodCondition{{
+				Type: v1.PodScheduled, Status: v1.ConditionFalse, Reason: "Unschedulable", Message: "0/3 nodes are available",
+			}}},
+			want: map[string]string{
+				browserkubeapiv1.ConditionScheduled:       "Unschedulable",
+				browserkubeapiv1.ConditionImagePulled:     browserkubeapiv1.ConditionReasonPullingImages,
+				browserkubeapiv1.ConditionContainersReady: browserkubeapiv1.ConditionReasonContainersNotReady,
+			},
+			starting: true,
+		},
+		{
+			name: "image pull back-off",
+			status: v1.PodStatus{
+				Conditions: scheduled,
+				ContainerStatuses: []v1.ContainerStatus{
+					{Name: containerNameBrowser, State: v1.ContainerState{Waiting: &v1.ContainerStateWaiting{Reason: "ImagePullBackOff"}}},
+					{Name: containerNameSidecar, ImageID: "sidecar", Ready: true},
+				},
+			},
+			want: map[string]string{
+				browserkubeapiv1.ConditionScheduled:       browserkubeapiv1.ConditionReasonPodScheduled,
+				browserkubeapiv1.ConditionImagePulled:     "ImagePullBackOff",
+				browserkubeapiv1.ConditionContainersReady: browserkubeapiv1.ConditionReasonContainersNotReady,
+			},
+			starting: true,
+		},
+		{
+			name: "containers starting",
+			status: v1.PodStatus{
+				Conditions: scheduled,
+				ContainerStatuses: []v1.ContainerStatus{
+					{Name: containerNameBrowser, ImageID: "browser", State: v1.ContainerState{Waiting: &v1.ContainerStateWaiting{Reason: "ContainerCreating"}}},
+					{Name: containerNameSidecar, ImageID: "sidecar", State: v1.ContainerState{Running: &v1.ContainerStateRunning{}}},
+				},
+			},
+			want: map[string]string{
+				browserkubeapiv1.ConditionImagePulled:     browserkubeapiv1.ConditionReasonImagesPulled,
+				browserkubeapiv1.ConditionContainersReady: browserkubeapiv1.ConditionReasonContainersNotReady,
+			},
+			starting: true,
+		},
+		{
+			name: "containers not ready",
+			status: v1.PodStatus{
+				Conditions: scheduled,
+				ContainerStatuses: []v1.ContainerStatus{
+					{Name: containerNameBrowser, ImageID: "browser", State: v1.ContainerState{Running: &v1.ContainerStateRunning{}}},
+					{Name: containerNameSidecar, ImageID: "sidecar", Ready: true},
+				},
+			},
+			want: map[string]string{
+				browserkubeapiv1.ConditionContainersReady: browserkubeapiv1.ConditionReasonContainersNotReady,
+			},
+		},
+		{
+			name: "crash loop",
+			status: v1.PodStatus{
+				Conditions: scheduled,
+				ContainerStatuses: []v1.ContainerStatus{
+					{Name: containerNameBrowser, ImageID: "browser", State: v1.ContainerState{Waiting: &v1.ContainerStateWaiting{Reason: "CrashLoopBackOff"}}},
+					{Name: containerNameSidecar, ImageID: "sidecar", Ready: true},
+				},
+			},
+			want: map[string]string{
+				browserkubeapiv1.ConditionScheduled:       browserkubeapiv1.ConditionReasonPodScheduled,
+				browserkubeapiv1.ConditionImagePulled:     browserkubeapiv1.ConditionReasonImagesPulled,
+				browserkubeapiv1.ConditionContainersReady: "CrashLoopBackOff",
+			},
+		},
+		{
+			name: "ready",
+			status: v1.PodStatus{
+				Conditions: scheduled,
+				ContainerStatuses: []v1.ContainerStatus{
+					{Name: containerNameBrowser, ImageID: "browser", Ready: true},
+					{Name: containerNameSidecar, ImageID: "sidecar", Ready: true},
+				},
+			},
+			want: map[string]string{
+				browserkubeapiv1.ConditionScheduled:       browserkubeapiv1.ConditionReasonPodScheduled,
+				browserkubeapiv1.ConditionImagePulled:     browserkubeapiv1.ConditionReasonImagesPulled,
+				browserkubeapiv1.ConditionContainersReady: browserkubeapiv1.ConditionReasonContainersReady,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := map[string]string{}
+			starting := false
+			for _, c := range podConditions(testPod(tt.status)) {
+				got[c.Type] = c.Reason
+				if c.Type == browserkubeapiv1.ConditionContainersReady {
+					starting = c.Status == metav1.ConditionUnknown
+				}
+			}
+			for condType, reason := range tt.want {
+				if got[condType] != reason {
+					t.Errorf("%s reason = %s, want %s", condType, got[condType], reason)
+				}
+			}
+			if starting != tt.starting {
+				t.Errorf("%s is Unknown = %t, want %t", browserkubeapiv1.ConditionContainersReady, starting, tt.starting)
+			}
+		})
+	}
+}
+
+func TestCheckPendingConditions(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := browserkubeapiv1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	browser := &browserkubeapiv1.Browser{
+		ObjectMeta: metav1.ObjectMeta{Name: "session", Namespace: defaultNs, CreationTimestamp: metav1.Now()},
+		Status:     browserkubeapiv1.BrowserStatus{Phase: browserkubeapiv1.PhasePending},
+	}
+	recorder := record.NewFakeRecorder(10)
+	r := &BrowserReconciler{
+		Client: fake.NewClientBuilder().WithScheme(scheme).
+			WithObjects(browser).WithStatusSubresource(browser).Build(),
+		Recorder: recorder,
+	}
+
+	pod := testPod(v1.PodStatus{
+		Conditions: []v1.PodCondition{{Type: v1.PodScheduled, Status: v1.ConditionTrue}},
+		ContainerStatuses: []v1.ContainerStatus{
+			{Name: containerNameBrowser, State: v1.ContainerState{Waiting: &v1.ContainerStateWaiting{
+				Reason: "ErrImagePull", Message: "manifest unknown",
+			}}},
+			{Name: containerNameSidecar, ImageID: "sidecar", Ready: true},
+		},
+	})
+	if res, err := r.checkPending(context.Background(), browser, pod); err != nil || res == nil || !res.Requeue {
+		t.Fatalf("checkPending() = %v, %v, want requeue", res, err)
+	}
+	if cond := browser.NotReadyCondition(); cond == nil || cond.Reason != "ErrImagePull" {
+		t.Errorf("NotReadyCondition() = %+v, want ErrImagePull", cond)
+	}
+	assertEvents(t, recorder, "Normal PodScheduled", "Warning ErrImagePull")
+
+	// the same pod state doesn't produce new events
+	if _, err := r.checkPending(context.Background(), browser, pod); err != nil {
+		t.Fatalf("checkPending() unexpected error = %v", err)
+	}
+	assertEvents(t, recorder)
+
+	pod.Status.PodIP = "10.0.0.1"
+	pod.Status.ContainerStatuses[0] = v1.ContainerStatus{Name: containerNameBrowser, ImageID: "browser", Ready: true}
+	if _, err := r.checkPending(context.Background(), browser, pod); err != nil {
+		t.Fatalf("checkPending() unexpected error = %v", err)
+	}
+	if browser.Status.Phase != browserkubeapiv1.PhaseRunning {
+		t.Errorf("phase = %s, want Running", browser.Status.Phase)
+	}
+	if !meta.IsStatusConditionTrue(browser.Status.Conditions, browserkubeapiv1.ConditionSessionActive) {
+		t.Errorf("conditions = %+v, want active session", browser.Status.Conditions)
+	}
+	assertEvents(t, recorder, "Normal ImagesPulled", "Normal ContainersReady", "Normal SessionStarted")
+}
+
+func assertEvents(t *testing.T, recorder *record.FakeRecorder, want ...string) {
+	t.Helper()
+	var got []string
+	for len(recorder.Events) > 0 {
+		got = append(got, <-recorder.Events)
+	}
+	if len(got) != len(want) {
+		t.Fatalf("events = %v, want %v", got, want)
+	}
+	for i := range want {
+		if !strings.HasPrefix(got[i], want[i]) {
+			t.Errorf("event = %s, want %s", got[i], want[i])
+		}
+	}
+}
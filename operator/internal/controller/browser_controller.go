@@ -30,18 +30,22 @@ import (
 	errors2 "github.com/pkg/errors"
 	apiv1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/tools/record"
 	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	browserkubeapiv1 "github.com/browserkube/browserkube/operator/api/v1"
 	"github.com/browserkube/browserkube/operator/internal/controller/utils"
+	"github.com/browserkube/browserkube/operator/pkg/browserset"
 )
 
 const (
@@ -58,12 +62,22 @@ const (
 	containerNameSidecar            = "sidecar"
 	containerNameRecorder           = "recorder"
 	containerNameClipboard          = "clipboard"
+	containerNameXServer            = "x-server"
+	containerNameVNCServer          = "vnc-server"
 	extensionInstallerContainerName = "extension-installer"
+	caInstallerContainerName        = "ca-installer"
+	caImageBundleContainerName      = "ca-image-bundle"
 )
 
 // selenium constants
 const sidecarSeleniumPath = "/wd/hub"
 
+// playwright readiness probe types configured by "playwright.probe" key of the readiness ConfigMap
+const (
+	readinessProbeTCP  = "tcp"
+	readinessProbeHTTP = "http"
+)
+
 // recorder constants
 const (
 	recorderVideosRelativePath = "/videos"
@@ -94,6 +108,8 @@ func (br *browserErr) Error() string {
 type BrowserReconciler struct {
 	client.Client
 	Scheme *runtime.Scheme
+	// Recorder records events on every browser condition transition and failure
+	Recorder record.EventRecorder
 
 	finalizerName string
 	opts          *BrowserCtrlOpts
@@ -102,18 +118,21 @@ type BrowserReconciler struct {
 func NewBrowserReconciler(
 	client client.Client,
 	scheme *runtime.Scheme,
+	recorder record.EventRecorder,
 	opts *BrowserCtrlOpts,
 ) *BrowserReconciler {
 	return &BrowserReconciler{
-		Client: client,
-		Scheme: scheme,
-		opts:   opts,
+		Client:   client,
+		Scheme:   scheme,
+		Recorder: recorder,
+		opts:     opts,
 		finalizerName: fmt.Sprintf("%s/finalizer",
 			browserkubeapiv1.GroupVersion.WithResource("browsers").GroupResource().String()),
 	}
 }
 
 //+kubebuilder:rbac:groups=api.browserkube.io,namespace=browserkube,resources=browsersets,verbs=get;list;watch
+//+kubebuilder:rbac:groups=api.browserkube.io,namespace=browserkube,resources=extensionpolicies,verbs=get;list;watch
 //+kubebuilder:rbac:groups=api.browserkube.io,namespace=browserkube,resources=browsers,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=api.browserkube.io,namespace=browserkube,resources=browsers/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=api.browserkube.io,namespace=browserkube,resources=browsers/finalizers,verbs=update
@@ -121,6 +140,9 @@ func NewBrowserReconciler(
 //+kubebuilder:rbac:groups=api.browserkube.io,namespace=browserkube,resources=sessionresults,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=core,namespace=browserkube,resources=pods,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=core,namespace=browserkube,resources=pods/status,verbs=get
+//+kubebuilder:rbac:groups=core,namespace=browserkube,resources=events,verbs=create;patch
+//+kubebuilder:rbac:groups=core,namespace=browserkube,resources=secrets,verbs=get;list;watch;create
+//+kubebuilder:rbac:groups=networking.k8s.io,namespace=browserkube,resources=networkpolicies,verbs=get;list;watch;create
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the cluster closer to the desired state.
@@ -166,8 +188,11 @@ func (r *BrowserReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ct
 				} else {
 					instance.Status.Reason = browserkubeapiv1.ReasonUnknown
 				}
+				browserFailuresTotal.WithLabelValues(string(instance.Status.Reason)).Inc()
 				instance.Status.Message = cErr.Error()
 				instance.Status.Phase = browserkubeapiv1.PhaseFailed
+				r.Recorder.Eventf(instance, apiv1.EventTypeWarning, eventReasonCreationFailed,
+					"%s: %s", instance.Status.Reason, instance.Status.Message)
 
 				if suErr := r.Status().Update(ctx, instance); suErr != nil {
 					return ctrl.Result{Requeue: false}, suErr
@@ -191,6 +216,12 @@ func (r *BrowserReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ct
 	if res, resErr := r.checkSidecarRunning(ctx, instance, &browserkubePod); res != nil {
 		return *res, resErr
 	}
+	if res, resErr := r.checkSessionActive(ctx, instance); res != nil {
+		return *res, resErr
+	}
+	if res, resErr := r.checkSessionDeadline(ctx, instance); res != nil {
+		return *res, resErr
+	}
 
 	return ctrl.Result{}, nil
 }
@@ -213,15 +244,15 @@ func (r *BrowserReconciler) createBrowser(
 
 	logger.Info("Starting browser pod", "image", browserConfig.Image)
 
-	readinessProbe, err := r.getReadinessProbe(ctx, browser.Namespace, browser.Spec.Type, browserConfig.Path, browserConfig.Port)
+	browserPodBuilder, profile, err := NewPodBuilder(browserConfig)
 	if err != nil {
-		logger.Error(err, fmt.Sprintf("error while getting readiness probe: %s", err))
+		logger.Error(err, "error while creating browser pod builder", "error", err.Error())
+		return err
 	}
 
-	browserPodBuilder, imgType, err := NewPodBuilder(browserConfig)
+	readinessProbe, err := r.getReadinessProbe(ctx, browser.Namespace, browser.Spec.Type, browserConfig.Path, browserConfig.Port, profile.ReadinessPath)
 	if err != nil {
-		logger.Error(err, "error while creating browser pod builder", "error", err.Error())
-		return err
+		logger.Error(err, fmt.Sprintf("error while getting readiness probe: %s", err))
 	}
 
 	browserPod, err := browserPodBuilder.Build(ctx, browser, r.opts, readinessProbe)
@@ -229,23 +260,65 @@ func (r *BrowserReconciler) createBrowser(
 		logger.Error(err, "error while creating browser pod", "error", err.Error())
 		return err
 	}
+	addProxy(browserPod, browser, browserConfig.Proxy)
+	addPooledEnv(browserPod, browser)
+	addCABundles(browserPod, browserConfig.CABundles, profile.HomeDir, r.opts.extensionInstallerImage)
+	browserPod, err = applyPodTemplateOverlay(browserPod, browserConfig.PodTemplateOverlay)
+	if err != nil {
+		logger.Error(err, "error while applying pod template overlay", "error", err.Error())
+		return &browserErr{reason: browserkubeapiv1.ReasonInvalidPodOverlay, error: err}
+	}
+	if browserPod.Annotations == nil {
+		browserPod.Annotations = map[string]string{}
+	}
+	browserPod.Annotations[browserkubeapiv1.AnnotationImageFlavour] = profile.Type.Flavour()
 
 	if err = controllerutil.SetControllerReference(browser, browserPod, r.Scheme); err != nil {
 		logger.Error(err, "error while setting controller reference")
 		return err
 	}
 
+	if browser.Spec.EnableVNC {
+		vncSecret, sErr := r.createVNCSecret(ctx, browser)
+		if sErr != nil {
+			logger.Error(sErr, "error while creating VNC secret", "error", sErr.Error())
+			return sErr
+		}
+		browser.Status.VNCSecret = vncSecret.Name
+	}
+	if browserConfig.NetworkPolicy != nil {
+		networkPolicy, nErr := r.createNetworkPolicy(ctx, browser, browserConfig.NetworkPolicy)
+		if nErr != nil {
+			logger.Error(nErr, "error while creating network policy", "error", nErr.Error())
+			return nErr
+		}
+		browser.Status.NetworkPolicy = networkPolicy.Name
+	}
+
 	err = r.Create(ctx, browserPod, &client.CreateOptions{})
 	if err != nil {
 		logger.Error(err, "error while creating browser object", "error", err.Error())
 		return err
 	}
+	r.Recorder.Eventf(browser, apiv1.EventTypeNormal, browserkubeapiv1.ConditionReasonPodCreated,
+		"Created pod %s with image %s", browserPod.Name, browserConfig.Image)
 
 	browser.Status.PortConfig = ports
+	if browserConfig.Port != "" {
+		// playwright sessions connect to the browser port directly
+		browser.Status.PortConfig.Browser = browserConfig.Port
+	}
 	browser.Status.Phase = browserkubeapiv1.PhasePending
 	browser.Status.Image = browserConfig.Image
 	browser.Status.PodName = browserPod.Name
-	browser.Status.VncPass = imgType.VncPass()
+	browser.Status.PortConfig.VNC = profile.VNCPort
+	browser.Status.BrowserSet = browser.Labels[browserkubeapiv1.LabelBrowserSet]
+	timeouts := browserConfig.Timeouts()
+	browser.Status.Timeouts = &timeouts
+	browser.Status.Recording = browserConfig.Recording
+	browser.Status.Proxy = browserConfig.Proxy
+	browser.Status.StartupDeadline = ptr.To(metav1.NewTime(browser.CreationTimestamp.Add(timeouts.Startup.Duration)))
+	r.setConditions(browser, podConditions(browserPod)...)
 	logger.Info("updating browser resource", "resource", fmt.Sprintf("%+v", browser))
 
 	if err := r.Status().Update(ctx, browser); err != nil {
@@ -256,7 +329,7 @@ func (r *BrowserReconciler) createBrowser(
 	return nil
 }
 
-func (r *BrowserReconciler) getReadinessProbe(ctx context.Context, namespace, browserType, path, port string) (*apiv1.Probe, error) {
+func (r *BrowserReconciler) getReadinessProbe(ctx context.Context, namespace, browserType, path, port, readinessPath string) (*apiv1.Probe, error) {
 	var livenessConfigMap apiv1.ConfigMap
 	err := r.Get(ctx, types.NamespacedName{
 		Namespace: namespace,
@@ -275,8 +348,9 @@ func (r *BrowserReconciler) getReadinessProbe(ctx context.Context, namespace, br
 		return nil, nil
 	}
 
-	probeAction := r.getReadinessProbeAction(browserType, path, port)
-	if probeAction == nil {
+	probeHandler := r.getReadinessProbeHandler(browserType, path, port, readinessPath,
+		livenessConfigMap.Data[utils.CreateReadinessConfigmapKey(prefix, "probe")])
+	if probeHandler == nil {
 		return nil, nil
 	}
 
@@ -294,9 +368,7 @@ func (r *BrowserReconciler) getReadinessProbe(ctx context.Context, namespace, br
 	}
 
 	return &apiv1.Probe{
-		ProbeHandler: apiv1.ProbeHandler{
-			HTTPGet: probeAction,
-		},
+		ProbeHandler:        *probeHandler,
 		InitialDelaySeconds: int32(initialDelay),
 		TimeoutSeconds:      int32(timeoutSecond),
 		FailureThreshold:    int32(failureThreshold),
@@ -315,7 +387,10 @@ func (r *BrowserReconciler) findBrowserConfig(ctx context.Context, browser *brow
 	if len(instances.Items) == 0 {
 		return nil, &browserErr{reason: browserkubeapiv1.ReasonConfigNotFound}
 	}
-	instance := instances.Items[0]
+	browserSets, err := browserset.Select(instances.Items, browser.Spec.BrowserSet, browser.Spec.BrowserSetSelector)
+	if err != nil {
+		return nil, &browserErr{reason: browserkubeapiv1.ReasonConfigNotFound, error: err}
+	}
 
 	var platform string
 	if browser.Spec.Platform == "" {
@@ -333,45 +408,113 @@ func (r *BrowserReconciler) findBrowserConfig(ctx context.Context, browser *brow
 	if browserType == "" {
 		browserType = browserkubeapiv1.TypeWebDriver
 	}
-
-	var browsers map[string]browserkubeapiv1.BrowsersConfig
-	switch browserType {
-	case browserkubeapiv1.TypePlaywright:
-		browsers = instance.Spec.Playwright
-	case browserkubeapiv1.TypeWebDriver:
-		browsers = instance.Spec.WebDriver
-	default:
+	if browserType != browserkubeapiv1.TypePlaywright && browserType != browserkubeapiv1.TypeWebDriver {
 		return nil, &browserErr{reason: browserkubeapiv1.ReasonUnknownSessionType}
 	}
+
 	switch platform {
 	case "linux":
-		browserMapping, ok := browsers[browserName]
-		if !ok {
-			return nil, &browserErr{reason: browserkubeapiv1.ReasonVersionNotSupported, error: fmt.Errorf("browser '%s' is not supported", browserName)}
+		resolved, rErr := browserset.Resolve(browserSets, browserType, browserName, browser.Spec.BrowserVersion)
+		if rErr != nil {
+			return nil, &browserErr{reason: browserkubeapiv1.ReasonVersionNotSupported, error: rErr}
 		}
-
-		browser.Spec.BrowserVersion = utils.FirstNonEmpty(browser.Spec.BrowserVersion, browserMapping.DefaultVersion)
-		browserConfig, ok := browserMapping.Versions[browser.Spec.BrowserVersion]
-		if !ok {
-			return nil, &browserErr{reason: browserkubeapiv1.ReasonVersionNotSupported, error: fmt.Errorf("browser '%s' is not supported", browser.Spec.BrowserVersion)}
+		instance := resolved.Set
+		browser.Spec.BrowserVersion = resolved.Version
+		if browser.Labels == nil {
+			browser.Labels = map[string]string{}
 		}
-		browserConfig.Path = utils.FirstNonEmpty(browserConfig.Path, browserMapping.DefaultPath)
+		browser.Labels[browserkubeapiv1.LabelBrowserSet] = instance.Name
+
+		browserConfig := resolved.Config
+		browserConfig.Path = utils.FirstNonEmpty(browserConfig.Path, resolved.Browsers.DefaultPath)
 		browserConfig.Timezone = utils.FirstNonEmpty(browser.Spec.Timezone, browserConfig.Timezone, instance.Spec.DefaultTimezone, "UTC")
 
 		// video options
 		browserConfig.EnableVideo = browser.Spec.EnableVideo
+		browserConfig.Recording = nil
+		if browserConfig.EnableVideo {
+			recording, recErr := browserset.Recording(resolved, browser.Spec.Recording, browser.Spec.ScreenResolution)
+			if recErr != nil {
+				return nil, &browserErr{reason: browserkubeapiv1.ReasonInvalidRecording, error: recErr}
+			}
+			browserConfig.Recording = &recording
+		}
 
-		// TODO write some unit tests
+		// version-level pod spec overrides the set-level one.
+		// Both are copied since they belong to the cached BrowserSet
+		browserConfig.Spec = browserConfig.Spec.DeepCopy()
 		if podSpec := instance.Spec.PodSpec; podSpec != nil {
 			if browserConfig.Spec == nil {
-				browserConfig.Spec = podSpec
-			} else {
-				merr := mergo.Merge(&browserConfig.Spec, podSpec)
-				if merr != nil {
+				browserConfig.Spec = podSpec.DeepCopy()
+			} else if merr := mergo.Merge(browserConfig.Spec, podSpec.DeepCopy()); merr != nil {
+				return nil, merr
+			}
+		}
+
+		// size profile overrides both
+		if profileName := browser.Spec.SizeProfile; profileName != "" {
+			profile, ok := browserset.SizeProfile(browserSets, profileName)
+			if !ok {
+				return nil, &browserErr{
+					reason: browserkubeapiv1.ReasonSizeProfileNotFound,
+					error:  fmt.Errorf("size profile '%s' is not found", profileName),
+				}
+			}
+			if browserConfig.Spec == nil {
+				browserConfig.Spec = &browserkubeapiv1.BrowserPodSpec{}
+			}
+			if browserConfig.Spec.Resources != nil {
+				if merr := mergo.Merge(profile, browserConfig.Spec.Resources); merr != nil {
 					return nil, merr
 				}
 			}
+			browserConfig.Spec.Resources = profile
+		}
+
+		overlay, oErr := browserset.PodTemplateOverlay(resolved)
+		if oErr != nil {
+			return nil, &browserErr{reason: browserkubeapiv1.ReasonInvalidPodOverlay, error: oErr}
+		}
+		browserConfig.PodTemplateOverlay = overlay
+
+		networkPolicy, nErr := browserset.NetworkPolicy(resolved, browser.Spec.Network)
+		if nErr != nil {
+			return nil, &browserErr{reason: browserkubeapiv1.ReasonNetworkNotAllowed, error: nErr}
+		}
+		browserConfig.NetworkPolicy = networkPolicy
+
+		proxy, pErr := browserset.Proxy(resolved, browser.Spec.Proxy)
+		if pErr != nil {
+			return nil, &browserErr{reason: browserkubeapiv1.ReasonProxyNotAllowed, error: pErr}
+		}
+		browserConfig.Proxy = proxy
+
+		for _, extension := range browser.Spec.Extensions {
+			if extension.Artifact != "" && r.opts.extensionArtifactsURL == "" {
+				return nil, &browserErr{
+					reason: browserkubeapiv1.ReasonInvalidExtension,
+					error:  fmt.Errorf("extension artifact %s can't be installed, artifacts URL isn't configured", extension.Artifact),
+				}
+			}
+		}
+		if eErr := r.checkExtensions(ctx, browser, instance.Name); eErr != nil {
+			return nil, eErr
+		}
+
+		caBundles, cErr := browserset.CABundles(resolved)
+		if cErr != nil {
+			return nil, &browserErr{reason: browserkubeapiv1.ReasonInvalidCABundle, error: cErr}
 		}
+		browserConfig.CABundles = caBundles
+
+		timeouts, tErr := browserset.Timeouts(resolved, browser.Spec.Timeouts)
+		if tErr != nil {
+			return nil, &browserErr{reason: browserkubeapiv1.ReasonTimeoutNotAllowed, error: tErr}
+		}
+		browserConfig.StartupTimeout = timeouts.Startup
+		browserConfig.SessionDeleteTimeout = timeouts.SessionDelete
+		browserConfig.IdleTimeout = timeouts.Idle
+		browserConfig.SessionTimeout = timeouts.Session
 		return &browserConfig, nil
 
 	default:
@@ -379,20 +522,26 @@ func (r *BrowserReconciler) findBrowserConfig(ctx context.Context, browser *brow
 	}
 }
 
-func (r *BrowserReconciler) deletePod(ctx context.Context, pod *apiv1.Pod) error {
+func (r *BrowserReconciler) deletePod(ctx context.Context, instance *browserkubeapiv1.Browser, pod *apiv1.Pod) error {
 	logger := log.FromContext(ctx)
 
+	gracePeriod := int64(podGracefulShutdownTimeout)
+	if timeouts := instance.Status.Timeouts; timeouts != nil && timeouts.SessionDelete != nil {
+		gracePeriod = int64(timeouts.SessionDelete.Seconds())
+	}
 	logger.Info("Deleting Browser pod", "name", pod.Name)
 	return r.Delete(ctx, pod, &client.DeleteOptions{
-		GracePeriodSeconds: ptr.To(int64(podGracefulShutdownTimeout)),
+		GracePeriodSeconds: ptr.To(gracePeriod),
 	})
 }
 
 // nolint:unparam
 func (r *BrowserReconciler) checkTerminated(ctx context.Context, instance *browserkubeapiv1.Browser, browserkubePod *apiv1.Pod) (*ctrl.Result, error) {
 	if instance.Status.Phase == browserkubeapiv1.PhaseTerminated {
-		if err := r.deletePod(context.Background(), browserkubePod); err != nil {
+		if err := r.deletePod(context.Background(), instance, browserkubePod); err != nil {
 			log.FromContext(ctx).Error(err, "unable to delete pod browser")
+			r.Recorder.Eventf(instance, apiv1.EventTypeWarning, eventReasonDeletionFailed,
+				"Unable to delete pod %s: %s", browserkubePod.Name, err)
 		}
 	}
 	return nil, nil
@@ -404,6 +553,7 @@ func (r *BrowserReconciler) checkPending(ctx context.Context, instance *browserk
 	if instance.Status.Phase != browserkubeapiv1.PhasePending {
 		return nil, nil
 	}
+	conditionsChanged := r.setConditions(instance, podConditions(browserkubePod)...)
 
 	var readyCount int
 	for _, status := range browserkubePod.Status.ContainerStatuses {
@@ -423,16 +573,40 @@ func (r *BrowserReconciler) checkPending(ctx context.Context, instance *browserk
 		}).String()
 		instance.Status.Host = host
 		instance.Status.SeleniumURL = seleniumURL
+		instance.Status.SessionDeadline = sessionDeadline(instance, time.Now())
+		r.setConditions(instance, sessionCondition(instance))
 
 		if err := r.Status().Update(ctx, instance); err != nil {
 			return &ctrl.Result{}, err
 		}
+		observeProvisioned(instance, browserkubePod.Annotations[browserkubeapiv1.AnnotationImageFlavour], time.Now())
 		return &ctrl.Result{}, nil
 	}
 	// cleanup resource if it can't get up and running
-	if instance.GetCreationTimestamp().Add(5 * time.Minute).Before(time.Now()) {
+	startupTimeout := browserset.DefaultTimeouts.Startup.Duration
+	if timeouts := instance.Status.Timeouts; timeouts != nil && timeouts.Startup != nil {
+		startupTimeout = timeouts.Startup.Duration
+	}
+	if instance.GetCreationTimestamp().Add(startupTimeout).Before(time.Now()) {
+		message := fmt.Sprintf("browser hasn't started in %s", startupTimeout)
+		if cond := instance.NotReadyCondition(); cond != nil {
+			message = fmt.Sprintf("%s: %s", message, cond.Message)
+		}
+		r.setConditions(instance, terminatedConditions(browserkubeapiv1.ConditionReasonStartTimeout, message)...)
+		instance.Status.Message = message
+		if err := r.Status().Update(ctx, instance); err != nil {
+			return &ctrl.Result{}, err
+		}
+		if instance.DeletionTimestamp.IsZero() {
+			browserStartupTimeoutsTotal.WithLabelValues(instance.Spec.BrowserName, instance.Spec.BrowserVersion).Inc()
+		}
 		return &ctrl.Result{}, r.Delete(ctx, instance)
 	}
+	if conditionsChanged {
+		if err := r.Status().Update(ctx, instance); err != nil {
+			return &ctrl.Result{}, err
+		}
+	}
 
 	// requeue to wait for pod to get up and running
 	return &ctrl.Result{Requeue: true}, nil
@@ -441,27 +615,102 @@ func (r *BrowserReconciler) checkPending(ctx context.Context, instance *browserk
 //nolint:unparam
 func (r *BrowserReconciler) checkSidecarRunning(ctx context.Context, instance *browserkubeapiv1.Browser, browserkubePod *apiv1.Pod) (*ctrl.Result, error) {
 	// sidecar container exited which signals that browser termination is requested
-	if browserkubePod.Status.Phase == apiv1.PodRunning {
-		logger := log.FromContext(ctx)
-		logger.Info("checking quit session:", "container status", browserkubePod.Status.ContainerStatuses)
-		for _, c := range browserkubePod.Status.ContainerStatuses {
-			if c.Name == containerNameSidecar && c.State.Terminated != nil {
-				logger.Info("Browser seems to be timed out. Deleting...")
-				instance.Status.Phase = browserkubeapiv1.PhaseTerminated
-				if err := r.Status().Update(ctx, instance); err != nil {
-					logger.Error(err, "unable to update browser status")
-				}
-				if instance.DeletionTimestamp.IsZero() {
-					if err := r.Delete(ctx, instance); err != nil {
-						logger.Error(err, "unable to delete timed out browser")
-					}
-				}
-				logger.Info("Browser is scheduled for deletion")
-				return &ctrl.Result{Requeue: true}, nil
-			}
+	if browserkubePod.Status.Phase != apiv1.PodRunning {
+		return nil, nil
+	}
+	logger := log.FromContext(ctx)
+	logger.Info("checking quit session:", "container status", browserkubePod.Status.ContainerStatuses)
+	var sidecar *apiv1.ContainerStatus
+	for i := range browserkubePod.Status.ContainerStatuses {
+		if c := &browserkubePod.Status.ContainerStatuses[i]; c.Name == containerNameSidecar && c.State.Terminated != nil {
+			sidecar = c
 		}
 	}
-	return nil, nil
+	reason, message := sidecarTermination(browserkubePod)
+	// browser crashed while starting is handled by checkPending
+	if sidecar == nil && (reason == "" || instance.Status.Phase != browserkubeapiv1.PhaseRunning) {
+		return nil, nil
+	}
+
+	logger.Info("Browser session has ended. Deleting...", "reason", reason)
+	if instance.Status.Phase != browserkubeapiv1.PhaseTerminated && sidecar != nil {
+		countSidecarTermination(instance, sidecar.State.Terminated.ExitCode)
+	}
+	instance.Status.Phase = browserkubeapiv1.PhaseTerminated
+	if instance.Status.TerminationReason == "" {
+		instance.Status.TerminationReason = reason
+	}
+	r.setConditions(instance, terminatedConditions(browserkubeapiv1.ConditionReasonSessionFinished, message)...)
+	if err := r.Status().Update(ctx, instance); err != nil {
+		logger.Error(err, "unable to update browser status")
+	}
+	if err := r.saveTerminationReason(ctx, instance); err != nil {
+		logger.Error(err, "unable to save termination reason to session result")
+	}
+	if instance.DeletionTimestamp.IsZero() {
+		if err := r.Delete(ctx, instance); err != nil {
+			logger.Error(err, "unable to delete timed out browser")
+		}
+	}
+	logger.Info("Browser is scheduled for deletion")
+	return &ctrl.Result{Requeue: true}, nil
+}
+
+// checkSessionActive keeps SessionActive condition of a running browser up to date, e.g. once pooled browser is claimed
+func (r *BrowserReconciler) checkSessionActive(ctx context.Context, instance *browserkubeapiv1.Browser) (*ctrl.Result, error) {
+	if instance.Status.Phase != browserkubeapiv1.PhaseRunning || !r.setConditions(instance, sessionCondition(instance)) {
+		return nil, nil
+	}
+	if err := r.Status().Update(ctx, instance); err != nil {
+		return &ctrl.Result{}, err
+	}
+	return &ctrl.Result{}, nil
+}
+
+// sessionDeadline returns the time the session of the browser started now ends at or nil if it never ends.
+// Idle pooled browsers have no session yet, their session starts when they are claimed
+func sessionDeadline(instance *browserkubeapiv1.Browser, now time.Time) *metav1.Time {
+	timeouts := instance.Status.Timeouts
+	if timeouts == nil || timeouts.Session == nil ||
+		instance.Labels[browserkubeapiv1.LabelPoolState] == browserkubeapiv1.PoolStateIdle {
+		return nil
+	}
+	return ptr.To(metav1.NewTime(now.Add(timeouts.Session.Duration)))
+}
+
+// checkSessionDeadline deletes the browser which outlived its session deadline.
+// Sidecar closes such sessions itself, so this only cleans up browsers which failed to do so
+func (r *BrowserReconciler) checkSessionDeadline(ctx context.Context, instance *browserkubeapiv1.Browser) (*ctrl.Result, error) {
+	if instance.Status.Phase != browserkubeapiv1.PhaseRunning || !instance.DeletionTimestamp.IsZero() {
+		return nil, nil
+	}
+	if instance.Status.SessionDeadline == nil {
+		// pooled browsers get the deadline once claimed
+		if instance.Status.SessionDeadline = sessionDeadline(instance, time.Now()); instance.Status.SessionDeadline == nil {
+			return nil, nil
+		}
+		if err := r.Status().Update(ctx, instance); err != nil {
+			return &ctrl.Result{}, err
+		}
+		return &ctrl.Result{RequeueAfter: time.Until(instance.Status.SessionDeadline.Time)}, nil
+	}
+	// give the sidecar a chance to close the session gracefully
+	deadline := instance.Status.SessionDeadline.Add(time.Duration(podGracefulShutdownTimeout) * time.Second)
+	if left := time.Until(deadline); left > 0 {
+		return &ctrl.Result{RequeueAfter: left}, nil
+	}
+
+	message := fmt.Sprintf("session has exceeded its deadline %s", instance.Status.SessionDeadline.Format(time.RFC3339))
+	instance.Status.Phase = browserkubeapiv1.PhaseTerminated
+	instance.Status.TerminationReason = browserkubeapiv1.TerminationReasonSessionTimeout
+	r.setConditions(instance, terminatedConditions(browserkubeapiv1.ConditionReasonSessionTimeout, message)...)
+	if err := r.Status().Update(ctx, instance); err != nil {
+		return &ctrl.Result{}, err
+	}
+	if err := r.saveTerminationReason(ctx, instance); err != nil {
+		log.FromContext(ctx).Error(err, "unable to save termination reason to session result")
+	}
+	return &ctrl.Result{}, r.Delete(ctx, instance)
 }
 
 func (r *BrowserReconciler) checkFinalizer(ctx context.Context, instance *browserkubeapiv1.Browser) (*ctrl.Result, error) {
@@ -513,6 +762,7 @@ func (r *BrowserReconciler) preDeleteHook(ctx context.Context, instance *browser
 	// make sure the status of browser is terminated
 	if instance.Status.Phase != browserkubeapiv1.PhaseTerminated {
 		instance.Status.Phase = browserkubeapiv1.PhaseTerminated
+		r.setConditions(instance, terminatedConditions(browserkubeapiv1.ConditionReasonDeleted, "browser has been deleted")...)
 		if err := r.Status().Update(ctx, instance); err != nil {
 			return err
 		}
@@ -520,21 +770,41 @@ func (r *BrowserReconciler) preDeleteHook(ctx context.Context, instance *browser
 	return nil
 }
 
-func (r *BrowserReconciler) getReadinessProbeAction(browserType, path, port string) *apiv1.HTTPGetAction {
+// getReadinessProbeHandler returns probe of the browser status. Image profile's readiness path is used as is if provided.
+// Playwright run-server has no status endpoint, so its port is checked unless HTTP probe is configured
+func (r *BrowserReconciler) getReadinessProbeHandler(browserType, path, port, readinessPath, probeType string) *apiv1.ProbeHandler {
 	switch browserType {
 	case browserkubeapiv1.TypeWebDriver:
-		hPath, _ := url.JoinPath(path, "/status")
-		return &apiv1.HTTPGetAction{
+		hPath := readinessPath
+		if hPath == "" {
+			hPath, _ = url.JoinPath(path, "/status")
+		}
+		return &apiv1.ProbeHandler{HTTPGet: &apiv1.HTTPGetAction{
 			Scheme: apiv1.URISchemeHTTP,
 			Port:   intstr.Parse(port),
 			Path:   hPath,
+		}}
+	case browserkubeapiv1.TypePlaywright:
+		if readinessPath != "" || probeType == readinessProbeHTTP {
+			// run-server answers plain HTTP requests to any path
+			return &apiv1.ProbeHandler{HTTPGet: &apiv1.HTTPGetAction{
+				Scheme: apiv1.URISchemeHTTP,
+				Port:   intstr.Parse(port),
+				Path:   utils.FirstNonEmpty(readinessPath, "/"),
+			}}
 		}
+		return &apiv1.ProbeHandler{TCPSocket: &apiv1.TCPSocketAction{
+			Port: intstr.Parse(port),
+		}}
 	}
 	return nil
 }
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *BrowserReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	if err := metrics.Registry.Register(newBrowserPhaseCollector(mgr.GetClient())); err != nil {
+		return err
+	}
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&browserkubeapiv1.Browser{}).
 		Owns(&apiv1.Pod{}).
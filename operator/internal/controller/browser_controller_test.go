@@ -517,9 +517,34 @@ func TestNewBrowserReconciler(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if got := NewBrowserReconciler(tt.args.client, tt.args.scheme, tt.args.opts); !reflect.DeepEqual(got, tt.want) {
+			if got := NewBrowserReconciler(tt.args.client, tt.args.scheme, nil, tt.args.opts); !reflect.DeepEqual(got, tt.want) {
 				t.Errorf("NewBrowserReconciler() = %v, want %v", got, tt.want)
 			}
 		})
 	}
 }
+
+func TestSessionDeadline(t *testing.T) {
+	now := time.Now()
+	browser := &browserkubeapiv1.Browser{
+		ObjectMeta: metav1.ObjectMeta{
+			Labels: map[string]string{browserkubeapiv1.LabelPoolState: browserkubeapiv1.PoolStateIdle},
+		},
+		Status: browserkubeapiv1.BrowserStatus{
+			Timeouts: &browserkubeapiv1.BrowserTimeouts{Session: &metav1.Duration{Duration: time.Hour}},
+		},
+	}
+	if got := sessionDeadline(browser, now); got != nil {
+		t.Errorf("sessionDeadline() of idle pooled browser = %v, want nil", got)
+	}
+
+	browser.Labels[browserkubeapiv1.LabelPoolState] = browserkubeapiv1.PoolStateClaimed
+	if got := sessionDeadline(browser, now); got == nil || !got.Time.Equal(now.Add(time.Hour)) {
+		t.Errorf("sessionDeadline() of claimed browser = %v, want %v", got, now.Add(time.Hour))
+	}
+
+	browser.Status.Timeouts = nil
+	if got := sessionDeadline(browser, now); got != nil {
+		t.Errorf("sessionDeadline() without session timeout = %v, want nil", got)
+	}
+}
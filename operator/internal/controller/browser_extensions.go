@@ -0,0 +1,26 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	browserkubeapiv1 "github.com/browserkube/browserkube/operator/api/v1"
+	"github.com/browserkube/browserkube/operator/pkg/extensionpolicy"
+)
+
+// checkExtensions rejects extensions of the browser which aren't allowed by ExtensionPolicies of its namespace
+func (r *BrowserReconciler) checkExtensions(ctx context.Context, browser *browserkubeapiv1.Browser, browserSet string) error {
+	if len(browser.Spec.Extensions) == 0 {
+		return nil
+	}
+	policies := &browserkubeapiv1.ExtensionPolicyList{}
+	if err := r.List(ctx, policies, client.InNamespace(browser.Namespace)); err != nil {
+		return fmt.Errorf("extension policies can't be loaded: %w", err)
+	}
+	if err := extensionpolicy.Check(policies.Items, browser, browserSet); err != nil {
+		return &browserErr{reason: browserkubeapiv1.ReasonExtensionNotAllowed, error: err}
+	}
+	return nil
+}
@@ -0,0 +1,80 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	browserkubeapiv1 "github.com/browserkube/browserkube/operator/api/v1"
+)
+
+func TestCheckExtensions(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := browserkubeapiv1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	policy := &browserkubeapiv1.ExtensionPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "internal", Namespace: defaultNs},
+		Spec: browserkubeapiv1.ExtensionPolicySpec{
+			Selector: &metav1.LabelSelector{MatchLabels: map[string]string{browserkubeapiv1.LabelBrowserSet: "internal"}},
+			Allowed:  []browserkubeapiv1.AllowedExtension{{ExtensionID: "allowed"}},
+		},
+	}
+	r := &BrowserReconciler{Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(policy).Build()}
+
+	tests := []struct {
+		name       string
+		namespace  string
+		browserSet string
+		extensions []browserkubeapiv1.BrowserExtension
+		wantErr    bool
+	}{
+		{
+			name:      "no extensions",
+			namespace: defaultNs,
+		},
+		{
+			name:       "allowed",
+			namespace:  defaultNs,
+			browserSet: "internal",
+			extensions: []browserkubeapiv1.BrowserExtension{{ExtensionID: "allowed"}},
+		},
+		{
+			name:       "other browser set",
+			namespace:  defaultNs,
+			browserSet: "public",
+			extensions: []browserkubeapiv1.BrowserExtension{{ExtensionID: "allowed"}},
+			wantErr:    true,
+		},
+		{
+			name:       "namespace without policies",
+			namespace:  "tenant",
+			browserSet: "internal",
+			extensions: []browserkubeapiv1.BrowserExtension{{ExtensionID: "allowed"}},
+			wantErr:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			browser := &browserkubeapiv1.Browser{
+				ObjectMeta: metav1.ObjectMeta{Name: "browser", Namespace: tt.namespace},
+				Spec:       browserkubeapiv1.BrowserSpec{BrowserName: browserName, Extensions: tt.extensions},
+			}
+			err := r.checkExtensions(context.Background(), browser, tt.browserSet)
+			if !tt.wantErr {
+				if err != nil {
+					t.Fatalf("checkExtensions() error = %v", err)
+				}
+				return
+			}
+			var bErr *browserErr
+			if !errors.As(err, &bErr) || bErr.reason != browserkubeapiv1.ReasonExtensionNotAllowed {
+				t.Errorf("checkExtensions() error = %v, want %s", err, browserkubeapiv1.ReasonExtensionNotAllowed)
+			}
+		})
+	}
+}
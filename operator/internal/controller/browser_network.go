@@ -0,0 +1,162 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+
+	apiv1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	browserkubeapiv1 "github.com/browserkube/browserkube/operator/api/v1"
+	"github.com/browserkube/browserkube/operator/pkg/browserset"
+)
+
+// dnsPort is allowed to kube-dns pods so the browser can resolve names
+const dnsPort = 53
+
+// lookupIP resolves allowed domains. Replaced in tests
+var lookupIP = func(ctx context.Context, host string) ([]net.IP, error) {
+	return net.DefaultResolver.LookupIP(ctx, "ip", host)
+}
+
+func getNetworkPolicyName(n string) string {
+	return fmt.Sprintf("browser-%s-network", strings.ToLower(n))
+}
+
+// createNetworkPolicy restricts network egress of the browser pod with a NetworkPolicy owned by the browser.
+// DNS and browserkube namespace stay reachable, so sidecar, recorder and backend keep working.
+// Allowed domains are resolved once, the policy isn't updated when their addresses change
+func (r *BrowserReconciler) createNetworkPolicy(
+	ctx context.Context,
+	browser *browserkubeapiv1.Browser,
+	policy *browserkubeapiv1.BrowserNetworkPolicy,
+) (*networkingv1.NetworkPolicy, error) {
+	egress, err := egressRules(ctx, policy, r.opts.OperatorNamespace)
+	if err != nil {
+		return nil, err
+	}
+
+	networkPolicy := &networkingv1.NetworkPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      getNetworkPolicyName(browser.Name),
+			Namespace: browser.Namespace,
+			Labels:    getBrowserPodLabels(browser.Name),
+		},
+		Spec: networkingv1.NetworkPolicySpec{
+			PodSelector: metav1.LabelSelector{
+				MatchLabels: map[string]string{browserkubeapiv1.LabelSessionID: browser.Name},
+			},
+			PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeEgress},
+			Egress:      egress,
+		},
+	}
+	if err = controllerutil.SetControllerReference(browser, networkPolicy, r.Scheme); err != nil {
+		return nil, err
+	}
+
+	err = r.Create(ctx, networkPolicy)
+	if err != nil && !errors.IsAlreadyExists(err) {
+		return nil, err
+	}
+	return networkPolicy, nil
+}
+
+func egressRules(
+	ctx context.Context,
+	policy *browserkubeapiv1.BrowserNetworkPolicy,
+	operatorNamespace string,
+) ([]networkingv1.NetworkPolicyEgressRule, error) {
+	udp, tcp := apiv1.ProtocolUDP, apiv1.ProtocolTCP
+	dns := intstr.FromInt32(dnsPort)
+	rules := []networkingv1.NetworkPolicyEgressRule{
+		{
+			To: []networkingv1.NetworkPolicyPeer{{
+				NamespaceSelector: &metav1.LabelSelector{},
+				PodSelector:       &metav1.LabelSelector{MatchLabels: map[string]string{"k8s-app": "kube-dns"}},
+			}},
+			Ports: []networkingv1.NetworkPolicyPort{{Protocol: &udp, Port: &dns}, {Protocol: &tcp, Port: &dns}},
+		},
+		{
+			To: []networkingv1.NetworkPolicyPeer{{
+				NamespaceSelector: &metav1.LabelSelector{
+					MatchLabels: map[string]string{apiv1.LabelMetadataName: operatorNamespace},
+				},
+			}},
+		},
+	}
+
+	cidrs := policy.AllowedCIDRs
+	if len(cidrs) == 0 && len(policy.AllowedDomains) == 0 {
+		cidrs = []string{"0.0.0.0/0", "::/0"}
+	}
+	for _, domain := range policy.AllowedDomains {
+		ips, err := lookupIP(ctx, domain)
+		if err != nil {
+			return nil, &browserErr{
+				reason: browserkubeapiv1.ReasonNetworkNotAllowed,
+				error:  fmt.Errorf("unable to resolve allowed domain %s: %w", domain, err),
+			}
+		}
+		for _, ip := range ips {
+			if ip.To4() != nil {
+				cidrs = append(cidrs, ip.String()+"/32")
+			} else {
+				cidrs = append(cidrs, ip.String()+"/128")
+			}
+		}
+	}
+
+	var denied []*net.IPNet
+	if !policy.AllowClusterInternal {
+		var err error
+		if denied, err = browserset.ParseCIDRs(browserset.ClusterInternalCIDRs); err != nil {
+			return nil, err
+		}
+	}
+	var peers []networkingv1.NetworkPolicyPeer
+	for _, cidr := range cidrs {
+		_, ipNet, err := net.ParseCIDR(cidr)
+		if err != nil {
+			return nil, &browserErr{reason: browserkubeapiv1.ReasonNetworkNotAllowed, error: err}
+		}
+		except, allowed := exceptCIDRs(ipNet, denied)
+		if !allowed {
+			return nil, &browserErr{
+				reason: browserkubeapiv1.ReasonNetworkNotAllowed,
+				error:  fmt.Errorf("%s is cluster-internal, the policy doesn't allow cluster-internal access", cidr),
+			}
+		}
+		peers = append(peers, networkingv1.NetworkPolicyPeer{
+			IPBlock: &networkingv1.IPBlock{CIDR: ipNet.String(), Except: except},
+		})
+	}
+	if len(peers) > 0 {
+		rules = append(rules, networkingv1.NetworkPolicyEgressRule{To: peers})
+	}
+	return rules, nil
+}
+
+// exceptCIDRs returns denied ranges within the allowed block.
+// Blocks within a denied range can't be allowed, ok is false for them
+func exceptCIDRs(allowed *net.IPNet, denied []*net.IPNet) (except []string, ok bool) {
+	ones, _ := allowed.Mask.Size()
+	for _, d := range denied {
+		dOnes, _ := d.Mask.Size()
+		if len(d.IP) != len(allowed.IP) {
+			continue
+		}
+		if dOnes <= ones && d.Contains(allowed.IP) {
+			return nil, false
+		}
+		if allowed.Contains(d.IP) {
+			except = append(except, d.String())
+		}
+	}
+	return except, true
+}
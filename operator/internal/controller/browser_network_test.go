@@ -0,0 +1,147 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"reflect"
+	"testing"
+
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	browserkubeapiv1 "github.com/browserkube/browserkube/operator/api/v1"
+)
+
+func TestCreateNetworkPolicy(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	if err := browserkubeapiv1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	r := &BrowserReconciler{
+		Client: fake.NewClientBuilder().WithScheme(scheme).Build(),
+		Scheme: scheme,
+		opts:   &BrowserCtrlOpts{OperatorNamespace: defaultNs},
+	}
+	browser := &browserkubeapiv1.Browser{
+		ObjectMeta: metav1.ObjectMeta{Name: "session", Namespace: defaultNs, UID: types.UID("uid-session")},
+	}
+
+	policy, err := r.createNetworkPolicy(context.Background(), browser, &browserkubeapiv1.BrowserNetworkPolicy{Enabled: true})
+	if err != nil {
+		t.Fatalf("createNetworkPolicy() unexpected error = %v", err)
+	}
+	created := &networkingv1.NetworkPolicy{}
+	if err = r.Get(context.Background(), types.NamespacedName{Namespace: defaultNs, Name: policy.Name}, created); err != nil {
+		t.Fatalf("network policy isn't created: %v", err)
+	}
+	if owner := metav1.GetControllerOf(created); owner == nil || owner.UID != browser.UID {
+		t.Errorf("network policy controller = %+v, want browser", owner)
+	}
+	if created.Spec.PodSelector.MatchLabels[browserkubeapiv1.LabelSessionID] != browser.Name {
+		t.Errorf("pod selector = %+v", created.Spec.PodSelector)
+	}
+	if !reflect.DeepEqual(created.Spec.PolicyTypes, []networkingv1.PolicyType{networkingv1.PolicyTypeEgress}) {
+		t.Errorf("policy types = %v, want egress only", created.Spec.PolicyTypes)
+	}
+
+	// retried creation reuses the existing policy
+	if _, err = r.createNetworkPolicy(context.Background(), browser, &browserkubeapiv1.BrowserNetworkPolicy{Enabled: true}); err != nil {
+		t.Errorf("createNetworkPolicy() unexpected error on retry = %v", err)
+	}
+}
+
+func TestEgressRules(t *testing.T) {
+	lookupIP = func(_ context.Context, host string) ([]net.IP, error) {
+		if host == "example.com" {
+			return []net.IP{net.ParseIP("93.184.216.34"), net.ParseIP("2606:2800:220:1::1")}, nil
+		}
+		return nil, fmt.Errorf("no such host")
+	}
+	defer func() {
+		lookupIP = func(ctx context.Context, host string) ([]net.IP, error) {
+			return net.DefaultResolver.LookupIP(ctx, "ip", host)
+		}
+	}()
+
+	tests := []struct {
+		name    string
+		policy  *browserkubeapiv1.BrowserNetworkPolicy
+		want    []networkingv1.IPBlock
+		wantErr bool
+	}{
+		{
+			name:   "unrestricted",
+			policy: &browserkubeapiv1.BrowserNetworkPolicy{Enabled: true},
+			want: []networkingv1.IPBlock{
+				{CIDR: "0.0.0.0/0", Except: []string{"10.0.0.0/8", "172.16.0.0/12", "192.168.0.0/16", "100.64.0.0/10", "169.254.0.0/16"}},
+				{CIDR: "::/0", Except: []string{"fc00::/7", "fe80::/10"}},
+			},
+		},
+		{
+			name:   "cluster internal allowed",
+			policy: &browserkubeapiv1.BrowserNetworkPolicy{Enabled: true, AllowClusterInternal: true},
+			want:   []networkingv1.IPBlock{{CIDR: "0.0.0.0/0"}, {CIDR: "::/0"}},
+		},
+		{
+			name: "CIDRs and domains",
+			policy: &browserkubeapiv1.BrowserNetworkPolicy{
+				AllowedCIDRs:         []string{"10.1.0.0/16", "203.0.113.7/24"},
+				AllowedDomains:       []string{"example.com"},
+				AllowClusterInternal: true,
+			},
+			want: []networkingv1.IPBlock{
+				{CIDR: "10.1.0.0/16"},
+				{CIDR: "203.0.113.0/24"},
+				{CIDR: "93.184.216.34/32"},
+				{CIDR: "2606:2800:220:1::1/128"},
+			},
+		},
+		{
+			name:   "CIDR containing cluster-internal range",
+			policy: &browserkubeapiv1.BrowserNetworkPolicy{AllowedCIDRs: []string{"8.0.0.0/6"}},
+			want:   []networkingv1.IPBlock{{CIDR: "8.0.0.0/6", Except: []string{"10.0.0.0/8"}}},
+		},
+		{
+			name:    "cluster-internal CIDR",
+			policy:  &browserkubeapiv1.BrowserNetworkPolicy{AllowedCIDRs: []string{"10.0.0.0/8"}},
+			wantErr: true,
+		},
+		{
+			name:    "unresolved domain",
+			policy:  &browserkubeapiv1.BrowserNetworkPolicy{AllowedDomains: []string{"example.invalid"}},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rules, err := egressRules(context.Background(), tt.policy, defaultNs)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("egressRules() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if len(rules) != 3 {
+				t.Fatalf("rules = %+v, want DNS, browserkube namespace and IP blocks", rules)
+			}
+			if ns := rules[1].To[0].NamespaceSelector.MatchLabels["kubernetes.io/metadata.name"]; ns != defaultNs {
+				t.Errorf("namespace rule = %+v, want %s", rules[1], defaultNs)
+			}
+			var got []networkingv1.IPBlock
+			for _, peer := range rules[2].To {
+				got = append(got, *peer.IPBlock)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("IP blocks = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,69 @@
+package controller
+
+import (
+	"strings"
+
+	apiv1 "k8s.io/api/core/v1"
+
+	browserkubeapiv1 "github.com/browserkube/browserkube/operator/api/v1"
+	"github.com/browserkube/browserkube/operator/pkg/browserset"
+)
+
+// proxyBypassedHosts are never proxied, so the sidecar and browser drivers keep talking to each other directly
+var proxyBypassedHosts = []string{"localhost", "127.0.0.1", "::1"}
+
+// addProxy sends traffic of the browser pod through the upstream proxy.
+// Browser container gets conventional proxy env variables. Sidecar translates the proxy to browser settings
+// of every new session and forwards the traffic to the proxies browsers can't use directly
+func addProxy(pod *apiv1.Pod, browser *browserkubeapiv1.Browser, proxy *browserkubeapiv1.BrowserProxy) {
+	if proxy == nil {
+		return
+	}
+	browserProxyURL := browserset.BrowserProxyURL(proxy)
+	noProxy := strings.Join(append(append([]string{}, proxyBypassedHosts...), proxy.NoProxy...), ",")
+
+	for i := range pod.Spec.Containers {
+		c := &pod.Spec.Containers[i]
+		switch c.Name {
+		case containerNameBrowser:
+			for _, name := range []string{"HTTP_PROXY", "HTTPS_PROXY", "ALL_PROXY"} {
+				c.Env = append(c.Env,
+					apiv1.EnvVar{Name: name, Value: browserProxyURL},
+					apiv1.EnvVar{Name: strings.ToLower(name), Value: browserProxyURL},
+				)
+			}
+			c.Env = append(c.Env,
+				apiv1.EnvVar{Name: "NO_PROXY", Value: noProxy},
+				apiv1.EnvVar{Name: "no_proxy", Value: noProxy},
+			)
+		case containerNameSidecar:
+			c.Env = append(c.Env,
+				apiv1.EnvVar{Name: "BROWSER_NAME", Value: browser.Spec.BrowserName},
+				apiv1.EnvVar{Name: "BROWSER_PROXY", Value: browserProxyURL},
+				apiv1.EnvVar{Name: "BROWSER_NO_PROXY", Value: noProxy},
+			)
+			if !browserset.ProxyForwarded(proxy) {
+				continue
+			}
+			c.Env = append(c.Env, apiv1.EnvVar{Name: "UPSTREAM_PROXY", Value: proxy.URL})
+			if proxy.CredentialsSecret != "" {
+				c.Env = append(c.Env,
+					proxyCredentialsEnvVar("UPSTREAM_PROXY_USERNAME", proxy.CredentialsSecret, browserkubeapiv1.ProxyUsernameKey),
+					proxyCredentialsEnvVar("UPSTREAM_PROXY_PASSWORD", proxy.CredentialsSecret, browserkubeapiv1.ProxyPasswordKey),
+				)
+			}
+		}
+	}
+}
+
+func proxyCredentialsEnvVar(name, secret, key string) apiv1.EnvVar {
+	return apiv1.EnvVar{
+		Name: name,
+		ValueFrom: &apiv1.EnvVarSource{
+			SecretKeyRef: &apiv1.SecretKeySelector{
+				LocalObjectReference: apiv1.LocalObjectReference{Name: secret},
+				Key:                  key,
+			},
+		},
+	}
+}
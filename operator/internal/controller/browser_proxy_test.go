@@ -0,0 +1,57 @@
+package controller
+
+import (
+	"testing"
+
+	apiv1 "k8s.io/api/core/v1"
+
+	browserkubeapiv1 "github.com/browserkube/browserkube/operator/api/v1"
+)
+
+func TestAddProxy(t *testing.T) {
+	browser := &browserkubeapiv1.Browser{Spec: browserkubeapiv1.BrowserSpec{BrowserName: "chrome"}}
+	env := func(c apiv1.Container) map[string]apiv1.EnvVar {
+		res := map[string]apiv1.EnvVar{}
+		for _, e := range c.Env {
+			res[e.Name] = e
+		}
+		return res
+	}
+
+	pod := testPod(apiv1.PodStatus{})
+	addProxy(pod, browser, &browserkubeapiv1.BrowserProxy{URL: "socks5://proxy.corp:1080", NoProxy: []string{".corp"}})
+	browserEnv, sidecarEnv := env(pod.Spec.Containers[0]), env(pod.Spec.Containers[1])
+	if got := browserEnv["https_proxy"].Value; got != "socks5://proxy.corp:1080" {
+		t.Errorf("browser https_proxy = %s", got)
+	}
+	if got := browserEnv["NO_PROXY"].Value; got != "localhost,127.0.0.1,::1,.corp" {
+		t.Errorf("browser NO_PROXY = %s", got)
+	}
+	if got := sidecarEnv["BROWSER_PROXY"].Value; got != "socks5://proxy.corp:1080" {
+		t.Errorf("sidecar BROWSER_PROXY = %s", got)
+	}
+	if _, ok := sidecarEnv["UPSTREAM_PROXY"]; ok {
+		t.Error("proxy used directly is forwarded")
+	}
+
+	// proxy requiring credentials is used through the sidecar forwarder
+	pod = testPod(apiv1.PodStatus{})
+	addProxy(pod, browser, &browserkubeapiv1.BrowserProxy{URL: "http://proxy.corp:3128", CredentialsSecret: "proxy-auth"})
+	browserEnv, sidecarEnv = env(pod.Spec.Containers[0]), env(pod.Spec.Containers[1])
+	if got := browserEnv["HTTP_PROXY"].Value; got != "http://127.0.0.1:3128" {
+		t.Errorf("browser HTTP_PROXY = %s", got)
+	}
+	if got := sidecarEnv["UPSTREAM_PROXY"].Value; got != "http://proxy.corp:3128" {
+		t.Errorf("sidecar UPSTREAM_PROXY = %s", got)
+	}
+	password := sidecarEnv["UPSTREAM_PROXY_PASSWORD"].ValueFrom
+	if password == nil || password.SecretKeyRef.Name != "proxy-auth" || password.SecretKeyRef.Key != browserkubeapiv1.ProxyPasswordKey {
+		t.Errorf("sidecar UPSTREAM_PROXY_PASSWORD = %+v", password)
+	}
+
+	pod = testPod(apiv1.PodStatus{})
+	addProxy(pod, browser, nil)
+	if len(pod.Spec.Containers[0].Env) != 0 || len(pod.Spec.Containers[1].Env) != 0 {
+		t.Errorf("env is added without proxy: %+v", pod.Spec.Containers)
+	}
+}
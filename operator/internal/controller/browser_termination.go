@@ -0,0 +1,100 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	browserkubeapiv1 "github.com/browserkube/browserkube/operator/api/v1"
+)
+
+var terminationReasons = map[browserkubeapiv1.TerminationReason]bool{
+	browserkubeapiv1.TerminationReasonIdleTimeout:    true,
+	browserkubeapiv1.TerminationReasonSessionTimeout: true,
+	browserkubeapiv1.TerminationReasonClientQuit:     true,
+	browserkubeapiv1.TerminationReasonBrowserCrash:   true,
+}
+
+// sidecarTermination tells why the session of the browser pod has ended and describes it.
+// Sidecar records the reason in its termination message. Browser container which has exited while the sidecar
+// didn't record a reason means the browser has crashed. Returns empty reason if the session is still active
+func sidecarTermination(pod *apiv1.Pod) (browserkubeapiv1.TerminationReason, string) {
+	var sidecar, browser *apiv1.ContainerStatus
+	for i := range pod.Status.ContainerStatuses {
+		switch c := &pod.Status.ContainerStatuses[i]; c.Name {
+		case containerNameSidecar:
+			sidecar = c
+		case containerNameBrowser:
+			browser = c
+		}
+	}
+	browserExited := browser != nil && (browser.State.Terminated != nil || browser.LastTerminationState.Terminated != nil)
+
+	if sidecar != nil && sidecar.State.Terminated != nil {
+		terminated := sidecar.State.Terminated
+		reason := browserkubeapiv1.TerminationReason(strings.TrimSpace(terminated.Message))
+		if !terminationReasons[reason] {
+			reason = ""
+			if browserExited {
+				reason = browserkubeapiv1.TerminationReasonBrowserCrash
+			}
+		}
+		message := fmt.Sprintf("sidecar exited with code %d", terminated.ExitCode)
+		if reason != "" {
+			message = fmt.Sprintf("%s: %s", message, reason)
+		}
+		return reason, message
+	}
+	if browserExited {
+		exitCode := int32(0)
+		if t := browser.State.Terminated; t != nil {
+			exitCode = t.ExitCode
+		} else {
+			exitCode = browser.LastTerminationState.Terminated.ExitCode
+		}
+		return browserkubeapiv1.TerminationReasonBrowserCrash, fmt.Sprintf("browser exited with code %d", exitCode)
+	}
+	return "", ""
+}
+
+// saveTerminationReason records why the session has ended in the SessionResult of the session.
+// Backend creates results of the sessions closed by clients. Sessions closed by the sidecar or the operator
+// get a result without files here, the backend adds the files it collects to it keeping the reason
+func (r *BrowserReconciler) saveTerminationReason(ctx context.Context, instance *browserkubeapiv1.Browser) error {
+	if instance.Status.TerminationReason == "" || instance.Labels[browserkubeapiv1.LabelPoolState] == browserkubeapiv1.PoolStateIdle {
+		// idle pooled browsers have no session
+		return nil
+	}
+	sessionID := instance.SessionID()
+
+	result := &browserkubeapiv1.SessionResult{}
+	err := r.Get(ctx, types.NamespacedName{Namespace: instance.Namespace, Name: sessionID}, result)
+	if errors.IsNotFound(err) {
+		result = &browserkubeapiv1.SessionResult{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        sessionID,
+				Namespace:   instance.Namespace,
+				Labels:      instance.Labels,
+				Annotations: instance.Annotations,
+			},
+			Spec: browserkubeapiv1.SessionResultSpec{
+				StartedAt:         instance.CreationTimestamp,
+				FinishedAt:        metav1.Now(),
+				Browser:           instance.Spec,
+				BrowserImage:      instance.Status.Image,
+				TerminationReason: instance.Status.TerminationReason,
+			},
+		}
+		return r.Create(ctx, result)
+	}
+	if err != nil || result.Spec.TerminationReason != "" {
+		return err
+	}
+	result.Spec.TerminationReason = instance.Status.TerminationReason
+	return r.Update(ctx, result)
+}
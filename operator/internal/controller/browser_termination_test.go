@@ -0,0 +1,127 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	browserkubeapiv1 "github.com/browserkube/browserkube/operator/api/v1"
+)
+
+func TestSidecarTermination(t *testing.T) {
+	running := v1.ContainerState{Running: &v1.ContainerStateRunning{}}
+	exited := func(code int32, message string) v1.ContainerState {
+		return v1.ContainerState{Terminated: &v1.ContainerStateTerminated{ExitCode: code, Message: message}}
+	}
+	tests := []struct {
+		name        string
+		sidecar     v1.ContainerState
+		browser     v1.ContainerState
+		wantReason  browserkubeapiv1.TerminationReason
+		wantMessage string
+	}{
+		{
+			name:    "session is active",
+			sidecar: running,
+			browser: running,
+		},
+		{
+			name:        "idle timeout",
+			sidecar:     exited(0, "IdleTimeout"),
+			browser:     running,
+			wantReason:  browserkubeapiv1.TerminationReasonIdleTimeout,
+			wantMessage: "sidecar exited with code 0: IdleTimeout",
+		},
+		{
+			name:        "sidecar exited without reason",
+			sidecar:     exited(2, "panic: runtime error"),
+			browser:     running,
+			wantMessage: "sidecar exited with code 2",
+		},
+		{
+			name:        "sidecar exited after browser crash",
+			sidecar:     exited(1, ""),
+			browser:     exited(139, ""),
+			wantReason:  browserkubeapiv1.TerminationReasonBrowserCrash,
+			wantMessage: "sidecar exited with code 1: BrowserCrash",
+		},
+		{
+			name:        "browser crashed",
+			sidecar:     running,
+			browser:     exited(137, ""),
+			wantReason:  browserkubeapiv1.TerminationReasonBrowserCrash,
+			wantMessage: "browser exited with code 137",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pod := testPod(v1.PodStatus{ContainerStatuses: []v1.ContainerStatus{
+				{Name: containerNameBrowser, State: tt.browser},
+				{Name: containerNameSidecar, State: tt.sidecar},
+			}})
+			reason, message := sidecarTermination(pod)
+			if reason != tt.wantReason || message != tt.wantMessage {
+				t.Errorf("sidecarTermination() = %q, %q, want %q, %q", reason, message, tt.wantReason, tt.wantMessage)
+			}
+		})
+	}
+}
+
+func TestCheckSidecarRunningTerminationReason(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := browserkubeapiv1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	newBrowser := func(name string) *browserkubeapiv1.Browser {
+		return &browserkubeapiv1.Browser{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: defaultNs, CreationTimestamp: metav1.Now()},
+			Spec:       browserkubeapiv1.BrowserSpec{BrowserName: "chrome", BrowserVersion: "120.0"},
+			Status:     browserkubeapiv1.BrowserStatus{Phase: browserkubeapiv1.PhaseRunning, Image: "chrome:120.0"},
+		}
+	}
+	timedOut, closed := newBrowser("timed-out"), newBrowser("closed")
+	existing := &browserkubeapiv1.SessionResult{ObjectMeta: metav1.ObjectMeta{Name: "closed", Namespace: defaultNs}}
+	r := &BrowserReconciler{
+		Client: fake.NewClientBuilder().WithScheme(scheme).
+			WithObjects(timedOut, closed, existing).WithStatusSubresource(timedOut, closed).Build(),
+		Recorder: record.NewFakeRecorder(10),
+	}
+	sidecarExited := func(message string) *v1.Pod {
+		return testPod(v1.PodStatus{Phase: v1.PodRunning, ContainerStatuses: []v1.ContainerStatus{
+			{Name: containerNameBrowser, State: v1.ContainerState{Running: &v1.ContainerStateRunning{}}},
+			{Name: containerNameSidecar, State: v1.ContainerState{Terminated: &v1.ContainerStateTerminated{Message: message}}},
+		}})
+	}
+
+	if res, err := r.checkSidecarRunning(context.Background(), timedOut, sidecarExited("IdleTimeout")); err != nil || res == nil {
+		t.Fatalf("checkSidecarRunning() = %v, %v, want result", res, err)
+	}
+	if timedOut.Status.TerminationReason != browserkubeapiv1.TerminationReasonIdleTimeout {
+		t.Errorf("termination reason = %q, want IdleTimeout", timedOut.Status.TerminationReason)
+	}
+	result := &browserkubeapiv1.SessionResult{}
+	if err := r.Get(context.Background(), types.NamespacedName{Namespace: defaultNs, Name: "timed-out"}, result); err != nil {
+		t.Fatalf("session result isn't created: %v", err)
+	}
+	if result.Spec.TerminationReason != browserkubeapiv1.TerminationReasonIdleTimeout ||
+		result.Spec.BrowserImage != "chrome:120.0" || result.Spec.Browser.BrowserName != "chrome" {
+		t.Errorf("session result = %+v", result.Spec)
+	}
+
+	// result created by the backend gets the reason
+	if _, err := r.checkSidecarRunning(context.Background(), closed, sidecarExited("ClientQuit")); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.Get(context.Background(), types.NamespacedName{Namespace: defaultNs, Name: "closed"}, result); err != nil {
+		t.Fatal(err)
+	}
+	if result.Spec.TerminationReason != browserkubeapiv1.TerminationReasonClientQuit {
+		t.Errorf("termination reason = %q, want ClientQuit", result.Spec.TerminationReason)
+	}
+}
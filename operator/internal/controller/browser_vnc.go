@@ -0,0 +1,84 @@
+package controller
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"math/big"
+	"strings"
+
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	browserkubeapiv1 "github.com/browserkube/browserkube/operator/api/v1"
+)
+
+// vncPasswordLength is the length of generated VNC passwords. VNC authentication ignores everything after 8 characters
+const vncPasswordLength = 8
+
+const vncPasswordAlphabet = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
+
+// generateVNCPassword returns random password of vncPasswordLength characters
+func generateVNCPassword() (string, error) {
+	alphabetLen := big.NewInt(int64(len(vncPasswordAlphabet)))
+	password := make([]byte, vncPasswordLength)
+	for i := range password {
+		n, err := rand.Int(rand.Reader, alphabetLen)
+		if err != nil {
+			return "", err
+		}
+		password[i] = vncPasswordAlphabet[n.Int64()]
+	}
+	return string(password), nil
+}
+
+func getVNCSecretName(n string) string {
+	return fmt.Sprintf("browser-%s-vnc", strings.ToLower(n))
+}
+
+// vncPasswordEnvVar passes the browser VNC password from its Secret to a container
+func vncPasswordEnvVar(name, browserName string) apiv1.EnvVar {
+	return apiv1.EnvVar{
+		Name: name,
+		ValueFrom: &apiv1.EnvVarSource{
+			SecretKeyRef: &apiv1.SecretKeySelector{
+				LocalObjectReference: apiv1.LocalObjectReference{Name: getVNCSecretName(browserName)},
+				Key:                  browserkubeapiv1.VNCSecretKey,
+			},
+		},
+	}
+}
+
+// createVNCSecret stores random VNC password of the browser in a Secret owned by the browser.
+// Secret left by a previous attempt to create the browser is reused
+func (r *BrowserReconciler) createVNCSecret(ctx context.Context, browser *browserkubeapiv1.Browser) (*apiv1.Secret, error) {
+	password, err := generateVNCPassword()
+	if err != nil {
+		return nil, fmt.Errorf("unable to generate VNC password: %w", err)
+	}
+
+	secret := &apiv1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      getVNCSecretName(browser.Name),
+			Namespace: browser.Namespace,
+			Labels:    getBrowserPodLabels(browser.Name),
+		},
+		Type:       apiv1.SecretTypeOpaque,
+		StringData: map[string]string{browserkubeapiv1.VNCSecretKey: password},
+	}
+	if err := controllerutil.SetControllerReference(browser, secret, r.Scheme); err != nil {
+		return nil, err
+	}
+
+	err = r.Create(ctx, secret)
+	if errors.IsAlreadyExists(err) {
+		err = r.Get(ctx, types.NamespacedName{Namespace: secret.Namespace, Name: secret.Name}, secret)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return secret, nil
+}
@@ -0,0 +1,104 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	browserkubeapiv1 "github.com/browserkube/browserkube/operator/api/v1"
+)
+
+func TestCreateVNCSecret(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	if err := browserkubeapiv1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	browser := func(name string) *browserkubeapiv1.Browser {
+		return &browserkubeapiv1.Browser{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: defaultNs, UID: types.UID("uid-" + name)},
+		}
+	}
+	existing := &v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: getVNCSecretName("retried"), Namespace: defaultNs},
+		Data:       map[string][]byte{browserkubeapiv1.VNCSecretKey: []byte("existing")},
+	}
+	r := &BrowserReconciler{
+		Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(existing).Build(),
+		Scheme: scheme,
+	}
+
+	tests := []struct {
+		name    string
+		browser *browserkubeapiv1.Browser
+		check   func(t *testing.T, password string)
+	}{
+		{
+			name:    "random password",
+			browser: browser("random"),
+			check: func(t *testing.T, password string) {
+				if len(password) != vncPasswordLength {
+					t.Errorf("password = %q, want %d characters", password, vncPasswordLength)
+				}
+			},
+		},
+		{
+			name:    "random password of selenoid images",
+			browser: browser("selenoid"),
+			check: func(t *testing.T, password string) {
+				if password == "selenoid" || len(password) != vncPasswordLength {
+					t.Errorf("password = %q, want random one", password)
+				}
+			},
+		},
+		{
+			name:    "secret of previous attempt",
+			browser: browser("retried"),
+			check: func(t *testing.T, password string) {
+				if password != "existing" {
+					t.Errorf("password = %q, want existing one", password)
+				}
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			secret, err := r.createVNCSecret(context.Background(), tt.browser)
+			if err != nil {
+				t.Fatalf("createVNCSecret() unexpected error = %v", err)
+			}
+			if secret.Name != getVNCSecretName(tt.browser.Name) {
+				t.Errorf("secret name = %s, want %s", secret.Name, getVNCSecretName(tt.browser.Name))
+			}
+			password := secret.StringData[browserkubeapiv1.VNCSecretKey]
+			if data, ok := secret.Data[browserkubeapiv1.VNCSecretKey]; ok {
+				password = string(data)
+			} else if owner := metav1.GetControllerOf(secret); owner == nil || owner.UID != tt.browser.UID {
+				t.Errorf("secret controller = %+v, want browser", owner)
+			}
+			tt.check(t, password)
+		})
+	}
+}
+
+func TestGenerateVNCPassword(t *testing.T) {
+	first, err := generateVNCPassword()
+	if err != nil {
+		t.Fatalf("generateVNCPassword() unexpected error = %v", err)
+	}
+	second, err := generateVNCPassword()
+	if err != nil {
+		t.Fatalf("generateVNCPassword() unexpected error = %v", err)
+	}
+	if first == second {
+		t.Errorf("generateVNCPassword() returned the same password twice: %s", first)
+	}
+}
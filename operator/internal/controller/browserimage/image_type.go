@@ -8,7 +8,7 @@ import (
 type ImageType int
 
 const (
-	ImageTypeSelenium = iota
+	ImageTypeSelenium ImageType = iota
 	ImageTypeSelenoid
 	ImageTypeAerokube
 	ImageTypeMicrosoft
@@ -18,22 +18,14 @@ var homeDirMapping = map[ImageType]string{
 	ImageTypeSelenium: "/home/seluser",
 	ImageTypeSelenoid: "/home/user",
 	ImageTypeAerokube: "/home/user",
-}
-
-var vncPassMapping = map[ImageType]string{
-	ImageTypeSelenium: "browserkube",
-	ImageTypeSelenoid: "selenoid",
-	ImageTypeAerokube: "browserkube",
+	// the images run as root, but home of pwuser is the documented working dir
+	ImageTypeMicrosoft: "/home/pwuser",
 }
 
 func (it ImageType) Homedir() string {
 	return homeDirMapping[it]
 }
 
-func (it ImageType) VncPass() string {
-	return vncPassMapping[it]
-}
-
 func ParseImageType(img string) (ImageType, error) {
 	var idx int
 	if idx = strings.LastIndexByte(img, '/'); idx < 0 {
@@ -45,7 +37,7 @@ func ParseImageType(img string) (ImageType, error) {
 		return ImageTypeSelenium, nil
 	case "selenoid":
 		return ImageTypeSelenoid, nil
-	case "quay.io/browser", "cdtp", "playwright":
+	case "quay.io/browser", "browsers", "cdtp", "playwright":
 		return ImageTypeAerokube, nil
 	case "mcr.microsoft.com":
 		return ImageTypeMicrosoft, nil
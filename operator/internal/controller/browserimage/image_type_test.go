@@ -66,34 +66,3 @@ func TestParseImageType(t *testing.T) {
 		})
 	}
 }
-
-func TestImageType_VncPass(t *testing.T) {
-	tests := []struct {
-		name string
-		it   ImageType
-		want string
-	}{
-		{
-			name: "aerokube",
-			want: "browserkube",
-			it:   ImageTypeAerokube,
-		},
-		{
-			name: "selenium",
-			want: "browserkube",
-			it:   ImageTypeSelenium,
-		},
-		{
-			name: "aerokube",
-			want: "selenoid",
-			it:   ImageTypeSelenoid,
-		},
-	}
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			if got := tt.it.VncPass(); got != tt.want {
-				t.Errorf("VncPass() = %v, want %v", got, tt.want)
-			}
-		})
-	}
-}
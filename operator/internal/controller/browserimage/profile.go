@@ -0,0 +1,111 @@
+package browserimage
+
+import (
+	"fmt"
+	"path/filepath"
+
+	browserkubeapiv1 "github.com/browserkube/browserkube/operator/api/v1"
+)
+
+const defaultVNCPort = "5900"
+
+// Profile describes how a browser image is run
+type Profile struct {
+	Type          ImageType
+	HomeDir       string
+	DisplayNum    string
+	VNCPort       string
+	ReadinessPath string
+	ServerCommand string
+	ExtensionDirs map[string][]string
+}
+
+// Display returns X display of the profile, e.g. :99
+func (p *Profile) Display() string {
+	return ":" + p.DisplayNum
+}
+
+var flavours = map[string]ImageType{
+	browserkubeapiv1.ImageFlavourSelenium:  ImageTypeSelenium,
+	browserkubeapiv1.ImageFlavourSelenoid:  ImageTypeSelenoid,
+	browserkubeapiv1.ImageFlavourAerokube:  ImageTypeAerokube,
+	browserkubeapiv1.ImageFlavourMicrosoft: ImageTypeMicrosoft,
+}
+
+// Flavour returns name of the image flavour, e.g. selenoid
+func (it ImageType) Flavour() string {
+	for name, t := range flavours {
+		if t == it {
+			return name
+		}
+	}
+	return ""
+}
+
+var displayNumMapping = map[ImageType]string{
+	ImageTypeSelenium:  "99",
+	ImageTypeSelenoid:  "99",
+	ImageTypeAerokube:  "0",
+	ImageTypeMicrosoft: "99",
+}
+
+// BuiltinProfile returns the profile shipped for the image type
+func BuiltinProfile(it ImageType) Profile {
+	homeDir := it.Homedir()
+	return Profile{
+		Type:          it,
+		HomeDir:       homeDir,
+		DisplayNum:    displayNumMapping[it],
+		VNCPort:       defaultVNCPort,
+		ExtensionDirs: defaultExtensionDirs(homeDir),
+	}
+}
+
+func defaultExtensionDirs(homeDir string) map[string][]string {
+	return map[string][]string{
+		"firefox": {filepath.Join(homeDir, "/.mozilla/extensions"), "/opt/firefox"},
+		"chrome":  {"/opt/google/chrome/extensions"},
+	}
+}
+
+// ResolveProfile merges the configured profile with the built-in one.
+// Built-in profile is selected by the configured flavour or detected from the image registry otherwise
+func ResolveProfile(image string, configured *browserkubeapiv1.ImageProfile) (Profile, error) {
+	var (
+		it  ImageType
+		err error
+	)
+	if configured != nil && configured.Flavour != "" {
+		var ok bool
+		if it, ok = flavours[configured.Flavour]; !ok {
+			return Profile{}, fmt.Errorf("unknown image flavour: %s", configured.Flavour)
+		}
+	} else if it, err = ParseImageType(image); err != nil {
+		return Profile{}, fmt.Errorf("%w, image profile flavour has to be provided", err)
+	}
+
+	profile := BuiltinProfile(it)
+	if configured == nil {
+		return profile, nil
+	}
+	if configured.HomeDir != "" {
+		profile.HomeDir = configured.HomeDir
+		profile.ExtensionDirs = defaultExtensionDirs(configured.HomeDir)
+	}
+	if configured.DisplayNum != "" {
+		profile.DisplayNum = configured.DisplayNum
+	}
+	if configured.VNCPort != "" {
+		profile.VNCPort = configured.VNCPort
+	}
+	if configured.ReadinessPath != "" {
+		profile.ReadinessPath = configured.ReadinessPath
+	}
+	if configured.ServerCommand != "" {
+		profile.ServerCommand = configured.ServerCommand
+	}
+	if configured.ExtensionDirs != nil {
+		profile.ExtensionDirs = configured.ExtensionDirs
+	}
+	return profile, nil
+}
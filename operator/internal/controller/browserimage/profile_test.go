@@ -0,0 +1,76 @@
+package browserimage
+
+import (
+	"reflect"
+	"testing"
+
+	browserkubeapiv1 "github.com/browserkube/browserkube/operator/api/v1"
+)
+
+func TestResolveProfile(t *testing.T) {
+	tests := []struct {
+		name       string
+		image      string
+		configured *browserkubeapiv1.ImageProfile
+		want       Profile
+		wantErr    bool
+	}{
+		{
+			name:  "detected by registry",
+			image: "selenoid/chrome:124.0",
+			want:  BuiltinProfile(ImageTypeSelenoid),
+		},
+		{
+			name:       "flavour of mirrored image",
+			image:      "registry.internal/selenium/chrome:124.0",
+			configured: &browserkubeapiv1.ImageProfile{Flavour: browserkubeapiv1.ImageFlavourSelenium},
+			want:       BuiltinProfile(ImageTypeSelenium),
+		},
+		{
+			name:  "overrides",
+			image: "registry.internal/custom/chrome:1.0",
+			configured: &browserkubeapiv1.ImageProfile{
+				Flavour:       browserkubeapiv1.ImageFlavourSelenoid,
+				HomeDir:       "/home/custom",
+				DisplayNum:    "1",
+				VNCPort:       "5901",
+				ReadinessPath: "/healthz",
+				ServerCommand: "exec playwright run-server",
+			},
+			want: Profile{
+				Type:          ImageTypeSelenoid,
+				HomeDir:       "/home/custom",
+				DisplayNum:    "1",
+				VNCPort:       "5901",
+				ReadinessPath: "/healthz",
+				ServerCommand: "exec playwright run-server",
+				ExtensionDirs: map[string][]string{
+					"firefox": {"/home/custom/.mozilla/extensions", "/opt/firefox"},
+					"chrome":  {"/opt/google/chrome/extensions"},
+				},
+			},
+		},
+		{
+			name:    "unknown registry",
+			image:   "registry.internal/custom/chrome:1.0",
+			wantErr: true,
+		},
+		{
+			name:       "unknown flavour",
+			image:      "selenoid/chrome:124.0",
+			configured: &browserkubeapiv1.ImageProfile{Flavour: "unknown"},
+			wantErr:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ResolveProfile(tt.image, tt.configured)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ResolveProfile() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ResolveProfile() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
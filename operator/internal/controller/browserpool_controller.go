@@ -0,0 +1,210 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"sort"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	browserkubeapiv1 "github.com/browserkube/browserkube/operator/api/v1"
+)
+
+// BrowserPoolReconciler keeps the desired number of pre-started idle browsers for each BrowserPool.
+// Idle browsers are invisible for the backend until a session claims one of them
+// by flipping LabelPoolState to claimed and removing the pool owner reference.
+type BrowserPoolReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+}
+
+func NewBrowserPoolReconciler(client client.Client, scheme *runtime.Scheme) *BrowserPoolReconciler {
+	return &BrowserPoolReconciler{
+		Client: client,
+		Scheme: scheme,
+	}
+}
+
+// poolPlan describes what has to be done to bring pool to the desired state
+type poolPlan struct {
+	status   browserkubeapiv1.BrowserPoolStatus
+	create   int
+	toDelete []*browserkubeapiv1.Browser
+}
+
+//+kubebuilder:rbac:groups=api.browserkube.io,namespace=browserkube,resources=browserpools,verbs=get;list;watch
+//+kubebuilder:rbac:groups=api.browserkube.io,namespace=browserkube,resources=browserpools/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=api.browserkube.io,namespace=browserkube,resources=browserpools/finalizers,verbs=update
+
+// Reconcile creates missing idle browsers, removes excessive ones and reports pool status
+func (r *BrowserPoolReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+	pool := &browserkubeapiv1.BrowserPool{}
+	if err := r.Get(ctx, req.NamespacedName, pool); err != nil {
+		if errors.IsNotFound(err) {
+			// pooled browsers are owned by the pool and collected by GC
+			return reconcile.Result{}, nil
+		}
+		logger.Error(err, "unable to get browser pool")
+		return reconcile.Result{}, err
+	}
+	if !pool.DeletionTimestamp.IsZero() {
+		return reconcile.Result{}, nil
+	}
+
+	browsers := &browserkubeapiv1.BrowserList{}
+	if err := r.List(ctx, browsers,
+		client.InNamespace(pool.Namespace),
+		client.MatchingLabels{browserkubeapiv1.LabelPool: pool.Name},
+	); err != nil {
+		logger.Error(err, "unable to list pooled browsers")
+		return reconcile.Result{}, err
+	}
+
+	plan := planPool(pool, browsers.Items)
+	for _, b := range plan.toDelete {
+		logger.Info("deleting pooled browser", "browser", b.Name)
+		if err := r.Delete(ctx, b); err != nil && !errors.IsNotFound(err) {
+			logger.Error(err, "unable to delete pooled browser", "browser", b.Name)
+			return reconcile.Result{}, err
+		}
+	}
+	for i := 0; i < plan.create; i++ {
+		b := newPooledBrowser(pool)
+		if err := controllerutil.SetControllerReference(pool, b, r.Scheme); err != nil {
+			return reconcile.Result{}, err
+		}
+		if err := r.Create(ctx, b); err != nil {
+			logger.Error(err, "unable to create pooled browser")
+			return reconcile.Result{}, err
+		}
+		logger.Info("pooled browser created", "browser", b.Name)
+	}
+
+	pool.Status = plan.status
+	if err := r.Status().Update(ctx, pool); err != nil {
+		logger.Error(err, "unable to update browser pool status")
+		return reconcile.Result{}, err
+	}
+	return reconcile.Result{}, nil
+}
+
+// planPool calculates pool status and the actions required to refill it
+func planPool(pool *browserkubeapiv1.BrowserPool, browsers []browserkubeapiv1.Browser) poolPlan {
+	var (
+		plan     poolPlan
+		idle     []*browserkubeapiv1.Browser
+		starting []*browserkubeapiv1.Browser
+	)
+	for i := range browsers {
+		b := &browsers[i]
+		if !b.DeletionTimestamp.IsZero() {
+			continue
+		}
+		if b.Labels[browserkubeapiv1.LabelPoolState] == browserkubeapiv1.PoolStateClaimed {
+			if b.Status.Phase != browserkubeapiv1.PhaseTerminated && b.Status.Phase != browserkubeapiv1.PhaseFailed {
+				plan.status.Claimed++
+			}
+			continue
+		}
+		switch b.Status.Phase {
+		case browserkubeapiv1.PhaseRunning:
+			idle = append(idle, b)
+		case browserkubeapiv1.PhaseFailed, browserkubeapiv1.PhaseTerminated:
+			// broken idle browser has to be replaced
+			plan.toDelete = append(plan.toDelete, b)
+		default:
+			starting = append(starting, b)
+		}
+	}
+
+	unclaimed := int32(len(idle) + len(starting))
+	if unclaimed < pool.Spec.MinIdle {
+		plan.create = int(pool.Spec.MinIdle - unclaimed)
+	}
+	if excess := int(unclaimed - pool.GetMaxIdle()); excess > 0 {
+		// get rid of the browsers which are not started yet first, then of the youngest idle ones
+		n := min(excess, len(starting))
+		plan.toDelete = append(plan.toDelete, starting[:n]...)
+		starting = starting[n:]
+		excess -= n
+
+		sort.SliceStable(idle, func(i, j int) bool {
+			return idle[j].CreationTimestamp.Before(&idle[i].CreationTimestamp)
+		})
+		plan.toDelete = append(plan.toDelete, idle[:excess]...)
+		idle = idle[excess:]
+	}
+
+	plan.status.Idle = int32(len(idle))
+	plan.status.Starting = int32(len(starting) + plan.create)
+	return plan
+}
+
+func newPooledBrowser(pool *browserkubeapiv1.BrowserPool) *browserkubeapiv1.Browser {
+	return &browserkubeapiv1.Browser{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: pool.Name + "-",
+			Namespace:    pool.Namespace,
+			Labels: map[string]string{
+				browserkubeapiv1.LabelPool:      pool.Name,
+				browserkubeapiv1.LabelPoolState: browserkubeapiv1.PoolStateIdle,
+			},
+			Annotations: map[string]string{
+				browserkubeapiv1.AnnotationPoolBrowserVersion: pool.Spec.BrowserVersion,
+			},
+		},
+		Spec: browserkubeapiv1.BrowserSpec{
+			BrowserName:      pool.Spec.BrowserName,
+			BrowserVersion:   pool.Spec.BrowserVersion,
+			Type:             pool.Spec.Type,
+			Timezone:         pool.Spec.Timezone,
+			EnableVNC:        pool.Spec.EnableVNC,
+			EnableVideo:      pool.Spec.EnableVideo,
+			ScreenResolution: pool.Spec.ScreenResolution,
+		},
+	}
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *BrowserPoolReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&browserkubeapiv1.BrowserPool{}).
+		// claimed browsers are not owned by the pool anymore, so they are mapped by label
+		Watches(&browserkubeapiv1.Browser{}, handler.EnqueueRequestsFromMapFunc(
+			func(_ context.Context, obj client.Object) []reconcile.Request {
+				poolName := obj.GetLabels()[browserkubeapiv1.LabelPool]
+				if poolName == "" {
+					return nil
+				}
+				return []reconcile.Request{{NamespacedName: types.NamespacedName{
+					Namespace: obj.GetNamespace(),
+					Name:      poolName,
+				}}}
+			})).
+		Complete(r)
+}
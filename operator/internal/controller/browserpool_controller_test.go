@@ -0,0 +1,98 @@
+package controller
+
+import (
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	browserkubeapiv1 "github.com/browserkube/browserkube/operator/api/v1"
+)
+
+func pooled(name, state string, phase browserkubeapiv1.Phase, age time.Duration) browserkubeapiv1.Browser {
+	return browserkubeapiv1.Browser{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              name,
+			CreationTimestamp: metav1.NewTime(time.Now().Add(-age)),
+			Labels: map[string]string{
+				browserkubeapiv1.LabelPool:      "pool",
+				browserkubeapiv1.LabelPoolState: state,
+			},
+		},
+		Status: browserkubeapiv1.BrowserStatus{Phase: phase},
+	}
+}
+
+func TestPlanPool(t *testing.T) {
+	tests := []struct {
+		name       string
+		min, max   int32
+		browsers   []browserkubeapiv1.Browser
+		wantCreate int
+		wantDelete []string
+		wantStatus browserkubeapiv1.BrowserPoolStatus
+	}{
+		{
+			name:       "empty pool is filled up to min idle",
+			min:        2,
+			wantCreate: 2,
+			wantStatus: browserkubeapiv1.BrowserPoolStatus{Starting: 2},
+		},
+		{
+			name: "claimed browsers are refilled",
+			min:  2,
+			browsers: []browserkubeapiv1.Browser{
+				pooled("b1", browserkubeapiv1.PoolStateIdle, browserkubeapiv1.PhaseRunning, time.Minute),
+				pooled("b2", browserkubeapiv1.PoolStateClaimed, browserkubeapiv1.PhaseRunning, time.Minute),
+			},
+			wantCreate: 1,
+			wantStatus: browserkubeapiv1.BrowserPoolStatus{Idle: 1, Starting: 1, Claimed: 1},
+		},
+		{
+			name: "failed idle browsers are replaced",
+			min:  1,
+			browsers: []browserkubeapiv1.Browser{
+				pooled("b1", browserkubeapiv1.PoolStateIdle, browserkubeapiv1.PhaseFailed, time.Minute),
+			},
+			wantCreate: 1,
+			wantDelete: []string{"b1"},
+			wantStatus: browserkubeapiv1.BrowserPoolStatus{Starting: 1},
+		},
+		{
+			name: "excessive browsers are removed, starting and youngest first",
+			min:  1,
+			max:  2,
+			browsers: []browserkubeapiv1.Browser{
+				pooled("old", browserkubeapiv1.PoolStateIdle, browserkubeapiv1.PhaseRunning, time.Hour),
+				pooled("young", browserkubeapiv1.PoolStateIdle, browserkubeapiv1.PhaseRunning, time.Minute),
+				pooled("middle", browserkubeapiv1.PoolStateIdle, browserkubeapiv1.PhaseRunning, 10*time.Minute),
+				pooled("starting", browserkubeapiv1.PoolStateIdle, browserkubeapiv1.PhasePending, time.Second),
+			},
+			wantDelete: []string{"starting", "young"},
+			wantStatus: browserkubeapiv1.BrowserPoolStatus{Idle: 2},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pool := &browserkubeapiv1.BrowserPool{
+				ObjectMeta: metav1.ObjectMeta{Name: "pool"},
+				Spec:       browserkubeapiv1.BrowserPoolSpec{MinIdle: tt.min, MaxIdle: tt.max},
+			}
+			plan := planPool(pool, tt.browsers)
+			if plan.create != tt.wantCreate {
+				t.Errorf("create = %d, want %d", plan.create, tt.wantCreate)
+			}
+			if plan.status != tt.wantStatus {
+				t.Errorf("status = %+v, want %+v", plan.status, tt.wantStatus)
+			}
+			if len(plan.toDelete) != len(tt.wantDelete) {
+				t.Fatalf("deleted %d browsers, want %d", len(plan.toDelete), len(tt.wantDelete))
+			}
+			for i, b := range plan.toDelete {
+				if b.Name != tt.wantDelete[i] {
+					t.Errorf("deleted[%d] = %s, want %s", i, b.Name, tt.wantDelete[i])
+				}
+			}
+		})
+	}
+}
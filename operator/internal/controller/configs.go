@@ -4,6 +4,7 @@ import "flag"
 
 type BrowserCtrlOpts struct {
 	OperatorNamespace       string
+	WatchNamespaces         string
 	sidecarImage            string
 	recorderImage           string
 	extensionInstallerImage string
@@ -12,14 +13,15 @@ type BrowserCtrlOpts struct {
 	clipboardImage          string
 	sidecarPort             string
 	browserUserConfig       string
-	browserExtensionConfig  string
 	browserReadinessConfig  string
+	extensionArtifactsURL   string
 }
 
 func InitBrowserCtrlOpts() *BrowserCtrlOpts {
 	cfg := &BrowserCtrlOpts{}
 
 	flag.StringVar(&cfg.OperatorNamespace, "operator-namespace", "browserkube", "Operator's namespace")
+	flag.StringVar(&cfg.WatchNamespaces, "watch-namespaces", "", "Comma-separated tenant namespaces to watch in addition to operator's namespace")
 
 	flag.StringVar(&cfg.sidecarImage, "sidecar-image", "", "Image of sidecar to be used")
 	flag.StringVar(&cfg.xServerImage, "x-server-image", "", "Image of x-server to be used")
@@ -27,9 +29,9 @@ func InitBrowserCtrlOpts() *BrowserCtrlOpts {
 	flag.StringVar(&cfg.clipboardImage, "clipboard-image", "", "Image of clipboard to be used")
 	flag.StringVar(&cfg.recorderImage, "recorder-image", "", "Image of recorder to be used")
 	flag.StringVar(&cfg.extensionInstallerImage, "extension-installer-image", "", "Image of extension-installer to be used")
+	flag.StringVar(&cfg.extensionArtifactsURL, "extension-artifacts-url", "", "URL of browserkube extensions API private extension artifacts are installed from")
 	flag.StringVar(&cfg.sidecarPort, "sidecar-port", "9999", "Port of sidecar")
 	flag.StringVar(&cfg.browserUserConfig, "browser-user-configmap", "browserkube-browsers-usergroup", "Browser Config Map Name")
-	flag.StringVar(&cfg.browserExtensionConfig, "browser-extension-configmap", "browserkube-browser-extension-config", "Browser Config Map Name")
 	flag.StringVar(&cfg.browserReadinessConfig, "browser-readinessprobe-configmap", "browserkube-browsers-readinessprobe-config", "Browser Readiness Config Map Name")
 
 	return cfg
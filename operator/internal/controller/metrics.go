@@ -0,0 +1,117 @@
+package controller
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	browserkubeapiv1 "github.com/browserkube/browserkube/operator/api/v1"
+)
+
+const metricsNamespace = "browserkube"
+
+// browserPhaseListTimeout limits listing of browsers on metrics scrape
+const browserPhaseListTimeout = 5 * time.Second
+
+var (
+	browserProvisioningSeconds = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: metricsNamespace,
+		Name:      "browser_provisioning_duration_seconds",
+		Help:      "Time from Browser creation until it is running",
+		Buckets:   []float64{1, 2.5, 5, 10, 20, 30, 45, 60, 90, 120, 180, 300},
+	}, []string{"browser", "version", "image_type"})
+
+	browserFailuresTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Name:      "browser_failures_total",
+		Help:      "Browsers failed to be created by reason",
+	}, []string{"reason"})
+
+	browserStartupTimeoutsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Name:      "browser_startup_timeouts_total",
+		Help:      "Browsers deleted since they haven't started within startup timeout",
+	}, []string{"browser", "version"})
+
+	browserSidecarTerminationsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Name:      "browser_sidecar_terminations_total",
+		Help:      "Browsers terminated since their sidecar exited",
+	}, []string{"browser", "version", "exit_code"})
+)
+
+func init() {
+	metrics.Registry.MustRegister(
+		browserProvisioningSeconds,
+		browserFailuresTotal,
+		browserStartupTimeoutsTotal,
+		browserSidecarTerminationsTotal,
+	)
+}
+
+// browserPhaseCollector reports current browsers by phase. Browsers are listed from the cache on every scrape
+type browserPhaseCollector struct {
+	reader client.Reader
+	desc   *prometheus.Desc
+}
+
+func newBrowserPhaseCollector(reader client.Reader) *browserPhaseCollector {
+	return &browserPhaseCollector{
+		reader: reader,
+		desc: prometheus.NewDesc(prometheus.BuildFQName(metricsNamespace, "", "browsers"),
+			"Current browsers by phase", []string{"phase"}, nil),
+	}
+}
+
+func (c *browserPhaseCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.desc
+}
+
+func (c *browserPhaseCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), browserPhaseListTimeout)
+	defer cancel()
+
+	browsers := &browserkubeapiv1.BrowserList{}
+	if err := c.reader.List(ctx, browsers); err != nil {
+		log.FromContext(ctx).Error(err, "unable to list browsers for metrics")
+		ch <- prometheus.NewInvalidMetric(c.desc, err)
+		return
+	}
+	counts := map[browserkubeapiv1.Phase]int{
+		browserkubeapiv1.PhasePending:    0,
+		browserkubeapiv1.PhaseRunning:    0,
+		browserkubeapiv1.PhaseTerminated: 0,
+		browserkubeapiv1.PhaseFailed:     0,
+	}
+	for i := range browsers.Items {
+		phase := browsers.Items[i].Status.Phase
+		if phase == "" {
+			// pod of the browser isn't created yet
+			phase = browserkubeapiv1.PhasePending
+		}
+		counts[phase]++
+	}
+	for phase, count := range counts {
+		ch <- prometheus.MustNewConstMetric(c.desc, prometheus.GaugeValue, float64(count), string(phase))
+	}
+}
+
+func observeProvisioned(instance *browserkubeapiv1.Browser, imageType string, now time.Time) {
+	if imageType == "" {
+		imageType = "unknown"
+	}
+	browserProvisioningSeconds.
+		WithLabelValues(instance.Spec.BrowserName, instance.Spec.BrowserVersion, imageType).
+		Observe(now.Sub(instance.CreationTimestamp.Time).Seconds())
+}
+
+func countSidecarTermination(instance *browserkubeapiv1.Browser, exitCode int32) {
+	browserSidecarTerminationsTotal.
+		WithLabelValues(instance.Spec.BrowserName, instance.Spec.BrowserVersion, strconv.Itoa(int(exitCode))).
+		Inc()
+}
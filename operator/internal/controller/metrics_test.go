@@ -0,0 +1,92 @@
+package controller
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	browserkubeapiv1 "github.com/browserkube/browserkube/operator/api/v1"
+)
+
+func TestBrowserPhaseCollector(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := browserkubeapiv1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	browser := func(name string, phase browserkubeapiv1.Phase) client.Object {
+		return &browserkubeapiv1.Browser{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: defaultNs},
+			Status:     browserkubeapiv1.BrowserStatus{Phase: phase},
+		}
+	}
+	reader := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		browser("new", ""),
+		browser("pending", browserkubeapiv1.PhasePending),
+		browser("running-1", browserkubeapiv1.PhaseRunning),
+		browser("running-2", browserkubeapiv1.PhaseRunning),
+	).Build()
+
+	expected := `
+# HELP browserkube_browsers Current browsers by phase
+# TYPE browserkube_browsers gauge
+browserkube_browsers{phase="Failed"} 0
+browserkube_browsers{phase="Pending"} 2
+browserkube_browsers{phase="Running"} 2
+browserkube_browsers{phase="Terminated"} 0
+`
+	if err := testutil.CollectAndCompare(newBrowserPhaseCollector(reader), strings.NewReader(expected)); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestObserveProvisioned(t *testing.T) {
+	browserProvisioningSeconds.Reset()
+	created := time.Now().Add(-42 * time.Second)
+	instance := &browserkubeapiv1.Browser{
+		ObjectMeta: metav1.ObjectMeta{CreationTimestamp: metav1.NewTime(created)},
+		Spec:       browserkubeapiv1.BrowserSpec{BrowserName: "chrome", BrowserVersion: "124.0"},
+	}
+	observeProvisioned(instance, browserkubeapiv1.ImageFlavourSelenoid, created.Add(42*time.Second))
+
+	expected := `
+# HELP browserkube_browser_provisioning_duration_seconds Time from Browser creation until it is running
+# TYPE browserkube_browser_provisioning_duration_seconds histogram
+browserkube_browser_provisioning_duration_seconds_bucket{browser="chrome",image_type="selenoid",version="124.0",le="1"} 0
+browserkube_browser_provisioning_duration_seconds_bucket{browser="chrome",image_type="selenoid",version="124.0",le="2.5"} 0
+browserkube_browser_provisioning_duration_seconds_bucket{browser="chrome",image_type="selenoid",version="124.0",le="5"} 0
+browserkube_browser_provisioning_duration_seconds_bucket{browser="chrome",image_type="selenoid",version="124.0",le="10"} 0
+browserkube_browser_provisioning_duration_seconds_bucket{browser="chrome",image_type="selenoid",version="124.0",le="20"} 0
+browserkube_browser_provisioning_duration_seconds_bucket{browser="chrome",image_type="selenoid",version="124.0",le="30"} 0
+browserkube_browser_provisioning_duration_seconds_bucket{browser="chrome",image_type="selenoid",version="124.0",le="45"} 1
+browserkube_browser_provisioning_duration_seconds_bucket{browser="chrome",image_type="selenoid",version="124.0",le="60"} 1
+browserkube_browser_provisioning_duration_seconds_bucket{browser="chrome",image_type="selenoid",version="124.0",le="90"} 1
+browserkube_browser_provisioning_duration_seconds_bucket{browser="chrome",image_type="selenoid",version="124.0",le="120"} 1
+browserkube_browser_provisioning_duration_seconds_bucket{browser="chrome",image_type="selenoid",version="124.0",le="180"} 1
+browserkube_browser_provisioning_duration_seconds_bucket{browser="chrome",image_type="selenoid",version="124.0",le="300"} 1
+browserkube_browser_provisioning_duration_seconds_bucket{browser="chrome",image_type="selenoid",version="124.0",le="+Inf"} 1
+browserkube_browser_provisioning_duration_seconds_sum{browser="chrome",image_type="selenoid",version="124.0"} 42
+browserkube_browser_provisioning_duration_seconds_count{browser="chrome",image_type="selenoid",version="124.0"} 1
+`
+	if err := testutil.CollectAndCompare(browserProvisioningSeconds, strings.NewReader(expected)); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestCountSidecarTermination(t *testing.T) {
+	browserSidecarTerminationsTotal.Reset()
+	instance := &browserkubeapiv1.Browser{
+		Spec: browserkubeapiv1.BrowserSpec{BrowserName: "firefox", BrowserVersion: "125.0"},
+	}
+	countSidecarTermination(instance, 0)
+	countSidecarTermination(instance, 0)
+
+	if got := testutil.ToFloat64(browserSidecarTerminationsTotal.WithLabelValues("firefox", "125.0", "0")); got != 2 {
+		t.Errorf("sidecar terminations = %v, want 2", got)
+	}
+}
@@ -0,0 +1,146 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	browserkubeapiv1 "github.com/browserkube/browserkube/operator/api/v1"
+	"github.com/browserkube/browserkube/operator/internal/controller/browserimage"
+)
+
+// microsoftVersionRe matches Playwright version in the image tag, e.g. v1.44.0-jammy
+var microsoftVersionRe = regexp.MustCompile(`^v?(\d+\.\d+\.\d+)`)
+
+// microsoftPodBuilder runs official mcr.microsoft.com/playwright images as Playwright server.
+// The images have browsers and Xvfb installed but no server, so it's started with npx
+// using the same version as the image to match the client version unless the image profile
+// configures the server command.
+type microsoftPodBuilder struct {
+	browserConfig *browserkubeapiv1.BrowserConfig
+	profile       browserimage.Profile
+}
+
+func (m *microsoftPodBuilder) Build(ctx context.Context, b *browserkubeapiv1.Browser, opts *BrowserCtrlOpts, readinessProbe *apiv1.Probe) (*apiv1.Pod, error) {
+	version, err := microsoftPlaywrightVersion(m.browserConfig.Image)
+	if err != nil {
+		return nil, err
+	}
+	volumeMounts := buildVolumeMounts(m.profile.HomeDir)
+
+	// headed browsers render to the display of the image profile, :99 by default. It's served by Xvfb
+	// started in the browser container or by x-server container if VNC is requested
+	display := m.profile.Display()
+
+	spec := &apiv1.PodSpec{
+		Hostname:      b.Name,
+		RestartPolicy: apiv1.RestartPolicyNever,
+		Containers: []apiv1.Container{
+			{
+				Name:  containerNameSidecar,
+				Image: opts.sidecarImage,
+				Ports: []apiv1.ContainerPort{
+					buildContainerPort("sidecar", opts.sidecarPort),
+				},
+				Env:          buildSidecarEnvVar(opts.sidecarPort, m.profile.HomeDir, m.browserConfig),
+				VolumeMounts: volumeMounts,
+				Resources:    buildResources(200, memory128Mi, 100, memory128Mi),
+			},
+			{
+				Name:       containerNameBrowser,
+				Image:      m.browserConfig.Image,
+				WorkingDir: m.profile.HomeDir,
+				Command:    []string{"/bin/sh", "-c"},
+				Args:       []string{m.buildCommand(b.Spec.EnableVNC)},
+				Ports: []apiv1.ContainerPort{
+					buildContainerPort("browser", m.browserConfig.Port),
+				},
+				Env:            m.buildBrowserEnvVars(b.Spec, version, display),
+				VolumeMounts:   volumeMounts,
+				ReadinessProbe: readinessProbe,
+				Resources:      buildResources(1000, size2Gi, 500, size2Gi),
+			},
+			{
+				Name:         containerNameClipboard,
+				Image:        opts.clipboardImage,
+				VolumeMounts: volumeMounts,
+				Ports: []apiv1.ContainerPort{
+					buildContainerPort("p", ports.Clipboard),
+				},
+				Env:   []apiv1.EnvVar{{Name: "DISPLAY", Value: display}},
+				Stdin: true,
+				TTY:   true,
+			},
+		},
+		Volumes: buildVolumes(opts),
+	}
+
+	if b.Spec.EnableVNC {
+		addContainersVNC(opts, spec, b.Name, b.Spec.ScreenResolution, display, volumeMounts)
+	}
+	if m.browserConfig.EnableVideo {
+		addContainerRecorder(opts, spec, m.browserConfig.Recording, m.profile.HomeDir, m.profile.DisplayNum, volumeMounts)
+	}
+
+	if len(b.Spec.Extensions) != 0 {
+		log.FromContext(ctx).Info("Browser extensions aren't supported by Playwright images, ignoring",
+			"Capabilities", fmt.Sprintf("%+v", b.Spec.Extensions))
+	}
+
+	browserPod := &apiv1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      getBrowserPodName(b.Name),
+			Labels:    getBrowserPodLabels(b.Name),
+			Namespace: b.Namespace,
+		},
+		Spec: *spec,
+	}
+	copySpec(browserPod, m.browserConfig.Spec)
+	return browserPod, nil
+}
+
+// microsoftServerCommand fetches Playwright server of the image version from the npm registry
+const microsoftServerCommand = "exec npx -y playwright@${PLAYWRIGHT_VERSION} run-server --port ${PLAYWRIGHT_PORT} --host 0.0.0.0"
+
+// buildCommand starts Playwright server, the one of the image profile if configured,
+// e.g. a server installed to a derived image for clusters without access to the npm registry.
+// Values are passed through env variables
+func (m *microsoftPodBuilder) buildCommand(enableVNC bool) string {
+	server := microsoftServerCommand
+	if m.profile.ServerCommand != "" {
+		server = m.profile.ServerCommand
+	}
+	if enableVNC {
+		// display is served by x-server container
+		return server
+	}
+	return "Xvfb ${DISPLAY} -screen 0 ${SCREEN_RESOLUTION} -nolisten tcp & " + server
+}
+
+func (m *microsoftPodBuilder) buildBrowserEnvVars(browser browserkubeapiv1.BrowserSpec, version, display string) []apiv1.EnvVar {
+	return []apiv1.EnvVar{
+		{Name: "TZ", Value: m.browserConfig.Timezone},
+		{Name: "DISPLAY", Value: display},
+		{Name: "SCREEN_RESOLUTION", Value: GetResolution(browser.ScreenResolution)},
+		{Name: "PLAYWRIGHT_VERSION", Value: version},
+		{Name: "PLAYWRIGHT_PORT", Value: m.browserConfig.Port},
+	}
+}
+
+// microsoftPlaywrightVersion extracts Playwright version from the image tag
+func microsoftPlaywrightVersion(image string) (string, error) {
+	idx := strings.LastIndexByte(image, ':')
+	if idx < 0 || idx < strings.LastIndexByte(image, '/') {
+		return "", fmt.Errorf("playwright image must be tagged with Playwright version: %s", image)
+	}
+	match := microsoftVersionRe.FindStringSubmatch(image[idx+1:])
+	if match == nil {
+		return "", fmt.Errorf("unable to parse Playwright version from image tag: %s", image)
+	}
+	return match[1], nil
+}
@@ -0,0 +1,125 @@
+package controller
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	browserkubeapiv1 "github.com/browserkube/browserkube/operator/api/v1"
+	"github.com/browserkube/browserkube/operator/internal/controller/browserimage"
+)
+
+func TestMicrosoftPlaywrightVersion(t *testing.T) {
+	tests := []struct {
+		image   string
+		want    string
+		wantErr bool
+	}{
+		{image: "mcr.microsoft.com/playwright:v1.36.0-jammy", want: "1.36.0"},
+		{image: "mcr.microsoft.com/playwright:v1.44.1", want: "1.44.1"},
+		{image: "mcr.microsoft.com/playwright:1.40.0-focal", want: "1.40.0"},
+		{image: "mcr.microsoft.com/playwright:jammy", wantErr: true},
+		{image: "mcr.microsoft.com/playwright", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.image, func(t *testing.T) {
+			got, err := microsoftPlaywrightVersion(tt.image)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("microsoftPlaywrightVersion() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("microsoftPlaywrightVersion() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMicrosoftPodBuilder(t *testing.T) {
+	tests := []struct {
+		name           string
+		enableVNC      bool
+		enableVideo    bool
+		serverCommand  string
+		wantContainers []string
+		wantDisplay    string
+		wantXvfb       bool
+		wantServer     string
+	}{
+		{
+			name:           "headed with own X server",
+			wantContainers: []string{containerNameSidecar, containerNameBrowser, containerNameClipboard},
+			wantDisplay:    ":99",
+			wantXvfb:       true,
+			wantServer:     "npx -y playwright@${PLAYWRIGHT_VERSION}",
+		},
+		{
+			name:           "server of the image profile",
+			serverCommand:  "exec /opt/playwright/node_modules/.bin/playwright run-server --port ${PLAYWRIGHT_PORT}",
+			wantContainers: []string{containerNameSidecar, containerNameBrowser, containerNameClipboard},
+			wantDisplay:    ":99",
+			wantXvfb:       true,
+			wantServer:     "exec /opt/playwright/node_modules/.bin/playwright run-server",
+		},
+		{
+			name:        "vnc and video",
+			enableVNC:   true,
+			enableVideo: true,
+			wantContainers: []string{
+				containerNameSidecar, containerNameBrowser, containerNameClipboard,
+				containerNameXServer, containerNameVNCServer, containerNameRecorder,
+			},
+			wantDisplay: ":99",
+			wantServer:  "npx -y playwright@${PLAYWRIGHT_VERSION}",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			profile := browserimage.BuiltinProfile(browserimage.ImageTypeMicrosoft)
+			profile.ServerCommand = tt.serverCommand
+			builder := &microsoftPodBuilder{
+				browserConfig: &browserkubeapiv1.BrowserConfig{
+					Image:       "mcr.microsoft.com/playwright:v1.36.0-jammy",
+					Port:        "4444",
+					EnableVideo: tt.enableVideo,
+				},
+				profile: profile,
+			}
+			pod, err := builder.Build(context.Background(), &browserkubeapiv1.Browser{
+				ObjectMeta: metav1.ObjectMeta{Name: "session", Namespace: defaultNs},
+				Spec:       browserkubeapiv1.BrowserSpec{BrowserName: "chromium", EnableVNC: tt.enableVNC},
+			}, &BrowserCtrlOpts{}, nil)
+			if err != nil {
+				t.Fatalf("Build() unexpected error = %v", err)
+			}
+
+			names := make([]string, 0, len(pod.Spec.Containers))
+			for _, c := range pod.Spec.Containers {
+				names = append(names, c.Name)
+			}
+			if strings.Join(names, ",") != strings.Join(tt.wantContainers, ",") {
+				t.Errorf("containers = %v, want %v", names, tt.wantContainers)
+			}
+
+			browser := pod.Spec.Containers[1]
+			env := map[string]string{}
+			for _, e := range browser.Env {
+				env[e.Name] = e.Value
+			}
+			if env["DISPLAY"] != tt.wantDisplay || env["PLAYWRIGHT_VERSION"] != "1.36.0" || env["PLAYWRIGHT_PORT"] != "4444" {
+				t.Errorf("browser env = %v", env)
+			}
+			if got := strings.Contains(browser.Args[0], "Xvfb"); got != tt.wantXvfb {
+				t.Errorf("browser command = %s, want Xvfb %v", browser.Args[0], tt.wantXvfb)
+			}
+			if !strings.Contains(browser.Args[0], tt.wantServer) {
+				t.Errorf("browser command = %s, want server %s", browser.Args[0], tt.wantServer)
+			}
+			if browser.Ports[0].ContainerPort != 4444 || browser.Ports[0].Protocol != v1.ProtocolTCP {
+				t.Errorf("browser ports = %v", browser.Ports)
+			}
+		})
+	}
+}
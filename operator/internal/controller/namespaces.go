@@ -0,0 +1,20 @@
+package controller
+
+import (
+	"strings"
+
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+)
+
+// CacheNamespaces returns namespaces the operator watches: its own namespace and the tenant namespaces
+func (o *BrowserCtrlOpts) CacheNamespaces() map[string]cache.Config {
+	namespaces := map[string]cache.Config{
+		o.OperatorNamespace: {},
+	}
+	for _, ns := range strings.Split(o.WatchNamespaces, ",") {
+		if ns = strings.TrimSpace(ns); ns != "" {
+			namespaces[ns] = cache.Config{}
+		}
+	}
+	return namespaces
+}
@@ -0,0 +1,38 @@
+package controller
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func TestCacheNamespaces(t *testing.T) {
+	tests := []struct {
+		name string
+		opts *BrowserCtrlOpts
+		want []string
+	}{
+		{
+			name: "operator namespace only",
+			opts: &BrowserCtrlOpts{OperatorNamespace: defaultNs},
+			want: []string{defaultNs},
+		},
+		{
+			name: "listed namespaces",
+			opts: &BrowserCtrlOpts{OperatorNamespace: defaultNs, WatchNamespaces: "team-a, team-c,"},
+			want: []string{defaultNs, "team-a", "team-c"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var names []string
+			for ns := range tt.opts.CacheNamespaces() {
+				names = append(names, ns)
+			}
+			sort.Strings(names)
+			if !reflect.DeepEqual(names, tt.want) {
+				t.Errorf("CacheNamespaces() = %v, want %v", names, tt.want)
+			}
+		})
+	}
+}
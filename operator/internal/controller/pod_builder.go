@@ -14,29 +14,37 @@ type BrowserPodBuilder interface {
 	Build(ctx context.Context, b *browserkubeapiv1.Browser, opts *BrowserCtrlOpts, readinessProbe *apiv1.Probe) (*apiv1.Pod, error)
 }
 
-func NewPodBuilder(browserConfig *browserkubeapiv1.BrowserConfig) (BrowserPodBuilder, browserimage.ImageType, error) {
+func NewPodBuilder(browserConfig *browserkubeapiv1.BrowserConfig) (BrowserPodBuilder, browserimage.Profile, error) {
 	// return appropriate pod builder for given browserConfig
-	imageType, err := browserimage.ParseImageType(browserConfig.Image)
+	profile, err := browserimage.ResolveProfile(browserConfig.Image, browserConfig.ImageProfile)
 	if err != nil {
-		return nil, -1, fmt.Errorf("unsupported browser image type: %s", browserConfig.Image)
+		return nil, browserimage.Profile{}, fmt.Errorf("unsupported browser image type: %s: %w", browserConfig.Image, err)
 	}
 
 	var builder BrowserPodBuilder
-	switch imageType {
+	switch profile.Type {
 	case browserimage.ImageTypeSelenium:
 		builder = &seleniumPodBuilder{
 			browserConfig: browserConfig,
+			profile:       profile,
 		}
 	case browserimage.ImageTypeSelenoid:
 		builder = &selenoidPodBuilder{
 			browserConfig: browserConfig,
+			profile:       profile,
 		}
 	case browserimage.ImageTypeAerokube:
 		builder = &aerokubePodBuilder{
 			browserConfig: browserConfig,
+			profile:       profile,
+		}
+	case browserimage.ImageTypeMicrosoft:
+		builder = &microsoftPodBuilder{
+			browserConfig: browserConfig,
+			profile:       profile,
 		}
 	default:
-		return nil, -1, fmt.Errorf("unknown image type")
+		return nil, browserimage.Profile{}, fmt.Errorf("unknown image type")
 	}
-	return builder, imageType, nil
+	return builder, profile, nil
 }
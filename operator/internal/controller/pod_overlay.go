@@ -0,0 +1,79 @@
+package controller
+
+import (
+	"encoding/json"
+	"fmt"
+
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/strategicpatch"
+)
+
+// applyPodTemplateOverlay patches the built browser pod with the overlay.
+// The overlay may add anything to the pod, but containers, ports, labels and name of the pod are managed by browserkube
+// and can't be removed or changed
+func applyPodTemplateOverlay(pod *apiv1.Pod, overlay *runtime.RawExtension) (*apiv1.Pod, error) {
+	if overlay == nil || len(overlay.Raw) == 0 {
+		return pod, nil
+	}
+	original, err := json.Marshal(pod)
+	if err != nil {
+		return nil, err
+	}
+	patched, err := strategicpatch.StrategicMergePatch(original, overlay.Raw, apiv1.Pod{})
+	if err != nil {
+		return nil, fmt.Errorf("unable to apply pod template overlay: %w", err)
+	}
+	result := &apiv1.Pod{}
+	if err = json.Unmarshal(patched, result); err != nil {
+		return nil, fmt.Errorf("unable to apply pod template overlay: %w", err)
+	}
+	if err = checkManagedFields(pod, result); err != nil {
+		return nil, fmt.Errorf("pod template overlay isn't allowed: %w", err)
+	}
+	return result, nil
+}
+
+// checkManagedFields makes sure the overlay kept everything browserkube relies on
+func checkManagedFields(built, patched *apiv1.Pod) error {
+	if patched.Name != built.Name || patched.Namespace != built.Namespace {
+		return fmt.Errorf("pod name can't be changed")
+	}
+	for k, v := range built.Labels {
+		if patched.Labels[k] != v {
+			return fmt.Errorf("label %s can't be changed", k)
+		}
+	}
+	if err := checkManagedContainers(built.Spec.InitContainers, patched.Spec.InitContainers); err != nil {
+		return err
+	}
+	return checkManagedContainers(built.Spec.Containers, patched.Spec.Containers)
+}
+
+func checkManagedContainers(built, patched []apiv1.Container) error {
+	byName := make(map[string]*apiv1.Container, len(patched))
+	for i := range patched {
+		byName[patched[i].Name] = &patched[i]
+	}
+	for _, c := range built {
+		container, ok := byName[c.Name]
+		if !ok {
+			return fmt.Errorf("container %s can't be removed", c.Name)
+		}
+		for _, port := range c.Ports {
+			if !hasPort(container.Ports, port) {
+				return fmt.Errorf("port %d of container %s can't be removed", port.ContainerPort, c.Name)
+			}
+		}
+	}
+	return nil
+}
+
+func hasPort(ports []apiv1.ContainerPort, port apiv1.ContainerPort) bool {
+	for _, p := range ports {
+		if p.ContainerPort == port.ContainerPort && p.Protocol == port.Protocol {
+			return true
+		}
+	}
+	return false
+}
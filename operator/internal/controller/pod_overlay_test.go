@@ -0,0 +1,168 @@
+package controller
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	browserkubeapiv1 "github.com/browserkube/browserkube/operator/api/v1"
+)
+
+const testOverlay = `{
+	"metadata": {"annotations": {"sidecar.istio.io/inject": "false"}},
+	"spec": {
+		"runtimeClassName": "gvisor",
+		"securityContext": {"fsGroup": 1200},
+		"initContainers": [{"name": "fonts", "image": "fonts:latest"}],
+		"volumes": [{"name": "ca-bundle", "configMap": {"name": "ca-bundle"}}],
+		"containers": [{
+			"name": "browser",
+			"env": [{"name": "LANG", "value": "de_DE.UTF-8"}],
+			"volumeMounts": [{"name": "ca-bundle", "mountPath": "/etc/ssl/certs/ca.pem", "subPath": "ca.pem"}]
+		}]
+	}
+}`
+
+func buildTestPod(t *testing.T, image, browserType string) *v1.Pod {
+	t.Helper()
+	builder, _, err := NewPodBuilder(&browserkubeapiv1.BrowserConfig{Image: image, Port: "4444"})
+	if err != nil {
+		t.Fatalf("NewPodBuilder() unexpected error = %v", err)
+	}
+	pod, err := builder.Build(context.Background(), &browserkubeapiv1.Browser{
+		ObjectMeta: metav1.ObjectMeta{Name: "session", Namespace: defaultNs},
+		Spec:       browserkubeapiv1.BrowserSpec{BrowserName: browserName, Type: browserType, EnableVNC: true},
+	}, &BrowserCtrlOpts{}, nil)
+	if err != nil {
+		t.Fatalf("Build() unexpected error = %v", err)
+	}
+	return pod
+}
+
+func TestApplyPodTemplateOverlay(t *testing.T) {
+	builders := []struct {
+		name        string
+		image       string
+		browserType string
+	}{
+		{name: "selenium", image: "selenium/standalone-chrome:124.0", browserType: browserkubeapiv1.TypeWebDriver},
+		{name: "selenoid", image: "selenoid/chrome:124.0", browserType: browserkubeapiv1.TypeWebDriver},
+		{name: "aerokube", image: "playwright/chrome:playwright-1.44.0", browserType: browserkubeapiv1.TypePlaywright},
+		{name: "microsoft", image: "mcr.microsoft.com/playwright:v1.44.0-jammy", browserType: browserkubeapiv1.TypePlaywright},
+	}
+	for _, b := range builders {
+		t.Run(b.name, func(t *testing.T) {
+			built := buildTestPod(t, b.image, b.browserType)
+			builtBrowser := findContainer(built.Spec.Containers, containerNameBrowser)
+
+			pod, err := applyPodTemplateOverlay(built.DeepCopy(), &runtime.RawExtension{Raw: []byte(testOverlay)})
+			if err != nil {
+				t.Fatalf("applyPodTemplateOverlay() unexpected error = %v", err)
+			}
+			if pod.Annotations["sidecar.istio.io/inject"] != "false" {
+				t.Errorf("annotations = %v", pod.Annotations)
+			}
+			if pod.Spec.RuntimeClassName == nil || *pod.Spec.RuntimeClassName != "gvisor" {
+				t.Errorf("runtimeClassName = %v, want gvisor", pod.Spec.RuntimeClassName)
+			}
+			if pod.Spec.SecurityContext == nil || pod.Spec.SecurityContext.FSGroup == nil || *pod.Spec.SecurityContext.FSGroup != 1200 {
+				t.Errorf("securityContext = %+v", pod.Spec.SecurityContext)
+			}
+			if findContainer(pod.Spec.InitContainers, "fonts") == nil {
+				t.Errorf("init containers = %+v, want fonts", pod.Spec.InitContainers)
+			}
+			if len(pod.Spec.Volumes) != len(built.Spec.Volumes)+1 {
+				t.Errorf("volumes = %+v, want ca-bundle added", pod.Spec.Volumes)
+			}
+
+			// managed containers are kept as is apart from the patched fields
+			if len(pod.Spec.Containers) != len(built.Spec.Containers) {
+				t.Fatalf("containers = %d, want %d", len(pod.Spec.Containers), len(built.Spec.Containers))
+			}
+			browser := findContainer(pod.Spec.Containers, containerNameBrowser)
+			if browser.Image != builtBrowser.Image || len(browser.Ports) != len(builtBrowser.Ports) {
+				t.Errorf("browser container = %+v, want image and ports of %+v", browser, builtBrowser)
+			}
+			if len(browser.Env) != len(builtBrowser.Env)+1 {
+				t.Errorf("browser env = %+v, want LANG added", browser.Env)
+			}
+			if len(browser.VolumeMounts) != len(builtBrowser.VolumeMounts)+1 {
+				t.Errorf("browser volume mounts = %+v, want ca-bundle added", browser.VolumeMounts)
+			}
+			if pod.Labels[browserkubeapiv1.LabelSessionID] != "session" {
+				t.Errorf("labels = %v", pod.Labels)
+			}
+		})
+	}
+}
+
+func TestApplyPodTemplateOverlay_GuardRails(t *testing.T) {
+	tests := []struct {
+		name    string
+		overlay string
+		wantErr string
+	}{
+		{
+			name:    "no overlay",
+			overlay: "",
+		},
+		{
+			name:    "container removed",
+			overlay: `{"spec":{"containers":[{"name":"sidecar","$patch":"delete"}]}}`,
+			wantErr: "container sidecar can't be removed",
+		},
+		{
+			name:    "containers replaced",
+			overlay: `{"spec":{"containers":[{"$patch":"replace"},{"name":"browser","image":"custom"}]}}`,
+			wantErr: "container sidecar can't be removed",
+		},
+		{
+			name:    "managed image changed",
+			overlay: `{"spec":{"containers":[{"name":"sidecar","image":"registry.internal/sidecar:1.0"}]}}`,
+		},
+		{
+			name:    "ports replaced",
+			overlay: `{"spec":{"containers":[{"name":"browser","ports":[{"$patch":"replace"},{"containerPort":8080}]}]}}`,
+			wantErr: "port 4444 of container browser can't be removed",
+		},
+		{
+			name:    "label changed",
+			overlay: `{"metadata":{"labels":{"io.browserkube.session-id":"other"}}}`,
+			wantErr: "label io.browserkube.session-id can't be changed",
+		},
+		{
+			name:    "pod renamed",
+			overlay: `{"metadata":{"name":"other"}}`,
+			wantErr: "pod name can't be changed",
+		},
+		{
+			name:    "invalid patch",
+			overlay: `{"spec":{"containers":"browser"}}`,
+			wantErr: "unable to apply pod template overlay",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			built := buildTestPod(t, "selenoid/chrome:124.0", browserkubeapiv1.TypeWebDriver)
+			var overlay *runtime.RawExtension
+			if tt.overlay != "" {
+				overlay = &runtime.RawExtension{Raw: []byte(tt.overlay)}
+			}
+
+			_, err := applyPodTemplateOverlay(built, overlay)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("applyPodTemplateOverlay() unexpected error = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("applyPodTemplateOverlay() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
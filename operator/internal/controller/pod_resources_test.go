@@ -0,0 +1,153 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	browserkubeapiv1 "github.com/browserkube/browserkube/operator/api/v1"
+)
+
+func requirements(cpu, memory string) *v1.ResourceRequirements {
+	return &v1.ResourceRequirements{
+		Limits: v1.ResourceList{
+			v1.ResourceCPU:    resource.MustParse(cpu),
+			v1.ResourceMemory: resource.MustParse(memory),
+		},
+	}
+}
+
+func TestFindBrowserConfigResources(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := browserkubeapiv1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	set := &browserkubeapiv1.BrowserSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "set", Namespace: defaultNs},
+		Spec: browserkubeapiv1.BrowserSetSpec{
+			PodSpec: &browserkubeapiv1.BrowserPodSpec{
+				Resources: &browserkubeapiv1.BrowserResources{
+					Browser: requirements("1", "1Gi"),
+					Sidecar: requirements("100m", "64Mi"),
+					ShmSize: ptrQuantity("512Mi"),
+				},
+			},
+			WebDriver: map[string]browserkubeapiv1.BrowsersConfig{
+				browserName: {
+					DefaultVersion: "124.0",
+					Versions: map[string]browserkubeapiv1.BrowserConfig{
+						"124.0": {
+							Image: "selenoid/chrome:124.0",
+							Port:  "4444",
+							Spec: &browserkubeapiv1.BrowserPodSpec{
+								Resources: &browserkubeapiv1.BrowserResources{
+									Browser: requirements("2", "2Gi"),
+								},
+							},
+						},
+					},
+				},
+			},
+			SizeProfiles: map[string]browserkubeapiv1.BrowserResources{
+				"large": {Browser: requirements("4", "4Gi"), ShmSize: ptrQuantity("2Gi")},
+			},
+		},
+	}
+
+	tests := []struct {
+		name        string
+		profile     string
+		wantBrowser *v1.ResourceRequirements
+		wantShm     string
+		wantErr     bool
+	}{
+		{
+			name:        "version overrides set",
+			wantBrowser: requirements("2", "2Gi"),
+			wantShm:     "512Mi",
+		},
+		{
+			name:        "profile overrides version",
+			profile:     "large",
+			wantBrowser: requirements("4", "4Gi"),
+			wantShm:     "2Gi",
+		},
+		{
+			name:    "unknown profile",
+			profile: "unknown",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &BrowserReconciler{
+				Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(set.DeepCopy()).Build(),
+			}
+			cfg, err := r.findBrowserConfig(context.Background(), &browserkubeapiv1.Browser{
+				ObjectMeta: metav1.ObjectMeta{Name: "browser", Namespace: defaultNs},
+				Spec:       browserkubeapiv1.BrowserSpec{BrowserName: browserName, SizeProfile: tt.profile},
+			})
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("findBrowserConfig() expected error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("findBrowserConfig() unexpected error = %v", err)
+			}
+
+			pod := &v1.Pod{Spec: v1.PodSpec{
+				Containers: []v1.Container{
+					{Name: containerNameBrowser, Resources: buildResources(1000, size2Gi, 500, size2Gi)},
+					{Name: containerNameSidecar},
+					{Name: containerNameClipboard},
+				},
+				Volumes: buildVolumes(&BrowserCtrlOpts{}),
+			}}
+			copySpec(pod, cfg.Spec)
+
+			if got := pod.Spec.Containers[0].Resources; !equalResources(got, *tt.wantBrowser) {
+				t.Errorf("browser resources = %v, want %v", got, *tt.wantBrowser)
+			}
+			if got := pod.Spec.Containers[1].Resources; !equalResources(got, *requirements("100m", "64Mi")) {
+				t.Errorf("sidecar resources = %v", got)
+			}
+			if got := pod.Spec.Containers[2].Resources; len(got.Limits) != 0 {
+				t.Errorf("clipboard resources = %v, want defaults", got)
+			}
+			for _, v := range pod.Spec.Volumes {
+				if v.Name == volumeNameShm && v.EmptyDir.SizeLimit.String() != tt.wantShm {
+					t.Errorf("shm size = %s, want %s", v.EmptyDir.SizeLimit, tt.wantShm)
+				}
+			}
+		})
+	}
+}
+
+func ptrQuantity(q string) *resource.Quantity {
+	qty := resource.MustParse(q)
+	return &qty
+}
+
+func equalResources(a, b v1.ResourceRequirements) bool {
+	if len(a.Limits) != len(b.Limits) || len(a.Requests) != len(b.Requests) {
+		return false
+	}
+	for k, v := range b.Limits {
+		if q := a.Limits[k]; q.Cmp(v) != 0 {
+			return false
+		}
+	}
+	for k, v := range b.Requests {
+		if q := a.Requests[k]; q.Cmp(v) != 0 {
+			return false
+		}
+	}
+	return true
+}
@@ -13,9 +13,30 @@ import (
 	"k8s.io/utils/ptr"
 
 	browserkubeapiv1 "github.com/browserkube/browserkube/operator/api/v1"
-	"github.com/browserkube/browserkube/operator/internal/controller/browserimage"
 )
 
+const volumeNameShm = "dshm"
+
+// findContainer returns the container with the name or nil
+func findContainer(containers []apiv1.Container, name string) *apiv1.Container {
+	for i := range containers {
+		if containers[i].Name == name {
+			return &containers[i]
+		}
+	}
+	return nil
+}
+
+// addPooledEnv tells the sidecar of a pooled browser to start its timeouts when the browser is claimed
+func addPooledEnv(pod *apiv1.Pod, browser *browserkubeapiv1.Browser) {
+	if browser.Labels[browserkubeapiv1.LabelPool] == "" {
+		return
+	}
+	if sidecar := findContainer(pod.Spec.Containers, containerNameSidecar); sidecar != nil {
+		sidecar.Env = append(sidecar.Env, apiv1.EnvVar{Name: "POOLED", Value: "true"})
+	}
+}
+
 func buildContainerPort(name, port string) apiv1.ContainerPort {
 	//nolint:gosec
 	p, _ := strconv.Atoi(port)
@@ -26,14 +47,14 @@ func buildContainerPort(name, port string) apiv1.ContainerPort {
 	}
 }
 
-func buildVolumeMounts(imageType browserimage.ImageType) []apiv1.VolumeMount {
+func buildVolumeMounts(homeDir string) []apiv1.VolumeMount {
 	mounts := []apiv1.VolumeMount{
-		{Name: "dshm", MountPath: "/dev/shm"},
+		{Name: volumeNameShm, MountPath: "/dev/shm"},
 		{Name: "usergroup", MountPath: "/etc/passwd", SubPath: "passwd"},
 		{Name: "usergroup", MountPath: "/etc/group", SubPath: "group"},
-		{Name: "videos", MountPath: filepath.Join(imageType.Homedir(), recorderVideosRelativePath)},
+		{Name: "videos", MountPath: filepath.Join(homeDir, recorderVideosRelativePath)},
 		{Name: "tmp", MountPath: "/tmp"},
-		{Name: "userhome", MountPath: imageType.Homedir()}, // used by selenoid images
+		{Name: "userhome", MountPath: homeDir}, // used by selenoid images
 	}
 
 	return mounts
@@ -54,6 +75,36 @@ func copySpec(p *apiv1.Pod, spec *browserkubeapiv1.BrowserPodSpec) {
 	p.Spec.ActiveDeadlineSeconds = spec.ActiveDeadlineSeconds
 	p.Spec.TerminationGracePeriodSeconds = spec.TerminationGracePeriodSeconds
 	p.Spec.ServiceAccountName = spec.ServiceAccountName
+	copyResources(p, spec.Resources)
+}
+
+// copyResources replaces default resources of the containers and /dev/shm size with the configured ones
+func copyResources(p *apiv1.Pod, resources *browserkubeapiv1.BrowserResources) {
+	if resources == nil {
+		return
+	}
+	byContainer := map[string]*apiv1.ResourceRequirements{
+		containerNameBrowser:   resources.Browser,
+		containerNameSidecar:   resources.Sidecar,
+		containerNameRecorder:  resources.Recorder,
+		containerNameClipboard: resources.Clipboard,
+		containerNameXServer:   resources.VNC,
+		containerNameVNCServer: resources.VNC,
+	}
+	for i := range p.Spec.Containers {
+		if r := byContainer[p.Spec.Containers[i].Name]; r != nil {
+			p.Spec.Containers[i].Resources = *r.DeepCopy()
+		}
+	}
+
+	if resources.ShmSize == nil {
+		return
+	}
+	for i := range p.Spec.Volumes {
+		if v := p.Spec.Volumes[i]; v.Name == volumeNameShm && v.EmptyDir != nil {
+			v.EmptyDir.SizeLimit = ptr.To(resources.ShmSize.DeepCopy())
+		}
+	}
 }
 
 func buildVolumes(opts *BrowserCtrlOpts) []apiv1.Volume {
@@ -77,7 +128,7 @@ func buildVolumes(opts *BrowserCtrlOpts) []apiv1.Volume {
 			},
 		},
 		{
-			Name: "dshm",
+			Name: volumeNameShm,
 			VolumeSource: apiv1.VolumeSource{EmptyDir: &apiv1.EmptyDirVolumeSource{
 				Medium:    apiv1.StorageMediumMemory,
 				SizeLimit: resource.NewQuantity(size1Gb, resource.BinarySI), // 1GB
@@ -118,18 +169,25 @@ func buildResources(
 	}
 }
 
-func buildSidecarEnvVar(sidecarPort string, imageType browserimage.ImageType, browserConfigPort, browserConfigPath string) []apiv1.EnvVar {
+func buildSidecarEnvVar(sidecarPort, homeDir string, browserConfig *browserkubeapiv1.BrowserConfig) []apiv1.EnvVar {
 	proxyURL := url.URL{
 		Scheme: "http",
-		Host:   net.JoinHostPort("localhost", browserConfigPort),
-		Path:   browserConfigPath,
+		Host:   net.JoinHostPort("localhost", browserConfig.Port),
+		Path:   browserConfig.Path,
 	}
 
-	return []apiv1.EnvVar{
+	env := []apiv1.EnvVar{
 		{Name: "PORT", Value: sidecarPort},
 		{Name: "PROXY_URL", Value: proxyURL.String()},
-		{Name: "BROWSER_HOME_DIR", Value: imageType.Homedir()},
+		{Name: "BROWSER_HOME_DIR", Value: homeDir},
+	}
+	if browserConfig.IdleTimeout != nil {
+		env = append(env, apiv1.EnvVar{Name: "IDLE_TIMEOUT", Value: browserConfig.IdleTimeout.Duration.String()})
 	}
+	if browserConfig.SessionTimeout != nil {
+		env = append(env, apiv1.EnvVar{Name: "SESSION_TIMEOUT", Value: browserConfig.SessionTimeout.Duration.String()})
+	}
+	return env
 }
 
 func GetResolution(res string) string {
@@ -146,7 +204,8 @@ func GetResolution(res string) string {
 func addContainerRecorder(
 	opts *BrowserCtrlOpts,
 	spec *apiv1.PodSpec,
-	imageType browserimage.ImageType,
+	recording *browserkubeapiv1.BrowserRecording,
+	homeDir string,
 	displayNum string,
 	volumeMounts []apiv1.VolumeMount,
 ) {
@@ -155,6 +214,29 @@ func addContainerRecorder(
 		Host:   net.JoinHostPort("localhost", "5555"),
 	}
 
+	args := []string{
+		"--display-num=" + displayNum,
+		"--file-path=" + filepath.Join(homeDir, "/videos"),
+	}
+	// options which aren't set are left to the recorder defaults
+	if recording != nil {
+		if recording.VideoSize != "" {
+			args = append(args, "--video-size="+recording.VideoSize)
+		}
+		if recording.FrameRate != 0 {
+			args = append(args, "--frame-rate="+strconv.Itoa(int(recording.FrameRate)))
+		}
+		if recording.Codec != "" {
+			args = append(args, "--codec="+recording.Codec)
+		}
+		if recording.CRF != nil {
+			args = append(args, "--crf="+strconv.Itoa(int(*recording.CRF)))
+		}
+		if recording.Format != "" {
+			args = append(args, "--format="+recording.Format)
+		}
+	}
+
 	spec.Containers[0].Env = append(spec.Containers[0].Env, apiv1.EnvVar{
 		Name: "RECORDER_URL", Value: recorderURL.String(),
 	})
@@ -169,17 +251,62 @@ func addContainerRecorder(
 			Ports: []apiv1.ContainerPort{
 				buildContainerPort("http", "5555"),
 			},
-			Args: []string{
-				"--video-size=1360x1020",
-				"--frame-rate=12",
-				"--display-num=" + displayNum,
-				"--codec=libx264",
-				"--file-path=" + filepath.Join(imageType.Homedir(), "/videos"),
-			},
+			Args:         args,
 			VolumeMounts: volumeMounts, // re-use volume mounts
 		})
 }
 
+// addContainersVNC adds X server and VNC server containers serving the display
+func addContainersVNC(
+	opts *BrowserCtrlOpts,
+	spec *apiv1.PodSpec,
+	browserName string,
+	screenResolution string,
+	display string,
+	volumeMounts []apiv1.VolumeMount,
+) {
+	spec.Containers = append(spec.Containers,
+		apiv1.Container{
+			Name:         containerNameXServer,
+			Image:        opts.xServerImage,
+			VolumeMounts: volumeMounts,
+			Ports: []apiv1.ContainerPort{
+				buildContainerPort("p", "6000"),
+			},
+			Env: []apiv1.EnvVar{
+				{Name: "SCREEN_RESOLUTION", Value: GetResolution(screenResolution)},
+				{Name: "DISPLAY", Value: display},
+			},
+		},
+	)
+	addContainerVNCServer(opts, spec, browserName, display, volumeMounts)
+}
+
+// addContainerVNCServer adds VNC server container serving the display over TCP.
+// VNC server is protected with the password from the browser VNC Secret
+func addContainerVNCServer(
+	opts *BrowserCtrlOpts,
+	spec *apiv1.PodSpec,
+	browserName string,
+	display string,
+	volumeMounts []apiv1.VolumeMount,
+) {
+	spec.Containers = append(spec.Containers,
+		apiv1.Container{
+			Name:         containerNameVNCServer,
+			Image:        opts.vncServerImage,
+			VolumeMounts: volumeMounts,
+			Ports: []apiv1.ContainerPort{
+				buildContainerPort("p", ports.VNC),
+			},
+			Env: []apiv1.EnvVar{
+				{Name: "DISPLAY_ARG", Value: "WAIT:127.0.0.1" + display},
+				vncPasswordEnvVar("VNCPASS", browserName),
+			},
+		},
+	)
+}
+
 func getBrowserPodName(n string) string {
 	return fmt.Sprintf("browser-%s", strings.ToLower(n))
 }
@@ -193,14 +320,16 @@ func getBrowserPodLabels(sessionID string) map[string]string {
 }
 
 func installPlugins(
+	opts *BrowserCtrlOpts,
 	spec *apiv1.PodSpec,
 	browserName string,
-	imageType browserimage.ImageType,
+	extensionDirs []string,
 	extensions []browserkubeapiv1.BrowserExtension,
-	extensionInstallerImage,
-	browserExtensionConfig string,
 ) {
-	extMounts := buildExtensionMounts(imageType)[browserName]
+	extMounts := make([]apiv1.VolumeMount, 0, len(extensionDirs))
+	for _, dir := range extensionDirs {
+		extMounts = append(extMounts, apiv1.VolumeMount{Name: "plugins", MountPath: dir})
+	}
 
 	for i, c := range spec.Containers {
 		if c.Name == containerNameBrowser {
@@ -209,45 +338,36 @@ func installPlugins(
 		}
 	}
 
-	args := make([]string, len(extensions))
+	args := make([]string, 0, 4*len(extensions))
 	for _, extension := range extensions {
 		args = append(args,
 			"--browserName="+browserName,
 			"--extensionId="+extension.ExtensionID,
 			"--updateUrl="+extension.UpdateURL,
+			"--artifactUrl="+extensionArtifactURL(opts.extensionArtifactsURL, extension),
 		)
 	}
+	// extensions are checked against ExtensionPolicies before the pod is created
 	spec.InitContainers = []apiv1.Container{
 		{
-			Name:  extensionInstallerContainerName,
-			Image: extensionInstallerImage,
-			Env: []apiv1.EnvVar{
-				{
-					Name: fmt.Sprintf("%s-WHITELIST-%s", strings.ToUpper(browserExtensionConfig), strings.ToUpper(browserName)),
-					ValueFrom: &apiv1.EnvVarSource{
-						ConfigMapKeyRef: &apiv1.ConfigMapKeySelector{
-							LocalObjectReference: apiv1.LocalObjectReference{
-								Name: browserExtensionConfig,
-							},
-							Key: fmt.Sprintf("whitelist.%s", strings.ToLower(browserName)),
-						},
-					},
-				},
-			},
+			Name:         extensionInstallerContainerName,
+			Image:        opts.extensionInstallerImage,
 			Args:         args,
 			VolumeMounts: extMounts,
 		},
 	}
 }
 
-func buildExtensionMounts(imageType browserimage.ImageType) map[string][]apiv1.VolumeMount {
-	return map[string][]apiv1.VolumeMount{
-		"firefox": {
-			{Name: "plugins", MountPath: filepath.Join(imageType.Homedir(), "/.mozilla/extensions")},
-			{Name: "plugins", MountPath: "/opt/firefox"},
-		},
-		"chrome": {
-			{Name: "plugins", MountPath: "/opt/google/chrome/extensions"},
-		},
+// extensionArtifactURL returns URL the extension artifact is downloaded from or empty string
+// if the extension is installed from the public store
+func extensionArtifactURL(artifactsURL string, extension browserkubeapiv1.BrowserExtension) string {
+	if extension.Artifact == "" {
+		return ""
+	}
+	version := extension.Version
+	if version == "" {
+		version = browserkubeapiv1.ExtensionArtifactLatest
 	}
+	return strings.TrimSuffix(artifactsURL, "/") + "/" + url.PathEscape(extension.Artifact) + "/" +
+		url.PathEscape(version) + "/artifact"
 }
@@ -0,0 +1,94 @@
+package controller
+
+import (
+	"reflect"
+	"testing"
+
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	browserkubeapiv1 "github.com/browserkube/browserkube/operator/api/v1"
+)
+
+func TestAddContainerRecorder(t *testing.T) {
+	tests := []struct {
+		name      string
+		recording *browserkubeapiv1.BrowserRecording
+		wantArgs  []string
+	}{
+		{
+			name:     "recorder defaults",
+			wantArgs: []string{"--display-num=99", "--file-path=/home/user/videos"},
+		},
+		{
+			name: "recording options",
+			recording: &browserkubeapiv1.BrowserRecording{
+				VideoSize: "1280x720", FrameRate: 24, Codec: "libvpx-vp9", CRF: ptr.To[int32](30), Format: "webm",
+			},
+			wantArgs: []string{
+				"--display-num=99", "--file-path=/home/user/videos", "--video-size=1280x720", "--frame-rate=24",
+				"--codec=libvpx-vp9", "--crf=30", "--format=webm",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spec := &apiv1.PodSpec{Containers: []apiv1.Container{{Name: containerNameSidecar}}}
+			addContainerRecorder(&BrowserCtrlOpts{}, spec, tt.recording, "/home/user", "99", nil)
+
+			if len(spec.Containers) != 2 || spec.Containers[1].Name != containerNameRecorder {
+				t.Fatalf("recorder container isn't added: %+v", spec.Containers)
+			}
+			if got := spec.Containers[1].Args; !reflect.DeepEqual(got, tt.wantArgs) {
+				t.Errorf("recorder args = %v, want %v", got, tt.wantArgs)
+			}
+		})
+	}
+}
+
+func TestInstallPlugins(t *testing.T) {
+	opts := &BrowserCtrlOpts{
+		extensionInstallerImage: "extension-installer:latest",
+		extensionArtifactsURL:   "http://browserkube-backend:4444/extensions/",
+	}
+	spec := &apiv1.PodSpec{Containers: []apiv1.Container{{Name: containerNameBrowser}}}
+	installPlugins(opts, spec, "chrome", []string{"/opt/google/chrome/extensions"}, []browserkubeapiv1.BrowserExtension{
+		{ExtensionID: "aapbdbdomjkkjkaonfhkkikfgjllcleb"},
+		{ExtensionID: "ghbmnnjooekpmoecnnnilnnbdlolhkhi", Artifact: "corp sso", Version: "1.2.0"},
+		{Artifact: "corp-vpn"},
+	})
+
+	if len(spec.InitContainers) != 1 {
+		t.Fatalf("init containers = %+v", spec.InitContainers)
+	}
+	wantArgs := []string{
+		"--browserName=chrome", "--extensionId=aapbdbdomjkkjkaonfhkkikfgjllcleb", "--updateUrl=", "--artifactUrl=",
+		"--browserName=chrome", "--extensionId=ghbmnnjooekpmoecnnnilnnbdlolhkhi", "--updateUrl=",
+		"--artifactUrl=http://browserkube-backend:4444/extensions/corp%20sso/1.2.0/artifact",
+		"--browserName=chrome", "--extensionId=", "--updateUrl=",
+		"--artifactUrl=http://browserkube-backend:4444/extensions/corp-vpn/latest/artifact",
+	}
+	if got := spec.InitContainers[0].Args; !reflect.DeepEqual(got, wantArgs) {
+		t.Errorf("installer args = %v, want %v", got, wantArgs)
+	}
+	if len(spec.Containers[0].VolumeMounts) != 1 {
+		t.Errorf("browser mounts = %+v", spec.Containers[0].VolumeMounts)
+	}
+}
+
+func TestAddPooledEnv(t *testing.T) {
+	for _, labels := range []map[string]string{nil, {browserkubeapiv1.LabelPool: "chrome"}} {
+		pod := &apiv1.Pod{Spec: apiv1.PodSpec{Containers: []apiv1.Container{{Name: containerNameSidecar}}}}
+		browser := &browserkubeapiv1.Browser{ObjectMeta: metav1.ObjectMeta{Labels: labels}}
+		addPooledEnv(pod, browser)
+
+		want := []apiv1.EnvVar(nil)
+		if labels != nil {
+			want = []apiv1.EnvVar{{Name: "POOLED", Value: "true"}}
+		}
+		if got := pod.Spec.Containers[0].Env; !reflect.DeepEqual(got, want) {
+			t.Errorf("sidecar env with labels %v = %v, want %v", labels, got, want)
+		}
+	}
+}
@@ -0,0 +1,82 @@
+package controller
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	browserkubeapiv1 "github.com/browserkube/browserkube/operator/api/v1"
+)
+
+func TestGetReadinessProbe(t *testing.T) {
+	tests := []struct {
+		name          string
+		data          map[string]string
+		browserType   string
+		readinessPath string
+		want          *v1.ProbeHandler
+	}{
+		{
+			name:        "webdriver status",
+			browserType: browserkubeapiv1.TypeWebDriver,
+			want: &v1.ProbeHandler{HTTPGet: &v1.HTTPGetAction{
+				Scheme: v1.URISchemeHTTP, Port: intstr.FromInt32(4444), Path: "/wd/hub/status",
+			}},
+		},
+		{
+			name:        "playwright port",
+			browserType: browserkubeapiv1.TypePlaywright,
+			want:        &v1.ProbeHandler{TCPSocket: &v1.TCPSocketAction{Port: intstr.FromInt32(4444)}},
+		},
+		{
+			name:        "playwright http",
+			data:        map[string]string{"playwright.probe": readinessProbeHTTP},
+			browserType: browserkubeapiv1.TypePlaywright,
+			want: &v1.ProbeHandler{HTTPGet: &v1.HTTPGetAction{
+				Scheme: v1.URISchemeHTTP, Port: intstr.FromInt32(4444), Path: "/",
+			}},
+		},
+		{
+			name:          "playwright image profile path",
+			browserType:   browserkubeapiv1.TypePlaywright,
+			readinessPath: "/healthz",
+			want: &v1.ProbeHandler{HTTPGet: &v1.HTTPGetAction{
+				Scheme: v1.URISchemeHTTP, Port: intstr.FromInt32(4444), Path: "/healthz",
+			}},
+		},
+		{
+			name:        "playwright disabled",
+			data:        map[string]string{"playwright.enabled": "false"},
+			browserType: browserkubeapiv1.TypePlaywright,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &BrowserReconciler{
+				Client: fake.NewClientBuilder().WithObjects(&v1.ConfigMap{
+					ObjectMeta: metav1.ObjectMeta{Name: probeConfigMapName, Namespace: defaultNs},
+					Data:       tt.data,
+				}).Build(),
+				opts: &BrowserCtrlOpts{browserReadinessConfig: probeConfigMapName},
+			}
+			probe, err := r.getReadinessProbe(context.Background(), defaultNs, tt.browserType, "/wd/hub", "4444", tt.readinessPath)
+			if err != nil {
+				t.Fatalf("getReadinessProbe() unexpected error = %v", err)
+			}
+			if tt.want == nil {
+				if probe != nil {
+					t.Errorf("getReadinessProbe() = %+v, want nil", probe)
+				}
+				return
+			}
+			if probe == nil || !reflect.DeepEqual(probe.ProbeHandler, *tt.want) {
+				t.Errorf("getReadinessProbe() = %+v, want %+v", probe, tt.want)
+			}
+		})
+	}
+}
@@ -14,10 +14,11 @@ import (
 
 type seleniumPodBuilder struct {
 	browserConfig *browserkubeapiv1.BrowserConfig
+	profile       browserimage.Profile
 }
 
 func (s *seleniumPodBuilder) Build(ctx context.Context, b *browserkubeapiv1.Browser, opts *BrowserCtrlOpts, readinessProbe *apiv1.Probe) (*apiv1.Pod, error) {
-	volumeMounts := buildVolumeMounts(browserimage.ImageTypeSelenium)
+	volumeMounts := buildVolumeMounts(s.profile.HomeDir)
 
 	spec := &apiv1.PodSpec{
 		Hostname:      b.Name,
@@ -29,7 +30,7 @@ func (s *seleniumPodBuilder) Build(ctx context.Context, b *browserkubeapiv1.Brow
 				Ports: []apiv1.ContainerPort{
 					buildContainerPort("sidecar", opts.sidecarPort),
 				},
-				Env:          buildSidecarEnvVar(opts.sidecarPort, browserimage.ImageTypeSelenium, s.browserConfig.Port, s.browserConfig.Path),
+				Env:          buildSidecarEnvVar(opts.sidecarPort, s.profile.HomeDir, s.browserConfig),
 				VolumeMounts: volumeMounts,
 				Resources:    buildResources(200, memory128Mi, 100, memory128Mi),
 			},
@@ -39,7 +40,7 @@ func (s *seleniumPodBuilder) Build(ctx context.Context, b *browserkubeapiv1.Brow
 				Ports: []apiv1.ContainerPort{
 					buildContainerPort("browser", s.browserConfig.Port),
 				},
-				Env:            s.buildBrowserEnvVars(s.browserConfig),
+				Env:            s.buildBrowserEnvVars(b, s.browserConfig),
 				VolumeMounts:   volumeMounts,
 				ReadinessProbe: readinessProbe,
 				Resources:      buildResources(1000, size2Gi, 500, size2Gi),
@@ -51,7 +52,7 @@ func (s *seleniumPodBuilder) Build(ctx context.Context, b *browserkubeapiv1.Brow
 				Ports: []apiv1.ContainerPort{
 					buildContainerPort("p", ports.Clipboard),
 				},
-				Env:   []apiv1.EnvVar{{Name: "DISPLAY", Value: ":99"}},
+				Env:   []apiv1.EnvVar{{Name: "DISPLAY", Value: s.profile.Display()}},
 				Stdin: true,
 				TTY:   true,
 			},
@@ -60,19 +61,17 @@ func (s *seleniumPodBuilder) Build(ctx context.Context, b *browserkubeapiv1.Brow
 	}
 
 	if s.browserConfig.EnableVideo {
-		addContainerRecorder(opts, spec, browserimage.ImageTypeSelenium, "99", volumeMounts)
+		addContainerRecorder(opts, spec, s.browserConfig.Recording, s.profile.HomeDir, s.profile.DisplayNum, volumeMounts)
 	}
 
 	logger := log.FromContext(ctx)
 
 	if b.Spec.Extensions != nil || len(b.Spec.Extensions) != 0 {
 		logger.Info("Browser Extension Capabilities: ", "Capabilities", fmt.Sprintf("%+v", b.Spec.Extensions))
-		installPlugins(spec,
+		installPlugins(opts, spec,
 			b.Spec.BrowserName,
-			browserimage.ImageTypeSelenium,
+			s.profile.ExtensionDirs[b.Spec.BrowserName],
 			b.Spec.Extensions,
-			opts.extensionInstallerImage,
-			opts.browserExtensionConfig,
 		)
 	}
 
@@ -95,10 +94,19 @@ func (s *seleniumPodBuilder) Build(ctx context.Context, b *browserkubeapiv1.Brow
 	return browserPod, nil
 }
 
-func (s *seleniumPodBuilder) buildBrowserEnvVars(browserConfig *browserkubeapiv1.BrowserConfig) []apiv1.EnvVar {
+func (s *seleniumPodBuilder) buildBrowserEnvVars(b *browserkubeapiv1.Browser, browserConfig *browserkubeapiv1.BrowserConfig) []apiv1.EnvVar {
 	vars := []apiv1.EnvVar{
 		{Name: "TZ", Value: browserConfig.Timezone},
-		{Name: "VNC_PASSWORD", Value: "browserkube"},
+	}
+
+	if b.Spec.EnableVNC {
+		// older images read VNC_PASSWORD, newer ones SE_VNC_PASSWORD
+		vars = append(vars,
+			vncPasswordEnvVar("VNC_PASSWORD", b.Name),
+			vncPasswordEnvVar("SE_VNC_PASSWORD", b.Name),
+		)
+	} else {
+		vars = append(vars, apiv1.EnvVar{Name: "SE_START_VNC", Value: "false"})
 	}
 
 	return vars
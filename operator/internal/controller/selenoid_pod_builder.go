@@ -14,10 +14,11 @@ import (
 
 type selenoidPodBuilder struct {
 	browserConfig *browserkubeapiv1.BrowserConfig
+	profile       browserimage.Profile
 }
 
 func (s *selenoidPodBuilder) Build(ctx context.Context, b *browserkubeapiv1.Browser, opts *BrowserCtrlOpts, readinessProbe *apiv1.Probe) (*apiv1.Pod, error) {
-	volumeMounts := buildVolumeMounts(browserimage.ImageTypeSelenoid)
+	volumeMounts := buildVolumeMounts(s.profile.HomeDir)
 
 	spec := &apiv1.PodSpec{
 		Hostname:      b.Name,
@@ -29,7 +30,7 @@ func (s *selenoidPodBuilder) Build(ctx context.Context, b *browserkubeapiv1.Brow
 				Ports: []apiv1.ContainerPort{
 					buildContainerPort("sidecar", opts.sidecarPort),
 				},
-				Env:          buildSidecarEnvVar(opts.sidecarPort, browserimage.ImageTypeSelenoid, s.browserConfig.Port, s.browserConfig.Path),
+				Env:          buildSidecarEnvVar(opts.sidecarPort, s.profile.HomeDir, s.browserConfig),
 				VolumeMounts: volumeMounts,
 				Resources:    buildResources(200, memory128Mi, 100, memory128Mi),
 			},
@@ -38,10 +39,9 @@ func (s *selenoidPodBuilder) Build(ctx context.Context, b *browserkubeapiv1.Brow
 				Image: s.browserConfig.Image,
 				Ports: []apiv1.ContainerPort{
 					buildContainerPort("browser", s.browserConfig.Port),
-					buildContainerPort("vnc", ports.VNC),
 					//buildContainerPort("devtools", ports.DevTools),
 				},
-				Env:            s.buildBrowserEnvVars(b, s.browserConfig, b.Spec.EnableVNC),
+				Env:            s.buildBrowserEnvVars(b, s.browserConfig),
 				VolumeMounts:   volumeMounts,
 				ReadinessProbe: readinessProbe,
 				Resources:      buildResources(1000, size2Gi, 500, size2Gi),
@@ -53,7 +53,7 @@ func (s *selenoidPodBuilder) Build(ctx context.Context, b *browserkubeapiv1.Brow
 				Ports: []apiv1.ContainerPort{
 					buildContainerPort("p", ports.Clipboard),
 				},
-				Env:   []apiv1.EnvVar{{Name: "DISPLAY", Value: ":99"}},
+				Env:   []apiv1.EnvVar{{Name: "DISPLAY", Value: s.profile.Display()}},
 				Stdin: true,
 				TTY:   true,
 			},
@@ -61,20 +61,23 @@ func (s *selenoidPodBuilder) Build(ctx context.Context, b *browserkubeapiv1.Brow
 		Volumes: buildVolumes(opts),
 	}
 
+	if b.Spec.EnableVNC {
+		// x11vnc of the image has the well-known password "selenoid", the display is served by a separate
+		// VNC server with the password of the browser instead. The image X server listens on TCP
+		addContainerVNCServer(opts, spec, b.Name, s.profile.Display(), volumeMounts)
+	}
 	if s.browserConfig.EnableVideo {
-		addContainerRecorder(opts, spec, browserimage.ImageTypeSelenoid, "99", volumeMounts)
+		addContainerRecorder(opts, spec, s.browserConfig.Recording, s.profile.HomeDir, s.profile.DisplayNum, volumeMounts)
 	}
 
 	logger := log.FromContext(ctx)
 
 	if b.Spec.Extensions != nil || len(b.Spec.Extensions) != 0 {
 		logger.Info("Browser Extension Capabilities: ", "Capabilities", fmt.Sprintf("%+v", b.Spec.Extensions))
-		installPlugins(spec,
+		installPlugins(opts, spec,
 			b.Spec.BrowserName,
-			browserimage.ImageTypeSelenoid,
+			s.profile.ExtensionDirs[b.Spec.BrowserName],
 			b.Spec.Extensions,
-			opts.extensionInstallerImage,
-			opts.browserExtensionConfig,
 		)
 	}
 
@@ -97,17 +100,10 @@ func (s *selenoidPodBuilder) Build(ctx context.Context, b *browserkubeapiv1.Brow
 	return browserPod, nil
 }
 
-func (s *selenoidPodBuilder) buildBrowserEnvVars(b *browserkubeapiv1.Browser, browserConfig *browserkubeapiv1.BrowserConfig, browserEnableVNC bool) []apiv1.EnvVar {
-	vars := []apiv1.EnvVar{
+func (s *selenoidPodBuilder) buildBrowserEnvVars(b *browserkubeapiv1.Browser, browserConfig *browserkubeapiv1.BrowserConfig) []apiv1.EnvVar {
+	return []apiv1.EnvVar{
 		{Name: "TZ", Value: browserConfig.Timezone},
-		{Name: "DISPLAY", Value: ":99"},
+		{Name: "DISPLAY", Value: s.profile.Display()},
 		{Name: "SCREEN_RESOLUTION", Value: GetResolution(b.Spec.ScreenResolution)},
 	}
-
-	if browserEnableVNC {
-		vars = append(vars, apiv1.EnvVar{Name: "ENABLE_VNC", Value: "true"})
-		//vars = append(vars, apiv1.EnvVar{Name: "ENABLE_VIDEO", Value: "false"})
-	}
-
-	return vars
 }
@@ -0,0 +1,45 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	browserkubeapiv1 "github.com/browserkube/browserkube/operator/api/v1"
+)
+
+func TestSelenoidPodBuilderVNC(t *testing.T) {
+	builder, _, err := NewPodBuilder(&browserkubeapiv1.BrowserConfig{Image: "selenoid/chrome:124.0", Port: "4444"})
+	if err != nil {
+		t.Fatalf("NewPodBuilder() unexpected error = %v", err)
+	}
+	pod, err := builder.Build(context.Background(), &browserkubeapiv1.Browser{
+		ObjectMeta: metav1.ObjectMeta{Name: "session", Namespace: defaultNs},
+		Spec:       browserkubeapiv1.BrowserSpec{BrowserName: browserName, EnableVNC: true},
+	}, &BrowserCtrlOpts{}, nil)
+	if err != nil {
+		t.Fatalf("Build() unexpected error = %v", err)
+	}
+
+	// VNC server of the image has the built-in password, it must not be enabled
+	for _, env := range findContainer(pod.Spec.Containers, containerNameBrowser).Env {
+		if env.Name == "ENABLE_VNC" {
+			t.Errorf("browser container has %s=%s", env.Name, env.Value)
+		}
+	}
+	vnc := findContainer(pod.Spec.Containers, containerNameVNCServer)
+	if vnc == nil {
+		t.Fatalf("pod has no %s container", containerNameVNCServer)
+	}
+	for _, env := range vnc.Env {
+		if env.Name != "VNCPASS" {
+			continue
+		}
+		if env.ValueFrom == nil || env.ValueFrom.SecretKeyRef == nil || env.ValueFrom.SecretKeyRef.Name != getVNCSecretName("session") {
+			t.Errorf("VNCPASS = %+v, want the browser VNC secret", env)
+		}
+		return
+	}
+	t.Errorf("%s container has no VNCPASS", containerNameVNCServer)
+}
@@ -117,8 +117,9 @@ var _ = BeforeSuite(func() {
 	})
 	Expect(err).ToNot(HaveOccurred())
 	err = (&BrowserReconciler{
-		Client: k8sManager.GetClient(),
-		Scheme: k8sManager.GetScheme(),
+		Client:   k8sManager.GetClient(),
+		Scheme:   k8sManager.GetScheme(),
+		Recorder: k8sManager.GetEventRecorderFor("browser-controller"),
 		finalizerName: fmt.Sprintf("%s/finalizer",
 			browserkubeapiv1.GroupVersion.WithResource("browsers").GroupResource().String()),
 		opts: &BrowserCtrlOpts{
@@ -130,7 +131,6 @@ var _ = BeforeSuite(func() {
 			browserUserConfig:       "browserkube-browsers-usergroup",
 			recorderImage:           "recorder",
 			extensionInstallerImage: "extension-installer",
-			browserExtensionConfig:  "browserkube-browser-extension-config",
 			browserReadinessConfig:  probeConfigMapName,
 		},
 	}).SetupWithManager(k8sManager)
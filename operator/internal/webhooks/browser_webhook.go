@@ -0,0 +1,198 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhooks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	browserkubeapiv1 "github.com/browserkube/browserkube/operator/api/v1"
+	"github.com/browserkube/browserkube/operator/pkg/browserset"
+	"github.com/browserkube/browserkube/operator/pkg/extensionpolicy"
+)
+
+const platformLinux = "linux"
+
+//+kubebuilder:webhook:path=/mutate-api-browserkube-io-v1-browser,mutating=true,failurePolicy=fail,sideEffects=None,groups=api.browserkube.io,resources=browsers,verbs=create,versions=v1,name=mbrowser.browserkube.io,admissionReviewVersions=v1
+//+kubebuilder:webhook:path=/validate-api-browserkube-io-v1-browser,mutating=false,failurePolicy=fail,sideEffects=None,groups=api.browserkube.io,resources=browsers,verbs=create,versions=v1,name=vbrowser.browserkube.io,admissionReviewVersions=v1
+
+// BrowserWebhook defaults platform, type and version of new browsers
+// and rejects the ones which can't be resolved against BrowserSets
+type BrowserWebhook struct {
+	client.Reader
+}
+
+var (
+	_ webhook.CustomDefaulter = &BrowserWebhook{}
+	_ webhook.CustomValidator = &BrowserWebhook{}
+)
+
+func NewBrowserWebhook(reader client.Reader) *BrowserWebhook {
+	return &BrowserWebhook{Reader: reader}
+}
+
+// SetupWithManager registers the webhooks in the manager's webhook server
+func (w *BrowserWebhook) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(&browserkubeapiv1.Browser{}).
+		WithDefaulter(w).
+		WithValidator(w).
+		Complete()
+}
+
+// Default fills in platform, type and the default browser version.
+// Version is left as is if it can't be resolved, validation reports the reason
+func (w *BrowserWebhook) Default(ctx context.Context, obj runtime.Object) error {
+	browser, ok := obj.(*browserkubeapiv1.Browser)
+	if !ok {
+		return apierrors.NewBadRequest(fmt.Sprintf("expected a Browser but got a %T", obj))
+	}
+	if browser.Spec.Platform == "" {
+		browser.Spec.Platform = platformLinux
+	}
+	if browser.Spec.Type == "" {
+		browser.Spec.Type = browserkubeapiv1.TypeWebDriver
+	}
+	if browser.Spec.BrowserVersion != "" || browser.Spec.BrowserName == "" {
+		return nil
+	}
+
+	if sets, err := w.selectSets(ctx, browser); err == nil {
+		browser.Spec.BrowserVersion = browserset.DefaultVersion(sets, browser.Spec.Type, strings.ToLower(browser.Spec.BrowserName))
+	}
+	return nil
+}
+
+func (w *BrowserWebhook) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	browser, ok := obj.(*browserkubeapiv1.Browser)
+	if !ok {
+		return nil, apierrors.NewBadRequest(fmt.Sprintf("expected a Browser but got a %T", obj))
+	}
+	if errs := w.validateBrowser(ctx, browser); len(errs) > 0 {
+		return nil, apierrors.NewInvalid(browserkubeapiv1.GroupVersion.WithKind("Browser").GroupKind(), browser.Name, errs)
+	}
+	return nil, nil
+}
+
+// ValidateUpdate accepts all updates: spec of a running browser is only changed when a pooled browser is claimed
+func (w *BrowserWebhook) ValidateUpdate(context.Context, runtime.Object, runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+func (w *BrowserWebhook) ValidateDelete(context.Context, runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+func (w *BrowserWebhook) validateBrowser(ctx context.Context, browser *browserkubeapiv1.Browser) field.ErrorList {
+	specPath := field.NewPath("spec")
+	var errs field.ErrorList
+	if browser.Spec.BrowserName == "" {
+		errs = append(errs, field.Required(specPath.Child("browserName"), "browser is not provided"))
+	}
+	if platform := strings.ToLower(browser.Spec.Platform); platform != "" && platform != platformLinux {
+		errs = append(errs, field.NotSupported(specPath.Child("platform"), browser.Spec.Platform, []string{platformLinux}))
+	}
+	if t := browser.Spec.Type; t != "" && t != browserkubeapiv1.TypeWebDriver && t != browserkubeapiv1.TypePlaywright {
+		errs = append(errs, field.NotSupported(specPath.Child("type"), t,
+			[]string{browserkubeapiv1.TypeWebDriver, browserkubeapiv1.TypePlaywright}))
+	}
+	errs = append(errs, validateTimezone(specPath.Child("timeZone"), browser.Spec.Timezone)...)
+	if selector := browser.Spec.BrowserSetSelector; selector != "" {
+		if _, err := labels.Parse(selector); err != nil {
+			errs = append(errs, field.Invalid(specPath.Child("browserSetSelector"), selector, err.Error()))
+		}
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+
+	sets, err := w.selectSets(ctx, browser)
+	if err != nil {
+		var notFound *browserset.NotFoundErr
+		switch {
+		case !errors.As(err, &notFound):
+			return field.ErrorList{field.InternalError(specPath, err)}
+		case browser.Spec.BrowserSet != "":
+			return field.ErrorList{field.NotFound(specPath.Child("browserSet"), browser.Spec.BrowserSet)}
+		case browser.Spec.BrowserSetSelector != "":
+			return field.ErrorList{field.Invalid(specPath.Child("browserSetSelector"), browser.Spec.BrowserSetSelector, err.Error())}
+		default:
+			return field.ErrorList{field.Invalid(specPath.Child("browserName"), browser.Spec.BrowserName, err.Error())}
+		}
+	}
+	resolved, err := browserset.Resolve(sets, browser.Spec.Type, browser.Spec.BrowserName, browser.Spec.BrowserVersion)
+	if err != nil {
+		errs = append(errs, field.Invalid(specPath.Child("browserVersion"), browser.Spec.BrowserVersion,
+			fmt.Sprintf("%s: %s", browser.Spec.BrowserName, err.Error())))
+	} else if _, err := browserset.Timeouts(resolved, browser.Spec.Timeouts); err != nil {
+		errs = append(errs, field.Forbidden(specPath.Child("timeouts"), err.Error()))
+	} else if _, err := browserset.NetworkPolicy(resolved, browser.Spec.Network); err != nil {
+		errs = append(errs, field.Forbidden(specPath.Child("network"), err.Error()))
+	} else if _, err := browserset.Proxy(resolved, browser.Spec.Proxy); err != nil {
+		errs = append(errs, field.Forbidden(specPath.Child("proxy"), err.Error()))
+	} else if browser.Spec.EnableVideo {
+		if _, err := browserset.Recording(resolved, browser.Spec.Recording, browser.Spec.ScreenResolution); err != nil {
+			errs = append(errs, field.Invalid(specPath.Child("recording"), browser.Spec.Recording, err.Error()))
+		}
+	}
+	if resolved != nil {
+		errs = append(errs, w.validateExtensions(ctx, specPath.Child("extensions"), browser, resolved.Set.Name)...)
+	}
+	if profile := browser.Spec.SizeProfile; profile != "" {
+		if _, ok := browserset.SizeProfile(sets, profile); !ok {
+			errs = append(errs, field.NotFound(specPath.Child("sizeProfile"), profile))
+		}
+	}
+	return errs
+}
+
+// validateExtensions rejects extensions which aren't allowed by ExtensionPolicies of the browser namespace
+func (w *BrowserWebhook) validateExtensions(ctx context.Context, path *field.Path, browser *browserkubeapiv1.Browser,
+	browserSet string,
+) field.ErrorList {
+	if len(browser.Spec.Extensions) == 0 {
+		return nil
+	}
+	policies := &browserkubeapiv1.ExtensionPolicyList{}
+	if err := w.List(ctx, policies, client.InNamespace(browser.Namespace)); err != nil {
+		return field.ErrorList{field.InternalError(path, fmt.Errorf("extension policies can't be loaded: %w", err))}
+	}
+	if err := extensionpolicy.Check(policies.Items, browser, browserSet); err != nil {
+		return field.ErrorList{field.Forbidden(path, err.Error())}
+	}
+	return nil
+}
+
+// selectSets returns BrowserSets the browser might be taken from ordered by priority
+func (w *BrowserWebhook) selectSets(ctx context.Context, browser *browserkubeapiv1.Browser) ([]browserkubeapiv1.BrowserSet, error) {
+	instances := &browserkubeapiv1.BrowserSetList{}
+	if err := w.List(ctx, instances, client.InNamespace(browser.Namespace)); err != nil {
+		return nil, fmt.Errorf("browser sets can't be loaded: %w", err)
+	}
+	return browserset.Select(instances.Items, browser.Spec.BrowserSet, browser.Spec.BrowserSetSelector)
+}
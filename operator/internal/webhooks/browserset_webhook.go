@@ -0,0 +1,291 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhooks
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	// timezones are validated against embedded database since operator image has no tzdata
+	_ "time/tzdata"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	browserkubeapiv1 "github.com/browserkube/browserkube/operator/api/v1"
+	"github.com/browserkube/browserkube/operator/internal/controller/browserimage"
+	"github.com/browserkube/browserkube/operator/pkg/browserset"
+)
+
+//+kubebuilder:webhook:path=/validate-api-browserkube-io-v1-browserset,mutating=false,failurePolicy=fail,sideEffects=None,groups=api.browserkube.io,resources=browsersets,verbs=create;update,versions=v1,name=vbrowserset.browserkube.io,admissionReviewVersions=v1
+
+// BrowserSetValidator rejects BrowserSets which would fail at session time
+type BrowserSetValidator struct {
+	client.Reader
+}
+
+var _ webhook.CustomValidator = &BrowserSetValidator{}
+
+func NewBrowserSetValidator(reader client.Reader) *BrowserSetValidator {
+	return &BrowserSetValidator{Reader: reader}
+}
+
+// SetupWithManager registers the webhook in the manager's webhook server
+func (v *BrowserSetValidator) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(&browserkubeapiv1.BrowserSet{}).
+		WithValidator(v).
+		Complete()
+}
+
+func (v *BrowserSetValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	return v.validate(ctx, obj)
+}
+
+func (v *BrowserSetValidator) ValidateUpdate(ctx context.Context, _, newObj runtime.Object) (admission.Warnings, error) {
+	return v.validate(ctx, newObj)
+}
+
+func (v *BrowserSetValidator) ValidateDelete(context.Context, runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+func (v *BrowserSetValidator) validate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	set, ok := obj.(*browserkubeapiv1.BrowserSet)
+	if !ok {
+		return nil, apierrors.NewBadRequest(fmt.Sprintf("expected a BrowserSet but got a %T", obj))
+	}
+	if errs := validateBrowserSet(set); len(errs) > 0 {
+		return nil, apierrors.NewInvalid(browserkubeapiv1.GroupVersion.WithKind("BrowserSet").GroupKind(), set.Name, errs)
+	}
+
+	others := &browserkubeapiv1.BrowserSetList{}
+	if err := v.List(ctx, others, client.InNamespace(set.Namespace)); err != nil {
+		return nil, apierrors.NewInternalError(fmt.Errorf("browser sets can't be loaded: %w", err))
+	}
+	return overlapWarnings(set, others.Items), nil
+}
+
+// validateBrowserSet checks the set on its own
+func validateBrowserSet(set *browserkubeapiv1.BrowserSet) field.ErrorList {
+	var errs field.ErrorList
+	specPath := field.NewPath("spec")
+	errs = append(errs, validateTimezone(specPath.Child("defaultTimezone"), set.Spec.DefaultTimezone)...)
+	errs = append(errs, validateTimeouts(specPath.Child("timeouts"), set.Spec.Timeouts)...)
+	errs = append(errs, validateTimeouts(specPath.Child("maxTimeouts"), set.Spec.MaxTimeouts)...)
+	errs = append(errs, validateNetworkPolicy(specPath.Child("networkPolicy"), set.Spec.NetworkPolicy)...)
+	errs = append(errs, validateRecording(specPath.Child("recording"), set.Spec.Recording)...)
+	errs = append(errs, validateProxy(specPath.Child("proxy"), set.Spec.Proxy)...)
+	if set.Spec.ForceProxy && set.Spec.Proxy == nil {
+		errs = append(errs, field.Required(specPath.Child("proxy"), "proxy is required to force it"))
+	}
+	errs = append(errs, validateCABundles(specPath.Child("caBundles"), set.Spec.CABundles)...)
+	errs = append(errs, validatePodTemplateOverlay(specPath.Child("podTemplateOverlay"), set.Spec.PodTemplateOverlay)...)
+	errs = append(errs, validateBrowsers(specPath.Child("webdriver"), set.Spec.WebDriver)...)
+	errs = append(errs, validateBrowsers(specPath.Child("playwright"), set.Spec.Playwright)...)
+	return errs
+}
+
+func validateBrowsers(path *field.Path, browsers map[string]browserkubeapiv1.BrowsersConfig) field.ErrorList {
+	var errs field.ErrorList
+	lowered := make(map[string]string, len(browsers))
+	for _, name := range sortedKeys(browsers) {
+		browserPath := path.Key(name)
+		// browser names are matched in lower case, so the other ones are never picked up
+		if name != strings.ToLower(name) {
+			errs = append(errs, field.Invalid(browserPath, name, "browser name must be lower case"))
+		}
+		if other, ok := lowered[strings.ToLower(name)]; ok {
+			errs = append(errs, field.Duplicate(browserPath, fmt.Sprintf("%s (same as %s)", name, other)))
+		}
+		lowered[strings.ToLower(name)] = name
+
+		cfg := browsers[name]
+		if cfg.DefaultVersion != "" {
+			if _, ok := cfg.Versions[cfg.DefaultVersion]; !ok {
+				errs = append(errs, field.NotFound(browserPath.Child("defaultVersion"), cfg.DefaultVersion))
+			}
+		}
+		if len(cfg.Versions) == 0 {
+			errs = append(errs, field.Required(browserPath.Child("versions"), "at least one version is required"))
+		}
+		for _, version := range sortedKeys(cfg.Versions) {
+			errs = append(errs, validateBrowserConfig(browserPath.Child("versions").Key(version), cfg.Versions[version])...)
+		}
+	}
+	return errs
+}
+
+func validateBrowserConfig(path *field.Path, cfg browserkubeapiv1.BrowserConfig) field.ErrorList {
+	var errs field.ErrorList
+	if cfg.Image == "" {
+		errs = append(errs, field.Required(path.Child("image"), "browser image is required"))
+	} else if _, err := browserimage.ResolveProfile(cfg.Image, cfg.ImageProfile); err != nil {
+		errs = append(errs, field.Invalid(path.Child("image"), cfg.Image, err.Error()))
+	}
+	if profile := cfg.ImageProfile; profile != nil && profile.VNCPort != "" {
+		errs = append(errs, validatePort(path.Child("imageProfile", "vncPort"), profile.VNCPort)...)
+	}
+	errs = append(errs, validatePort(path.Child("port"), cfg.Port)...)
+	errs = append(errs, validateTimezone(path.Child("timezone"), cfg.Timezone)...)
+	errs = append(errs, validateTimeout(path.Child("startupTimeout"), cfg.StartupTimeout)...)
+	errs = append(errs, validateTimeout(path.Child("sessionDeleteTimeout"), cfg.SessionDeleteTimeout)...)
+	errs = append(errs, validateTimeout(path.Child("idleTimeout"), cfg.IdleTimeout)...)
+	errs = append(errs, validateTimeout(path.Child("sessionTimeout"), cfg.SessionTimeout)...)
+	errs = append(errs, validateNetworkPolicy(path.Child("networkPolicy"), cfg.NetworkPolicy)...)
+	errs = append(errs, validateRecording(path.Child("recording"), cfg.Recording)...)
+	errs = append(errs, validateProxy(path.Child("proxy"), cfg.Proxy)...)
+	errs = append(errs, validateCABundles(path.Child("caBundles"), cfg.CABundles)...)
+	errs = append(errs, validatePodTemplateOverlay(path.Child("podTemplateOverlay"), cfg.PodTemplateOverlay)...)
+	return errs
+}
+
+func validateTimeouts(path *field.Path, timeouts *browserkubeapiv1.BrowserTimeouts) field.ErrorList {
+	if timeouts == nil {
+		return nil
+	}
+	var errs field.ErrorList
+	errs = append(errs, validateTimeout(path.Child("startup"), timeouts.Startup)...)
+	errs = append(errs, validateTimeout(path.Child("sessionDelete"), timeouts.SessionDelete)...)
+	errs = append(errs, validateTimeout(path.Child("idle"), timeouts.Idle)...)
+	errs = append(errs, validateTimeout(path.Child("session"), timeouts.Session)...)
+	return errs
+}
+
+func validateTimeout(path *field.Path, timeout *metav1.Duration) field.ErrorList {
+	if timeout != nil && timeout.Duration <= 0 {
+		return field.ErrorList{field.Invalid(path, timeout.Duration.String(), "must be positive")}
+	}
+	return nil
+}
+
+func validateNetworkPolicy(path *field.Path, policy *browserkubeapiv1.BrowserNetworkPolicy) field.ErrorList {
+	if policy == nil {
+		return nil
+	}
+	var errs field.ErrorList
+	for i, cidr := range policy.AllowedCIDRs {
+		_, ipNet, err := net.ParseCIDR(cidr)
+		switch {
+		case err != nil:
+			errs = append(errs, field.Invalid(path.Child("allowedCIDRs").Index(i), cidr, "must be a valid CIDR"))
+		case !policy.AllowClusterInternal && browserset.ClusterInternal(ipNet):
+			errs = append(errs, field.Invalid(path.Child("allowedCIDRs").Index(i), cidr,
+				"is cluster-internal, requires allowClusterInternal"))
+		}
+	}
+	for i, domain := range policy.AllowedDomains {
+		if msgs := validation.IsDNS1123Subdomain(strings.ToLower(strings.TrimSuffix(domain, "."))); len(msgs) > 0 {
+			errs = append(errs, field.Invalid(path.Child("allowedDomains").Index(i), domain, strings.Join(msgs, ", ")))
+		}
+	}
+	return errs
+}
+
+func validateRecording(path *field.Path, recording *browserkubeapiv1.BrowserRecording) field.ErrorList {
+	if err := browserset.ValidateRecording(recording); err != nil {
+		return field.ErrorList{field.Invalid(path, recording, err.Error())}
+	}
+	return nil
+}
+
+func validateProxy(path *field.Path, proxy *browserkubeapiv1.BrowserProxy) field.ErrorList {
+	if err := browserset.ValidateProxy(proxy); err != nil {
+		return field.ErrorList{field.Invalid(path, proxy.URL, err.Error())}
+	}
+	return nil
+}
+
+func validateCABundles(path *field.Path, bundles []browserkubeapiv1.CABundle) field.ErrorList {
+	var errs field.ErrorList
+	for i, bundle := range bundles {
+		if err := browserset.ValidateCABundle(bundle); err != nil {
+			errs = append(errs, field.Invalid(path.Index(i), bundle, err.Error()))
+		}
+	}
+	return errs
+}
+
+func validatePodTemplateOverlay(path *field.Path, overlay *runtime.RawExtension) field.ErrorList {
+	if err := browserset.ValidatePodTemplateOverlay(overlay); err != nil {
+		return field.ErrorList{field.Invalid(path, string(overlay.Raw), err.Error())}
+	}
+	return nil
+}
+
+func validatePort(path *field.Path, port string) field.ErrorList {
+	if p, err := strconv.Atoi(port); err != nil || p < 1 || p > 65535 {
+		return field.ErrorList{field.Invalid(path, port, "must be a number between 1 and 65535")}
+	}
+	return nil
+}
+
+func validateTimezone(path *field.Path, tz string) field.ErrorList {
+	if tz == "" {
+		return nil
+	}
+	if _, err := time.LoadLocation(tz); err != nil {
+		return field.ErrorList{field.Invalid(path, tz, "must be a valid IANA time zone name")}
+	}
+	return nil
+}
+
+// overlapWarnings reports browser versions defined by other sets with the same priority.
+// Such versions are taken from the set which goes first by name which is rarely intended
+func overlapWarnings(set *browserkubeapiv1.BrowserSet, others []browserkubeapiv1.BrowserSet) admission.Warnings {
+	var warnings admission.Warnings
+	for i := range others {
+		other := &others[i]
+		if other.Name == set.Name || other.Spec.Priority != set.Spec.Priority {
+			continue
+		}
+		for _, browserType := range []string{browserkubeapiv1.TypeWebDriver, browserkubeapiv1.TypePlaywright} {
+			otherBrowsers := browserset.Browsers(other, browserType)
+			browsers := browserset.Browsers(set, browserType)
+			for _, name := range sortedKeys(browsers) {
+				for _, version := range sortedKeys(browsers[name].Versions) {
+					if _, ok := otherBrowsers[name].Versions[version]; ok {
+						warnings = append(warnings, fmt.Sprintf(
+							"%s browser %s %s is also defined by BrowserSet %s with the same priority %d",
+							browserType, name, version, other.Name, set.Spec.Priority))
+					}
+				}
+			}
+		}
+	}
+	return warnings
+}
+
+func sortedKeys[T any](m map[string]T) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
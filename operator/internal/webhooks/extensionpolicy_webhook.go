@@ -0,0 +1,96 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhooks
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	browserkubeapiv1 "github.com/browserkube/browserkube/operator/api/v1"
+	"github.com/browserkube/browserkube/operator/pkg/extensionpolicy"
+)
+
+//+kubebuilder:webhook:path=/validate-api-browserkube-io-v1-extensionpolicy,mutating=false,failurePolicy=fail,sideEffects=None,groups=api.browserkube.io,resources=extensionpolicies,verbs=create;update,versions=v1,name=vextensionpolicy.browserkube.io,admissionReviewVersions=v1
+
+// ExtensionPolicyValidator rejects ExtensionPolicies which can't be applied
+type ExtensionPolicyValidator struct{}
+
+var _ webhook.CustomValidator = &ExtensionPolicyValidator{}
+
+func NewExtensionPolicyValidator() *ExtensionPolicyValidator {
+	return &ExtensionPolicyValidator{}
+}
+
+// SetupWithManager registers the webhook in the manager's webhook server
+func (v *ExtensionPolicyValidator) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(&browserkubeapiv1.ExtensionPolicy{}).
+		WithValidator(v).
+		Complete()
+}
+
+func (v *ExtensionPolicyValidator) ValidateCreate(_ context.Context, obj runtime.Object) (admission.Warnings, error) {
+	return v.validate(obj)
+}
+
+func (v *ExtensionPolicyValidator) ValidateUpdate(_ context.Context, _, newObj runtime.Object) (admission.Warnings, error) {
+	return v.validate(newObj)
+}
+
+func (v *ExtensionPolicyValidator) ValidateDelete(context.Context, runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+func (v *ExtensionPolicyValidator) validate(obj runtime.Object) (admission.Warnings, error) {
+	policy, ok := obj.(*browserkubeapiv1.ExtensionPolicy)
+	if !ok {
+		return nil, apierrors.NewBadRequest(fmt.Sprintf("expected an ExtensionPolicy but got a %T", obj))
+	}
+	if errs := validateExtensionPolicy(policy); len(errs) > 0 {
+		return nil, apierrors.NewInvalid(browserkubeapiv1.GroupVersion.WithKind("ExtensionPolicy").GroupKind(), policy.Name, errs)
+	}
+	return nil, nil
+}
+
+func validateExtensionPolicy(policy *browserkubeapiv1.ExtensionPolicy) field.ErrorList {
+	var errs field.ErrorList
+	specPath := field.NewPath("spec")
+	if _, err := metav1.LabelSelectorAsSelector(policy.Spec.Selector); err != nil {
+		errs = append(errs, field.Invalid(specPath.Child("selector"), policy.Spec.Selector, err.Error()))
+	}
+	for i, name := range policy.Spec.Browsers {
+		// browser names are matched in lower case like the ones of BrowserSets
+		if name != strings.ToLower(name) {
+			errs = append(errs, field.Invalid(specPath.Child("browsers").Index(i), name, "browser name must be lower case"))
+		}
+	}
+	for i, entry := range policy.Spec.Allowed {
+		if err := extensionpolicy.ValidateAllowed(entry); err != nil {
+			errs = append(errs, field.Invalid(specPath.Child("allowed").Index(i), entry, err.Error()))
+		}
+	}
+	return errs
+}
@@ -0,0 +1,193 @@
+// Package browserset resolves browser configuration from multiple BrowserSets.
+//
+// BrowserSets are ordered by priority (the higher goes first), sets with equal priority are ordered by name.
+// A browser version is taken from the first set defining it. The default version of a browser
+// is taken from the first set defining the browser with a non-empty default version.
+package browserset
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/labels"
+
+	v1 "github.com/browserkube/browserkube/operator/api/v1"
+)
+
+// NotFoundErr is returned when requested browser, version or set can't be found
+type NotFoundErr struct {
+	msg string
+}
+
+func (e *NotFoundErr) Error() string {
+	return e.msg
+}
+
+// Resolved is a browser configuration found in BrowserSets
+type Resolved struct {
+	// Set is the BrowserSet the configuration is taken from
+	Set      *v1.BrowserSet
+	Browsers v1.BrowsersConfig
+	Version  string
+	Config   v1.BrowserConfig
+}
+
+// Entry is a browser version available in merged catalogue
+type Entry struct {
+	Set     string
+	Type    string
+	Name    string
+	Version string
+	Default bool
+	Config  v1.BrowserConfig
+}
+
+// Sort orders BrowserSets by priority
+func Sort(sets []v1.BrowserSet) {
+	sort.SliceStable(sets, func(i, j int) bool {
+		if sets[i].Spec.Priority != sets[j].Spec.Priority {
+			return sets[i].Spec.Priority > sets[j].Spec.Priority
+		}
+		return sets[i].Name < sets[j].Name
+	})
+}
+
+// Select returns BrowserSets with the given name or matching the label selector ordered by priority.
+// All the sets are returned if neither name nor selector is provided
+func Select(sets []v1.BrowserSet, name, selector string) ([]v1.BrowserSet, error) {
+	if len(sets) == 0 {
+		return nil, &NotFoundErr{msg: "no browser sets found"}
+	}
+	sel := labels.Everything()
+	if selector != "" {
+		var err error
+		if sel, err = labels.Parse(selector); err != nil {
+			return nil, fmt.Errorf("incorrect browser set selector '%s': %w", selector, err)
+		}
+	}
+
+	selected := make([]v1.BrowserSet, 0, len(sets))
+	for i := range sets {
+		if name != "" && sets[i].Name != name {
+			continue
+		}
+		if !sel.Matches(labels.Set(sets[i].Labels)) {
+			continue
+		}
+		selected = append(selected, sets[i])
+	}
+	if len(selected) == 0 {
+		if name != "" {
+			return nil, &NotFoundErr{msg: fmt.Sprintf("browser set '%s' is not found", name)}
+		}
+		return nil, &NotFoundErr{msg: fmt.Sprintf("no browser sets found matching '%s'", selector)}
+	}
+	Sort(selected)
+	return selected, nil
+}
+
+// Browsers returns browsers of the given type defined by BrowserSet
+func Browsers(set *v1.BrowserSet, browserType string) map[string]v1.BrowsersConfig {
+	switch browserType {
+	case v1.TypePlaywright:
+		return set.Spec.Playwright
+	case v1.TypeWebDriver, "":
+		return set.Spec.WebDriver
+	}
+	return nil
+}
+
+// DefaultVersion returns default version of the browser. Sets must be sorted
+func DefaultVersion(sets []v1.BrowserSet, browserType, browserName string) string {
+	for i := range sets {
+		if browsers, ok := Browsers(&sets[i], browserType)[browserName]; ok && browsers.DefaultVersion != "" {
+			return browsers.DefaultVersion
+		}
+	}
+	return ""
+}
+
+// Resolve finds configuration of the browser version. Default version is used if version is empty.
+// Sets must be sorted
+func Resolve(sets []v1.BrowserSet, browserType, browserName, version string) (*Resolved, error) {
+	browserName = strings.ToLower(browserName)
+	if version == "" {
+		version = DefaultVersion(sets, browserType, browserName)
+	}
+
+	var browserFound bool
+	for i := range sets {
+		browsers, ok := Browsers(&sets[i], browserType)[browserName]
+		if !ok {
+			continue
+		}
+		browserFound = true
+		if cfg, ok := browsers.Versions[version]; ok {
+			return &Resolved{
+				Set:      &sets[i],
+				Browsers: browsers,
+				Version:  version,
+				Config:   cfg,
+			}, nil
+		}
+	}
+	if !browserFound {
+		return nil, &NotFoundErr{msg: fmt.Sprintf("browser '%s' is not supported", browserName)}
+	}
+	return nil, &NotFoundErr{msg: fmt.Sprintf("browser '%s' is not supported", version)}
+}
+
+// SizeProfile returns a copy of the size profile defined by the first set. Sets must be sorted
+func SizeProfile(sets []v1.BrowserSet, name string) (*v1.BrowserResources, bool) {
+	for i := range sets {
+		if profile, ok := sets[i].Spec.SizeProfiles[name]; ok {
+			return profile.DeepCopy(), true
+		}
+	}
+	return nil, false
+}
+
+// Catalogue merges browsers of all the sets. Sets must be sorted
+func Catalogue(sets []v1.BrowserSet) []Entry {
+	var entries []Entry
+	for _, browserType := range []string{v1.TypeWebDriver, v1.TypePlaywright} {
+		seen := map[string]bool{}
+		for i := range sets {
+			set := &sets[i]
+			browsers := Browsers(set, browserType)
+			names := make([]string, 0, len(browsers))
+			for name := range browsers {
+				names = append(names, name)
+			}
+			sort.Strings(names)
+
+			for _, name := range names {
+				defaultVersion := DefaultVersion(sets, browserType, name)
+				versions := make([]string, 0, len(browsers[name].Versions))
+				for version := range browsers[name].Versions {
+					versions = append(versions, version)
+				}
+				sort.Strings(versions)
+
+				for _, version := range versions {
+					key := name + "/" + version
+					if seen[key] {
+						// defined by the set with higher priority
+						continue
+					}
+					seen[key] = true
+					entries = append(entries, Entry{
+						Set:     set.Name,
+						Type:    browserType,
+						Name:    name,
+						Version: version,
+						Default: version == defaultVersion,
+						Config:  browsers[name].Versions[version],
+					})
+				}
+			}
+		}
+	}
+	return entries
+}
@@ -0,0 +1,125 @@
+package browserset
+
+import (
+	"reflect"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1 "github.com/browserkube/browserkube/operator/api/v1"
+)
+
+func newSet(name string, priority int32, lbls map[string]string, chromeDefault string, chromeVersions ...string) v1.BrowserSet {
+	versions := map[string]v1.BrowserConfig{}
+	for _, v := range chromeVersions {
+		versions[v] = v1.BrowserConfig{Image: name + "/chrome:" + v}
+	}
+	return v1.BrowserSet{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Labels: lbls},
+		Spec: v1.BrowserSetSpec{
+			Priority: priority,
+			WebDriver: map[string]v1.BrowsersConfig{
+				"chrome": {DefaultVersion: chromeDefault, Versions: versions},
+			},
+		},
+	}
+}
+
+func testSets() []v1.BrowserSet {
+	return []v1.BrowserSet{
+		newSet("base", 0, map[string]string{"team": "all"}, "120", "119", "120"),
+		newSet("team-a", 10, map[string]string{"team": "a"}, "", "120", "121"),
+		newSet("team-b", 10, map[string]string{"team": "b"}, "122", "122"),
+	}
+}
+
+func TestSelect(t *testing.T) {
+	tests := []struct {
+		name     string
+		setName  string
+		selector string
+		want     []string
+		wantErr  bool
+	}{
+		{name: "all sets by priority", want: []string{"team-a", "team-b", "base"}},
+		{name: "by name", setName: "base", want: []string{"base"}},
+		{name: "by selector", selector: "team in (a,all)", want: []string{"team-a", "base"}},
+		{name: "name and selector mismatch", setName: "base", selector: "team=a", wantErr: true},
+		{name: "unknown name", setName: "unknown", wantErr: true},
+		{name: "incorrect selector", selector: "team in (", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Select(testSets(), tt.setName, tt.selector)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Select() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			names := make([]string, 0, len(got))
+			for i := range got {
+				names = append(names, got[i].Name)
+			}
+			if !reflect.DeepEqual(names, tt.want) {
+				t.Errorf("Select() = %v, want %v", names, tt.want)
+			}
+		})
+	}
+}
+
+func TestResolve(t *testing.T) {
+	sets := testSets()
+	Sort(sets)
+
+	tests := []struct {
+		name        string
+		browserName string
+		version     string
+		wantSet     string
+		wantVersion string
+		wantErr     string
+	}{
+		{name: "higher priority wins", browserName: "chrome", version: "120", wantSet: "team-a", wantVersion: "120"},
+		{name: "lower priority fallback", browserName: "Chrome", version: "119", wantSet: "base", wantVersion: "119"},
+		{name: "first non-empty default", browserName: "chrome", wantSet: "team-b", wantVersion: "122"},
+		{name: "unknown version", browserName: "chrome", version: "1", wantErr: "browser '1' is not supported"},
+		{name: "unknown browser", browserName: "opera", version: "1", wantErr: "browser 'opera' is not supported"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Resolve(sets, v1.TypeWebDriver, tt.browserName, tt.version)
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Fatalf("Resolve() error = %v, want %s", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Resolve() unexpected error = %v", err)
+			}
+			if got.Set.Name != tt.wantSet || got.Version != tt.wantVersion {
+				t.Errorf("Resolve() = %s/%s, want %s/%s", got.Set.Name, got.Version, tt.wantSet, tt.wantVersion)
+			}
+			if wantImage := tt.wantSet + "/chrome:" + tt.wantVersion; got.Config.Image != wantImage {
+				t.Errorf("Resolve() image = %s, want %s", got.Config.Image, wantImage)
+			}
+		})
+	}
+}
+
+func TestCatalogue(t *testing.T) {
+	sets := testSets()
+	Sort(sets)
+
+	got := Catalogue(sets)
+	want := []Entry{
+		{Set: "team-a", Type: v1.TypeWebDriver, Name: "chrome", Version: "120", Config: v1.BrowserConfig{Image: "team-a/chrome:120"}},
+		{Set: "team-a", Type: v1.TypeWebDriver, Name: "chrome", Version: "121", Config: v1.BrowserConfig{Image: "team-a/chrome:121"}},
+		{Set: "team-b", Type: v1.TypeWebDriver, Name: "chrome", Version: "122", Default: true, Config: v1.BrowserConfig{Image: "team-b/chrome:122"}},
+		{Set: "base", Type: v1.TypeWebDriver, Name: "chrome", Version: "119", Config: v1.BrowserConfig{Image: "base/chrome:119"}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Catalogue() = %+v, want %+v", got, want)
+	}
+}
@@ -0,0 +1,47 @@
+package browserset
+
+import (
+	"fmt"
+
+	v1 "github.com/browserkube/browserkube/operator/api/v1"
+)
+
+// CABundleErr is returned when a CA bundle reference is invalid
+type CABundleErr struct {
+	msg string
+}
+
+func (e *CABundleErr) Error() string {
+	return e.msg
+}
+
+// CABundles returns CA bundles trusted by the resolved browser: the set ones followed by the version ones.
+// Bundle keys are defaulted. Returns nil if there are none
+func CABundles(resolved *Resolved) ([]v1.CABundle, error) {
+	configured := append(append([]v1.CABundle{}, resolved.Set.Spec.CABundles...), resolved.Config.CABundles...)
+	if len(configured) == 0 {
+		return nil, nil
+	}
+	bundles := make([]v1.CABundle, 0, len(configured))
+	for _, bundle := range configured {
+		if err := ValidateCABundle(bundle); err != nil {
+			return nil, err
+		}
+		if bundle.Key == "" {
+			bundle.Key = v1.CABundleDefaultKey
+		}
+		bundles = append(bundles, bundle)
+	}
+	return bundles, nil
+}
+
+// ValidateCABundle checks that the bundle references either a ConfigMap or a Secret
+func ValidateCABundle(bundle v1.CABundle) error {
+	switch {
+	case bundle.ConfigMap == "" && bundle.Secret == "":
+		return &CABundleErr{msg: "CA bundle must reference a ConfigMap or a Secret"}
+	case bundle.ConfigMap != "" && bundle.Secret != "":
+		return &CABundleErr{msg: fmt.Sprintf("CA bundle must reference either ConfigMap %s or Secret %s", bundle.ConfigMap, bundle.Secret)}
+	}
+	return nil
+}
@@ -0,0 +1,56 @@
+package browserset
+
+import (
+	"reflect"
+	"testing"
+
+	v1 "github.com/browserkube/browserkube/operator/api/v1"
+)
+
+func TestCABundles(t *testing.T) {
+	tests := []struct {
+		name    string
+		set     []v1.CABundle
+		version []v1.CABundle
+		want    []v1.CABundle
+		wantErr bool
+	}{
+		{
+			name: "not configured",
+		},
+		{
+			name:    "set and version bundles",
+			set:     []v1.CABundle{{ConfigMap: "corp-ca"}},
+			version: []v1.CABundle{{Secret: "staging-ca", Key: "root.pem"}},
+			want: []v1.CABundle{
+				{ConfigMap: "corp-ca", Key: v1.CABundleDefaultKey},
+				{Secret: "staging-ca", Key: "root.pem"},
+			},
+		},
+		{
+			name:    "no source",
+			set:     []v1.CABundle{{Key: "ca.crt"}},
+			wantErr: true,
+		},
+		{
+			name:    "both sources",
+			version: []v1.CABundle{{ConfigMap: "corp-ca", Secret: "corp-ca"}},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resolved := &Resolved{
+				Set:    &v1.BrowserSet{Spec: v1.BrowserSetSpec{CABundles: tt.set}},
+				Config: v1.BrowserConfig{CABundles: tt.version},
+			}
+			got, err := CABundles(resolved)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("CABundles() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("CABundles() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,130 @@
+package browserset
+
+import (
+	"fmt"
+	"net"
+	"strings"
+
+	v1 "github.com/browserkube/browserkube/operator/api/v1"
+)
+
+// ClusterInternalCIDRs are private, shared and link-local ranges denied unless the policy allows cluster-internal access
+var ClusterInternalCIDRs = []string{
+	"10.0.0.0/8",
+	"172.16.0.0/12",
+	"192.168.0.0/16",
+	"100.64.0.0/10",
+	"169.254.0.0/16",
+	"fc00::/7",
+	"fe80::/10",
+}
+
+// NetworkErr is returned when requested network access is not allowed by the BrowserSet
+type NetworkErr struct {
+	msg string
+}
+
+func (e *NetworkErr) Error() string {
+	return e.msg
+}
+
+// NetworkPolicy returns network policy effective for the resolved browser or nil if the browser isn't restricted.
+// Version policy overrides the set one. Requested network may only narrow the configured policy:
+// requested CIDRs must be within the allowed ones and requested domains must be allowed explicitly
+func NetworkPolicy(resolved *Resolved, requested *v1.BrowserNetwork) (*v1.BrowserNetworkPolicy, error) {
+	configured := resolved.Config.NetworkPolicy
+	if configured == nil {
+		configured = resolved.Set.Spec.NetworkPolicy
+	}
+	if requested == nil || (len(requested.AllowedCIDRs) == 0 && len(requested.AllowedDomains) == 0) {
+		if configured == nil || !configured.Enabled {
+			return nil, nil
+		}
+		return configured.DeepCopy(), nil
+	}
+
+	if configured == nil {
+		configured = &v1.BrowserNetworkPolicy{}
+	}
+	restricted := len(configured.AllowedCIDRs) > 0 || len(configured.AllowedDomains) > 0
+	allowedNets, err := ParseCIDRs(configured.AllowedCIDRs)
+	if err != nil {
+		return nil, err
+	}
+
+	var violations []string
+	for _, cidr := range requested.AllowedCIDRs {
+		_, ipNet, pErr := net.ParseCIDR(cidr)
+		switch {
+		case pErr != nil:
+			violations = append(violations, fmt.Sprintf("%s is not a valid CIDR", cidr))
+		case restricted && !containedIn(ipNet, allowedNets):
+			violations = append(violations, fmt.Sprintf("CIDR %s is not allowed", cidr))
+		case !configured.AllowClusterInternal && overlapsClusterInternal(ipNet):
+			violations = append(violations, fmt.Sprintf("CIDR %s overlaps cluster-internal ranges", cidr))
+		}
+	}
+	for _, domain := range requested.AllowedDomains {
+		if restricted && !containsDomain(configured.AllowedDomains, domain) {
+			violations = append(violations, fmt.Sprintf("domain %s is not allowed", domain))
+		}
+	}
+	if len(violations) > 0 {
+		return nil, &NetworkErr{msg: strings.Join(violations, ", ")}
+	}
+	return &v1.BrowserNetworkPolicy{
+		Enabled:              true,
+		AllowedCIDRs:         requested.AllowedCIDRs,
+		AllowedDomains:       requested.AllowedDomains,
+		AllowClusterInternal: configured.AllowClusterInternal,
+	}, nil
+}
+
+// ParseCIDRs parses every CIDR of the list
+func ParseCIDRs(cidrs []string) ([]*net.IPNet, error) {
+	nets := make([]*net.IPNet, 0, len(cidrs))
+	for _, cidr := range cidrs {
+		_, ipNet, err := net.ParseCIDR(cidr)
+		if err != nil {
+			return nil, fmt.Errorf("%s is not a valid CIDR", cidr)
+		}
+		nets = append(nets, ipNet)
+	}
+	return nets, nil
+}
+
+// ClusterInternal tells whether the block is within one of ClusterInternalCIDRs
+func ClusterInternal(ipNet *net.IPNet) bool {
+	internal, _ := ParseCIDRs(ClusterInternalCIDRs)
+	return containedIn(ipNet, internal)
+}
+
+func overlapsClusterInternal(ipNet *net.IPNet) bool {
+	internal, _ := ParseCIDRs(ClusterInternalCIDRs)
+	for _, n := range internal {
+		if len(n.IP) == len(ipNet.IP) && (n.Contains(ipNet.IP) || ipNet.Contains(n.IP)) {
+			return true
+		}
+	}
+	return false
+}
+
+func containedIn(ipNet *net.IPNet, nets []*net.IPNet) bool {
+	ones, _ := ipNet.Mask.Size()
+	for _, n := range nets {
+		nOnes, _ := n.Mask.Size()
+		if n.Contains(ipNet.IP) && nOnes <= ones && len(n.IP) == len(ipNet.IP) {
+			return true
+		}
+	}
+	return false
+}
+
+func containsDomain(domains []string, domain string) bool {
+	for _, d := range domains {
+		if strings.EqualFold(strings.TrimSuffix(d, "."), strings.TrimSuffix(domain, ".")) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,125 @@
+package browserset
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	v1 "github.com/browserkube/browserkube/operator/api/v1"
+)
+
+func TestNetworkPolicy(t *testing.T) {
+	setPolicy := &v1.BrowserNetworkPolicy{
+		Enabled:        true,
+		AllowedCIDRs:   []string{"203.0.113.0/24", "2001:db8::/32"},
+		AllowedDomains: []string{"example.com"},
+	}
+
+	tests := []struct {
+		name      string
+		set       *v1.BrowserNetworkPolicy
+		version   *v1.BrowserNetworkPolicy
+		requested *v1.BrowserNetwork
+		want      *v1.BrowserNetworkPolicy
+		wantErr   bool
+	}{
+		{
+			name: "not configured",
+		},
+		{
+			name: "disabled",
+			set:  &v1.BrowserNetworkPolicy{AllowedCIDRs: []string{"203.0.113.0/24"}},
+		},
+		{
+			name: "set policy",
+			set:  setPolicy,
+			want: setPolicy,
+		},
+		{
+			name:    "version policy wins",
+			set:     setPolicy,
+			version: &v1.BrowserNetworkPolicy{Enabled: true, AllowClusterInternal: true},
+			want:    &v1.BrowserNetworkPolicy{Enabled: true, AllowClusterInternal: true},
+		},
+		{
+			name:      "narrowed",
+			set:       setPolicy,
+			requested: &v1.BrowserNetwork{AllowedCIDRs: []string{"203.0.113.8/29", "2001:db8:1::/48"}, AllowedDomains: []string{"Example.com."}},
+			want: &v1.BrowserNetworkPolicy{
+				Enabled:        true,
+				AllowedCIDRs:   []string{"203.0.113.8/29", "2001:db8:1::/48"},
+				AllowedDomains: []string{"Example.com."},
+			},
+		},
+		{
+			name:      "requested without set policy",
+			requested: &v1.BrowserNetwork{AllowedCIDRs: []string{"198.51.100.0/24"}},
+			want:      &v1.BrowserNetworkPolicy{Enabled: true, AllowedCIDRs: []string{"198.51.100.0/24"}},
+		},
+		{
+			name:      "cluster-internal CIDR",
+			requested: &v1.BrowserNetwork{AllowedCIDRs: []string{"10.0.0.0/8"}},
+			wantErr:   true,
+		},
+		{
+			name:      "CIDR containing cluster-internal range",
+			requested: &v1.BrowserNetwork{AllowedCIDRs: []string{"8.0.0.0/6"}},
+			wantErr:   true,
+		},
+		{
+			name:      "cluster-internal CIDR allowed",
+			set:       &v1.BrowserNetworkPolicy{AllowClusterInternal: true},
+			requested: &v1.BrowserNetwork{AllowedCIDRs: []string{"10.1.0.0/16"}},
+			want: &v1.BrowserNetworkPolicy{
+				Enabled:              true,
+				AllowedCIDRs:         []string{"10.1.0.0/16"},
+				AllowClusterInternal: true,
+			},
+		},
+		{
+			name:      "wider CIDR",
+			set:       setPolicy,
+			requested: &v1.BrowserNetwork{AllowedCIDRs: []string{"203.0.0.0/16"}},
+			wantErr:   true,
+		},
+		{
+			name:      "other CIDR",
+			set:       setPolicy,
+			requested: &v1.BrowserNetwork{AllowedCIDRs: []string{"198.51.100.0/24"}},
+			wantErr:   true,
+		},
+		{
+			name:      "invalid CIDR",
+			requested: &v1.BrowserNetwork{AllowedCIDRs: []string{"203.0.113.0"}},
+			wantErr:   true,
+		},
+		{
+			name:      "other domain",
+			set:       setPolicy,
+			requested: &v1.BrowserNetwork{AllowedDomains: []string{"example.org"}},
+			wantErr:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resolved := &Resolved{
+				Set:    &v1.BrowserSet{Spec: v1.BrowserSetSpec{NetworkPolicy: tt.set}},
+				Config: v1.BrowserConfig{NetworkPolicy: tt.version},
+			}
+			got, err := NetworkPolicy(resolved, tt.requested)
+			if tt.wantErr {
+				var networkErr *NetworkErr
+				if !errors.As(err, &networkErr) {
+					t.Fatalf("NetworkPolicy() error = %v, want NetworkErr", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("NetworkPolicy() unexpected error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NetworkPolicy() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,94 @@
+package browserset
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/strategicpatch"
+)
+
+// overlayFields are the top-level Pod fields pod template overlay may patch
+var overlayFields = map[string]bool{
+	"metadata": true,
+	"spec":     true,
+}
+
+// PodTemplateOverlay returns pod template overlay of the resolved browser.
+// Set overlay and version overlay are merged into a single patch, the version one wins on conflicts
+func PodTemplateOverlay(resolved *Resolved) (*runtime.RawExtension, error) {
+	var patches []strategicpatch.JSONMap
+	for _, overlay := range []*runtime.RawExtension{resolved.Set.Spec.PodTemplateOverlay, resolved.Config.PodTemplateOverlay} {
+		patch, err := overlayPatch(overlay)
+		if err != nil {
+			return nil, err
+		}
+		if patch != nil {
+			patches = append(patches, patch)
+		}
+	}
+	switch len(patches) {
+	case 0:
+		return nil, nil
+	case 1:
+		return toRawExtension(patches[0])
+	}
+
+	schema, err := strategicpatch.NewPatchMetaFromStruct(corev1.Pod{})
+	if err != nil {
+		return nil, err
+	}
+	merged, err := strategicpatch.MergeStrategicMergeMapPatchUsingLookupPatchMeta(schema, patches...)
+	if err != nil {
+		return nil, fmt.Errorf("unable to merge pod template overlays: %w", err)
+	}
+	return toRawExtension(merged)
+}
+
+// ValidatePodTemplateOverlay checks the overlay is a strategic merge patch of Pod metadata and spec
+func ValidatePodTemplateOverlay(overlay *runtime.RawExtension) error {
+	patch, err := overlayPatch(overlay)
+	if err != nil || patch == nil {
+		return err
+	}
+	patched, err := strategicpatch.StrategicMergeMapPatch(map[string]interface{}{}, patch, corev1.Pod{})
+	if err != nil {
+		return fmt.Errorf("overlay isn't a valid pod patch: %w", err)
+	}
+	raw, err := json.Marshal(patched)
+	if err != nil {
+		return err
+	}
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.DisallowUnknownFields()
+	if err = decoder.Decode(&corev1.Pod{}); err != nil {
+		return fmt.Errorf("overlay isn't a valid pod patch: %w", err)
+	}
+	return nil
+}
+
+func overlayPatch(overlay *runtime.RawExtension) (strategicpatch.JSONMap, error) {
+	if overlay == nil || len(overlay.Raw) == 0 {
+		return nil, nil
+	}
+	var patch strategicpatch.JSONMap
+	if err := json.Unmarshal(overlay.Raw, &patch); err != nil {
+		return nil, fmt.Errorf("overlay must be an object: %w", err)
+	}
+	for field := range patch {
+		if !overlayFields[field] {
+			return nil, fmt.Errorf("overlay may patch metadata and spec only, found: %s", field)
+		}
+	}
+	return patch, nil
+}
+
+func toRawExtension(patch strategicpatch.JSONMap) (*runtime.RawExtension, error) {
+	raw, err := json.Marshal(patch)
+	if err != nil {
+		return nil, err
+	}
+	return &runtime.RawExtension{Raw: raw}, nil
+}
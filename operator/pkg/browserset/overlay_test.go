@@ -0,0 +1,109 @@
+package browserset
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/runtime"
+
+	v1 "github.com/browserkube/browserkube/operator/api/v1"
+)
+
+func rawOverlay(s string) *runtime.RawExtension {
+	return &runtime.RawExtension{Raw: []byte(s)}
+}
+
+func TestPodTemplateOverlay(t *testing.T) {
+	setOverlay := rawOverlay(`{
+		"metadata": {"annotations": {"sidecar.istio.io/inject": "false"}},
+		"spec": {"runtimeClassName": "gvisor", "containers": [{"name": "browser", "env": [{"name": "LANG", "value": "en_US"}]}]}
+	}`)
+	versionOverlay := rawOverlay(`{
+		"spec": {"runtimeClassName": "kata", "containers": [{"name": "browser", "env": [{"name": "TZ_DATA", "value": "1"}]}]}
+	}`)
+
+	tests := []struct {
+		name    string
+		set     *runtime.RawExtension
+		version *runtime.RawExtension
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "no overlays",
+		},
+		{
+			name: "set overlay",
+			set:  setOverlay,
+			want: string(setOverlay.Raw),
+		},
+		{
+			name:    "version overlay wins",
+			set:     setOverlay,
+			version: versionOverlay,
+			want: `{
+				"metadata": {"annotations": {"sidecar.istio.io/inject": "false"}},
+				"spec": {"runtimeClassName": "kata", "containers": [
+					{"name": "browser", "env": [{"name": "TZ_DATA", "value": "1"}, {"name": "LANG", "value": "en_US"}]}
+				]}
+			}`,
+		},
+		{
+			name:    "not an object",
+			version: rawOverlay(`["spec"]`),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resolved := &Resolved{
+				Set:    &v1.BrowserSet{Spec: v1.BrowserSetSpec{PodTemplateOverlay: tt.set}},
+				Config: v1.BrowserConfig{PodTemplateOverlay: tt.version},
+			}
+			got, err := PodTemplateOverlay(resolved)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("PodTemplateOverlay() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.want == "" {
+				if got != nil {
+					t.Errorf("PodTemplateOverlay() = %s, want nil", got.Raw)
+				}
+				return
+			}
+
+			var gotMap, wantMap map[string]interface{}
+			if err = json.Unmarshal(got.Raw, &gotMap); err != nil {
+				t.Fatal(err)
+			}
+			if err = json.Unmarshal([]byte(tt.want), &wantMap); err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(gotMap, wantMap) {
+				t.Errorf("PodTemplateOverlay() = %s, want %s", got.Raw, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidatePodTemplateOverlay(t *testing.T) {
+	tests := []struct {
+		name    string
+		overlay *runtime.RawExtension
+		wantErr bool
+	}{
+		{name: "empty"},
+		{name: "metadata and spec", overlay: rawOverlay(`{"metadata":{"labels":{"team":"qa"}},"spec":{"runtimeClassName":"gvisor"}}`)},
+		{name: "delete directive", overlay: rawOverlay(`{"spec":{"containers":[{"name":"clipboard","$patch":"delete"}]}}`)},
+		{name: "status", overlay: rawOverlay(`{"status":{"phase":"Running"}}`), wantErr: true},
+		{name: "wrong type", overlay: rawOverlay(`{"spec":{"containers":"browser"}}`), wantErr: true},
+		{name: "unknown field", overlay: rawOverlay(`{"spec":{"runtimeClass":"gvisor"}}`), wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := ValidatePodTemplateOverlay(tt.overlay); (err != nil) != tt.wantErr {
+				t.Errorf("ValidatePodTemplateOverlay() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,123 @@
+package browserset
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"reflect"
+	"slices"
+	"strings"
+
+	v1 "github.com/browserkube/browserkube/operator/api/v1"
+)
+
+// ProxyForwarderAddr is the address the sidecar forwards browser traffic to the upstream proxy from.
+// Browsers can't authenticate to proxies or talk TLS to them, so such proxies are used through the forwarder
+const ProxyForwarderAddr = "127.0.0.1:3128"
+
+// ProxySchemes are the supported upstream proxy schemes
+var ProxySchemes = []string{"http", "https", "socks5"}
+
+// ProxyErr is returned when the requested proxy is invalid or isn't allowed by the BrowserSet
+type ProxyErr struct {
+	msg string
+}
+
+func (e *ProxyErr) Error() string {
+	return e.msg
+}
+
+// Proxy returns upstream proxy effective for the resolved browser or nil if the browser connects directly.
+// Version proxy overrides the set one. Requested proxy overrides both unless the set forces its proxy
+func Proxy(resolved *Resolved, requested *v1.BrowserProxy) (*v1.BrowserProxy, error) {
+	configured := resolved.Config.Proxy
+	if configured == nil {
+		configured = resolved.Set.Spec.Proxy
+	}
+	if resolved.Set.Spec.ForceProxy {
+		if configured == nil {
+			return nil, &ProxyErr{msg: fmt.Sprintf("BrowserSet %s forces proxy but has none configured", resolved.Set.Name)}
+		}
+		if requested != nil && !reflect.DeepEqual(requested, configured) {
+			return nil, &ProxyErr{msg: fmt.Sprintf("BrowserSet %s forces proxy %s", resolved.Set.Name, configured.URL)}
+		}
+	}
+
+	if requested != nil && requested.CredentialsSecret != "" && !configuresCredentials(resolved, requested) {
+		return nil, &ProxyErr{msg: fmt.Sprintf("credentials Secret %s isn't configured for proxy %s by BrowserSet %s",
+			requested.CredentialsSecret, requested.URL, resolved.Set.Name)}
+	}
+
+	effective := configured
+	if requested != nil {
+		effective = requested
+	}
+	if effective == nil {
+		return nil, nil
+	}
+	if err := ValidateProxy(effective); err != nil {
+		return nil, err
+	}
+	return effective.DeepCopy(), nil
+}
+
+// configuresCredentials tells whether the set or the browser version configures the requested credentials Secret
+// for the requested proxy. Sessions can't send credentials of the namespace to proxies of their own choice
+func configuresCredentials(resolved *Resolved, requested *v1.BrowserProxy) bool {
+	for _, p := range []*v1.BrowserProxy{resolved.Config.Proxy, resolved.Set.Spec.Proxy} {
+		if p != nil && p.URL == requested.URL && p.CredentialsSecret == requested.CredentialsSecret {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidateProxy checks the proxy URL and bypass list. Nil proxy is valid
+func ValidateProxy(p *v1.BrowserProxy) error {
+	if p == nil {
+		return nil
+	}
+	var violations []string
+	u, err := url.Parse(p.URL)
+	switch {
+	case p.URL == "":
+		violations = append(violations, "proxy url is required")
+	case err != nil:
+		violations = append(violations, fmt.Sprintf("proxy url %s is invalid", p.URL))
+	default:
+		if !slices.Contains(ProxySchemes, u.Scheme) {
+			violations = append(violations, fmt.Sprintf("proxy scheme %s isn't supported", u.Scheme))
+		}
+		if _, port, sErr := net.SplitHostPort(u.Host); sErr != nil || port == "" || u.Hostname() == "" {
+			violations = append(violations, fmt.Sprintf("proxy url %s must have host and port", p.URL))
+		}
+		if u.User != nil {
+			violations = append(violations, "proxy credentials must be provided by credentialsSecret")
+		}
+		if strings.Trim(u.Path, "/") != "" || u.RawQuery != "" {
+			violations = append(violations, fmt.Sprintf("proxy url %s must not have path or query", p.URL))
+		}
+	}
+	for _, host := range p.NoProxy {
+		if strings.TrimSpace(host) == "" || strings.ContainsAny(host, ",; ") {
+			violations = append(violations, fmt.Sprintf("no proxy entry %q is invalid", host))
+		}
+	}
+	if len(violations) > 0 {
+		return &ProxyErr{msg: strings.Join(violations, ", ")}
+	}
+	return nil
+}
+
+// ProxyForwarded tells whether browsers reach the proxy through the sidecar forwarder
+func ProxyForwarded(p *v1.BrowserProxy) bool {
+	return p.CredentialsSecret != "" || strings.HasPrefix(p.URL, "https:")
+}
+
+// BrowserProxyURL returns the proxy URL browsers are configured with
+func BrowserProxyURL(p *v1.BrowserProxy) string {
+	if ProxyForwarded(p) {
+		return "http://" + ProxyForwarderAddr
+	}
+	return p.URL
+}
@@ -0,0 +1,165 @@
+package browserset
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	v1 "github.com/browserkube/browserkube/operator/api/v1"
+)
+
+// DefaultScreenResolution is the screen resolution of browsers not requesting one
+const DefaultScreenResolution = "1920x1080"
+
+// DefaultRecording is used for the options neither BrowserSet nor the session configures
+var DefaultRecording = v1.BrowserRecording{
+	FrameRate: 12,
+	Format:    "mp4",
+}
+
+// RecordingCodecs are the codecs supported for every container format. The first one is the default of the format
+var RecordingCodecs = map[string][]string{
+	"mp4":  {"libx264", "libx265"},
+	"webm": {"libvpx-vp9", "libvpx"},
+}
+
+// maxCRF is the worst quality accepted by the codec
+var maxCRF = map[string]int32{
+	"libx264":    51,
+	"libx265":    51,
+	"libvpx-vp9": 63,
+	"libvpx":     63,
+}
+
+const maxFrameRate = 60
+
+// RecordingErr is returned when recording options are invalid
+type RecordingErr struct {
+	msg string
+}
+
+func (e *RecordingErr) Error() string {
+	return e.msg
+}
+
+// Recording returns recording options effective for the resolved browser.
+// Version options override the set ones which override DefaultRecording. Requested options override all of them.
+// Format without a codec switches to the default codec of the format. Video size defaults to the screen resolution
+// and can't exceed it
+func Recording(resolved *Resolved, requested *v1.BrowserRecording, screenResolution string) (v1.BrowserRecording, error) {
+	effective := DefaultRecording
+	for _, r := range []*v1.BrowserRecording{resolved.Set.Spec.Recording, resolved.Config.Recording, requested} {
+		mergeRecording(&effective, r)
+	}
+	if effective.Codec == "" {
+		if codecs := RecordingCodecs[effective.Format]; len(codecs) > 0 {
+			effective.Codec = codecs[0]
+		}
+	}
+
+	screen := screenSize(screenResolution)
+	if effective.VideoSize == "" {
+		effective.VideoSize = screen
+	}
+	if err := ValidateRecording(&effective); err != nil {
+		return effective, err
+	}
+
+	width, height, _ := parseSize(effective.VideoSize)
+	screenWidth, screenHeight, err := parseSize(screen)
+	if err != nil {
+		return effective, &RecordingErr{msg: fmt.Sprintf("screen resolution %s is invalid", screenResolution)}
+	}
+	if width > screenWidth || height > screenHeight {
+		return effective, &RecordingErr{msg: fmt.Sprintf("video size %s exceeds screen resolution %s",
+			effective.VideoSize, screen)}
+	}
+	return effective, nil
+}
+
+// ValidateRecording checks the options regardless of the screen they're recorded from.
+// Options which aren't set are considered valid
+func ValidateRecording(r *v1.BrowserRecording) error {
+	if r == nil {
+		return nil
+	}
+	var violations []string
+	if r.VideoSize != "" {
+		if _, _, err := parseSize(r.VideoSize); err != nil {
+			violations = append(violations, err.Error())
+		}
+	}
+	if r.FrameRate < 0 || r.FrameRate > maxFrameRate {
+		violations = append(violations, fmt.Sprintf("frame rate %d must be between 1 and %d", r.FrameRate, maxFrameRate))
+	}
+	if r.Format != "" {
+		if codecs, ok := RecordingCodecs[r.Format]; !ok {
+			violations = append(violations, fmt.Sprintf("format %s isn't supported", r.Format))
+		} else if r.Codec != "" && !slices.Contains(codecs, r.Codec) {
+			violations = append(violations, fmt.Sprintf("codec %s isn't supported by %s format", r.Codec, r.Format))
+		}
+	}
+	if _, ok := maxCRF[r.Codec]; r.Codec != "" && !ok {
+		violations = append(violations, fmt.Sprintf("codec %s isn't supported", r.Codec))
+	}
+	if r.CRF != nil {
+		limit, ok := maxCRF[r.Codec]
+		if !ok {
+			limit = 51
+		}
+		if *r.CRF < 0 || *r.CRF > limit {
+			violations = append(violations, fmt.Sprintf("crf %d must be between 0 and %d", *r.CRF, limit))
+		}
+	}
+	if len(violations) > 0 {
+		return &RecordingErr{msg: strings.Join(violations, ", ")}
+	}
+	return nil
+}
+
+func mergeRecording(dst, src *v1.BrowserRecording) {
+	if src == nil {
+		return
+	}
+	if src.Format != "" && src.Format != dst.Format {
+		// codec of another format isn't applicable anymore
+		dst.Format = src.Format
+		dst.Codec = ""
+	}
+	if src.Codec != "" {
+		dst.Codec = src.Codec
+	}
+	if src.VideoSize != "" {
+		dst.VideoSize = src.VideoSize
+	}
+	if src.FrameRate != 0 {
+		dst.FrameRate = src.FrameRate
+	}
+	if src.CRF != nil {
+		crf := *src.CRF
+		dst.CRF = &crf
+	}
+}
+
+// screenSize strips the color depth from the screen resolution
+func screenSize(res string) string {
+	if res == "" {
+		return DefaultScreenResolution
+	}
+	parts := strings.Split(res, "x")
+	if len(parts) > 2 {
+		parts = parts[:2]
+	}
+	return strings.Join(parts, "x")
+}
+
+func parseSize(size string) (int, int, error) {
+	w, h, ok := strings.Cut(size, "x")
+	width, wErr := strconv.Atoi(w)
+	height, hErr := strconv.Atoi(h)
+	if !ok || wErr != nil || hErr != nil || width <= 0 || height <= 0 {
+		return 0, 0, fmt.Errorf("video size %s must be <width>x<height>", size)
+	}
+	return width, height, nil
+}
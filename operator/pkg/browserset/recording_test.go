@@ -0,0 +1,82 @@
+package browserset
+
+import (
+	"reflect"
+	"testing"
+
+	"k8s.io/utils/ptr"
+
+	v1 "github.com/browserkube/browserkube/operator/api/v1"
+)
+
+func TestRecording(t *testing.T) {
+	set := &v1.BrowserSet{Spec: v1.BrowserSetSpec{
+		Recording: &v1.BrowserRecording{FrameRate: 24, CRF: ptr.To[int32](28)},
+	}}
+	resolved := &Resolved{Set: set, Config: v1.BrowserConfig{Recording: &v1.BrowserRecording{Codec: "libx265"}}}
+
+	tests := []struct {
+		name      string
+		requested *v1.BrowserRecording
+		screen    string
+		want      v1.BrowserRecording
+		wantErr   bool
+	}{
+		{
+			name: "version overrides set and defaults, size of the default screen",
+			want: v1.BrowserRecording{VideoSize: "1920x1080", FrameRate: 24, Codec: "libx265", CRF: ptr.To[int32](28), Format: "mp4"},
+		},
+		{
+			name:   "size of the screen without depth",
+			screen: "1280x1024x24",
+			want:   v1.BrowserRecording{VideoSize: "1280x1024", FrameRate: 24, Codec: "libx265", CRF: ptr.To[int32](28), Format: "mp4"},
+		},
+		{
+			name:      "requested format switches to its default codec",
+			requested: &v1.BrowserRecording{Format: "webm", VideoSize: "1280x720", FrameRate: 30},
+			want:      v1.BrowserRecording{VideoSize: "1280x720", FrameRate: 30, Codec: "libvpx-vp9", CRF: ptr.To[int32](28), Format: "webm"},
+		},
+		{
+			name:      "codec of another format",
+			requested: &v1.BrowserRecording{Format: "mp4", Codec: "libvpx"},
+			wantErr:   true,
+		},
+		{
+			name:      "video size exceeds screen",
+			requested: &v1.BrowserRecording{VideoSize: "1920x1080"},
+			screen:    "1280x1024",
+			wantErr:   true,
+		},
+		{
+			name:      "invalid video size",
+			requested: &v1.BrowserRecording{VideoSize: "big"},
+			wantErr:   true,
+		},
+		{
+			name:      "crf out of codec range",
+			requested: &v1.BrowserRecording{CRF: ptr.To[int32](60)},
+			wantErr:   true,
+		},
+		{
+			name:      "frame rate too high",
+			requested: &v1.BrowserRecording{FrameRate: 120},
+			wantErr:   true,
+		},
+		{
+			name:      "unsupported format",
+			requested: &v1.BrowserRecording{Format: "avi"},
+			wantErr:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Recording(resolved, tt.requested, tt.screen)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Recording() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Recording() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
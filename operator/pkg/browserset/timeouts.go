@@ -0,0 +1,83 @@
+package browserset
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1 "github.com/browserkube/browserkube/operator/api/v1"
+)
+
+// DefaultTimeouts are used when neither BrowserSet nor browser version configures a timeout
+var DefaultTimeouts = v1.BrowserTimeouts{
+	Startup:       &metav1.Duration{Duration: 5 * time.Minute},
+	SessionDelete: &metav1.Duration{Duration: 30 * time.Second},
+	Idle:          &metav1.Duration{Duration: 10 * time.Minute},
+	Session:       &metav1.Duration{Duration: time.Hour},
+}
+
+// TimeoutErr is returned when requested timeouts are not allowed by the BrowserSet
+type TimeoutErr struct {
+	msg string
+}
+
+func (e *TimeoutErr) Error() string {
+	return e.msg
+}
+
+type timeoutField struct {
+	name string
+	get  func(t *v1.BrowserTimeouts) **metav1.Duration
+}
+
+var timeoutFields = []timeoutField{
+	{name: "startup", get: func(t *v1.BrowserTimeouts) **metav1.Duration { return &t.Startup }},
+	{name: "sessionDelete", get: func(t *v1.BrowserTimeouts) **metav1.Duration { return &t.SessionDelete }},
+	{name: "idle", get: func(t *v1.BrowserTimeouts) **metav1.Duration { return &t.Idle }},
+	{name: "session", get: func(t *v1.BrowserTimeouts) **metav1.Duration { return &t.Session }},
+}
+
+// Timeouts returns timeouts effective for the resolved browser.
+// Version timeouts override the set ones which override DefaultTimeouts. Requested timeouts override all of them
+// as long as they don't exceed set's maxTimeouts. A requested timeout without a bound may only shorten the configured one
+func Timeouts(resolved *Resolved, requested *v1.BrowserTimeouts) (v1.BrowserTimeouts, error) {
+	configured := resolved.Config.Timeouts()
+	defaults := resolved.Set.Spec.Timeouts
+	bounds := resolved.Set.Spec.MaxTimeouts
+
+	var effective v1.BrowserTimeouts
+	var violations []string
+	for _, f := range timeoutFields {
+		value := *f.get(&configured)
+		if value == nil && defaults != nil {
+			value = *f.get(defaults)
+		}
+		if value == nil {
+			value = *f.get(&DefaultTimeouts)
+		}
+
+		if requested != nil {
+			if req := *f.get(requested); req != nil {
+				limit := value
+				if bounds != nil && *f.get(bounds) != nil {
+					limit = *f.get(bounds)
+				}
+				switch {
+				case req.Duration <= 0:
+					violations = append(violations, fmt.Sprintf("%s timeout must be positive", f.name))
+				case req.Duration > limit.Duration:
+					violations = append(violations, fmt.Sprintf("%s timeout %s exceeds %s", f.name, req.Duration, limit.Duration))
+				default:
+					value = req
+				}
+			}
+		}
+		*f.get(&effective) = &metav1.Duration{Duration: value.Duration}
+	}
+	if len(violations) > 0 {
+		return effective, &TimeoutErr{msg: strings.Join(violations, ", ")}
+	}
+	return effective, nil
+}
@@ -0,0 +1,76 @@
+package browserset
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1 "github.com/browserkube/browserkube/operator/api/v1"
+)
+
+func duration(d time.Duration) *metav1.Duration {
+	return &metav1.Duration{Duration: d}
+}
+
+func TestTimeouts(t *testing.T) {
+	set := &v1.BrowserSet{Spec: v1.BrowserSetSpec{
+		Timeouts:    &v1.BrowserTimeouts{Idle: duration(5 * time.Minute), Session: duration(30 * time.Minute)},
+		MaxTimeouts: &v1.BrowserTimeouts{Session: duration(2 * time.Hour)},
+	}}
+	resolved := &Resolved{Set: set, Config: v1.BrowserConfig{StartupTimeout: duration(2 * time.Minute)}}
+
+	tests := []struct {
+		name      string
+		requested *v1.BrowserTimeouts
+		want      v1.BrowserTimeouts
+		wantErr   bool
+	}{
+		{
+			name: "version overrides set and defaults",
+			want: v1.BrowserTimeouts{
+				Startup:       duration(2 * time.Minute),
+				SessionDelete: duration(30 * time.Second),
+				Idle:          duration(5 * time.Minute),
+				Session:       duration(30 * time.Minute),
+			},
+		},
+		{
+			name:      "requested within bounds",
+			requested: &v1.BrowserTimeouts{Idle: duration(time.Minute), Session: duration(90 * time.Minute)},
+			want: v1.BrowserTimeouts{
+				Startup:       duration(2 * time.Minute),
+				SessionDelete: duration(30 * time.Second),
+				Idle:          duration(time.Minute),
+				Session:       duration(90 * time.Minute),
+			},
+		},
+		{
+			name:      "requested exceeds bound",
+			requested: &v1.BrowserTimeouts{Session: duration(3 * time.Hour)},
+			wantErr:   true,
+		},
+		{
+			name:      "requested exceeds configured timeout without bound",
+			requested: &v1.BrowserTimeouts{Idle: duration(10 * time.Minute)},
+			wantErr:   true,
+		},
+		{
+			name:      "requested is not positive",
+			requested: &v1.BrowserTimeouts{Startup: duration(0)},
+			wantErr:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Timeouts(resolved, tt.requested)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Timeouts() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Timeouts() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -17,6 +17,7 @@ type BrowsersSetsInterface interface {
 	Get(ctx context.Context, name string, options metav1.GetOptions) (*v1.BrowserSet, error)
 	Watch(ctx context.Context, pts metav1.ListOptions) (watch.Interface, error)
 	Patch(ctx context.Context, resourceName string, b []byte, opts metav1.PatchOptions) error
+	Update(ctx context.Context, bs *v1.BrowserSet) (*v1.BrowserSet, error)
 }
 
 type browserSetClient struct {
@@ -72,3 +73,17 @@ func (c *browserSetClient) Patch(ctx context.Context, resourceName string, b []b
 		Do(ctx).
 		Error()
 }
+
+func (c *browserSetClient) Update(ctx context.Context, bs *v1.BrowserSet) (*v1.BrowserSet, error) {
+	result := v1.BrowserSet{}
+	err := c.restClient.
+		Put().
+		Namespace(c.ns).
+		Resource("browsersets").
+		Name(bs.Name).
+		Body(bs).
+		Do(ctx).
+		Into(&result)
+
+	return &result, err
+}
@@ -17,6 +17,7 @@ type BrowsersInterface interface {
 	List(ctx context.Context, opts metav1.ListOptions) (*v1.BrowserList, error)
 	Get(ctx context.Context, name string, options metav1.GetOptions) (*v1.Browser, error)
 	Create(context.Context, *v1.Browser) (*v1.Browser, error)
+	Update(context.Context, *v1.Browser) (*v1.Browser, error)
 	Watch(ctx context.Context, pts metav1.ListOptions) (watch.Interface, error)
 	Delete(ctx context.Context, name string, opts metav1.DeleteOptions) error
 	WatchByName(ctx context.Context, name string) (watch.Interface, error)
@@ -67,6 +68,20 @@ func (c *browserClient) Create(ctx context.Context, project *v1.Browser) (*v1.Br
 	return &result, err
 }
 
+func (c *browserClient) Update(ctx context.Context, browser *v1.Browser) (*v1.Browser, error) {
+	result := v1.Browser{}
+	err := c.restClient.
+		Put().
+		Namespace(c.ns).
+		Resource("browsers").
+		Name(browser.Name).
+		Body(browser).
+		Do(ctx).
+		Into(&result)
+
+	return &result, err
+}
+
 func (c *browserClient) Watch(ctx context.Context, opts metav1.ListOptions) (watch.Interface, error) {
 	opts.Watch = true
 	return c.restClient.
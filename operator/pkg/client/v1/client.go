@@ -11,6 +11,7 @@ type Interface interface {
 	RESTClient() rest.Interface
 	Browsers(namespace string) BrowsersInterface
 	BrowserSets(namespace string) BrowsersSetsInterface
+	ExtensionPolicies(namespace string) ExtensionPoliciesInterface
 	SessionResults(namespace string) SessionResultsInterface
 }
 
@@ -55,6 +56,13 @@ func (c *browserkubeV1Client) BrowserSets(namespace string) BrowsersSetsInterfac
 	}
 }
 
+func (c *browserkubeV1Client) ExtensionPolicies(namespace string) ExtensionPoliciesInterface {
+	return &extensionPolicyClient{
+		restClient: c.restClient,
+		ns:         namespace,
+	}
+}
+
 func (c *browserkubeV1Client) SessionResults(namespace string) SessionResultsInterface {
 	return &sessionResultClient{
 		restClient: c.restClient,
@@ -0,0 +1,33 @@
+package v1
+
+import (
+	"context"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+
+	v1 "github.com/browserkube/browserkube/operator/api/v1"
+)
+
+type ExtensionPoliciesInterface interface {
+	List(ctx context.Context, opts metav1.ListOptions) (*v1.ExtensionPolicyList, error)
+}
+
+type extensionPolicyClient struct {
+	restClient rest.Interface
+	ns         string
+}
+
+func (c *extensionPolicyClient) List(ctx context.Context, opts metav1.ListOptions) (*v1.ExtensionPolicyList, error) {
+	result := v1.ExtensionPolicyList{}
+	err := c.restClient.
+		Get().
+		Namespace(c.ns).
+		Resource("extensionpolicies").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Do(ctx).
+		Into(&result)
+
+	return &result, err
+}
@@ -20,6 +20,10 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 		&v1.SessionResultList{},
 		&v1.BrowserSet{},
 		&v1.BrowserSetList{},
+		&v1.BrowserPool{},
+		&v1.BrowserPoolList{},
+		&v1.ExtensionPolicy{},
+		&v1.ExtensionPolicyList{},
 	)
 
 	metav1.AddToGroupVersion(scheme, v1.GroupVersion)
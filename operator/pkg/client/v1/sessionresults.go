@@ -15,6 +15,7 @@ type SessionResultsInterface interface {
 	List(ctx context.Context, opts metav1.ListOptions) (*v1.SessionResultList, error)
 	Get(ctx context.Context, name string, options metav1.GetOptions) (*v1.SessionResult, error)
 	Create(context.Context, *v1.SessionResult) (*v1.SessionResult, error)
+	Update(context.Context, *v1.SessionResult) (*v1.SessionResult, error)
 	Watch(ctx context.Context, pts metav1.ListOptions) (watch.Interface, error)
 	Delete(ctx context.Context, name string, opts metav1.DeleteOptions) error
 }
@@ -64,6 +65,20 @@ func (c *sessionResultClient) Create(ctx context.Context, res *v1.SessionResult)
 	return &result, err
 }
 
+func (c *sessionResultClient) Update(ctx context.Context, res *v1.SessionResult) (*v1.SessionResult, error) {
+	result := v1.SessionResult{}
+	err := c.restClient.
+		Put().
+		Namespace(c.ns).
+		Resource("sessionresults").
+		Name(res.Name).
+		Body(res).
+		Do(ctx).
+		Into(&result)
+
+	return &result, err
+}
+
 func (c *sessionResultClient) Watch(ctx context.Context, opts metav1.ListOptions) (watch.Interface, error) {
 	opts.Watch = true
 	return c.restClient.
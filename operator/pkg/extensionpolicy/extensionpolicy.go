@@ -0,0 +1,256 @@
+// Package extensionpolicy checks browser extensions against ExtensionPolicies.
+//
+// An extension is allowed if any of the policies applying to the browser allows it. A policy applies to the browser
+// if the browser name is listed in the policy (or the list is empty) and the browser labels match the policy selector.
+// The browser is matched with LabelBrowserSet of the BrowserSet it's resolved from, so policies may select BrowserSets.
+package extensionpolicy
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	v1 "github.com/browserkube/browserkube/operator/api/v1"
+)
+
+// RejectedErr is returned when the browser requests extensions which aren't allowed by the policies
+type RejectedErr struct {
+	Extensions []v1.BrowserExtension
+}
+
+func (e *RejectedErr) Error() string {
+	rejected := make([]string, 0, len(e.Extensions))
+	for _, ext := range e.Extensions {
+		rejected = append(rejected, describe(ext))
+	}
+	return "extensions aren't allowed by extension policies: " + strings.Join(rejected, ", ")
+}
+
+// InvalidErr is returned when the policy can't be applied
+type InvalidErr struct {
+	msg string
+}
+
+func (e *InvalidErr) Error() string {
+	return e.msg
+}
+
+// Check returns RejectedErr listing extensions of the browser which aren't allowed by the policies.
+// browserSet is the name of the BrowserSet the browser is resolved from, it's empty if the set isn't known yet
+func Check(policies []v1.ExtensionPolicy, browser *v1.Browser, browserSet string) error {
+	if len(browser.Spec.Extensions) == 0 {
+		return nil
+	}
+	browserLabels := labels.Set{}
+	for k, v := range browser.Labels {
+		browserLabels[k] = v
+	}
+	if browserSet != "" {
+		browserLabels[v1.LabelBrowserSet] = browserSet
+	}
+
+	var applied []v1.AllowedExtension
+	for i := range policies {
+		ok, err := applies(&policies[i], strings.ToLower(browser.Spec.BrowserName), browserLabels)
+		if err != nil {
+			return err
+		}
+		if ok {
+			applied = append(applied, policies[i].Spec.Allowed...)
+		}
+	}
+
+	var rejected []v1.BrowserExtension
+	for _, ext := range browser.Spec.Extensions {
+		if !allowed(applied, ext) {
+			rejected = append(rejected, ext)
+		}
+	}
+	if len(rejected) > 0 {
+		return &RejectedErr{Extensions: rejected}
+	}
+	return nil
+}
+
+// Validate checks that the policy selector and version constraints can be parsed
+// and every allowed extension is matched either by ID or by artifact
+func Validate(policy *v1.ExtensionPolicy) error {
+	if _, err := metav1.LabelSelectorAsSelector(policy.Spec.Selector); err != nil {
+		return &InvalidErr{msg: fmt.Sprintf("selector is invalid: %s", err)}
+	}
+	for _, entry := range policy.Spec.Allowed {
+		if err := ValidateAllowed(entry); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ValidateAllowed checks the allowed extension entry
+func ValidateAllowed(entry v1.AllowedExtension) error {
+	if entry.ExtensionID == "" && entry.Artifact == "" {
+		return &InvalidErr{msg: "allowed extension must have an extension ID or an artifact"}
+	}
+	if _, err := parseConstraints(entry.Versions); err != nil {
+		return err
+	}
+	return nil
+}
+
+func applies(policy *v1.ExtensionPolicy, browserName string, browserLabels labels.Set) (bool, error) {
+	if len(policy.Spec.Browsers) > 0 {
+		var listed bool
+		for _, name := range policy.Spec.Browsers {
+			listed = listed || strings.ToLower(name) == browserName
+		}
+		if !listed {
+			return false, nil
+		}
+	}
+	if policy.Spec.Selector == nil {
+		return true, nil
+	}
+	selector, err := metav1.LabelSelectorAsSelector(policy.Spec.Selector)
+	if err != nil {
+		return false, &InvalidErr{msg: fmt.Sprintf("selector of extension policy %s is invalid: %s", policy.Name, err)}
+	}
+	return selector.Matches(browserLabels), nil
+}
+
+func allowed(applied []v1.AllowedExtension, ext v1.BrowserExtension) bool {
+	for _, entry := range applied {
+		matched := (entry.ExtensionID != "" || entry.Artifact != "") &&
+			(entry.ExtensionID == "" || entry.ExtensionID == ext.ExtensionID) &&
+			(entry.Artifact == "" || entry.Artifact == ext.Artifact)
+		if !matched {
+			continue
+		}
+		constraints, err := parseConstraints(entry.Versions)
+		if err != nil {
+			continue
+		}
+		if constraints.satisfiedBy(ext.Version) {
+			return true
+		}
+	}
+	return false
+}
+
+func describe(ext v1.BrowserExtension) string {
+	var desc string
+	switch {
+	case ext.Artifact != "" && ext.ExtensionID != "":
+		desc = fmt.Sprintf("artifact %s (%s)", ext.Artifact, ext.ExtensionID)
+	case ext.Artifact != "":
+		desc = "artifact " + ext.Artifact
+	default:
+		desc = ext.ExtensionID
+	}
+	if ext.Version != "" {
+		desc += " " + ext.Version
+	}
+	return desc
+}
+
+type constraint struct {
+	op      string
+	version string
+}
+
+type constraints []constraint
+
+// constraintOps are ordered so that two-character operators are matched first
+var constraintOps = []string{">=", "<=", "!=", ">", "<", "="}
+
+// parseConstraints parses comma-separated version constraints, e.g. ">=1.2, <2". A version without an operator is exact
+func parseConstraints(s string) (constraints, error) {
+	if strings.TrimSpace(s) == "" {
+		return nil, nil
+	}
+	var parsed constraints
+	for _, part := range strings.Split(s, ",") {
+		part = strings.TrimSpace(part)
+		c := constraint{op: "=", version: part}
+		for _, op := range constraintOps {
+			if strings.HasPrefix(part, op) {
+				c = constraint{op: op, version: strings.TrimSpace(strings.TrimPrefix(part, op))}
+				break
+			}
+		}
+		if !validVersion(c.version) {
+			return nil, &InvalidErr{msg: fmt.Sprintf("version constraint %q is invalid", part)}
+		}
+		parsed = append(parsed, c)
+	}
+	return parsed, nil
+}
+
+// satisfiedBy reports whether the version satisfies all the constraints. Empty version satisfies no constraints
+func (cs constraints) satisfiedBy(version string) bool {
+	if len(cs) == 0 {
+		return true
+	}
+	if !validVersion(version) {
+		return false
+	}
+	for _, c := range cs {
+		cmp := compareVersions(version, c.version)
+		var ok bool
+		switch c.op {
+		case "=":
+			ok = cmp == 0
+		case "!=":
+			ok = cmp != 0
+		case ">":
+			ok = cmp > 0
+		case ">=":
+			ok = cmp >= 0
+		case "<":
+			ok = cmp < 0
+		case "<=":
+			ok = cmp <= 0
+		}
+		if !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// validVersion accepts extension versions: one to four dot-separated integers, e.g. 1.10.0.2
+func validVersion(version string) bool {
+	parts := strings.Split(version, ".")
+	if len(parts) > 4 {
+		return false
+	}
+	for _, part := range parts {
+		if _, err := strconv.ParseUint(part, 10, 32); err != nil {
+			return false
+		}
+	}
+	return true
+}
+
+// compareVersions compares valid extension versions numerically, missing parts are zeros: 1.2 == 1.2.0
+func compareVersions(a, b string) int {
+	aParts, bParts := strings.Split(a, "."), strings.Split(b, ".")
+	for i := 0; i < len(aParts) || i < len(bParts); i++ {
+		var aNum, bNum uint64
+		if i < len(aParts) {
+			aNum, _ = strconv.ParseUint(aParts[i], 10, 32)
+		}
+		if i < len(bParts) {
+			bNum, _ = strconv.ParseUint(bParts[i], 10, 32)
+		}
+		if aNum != bNum {
+			if aNum > bNum {
+				return 1
+			}
+			return -1
+		}
+	}
+	return 0
+}
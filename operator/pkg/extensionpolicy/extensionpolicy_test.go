@@ -0,0 +1,157 @@
+package extensionpolicy
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1 "github.com/browserkube/browserkube/operator/api/v1"
+)
+
+func TestCheck(t *testing.T) {
+	adblock := v1.BrowserExtension{ExtensionID: "cjpalhdlnbpafiamejdnhcphjbkeiagm"}
+	artifact := v1.BrowserExtension{Artifact: "sso-helper", Version: "1.10.2"}
+	firefox := v1.BrowserExtension{ExtensionID: "uBlock0@raymondhill.net"}
+
+	policies := []v1.ExtensionPolicy{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "chrome"},
+			Spec: v1.ExtensionPolicySpec{
+				Browsers: []string{"Chrome"},
+				Allowed:  []v1.AllowedExtension{{ExtensionID: adblock.ExtensionID}},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "internal"},
+			Spec: v1.ExtensionPolicySpec{
+				Selector: &metav1.LabelSelector{MatchLabels: map[string]string{v1.LabelBrowserSet: "internal"}},
+				Allowed:  []v1.AllowedExtension{{Artifact: "sso-helper", Versions: ">=1.9, <2"}},
+			},
+		},
+	}
+
+	tests := []struct {
+		name       string
+		browser    string
+		browserSet string
+		extensions []v1.BrowserExtension
+		want       []v1.BrowserExtension
+	}{
+		{
+			name:    "no extensions",
+			browser: "firefox",
+		},
+		{
+			name:       "allowed for the browser",
+			browser:    "chrome",
+			extensions: []v1.BrowserExtension{adblock},
+		},
+		{
+			name:       "other browser",
+			browser:    "firefox",
+			extensions: []v1.BrowserExtension{adblock, firefox},
+			want:       []v1.BrowserExtension{adblock, firefox},
+		},
+		{
+			name:       "selected by browser set",
+			browser:    "chrome",
+			browserSet: "internal",
+			extensions: []v1.BrowserExtension{adblock, artifact},
+		},
+		{
+			name:       "not selected by browser set",
+			browser:    "chrome",
+			browserSet: "public",
+			extensions: []v1.BrowserExtension{adblock, artifact},
+			want:       []v1.BrowserExtension{artifact},
+		},
+		{
+			name:       "version out of range",
+			browser:    "chrome",
+			browserSet: "internal",
+			extensions: []v1.BrowserExtension{{Artifact: "sso-helper", Version: "2.0"}},
+			want:       []v1.BrowserExtension{{Artifact: "sso-helper", Version: "2.0"}},
+		},
+		{
+			name:       "latest version doesn't satisfy constraints",
+			browser:    "chrome",
+			browserSet: "internal",
+			extensions: []v1.BrowserExtension{{Artifact: "sso-helper"}},
+			want:       []v1.BrowserExtension{{Artifact: "sso-helper"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			browser := &v1.Browser{Spec: v1.BrowserSpec{BrowserName: tt.browser, Extensions: tt.extensions}}
+			err := Check(policies, browser, tt.browserSet)
+			if tt.want == nil {
+				if err != nil {
+					t.Fatalf("Check() error = %v", err)
+				}
+				return
+			}
+			var rejected *RejectedErr
+			if !errors.As(err, &rejected) {
+				t.Fatalf("Check() error = %v, want RejectedErr", err)
+			}
+			if !reflect.DeepEqual(rejected.Extensions, tt.want) {
+				t.Errorf("Check() rejected = %v, want %v", rejected.Extensions, tt.want)
+			}
+		})
+	}
+}
+
+func TestRejectedErr(t *testing.T) {
+	err := &RejectedErr{Extensions: []v1.BrowserExtension{
+		{ExtensionID: "cjpalhdlnbpafiamejdnhcphjbkeiagm"},
+		{Artifact: "sso-helper", ExtensionID: "abc", Version: "1.2"},
+	}}
+	want := "extensions aren't allowed by extension policies: cjpalhdlnbpafiamejdnhcphjbkeiagm, artifact sso-helper (abc) 1.2"
+	if err.Error() != want {
+		t.Errorf("Error() = %s, want %s", err.Error(), want)
+	}
+}
+
+func TestValidateAllowed(t *testing.T) {
+	tests := []struct {
+		entry   v1.AllowedExtension
+		wantErr bool
+	}{
+		{entry: v1.AllowedExtension{ExtensionID: "abc"}},
+		{entry: v1.AllowedExtension{Artifact: "sso-helper", Versions: ">= 1.2.0.1, !=1.3, 2"}},
+		{entry: v1.AllowedExtension{}, wantErr: true},
+		{entry: v1.AllowedExtension{ExtensionID: "abc", Versions: "~1.2"}, wantErr: true},
+		{entry: v1.AllowedExtension{ExtensionID: "abc", Versions: ">=1.2.3.4.5"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		if err := ValidateAllowed(tt.entry); (err != nil) != tt.wantErr {
+			t.Errorf("ValidateAllowed(%+v) error = %v, wantErr %v", tt.entry, err, tt.wantErr)
+		}
+	}
+}
+
+func Test_constraints_satisfiedBy(t *testing.T) {
+	tests := []struct {
+		constraints string
+		version     string
+		want        bool
+	}{
+		{constraints: "", version: "", want: true},
+		{constraints: "1.2", version: "1.2.0", want: true},
+		{constraints: ">=1.9, <2", version: "1.10.0", want: true},
+		{constraints: ">=1.9, <2", version: "2.0.0.1", want: false},
+		{constraints: "!=1.3", version: "1.3.0", want: false},
+		{constraints: ">1", version: "latest", want: false},
+	}
+	for _, tt := range tests {
+		cs, err := parseConstraints(tt.constraints)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := cs.satisfiedBy(tt.version); got != tt.want {
+			t.Errorf("%q satisfiedBy(%s) = %v, want %v", tt.constraints, tt.version, got, tt.want)
+		}
+	}
+}